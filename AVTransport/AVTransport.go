@@ -1,23 +1,104 @@
 package avtransport
 
 import (
-	"bytes"
-	"encoding/xml"
-	"errors"
-	"io/ioutil"
+	"context"
 	"net/http"
 	"net/url"
+	"sync"
+
+	"github.com/szatmary/sonos/soap"
+)
+
+const _ServiceURN = "urn:schemas-upnp-org:service:AVTransport:1"
+
+// ErrorCodes describes the error codes specific to this service
+var ErrorCodes = map[int]string{
+	701: "Transition not available",
+	702: "No contents",
+	703: "Read error",
+	704: "Format not supported for playback",
+	705: "Transport is locked",
+	706: "Write error",
+	707: "Media is protected or not writeable",
+	708: "Format not supported for recording",
+	709: "Media is full",
+	710: "Seek mode not supported",
+	711: "Illegal seek target",
+	712: "Play mode not supported",
+	713: "Record quality not supported",
+	714: "Illegal MIME-type",
+	715: "Content 'BUSY'",
+	716: "Resource not found",
+	717: "Play speed not supported",
+	718: "Invalid InstanceID",
+	737: "No DNS server",
+	738: "Bad domain name",
+	739: "Server error",
+	800: "Command not supported by this player, it may not be the group coordinator",
+}
+
+func init() {
+	soap.RegisterErrorCodes(_ServiceURN, ErrorCodes)
+}
+
+// TransportState holds the values allowed for the TransportState state variable
+type TransportState string
+
+const (
+	TransportStateStopped        TransportState = "STOPPED"
+	TransportStatePlaying        TransportState = "PLAYING"
+	TransportStatePausedPlayback TransportState = "PAUSED_PLAYBACK"
+	TransportStateTransitioning  TransportState = "TRANSITIONING"
+)
+
+// PlaybackStorageMedium holds the values allowed for the PlaybackStorageMedium state variable
+type PlaybackStorageMedium string
+
+const (
+	PlaybackStorageMediumNone    PlaybackStorageMedium = "NONE"
+	PlaybackStorageMediumNetwork PlaybackStorageMedium = "NETWORK"
+)
+
+// RecordStorageMedium holds the values allowed for the RecordStorageMedium state variable
+type RecordStorageMedium string
+
+const (
+	RecordStorageMediumNone RecordStorageMedium = "NONE"
 )
 
+// PlayMode holds the values allowed for the CurrentPlayMode state variable
+type PlayMode string
+
 const (
-	_ServiceURN     = "urn:schemas-upnp-org:service:AVTransport:1"
-	_EncodingSchema = "http://schemas.xmlsoap.org/soap/encoding/"
-	_EnvelopeSchema = "http://schemas.xmlsoap.org/soap/envelope/"
+	PlayModeNormal           PlayMode = "NORMAL"
+	PlayModeRepeatAll        PlayMode = "REPEAT_ALL"
+	PlayModeRepeatOne        PlayMode = "REPEAT_ONE"
+	PlayModeShuffleNoRepeat  PlayMode = "SHUFFLE_NOREPEAT"
+	PlayModeShuffle          PlayMode = "SHUFFLE"
+	PlayModeShuffleRepeatOne PlayMode = "SHUFFLE_REPEAT_ONE"
+)
+
+// TransportPlaySpeed holds the values allowed for the TransportPlaySpeed state variable
+type TransportPlaySpeed string
+
+const (
+	TransportPlaySpeed1 TransportPlaySpeed = "1"
+)
+
+// SeekUnit holds the values allowed for the A_ARG_TYPE_SeekMode state variable
+type SeekUnit string
+
+const (
+	SeekUnitTrackNR   SeekUnit = "TRACK_NR"
+	SeekUnitRelTime   SeekUnit = "REL_TIME"
+	SeekUnitTimeDelta SeekUnit = "TIME_DELTA"
 )
 
 type Service struct {
 	ControlEndpoint *url.URL
 	EventEndpoint   *url.URL
+	// Client sends the actions, soap.DefaultClient is used if it is nil
+	Client *soap.Client
 }
 
 func NewService(deviceUrl *url.URL) *Service {
@@ -35,201 +116,69 @@ func NewService(deviceUrl *url.URL) *Service {
 	}
 }
 
-type Envelope struct {
-	XMLName       xml.Name `xml:"s:Envelope"`
-	Xmlns         string   `xml:"xmlns:s,attr"`
-	EncodingStyle string   `xml:"s:encodingStyle,attr"`
-	Body          Body     `xml:"s:Body"`
-}
-type Body struct {
-	XMLName                            xml.Name                                `xml:"s:Body"`
-	SetAVTransportURI                  *SetAVTransportURIArgs                  `xml:"u:SetAVTransportURI,omitempty"`
-	SetNextAVTransportURI              *SetNextAVTransportURIArgs              `xml:"u:SetNextAVTransportURI,omitempty"`
-	AddURIToQueue                      *AddURIToQueueArgs                      `xml:"u:AddURIToQueue,omitempty"`
-	AddMultipleURIsToQueue             *AddMultipleURIsToQueueArgs             `xml:"u:AddMultipleURIsToQueue,omitempty"`
-	ReorderTracksInQueue               *ReorderTracksInQueueArgs               `xml:"u:ReorderTracksInQueue,omitempty"`
-	RemoveTrackFromQueue               *RemoveTrackFromQueueArgs               `xml:"u:RemoveTrackFromQueue,omitempty"`
-	RemoveTrackRangeFromQueue          *RemoveTrackRangeFromQueueArgs          `xml:"u:RemoveTrackRangeFromQueue,omitempty"`
-	RemoveAllTracksFromQueue           *RemoveAllTracksFromQueueArgs           `xml:"u:RemoveAllTracksFromQueue,omitempty"`
-	SaveQueue                          *SaveQueueArgs                          `xml:"u:SaveQueue,omitempty"`
-	BackupQueue                        *BackupQueueArgs                        `xml:"u:BackupQueue,omitempty"`
-	CreateSavedQueue                   *CreateSavedQueueArgs                   `xml:"u:CreateSavedQueue,omitempty"`
-	AddURIToSavedQueue                 *AddURIToSavedQueueArgs                 `xml:"u:AddURIToSavedQueue,omitempty"`
-	ReorderTracksInSavedQueue          *ReorderTracksInSavedQueueArgs          `xml:"u:ReorderTracksInSavedQueue,omitempty"`
-	GetMediaInfo                       *GetMediaInfoArgs                       `xml:"u:GetMediaInfo,omitempty"`
-	GetTransportInfo                   *GetTransportInfoArgs                   `xml:"u:GetTransportInfo,omitempty"`
-	GetPositionInfo                    *GetPositionInfoArgs                    `xml:"u:GetPositionInfo,omitempty"`
-	GetDeviceCapabilities              *GetDeviceCapabilitiesArgs              `xml:"u:GetDeviceCapabilities,omitempty"`
-	GetTransportSettings               *GetTransportSettingsArgs               `xml:"u:GetTransportSettings,omitempty"`
-	GetCrossfadeMode                   *GetCrossfadeModeArgs                   `xml:"u:GetCrossfadeMode,omitempty"`
-	Stop                               *StopArgs                               `xml:"u:Stop,omitempty"`
-	Play                               *PlayArgs                               `xml:"u:Play,omitempty"`
-	Pause                              *PauseArgs                              `xml:"u:Pause,omitempty"`
-	Seek                               *SeekArgs                               `xml:"u:Seek,omitempty"`
-	Next                               *NextArgs                               `xml:"u:Next,omitempty"`
-	Previous                           *PreviousArgs                           `xml:"u:Previous,omitempty"`
-	SetPlayMode                        *SetPlayModeArgs                        `xml:"u:SetPlayMode,omitempty"`
-	SetCrossfadeMode                   *SetCrossfadeModeArgs                   `xml:"u:SetCrossfadeMode,omitempty"`
-	NotifyDeletedURI                   *NotifyDeletedURIArgs                   `xml:"u:NotifyDeletedURI,omitempty"`
-	GetCurrentTransportActions         *GetCurrentTransportActionsArgs         `xml:"u:GetCurrentTransportActions,omitempty"`
-	BecomeCoordinatorOfStandaloneGroup *BecomeCoordinatorOfStandaloneGroupArgs `xml:"u:BecomeCoordinatorOfStandaloneGroup,omitempty"`
-	DelegateGroupCoordinationTo        *DelegateGroupCoordinationToArgs        `xml:"u:DelegateGroupCoordinationTo,omitempty"`
-	BecomeGroupCoordinator             *BecomeGroupCoordinatorArgs             `xml:"u:BecomeGroupCoordinator,omitempty"`
-	BecomeGroupCoordinatorAndSource    *BecomeGroupCoordinatorAndSourceArgs    `xml:"u:BecomeGroupCoordinatorAndSource,omitempty"`
-	ChangeCoordinator                  *ChangeCoordinatorArgs                  `xml:"u:ChangeCoordinator,omitempty"`
-	ChangeTransportSettings            *ChangeTransportSettingsArgs            `xml:"u:ChangeTransportSettings,omitempty"`
-	ConfigureSleepTimer                *ConfigureSleepTimerArgs                `xml:"u:ConfigureSleepTimer,omitempty"`
-	GetRemainingSleepTimerDuration     *GetRemainingSleepTimerDurationArgs     `xml:"u:GetRemainingSleepTimerDuration,omitempty"`
-	RunAlarm                           *RunAlarmArgs                           `xml:"u:RunAlarm,omitempty"`
-	StartAutoplay                      *StartAutoplayArgs                      `xml:"u:StartAutoplay,omitempty"`
-	GetRunningAlarmProperties          *GetRunningAlarmPropertiesArgs          `xml:"u:GetRunningAlarmProperties,omitempty"`
-	SnoozeAlarm                        *SnoozeAlarmArgs                        `xml:"u:SnoozeAlarm,omitempty"`
-	EndDirectControlSession            *EndDirectControlSessionArgs            `xml:"u:EndDirectControlSession,omitempty"`
-}
-type EnvelopeResponse struct {
-	XMLName       xml.Name     `xml:"Envelope"`
-	Xmlns         string       `xml:"xmlns:s,attr"`
-	EncodingStyle string       `xml:"encodingStyle,attr"`
-	Body          BodyResponse `xml:"Body"`
-}
-type BodyResponse struct {
-	XMLName                            xml.Name                                    `xml:"Body"`
-	SetAVTransportURI                  *SetAVTransportURIResponse                  `xml:"SetAVTransportURIResponse,omitempty"`
-	SetNextAVTransportURI              *SetNextAVTransportURIResponse              `xml:"SetNextAVTransportURIResponse,omitempty"`
-	AddURIToQueue                      *AddURIToQueueResponse                      `xml:"AddURIToQueueResponse,omitempty"`
-	AddMultipleURIsToQueue             *AddMultipleURIsToQueueResponse             `xml:"AddMultipleURIsToQueueResponse,omitempty"`
-	ReorderTracksInQueue               *ReorderTracksInQueueResponse               `xml:"ReorderTracksInQueueResponse,omitempty"`
-	RemoveTrackFromQueue               *RemoveTrackFromQueueResponse               `xml:"RemoveTrackFromQueueResponse,omitempty"`
-	RemoveTrackRangeFromQueue          *RemoveTrackRangeFromQueueResponse          `xml:"RemoveTrackRangeFromQueueResponse,omitempty"`
-	RemoveAllTracksFromQueue           *RemoveAllTracksFromQueueResponse           `xml:"RemoveAllTracksFromQueueResponse,omitempty"`
-	SaveQueue                          *SaveQueueResponse                          `xml:"SaveQueueResponse,omitempty"`
-	BackupQueue                        *BackupQueueResponse                        `xml:"BackupQueueResponse,omitempty"`
-	CreateSavedQueue                   *CreateSavedQueueResponse                   `xml:"CreateSavedQueueResponse,omitempty"`
-	AddURIToSavedQueue                 *AddURIToSavedQueueResponse                 `xml:"AddURIToSavedQueueResponse,omitempty"`
-	ReorderTracksInSavedQueue          *ReorderTracksInSavedQueueResponse          `xml:"ReorderTracksInSavedQueueResponse,omitempty"`
-	GetMediaInfo                       *GetMediaInfoResponse                       `xml:"GetMediaInfoResponse,omitempty"`
-	GetTransportInfo                   *GetTransportInfoResponse                   `xml:"GetTransportInfoResponse,omitempty"`
-	GetPositionInfo                    *GetPositionInfoResponse                    `xml:"GetPositionInfoResponse,omitempty"`
-	GetDeviceCapabilities              *GetDeviceCapabilitiesResponse              `xml:"GetDeviceCapabilitiesResponse,omitempty"`
-	GetTransportSettings               *GetTransportSettingsResponse               `xml:"GetTransportSettingsResponse,omitempty"`
-	GetCrossfadeMode                   *GetCrossfadeModeResponse                   `xml:"GetCrossfadeModeResponse,omitempty"`
-	Stop                               *StopResponse                               `xml:"StopResponse,omitempty"`
-	Play                               *PlayResponse                               `xml:"PlayResponse,omitempty"`
-	Pause                              *PauseResponse                              `xml:"PauseResponse,omitempty"`
-	Seek                               *SeekResponse                               `xml:"SeekResponse,omitempty"`
-	Next                               *NextResponse                               `xml:"NextResponse,omitempty"`
-	Previous                           *PreviousResponse                           `xml:"PreviousResponse,omitempty"`
-	SetPlayMode                        *SetPlayModeResponse                        `xml:"SetPlayModeResponse,omitempty"`
-	SetCrossfadeMode                   *SetCrossfadeModeResponse                   `xml:"SetCrossfadeModeResponse,omitempty"`
-	NotifyDeletedURI                   *NotifyDeletedURIResponse                   `xml:"NotifyDeletedURIResponse,omitempty"`
-	GetCurrentTransportActions         *GetCurrentTransportActionsResponse         `xml:"GetCurrentTransportActionsResponse,omitempty"`
-	BecomeCoordinatorOfStandaloneGroup *BecomeCoordinatorOfStandaloneGroupResponse `xml:"BecomeCoordinatorOfStandaloneGroupResponse,omitempty"`
-	DelegateGroupCoordinationTo        *DelegateGroupCoordinationToResponse        `xml:"DelegateGroupCoordinationToResponse,omitempty"`
-	BecomeGroupCoordinator             *BecomeGroupCoordinatorResponse             `xml:"BecomeGroupCoordinatorResponse,omitempty"`
-	BecomeGroupCoordinatorAndSource    *BecomeGroupCoordinatorAndSourceResponse    `xml:"BecomeGroupCoordinatorAndSourceResponse,omitempty"`
-	ChangeCoordinator                  *ChangeCoordinatorResponse                  `xml:"ChangeCoordinatorResponse,omitempty"`
-	ChangeTransportSettings            *ChangeTransportSettingsResponse            `xml:"ChangeTransportSettingsResponse,omitempty"`
-	ConfigureSleepTimer                *ConfigureSleepTimerResponse                `xml:"ConfigureSleepTimerResponse,omitempty"`
-	GetRemainingSleepTimerDuration     *GetRemainingSleepTimerDurationResponse     `xml:"GetRemainingSleepTimerDurationResponse,omitempty"`
-	RunAlarm                           *RunAlarmResponse                           `xml:"RunAlarmResponse,omitempty"`
-	StartAutoplay                      *StartAutoplayResponse                      `xml:"StartAutoplayResponse,omitempty"`
-	GetRunningAlarmProperties          *GetRunningAlarmPropertiesResponse          `xml:"GetRunningAlarmPropertiesResponse,omitempty"`
-	SnoozeAlarm                        *SnoozeAlarmResponse                        `xml:"SnoozeAlarmResponse,omitempty"`
-	EndDirectControlSession            *EndDirectControlSessionResponse            `xml:"EndDirectControlSessionResponse,omitempty"`
-}
-
-func (s *Service) exec(actionName string, httpClient *http.Client, envelope *Envelope) (*EnvelopeResponse, error) {
-	marshaled, err := xml.Marshal(envelope)
-	if err != nil {
-		return nil, err
-	}
-	postBody := []byte(`<?xml version="1.0"?>`)
-	postBody = append(postBody, marshaled...)
-	req, err := http.NewRequest(`POST`, s.ControlEndpoint.String(), bytes.NewBuffer(postBody))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set(`Content-Type`, `text/xml; charset="utf-8"`)
-	req.Header.Set(`SOAPAction`, _ServiceURN+`#`+actionName)
-	res, err := httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-	responseBody, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-	var envelopeResponse EnvelopeResponse
-	err = xml.Unmarshal(responseBody, &envelopeResponse)
-	if err != nil {
-		return nil, err
-	}
-	return &envelopeResponse, nil
-}
-
 type SetAVTransportURIArgs struct {
-	Xmlns              string `xml:"xmlns:u,attr"`
 	InstanceID         uint32 `xml:"InstanceID"`
 	CurrentURI         string `xml:"CurrentURI"`
 	CurrentURIMetaData string `xml:"CurrentURIMetaData"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetAVTransportURIArgs) Validate() error {
+	return nil
+}
+
 type SetAVTransportURIResponse struct {
 }
 
 func (s *Service) SetAVTransportURI(httpClient *http.Client, args *SetAVTransportURIArgs) (*SetAVTransportURIResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetAVTransportURI`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetAVTransportURI: args},
-		})
-	if err != nil {
+	return s.SetAVTransportURIContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetAVTransportURIContext(ctx context.Context, httpClient *http.Client, args *SetAVTransportURIArgs) (*SetAVTransportURIResponse, error) {
+	var r SetAVTransportURIResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetAVTransportURI`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetAVTransportURI == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.SetAVTransportURI()`)
-	}
-
-	return r.Body.SetAVTransportURI, nil
+	return &r, nil
 }
 
 type SetNextAVTransportURIArgs struct {
-	Xmlns           string `xml:"xmlns:u,attr"`
 	InstanceID      uint32 `xml:"InstanceID"`
 	NextURI         string `xml:"NextURI"`
 	NextURIMetaData string `xml:"NextURIMetaData"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetNextAVTransportURIArgs) Validate() error {
+	return nil
+}
+
 type SetNextAVTransportURIResponse struct {
 }
 
 func (s *Service) SetNextAVTransportURI(httpClient *http.Client, args *SetNextAVTransportURIArgs) (*SetNextAVTransportURIResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetNextAVTransportURI`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetNextAVTransportURI: args},
-		})
-	if err != nil {
+	return s.SetNextAVTransportURIContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetNextAVTransportURIContext(ctx context.Context, httpClient *http.Client, args *SetNextAVTransportURIArgs) (*SetNextAVTransportURIResponse, error) {
+	var r SetNextAVTransportURIResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetNextAVTransportURI`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetNextAVTransportURI == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.SetNextAVTransportURI()`)
-	}
-
-	return r.Body.SetNextAVTransportURI, nil
+	return &r, nil
 }
 
 type AddURIToQueueArgs struct {
-	Xmlns                           string `xml:"xmlns:u,attr"`
 	InstanceID                      uint32 `xml:"InstanceID"`
 	EnqueuedURI                     string `xml:"EnqueuedURI"`
 	EnqueuedURIMetaData             string `xml:"EnqueuedURIMetaData"`
 	DesiredFirstTrackNumberEnqueued uint32 `xml:"DesiredFirstTrackNumberEnqueued"`
 	EnqueueAsNext                   bool   `xml:"EnqueueAsNext"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *AddURIToQueueArgs) Validate() error {
+	return nil
+}
+
 type AddURIToQueueResponse struct {
 	FirstTrackNumberEnqueued uint32 `xml:"FirstTrackNumberEnqueued"`
 	NumTracksAdded           uint32 `xml:"NumTracksAdded"`
@@ -237,25 +186,17 @@ type AddURIToQueueResponse struct {
 }
 
 func (s *Service) AddURIToQueue(httpClient *http.Client, args *AddURIToQueueArgs) (*AddURIToQueueResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`AddURIToQueue`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{AddURIToQueue: args},
-		})
-	if err != nil {
+	return s.AddURIToQueueContext(context.Background(), httpClient, args)
+}
+func (s *Service) AddURIToQueueContext(ctx context.Context, httpClient *http.Client, args *AddURIToQueueArgs) (*AddURIToQueueResponse, error) {
+	var r AddURIToQueueResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `AddURIToQueue`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.AddURIToQueue == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.AddURIToQueue()`)
-	}
-
-	return r.Body.AddURIToQueue, nil
+	return &r, nil
 }
 
 type AddMultipleURIsToQueueArgs struct {
-	Xmlns                           string `xml:"xmlns:u,attr"`
 	InstanceID                      uint32 `xml:"InstanceID"`
 	UpdateID                        uint32 `xml:"UpdateID"`
 	NumberOfURIs                    uint32 `xml:"NumberOfURIs"`
@@ -266,6 +207,12 @@ type AddMultipleURIsToQueueArgs struct {
 	DesiredFirstTrackNumberEnqueued uint32 `xml:"DesiredFirstTrackNumberEnqueued"`
 	EnqueueAsNext                   bool   `xml:"EnqueueAsNext"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *AddMultipleURIsToQueueArgs) Validate() error {
+	return nil
+}
+
 type AddMultipleURIsToQueueResponse struct {
 	FirstTrackNumberEnqueued uint32 `xml:"FirstTrackNumberEnqueued"`
 	NumTracksAdded           uint32 `xml:"NumTracksAdded"`
@@ -274,193 +221,179 @@ type AddMultipleURIsToQueueResponse struct {
 }
 
 func (s *Service) AddMultipleURIsToQueue(httpClient *http.Client, args *AddMultipleURIsToQueueArgs) (*AddMultipleURIsToQueueResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`AddMultipleURIsToQueue`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{AddMultipleURIsToQueue: args},
-		})
-	if err != nil {
+	return s.AddMultipleURIsToQueueContext(context.Background(), httpClient, args)
+}
+func (s *Service) AddMultipleURIsToQueueContext(ctx context.Context, httpClient *http.Client, args *AddMultipleURIsToQueueArgs) (*AddMultipleURIsToQueueResponse, error) {
+	var r AddMultipleURIsToQueueResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `AddMultipleURIsToQueue`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.AddMultipleURIsToQueue == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.AddMultipleURIsToQueue()`)
-	}
-
-	return r.Body.AddMultipleURIsToQueue, nil
+	return &r, nil
 }
 
 type ReorderTracksInQueueArgs struct {
-	Xmlns          string `xml:"xmlns:u,attr"`
 	InstanceID     uint32 `xml:"InstanceID"`
 	StartingIndex  uint32 `xml:"StartingIndex"`
 	NumberOfTracks uint32 `xml:"NumberOfTracks"`
 	InsertBefore   uint32 `xml:"InsertBefore"`
 	UpdateID       uint32 `xml:"UpdateID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *ReorderTracksInQueueArgs) Validate() error {
+	return nil
+}
+
 type ReorderTracksInQueueResponse struct {
 }
 
 func (s *Service) ReorderTracksInQueue(httpClient *http.Client, args *ReorderTracksInQueueArgs) (*ReorderTracksInQueueResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`ReorderTracksInQueue`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{ReorderTracksInQueue: args},
-		})
-	if err != nil {
+	return s.ReorderTracksInQueueContext(context.Background(), httpClient, args)
+}
+func (s *Service) ReorderTracksInQueueContext(ctx context.Context, httpClient *http.Client, args *ReorderTracksInQueueArgs) (*ReorderTracksInQueueResponse, error) {
+	var r ReorderTracksInQueueResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `ReorderTracksInQueue`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.ReorderTracksInQueue == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.ReorderTracksInQueue()`)
-	}
-
-	return r.Body.ReorderTracksInQueue, nil
+	return &r, nil
 }
 
 type RemoveTrackFromQueueArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	ObjectID   string `xml:"ObjectID"`
 	UpdateID   uint32 `xml:"UpdateID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *RemoveTrackFromQueueArgs) Validate() error {
+	return nil
+}
+
 type RemoveTrackFromQueueResponse struct {
 }
 
 func (s *Service) RemoveTrackFromQueue(httpClient *http.Client, args *RemoveTrackFromQueueArgs) (*RemoveTrackFromQueueResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`RemoveTrackFromQueue`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{RemoveTrackFromQueue: args},
-		})
-	if err != nil {
+	return s.RemoveTrackFromQueueContext(context.Background(), httpClient, args)
+}
+func (s *Service) RemoveTrackFromQueueContext(ctx context.Context, httpClient *http.Client, args *RemoveTrackFromQueueArgs) (*RemoveTrackFromQueueResponse, error) {
+	var r RemoveTrackFromQueueResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `RemoveTrackFromQueue`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.RemoveTrackFromQueue == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.RemoveTrackFromQueue()`)
-	}
-
-	return r.Body.RemoveTrackFromQueue, nil
+	return &r, nil
 }
 
 type RemoveTrackRangeFromQueueArgs struct {
-	Xmlns          string `xml:"xmlns:u,attr"`
 	InstanceID     uint32 `xml:"InstanceID"`
 	UpdateID       uint32 `xml:"UpdateID"`
 	StartingIndex  uint32 `xml:"StartingIndex"`
 	NumberOfTracks uint32 `xml:"NumberOfTracks"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *RemoveTrackRangeFromQueueArgs) Validate() error {
+	return nil
+}
+
 type RemoveTrackRangeFromQueueResponse struct {
 	NewUpdateID uint32 `xml:"NewUpdateID"`
 }
 
 func (s *Service) RemoveTrackRangeFromQueue(httpClient *http.Client, args *RemoveTrackRangeFromQueueArgs) (*RemoveTrackRangeFromQueueResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`RemoveTrackRangeFromQueue`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{RemoveTrackRangeFromQueue: args},
-		})
-	if err != nil {
+	return s.RemoveTrackRangeFromQueueContext(context.Background(), httpClient, args)
+}
+func (s *Service) RemoveTrackRangeFromQueueContext(ctx context.Context, httpClient *http.Client, args *RemoveTrackRangeFromQueueArgs) (*RemoveTrackRangeFromQueueResponse, error) {
+	var r RemoveTrackRangeFromQueueResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `RemoveTrackRangeFromQueue`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.RemoveTrackRangeFromQueue == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.RemoveTrackRangeFromQueue()`)
-	}
-
-	return r.Body.RemoveTrackRangeFromQueue, nil
+	return &r, nil
 }
 
 type RemoveAllTracksFromQueueArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *RemoveAllTracksFromQueueArgs) Validate() error {
+	return nil
+}
+
 type RemoveAllTracksFromQueueResponse struct {
 }
 
 func (s *Service) RemoveAllTracksFromQueue(httpClient *http.Client, args *RemoveAllTracksFromQueueArgs) (*RemoveAllTracksFromQueueResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`RemoveAllTracksFromQueue`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{RemoveAllTracksFromQueue: args},
-		})
-	if err != nil {
+	return s.RemoveAllTracksFromQueueContext(context.Background(), httpClient, args)
+}
+func (s *Service) RemoveAllTracksFromQueueContext(ctx context.Context, httpClient *http.Client, args *RemoveAllTracksFromQueueArgs) (*RemoveAllTracksFromQueueResponse, error) {
+	var r RemoveAllTracksFromQueueResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `RemoveAllTracksFromQueue`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.RemoveAllTracksFromQueue == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.RemoveAllTracksFromQueue()`)
-	}
-
-	return r.Body.RemoveAllTracksFromQueue, nil
+	return &r, nil
 }
 
 type SaveQueueArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	Title      string `xml:"Title"`
 	ObjectID   string `xml:"ObjectID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SaveQueueArgs) Validate() error {
+	return nil
+}
+
 type SaveQueueResponse struct {
 	AssignedObjectID string `xml:"AssignedObjectID"`
 }
 
 func (s *Service) SaveQueue(httpClient *http.Client, args *SaveQueueArgs) (*SaveQueueResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SaveQueue`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SaveQueue: args},
-		})
-	if err != nil {
+	return s.SaveQueueContext(context.Background(), httpClient, args)
+}
+func (s *Service) SaveQueueContext(ctx context.Context, httpClient *http.Client, args *SaveQueueArgs) (*SaveQueueResponse, error) {
+	var r SaveQueueResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SaveQueue`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SaveQueue == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.SaveQueue()`)
-	}
-
-	return r.Body.SaveQueue, nil
+	return &r, nil
 }
 
 type BackupQueueArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *BackupQueueArgs) Validate() error {
+	return nil
+}
+
 type BackupQueueResponse struct {
 }
 
 func (s *Service) BackupQueue(httpClient *http.Client, args *BackupQueueArgs) (*BackupQueueResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`BackupQueue`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{BackupQueue: args},
-		})
-	if err != nil {
+	return s.BackupQueueContext(context.Background(), httpClient, args)
+}
+func (s *Service) BackupQueueContext(ctx context.Context, httpClient *http.Client, args *BackupQueueArgs) (*BackupQueueResponse, error) {
+	var r BackupQueueResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `BackupQueue`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.BackupQueue == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.BackupQueue()`)
-	}
-
-	return r.Body.BackupQueue, nil
+	return &r, nil
 }
 
 type CreateSavedQueueArgs struct {
-	Xmlns               string `xml:"xmlns:u,attr"`
 	InstanceID          uint32 `xml:"InstanceID"`
 	Title               string `xml:"Title"`
 	EnqueuedURI         string `xml:"EnqueuedURI"`
 	EnqueuedURIMetaData string `xml:"EnqueuedURIMetaData"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *CreateSavedQueueArgs) Validate() error {
+	return nil
+}
+
 type CreateSavedQueueResponse struct {
 	NumTracksAdded   uint32 `xml:"NumTracksAdded"`
 	NewQueueLength   uint32 `xml:"NewQueueLength"`
@@ -469,25 +402,17 @@ type CreateSavedQueueResponse struct {
 }
 
 func (s *Service) CreateSavedQueue(httpClient *http.Client, args *CreateSavedQueueArgs) (*CreateSavedQueueResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`CreateSavedQueue`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{CreateSavedQueue: args},
-		})
-	if err != nil {
+	return s.CreateSavedQueueContext(context.Background(), httpClient, args)
+}
+func (s *Service) CreateSavedQueueContext(ctx context.Context, httpClient *http.Client, args *CreateSavedQueueArgs) (*CreateSavedQueueResponse, error) {
+	var r CreateSavedQueueResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `CreateSavedQueue`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.CreateSavedQueue == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.CreateSavedQueue()`)
-	}
-
-	return r.Body.CreateSavedQueue, nil
+	return &r, nil
 }
 
 type AddURIToSavedQueueArgs struct {
-	Xmlns               string `xml:"xmlns:u,attr"`
 	InstanceID          uint32 `xml:"InstanceID"`
 	ObjectID            string `xml:"ObjectID"`
 	UpdateID            uint32 `xml:"UpdateID"`
@@ -495,6 +420,12 @@ type AddURIToSavedQueueArgs struct {
 	EnqueuedURIMetaData string `xml:"EnqueuedURIMetaData"`
 	AddAtIndex          uint32 `xml:"AddAtIndex"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *AddURIToSavedQueueArgs) Validate() error {
+	return nil
+}
+
 type AddURIToSavedQueueResponse struct {
 	NumTracksAdded uint32 `xml:"NumTracksAdded"`
 	NewQueueLength uint32 `xml:"NewQueueLength"`
@@ -502,31 +433,29 @@ type AddURIToSavedQueueResponse struct {
 }
 
 func (s *Service) AddURIToSavedQueue(httpClient *http.Client, args *AddURIToSavedQueueArgs) (*AddURIToSavedQueueResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`AddURIToSavedQueue`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{AddURIToSavedQueue: args},
-		})
-	if err != nil {
+	return s.AddURIToSavedQueueContext(context.Background(), httpClient, args)
+}
+func (s *Service) AddURIToSavedQueueContext(ctx context.Context, httpClient *http.Client, args *AddURIToSavedQueueArgs) (*AddURIToSavedQueueResponse, error) {
+	var r AddURIToSavedQueueResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `AddURIToSavedQueue`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.AddURIToSavedQueue == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.AddURIToSavedQueue()`)
-	}
-
-	return r.Body.AddURIToSavedQueue, nil
+	return &r, nil
 }
 
 type ReorderTracksInSavedQueueArgs struct {
-	Xmlns           string `xml:"xmlns:u,attr"`
 	InstanceID      uint32 `xml:"InstanceID"`
 	ObjectID        string `xml:"ObjectID"`
 	UpdateID        uint32 `xml:"UpdateID"`
 	TrackList       string `xml:"TrackList"`
 	NewPositionList string `xml:"NewPositionList"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *ReorderTracksInSavedQueueArgs) Validate() error {
+	return nil
+}
+
 type ReorderTracksInSavedQueueResponse struct {
 	QueueLengthChange int32  `xml:"QueueLengthChange"`
 	NewQueueLength    uint32 `xml:"NewQueueLength"`
@@ -534,89 +463,83 @@ type ReorderTracksInSavedQueueResponse struct {
 }
 
 func (s *Service) ReorderTracksInSavedQueue(httpClient *http.Client, args *ReorderTracksInSavedQueueArgs) (*ReorderTracksInSavedQueueResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`ReorderTracksInSavedQueue`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{ReorderTracksInSavedQueue: args},
-		})
-	if err != nil {
+	return s.ReorderTracksInSavedQueueContext(context.Background(), httpClient, args)
+}
+func (s *Service) ReorderTracksInSavedQueueContext(ctx context.Context, httpClient *http.Client, args *ReorderTracksInSavedQueueArgs) (*ReorderTracksInSavedQueueResponse, error) {
+	var r ReorderTracksInSavedQueueResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `ReorderTracksInSavedQueue`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.ReorderTracksInSavedQueue == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.ReorderTracksInSavedQueue()`)
-	}
-
-	return r.Body.ReorderTracksInSavedQueue, nil
+	return &r, nil
 }
 
 type GetMediaInfoArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetMediaInfoArgs) Validate() error {
+	return nil
+}
+
 type GetMediaInfoResponse struct {
-	NrTracks           uint32 `xml:"NrTracks"`
-	MediaDuration      string `xml:"MediaDuration"`
-	CurrentURI         string `xml:"CurrentURI"`
-	CurrentURIMetaData string `xml:"CurrentURIMetaData"`
-	NextURI            string `xml:"NextURI"`
-	NextURIMetaData    string `xml:"NextURIMetaData"`
-	PlayMedium         string `xml:"PlayMedium"`
-	RecordMedium       string `xml:"RecordMedium"`
-	WriteStatus        string `xml:"WriteStatus"`
+	NrTracks           uint32                `xml:"NrTracks"`
+	MediaDuration      string                `xml:"MediaDuration"`
+	CurrentURI         string                `xml:"CurrentURI"`
+	CurrentURIMetaData string                `xml:"CurrentURIMetaData"`
+	NextURI            string                `xml:"NextURI"`
+	NextURIMetaData    string                `xml:"NextURIMetaData"`
+	PlayMedium         PlaybackStorageMedium `xml:"PlayMedium"`
+	RecordMedium       RecordStorageMedium   `xml:"RecordMedium"`
+	WriteStatus        string                `xml:"WriteStatus"`
 }
 
 func (s *Service) GetMediaInfo(httpClient *http.Client, args *GetMediaInfoArgs) (*GetMediaInfoResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetMediaInfo`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetMediaInfo: args},
-		})
-	if err != nil {
+	return s.GetMediaInfoContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetMediaInfoContext(ctx context.Context, httpClient *http.Client, args *GetMediaInfoArgs) (*GetMediaInfoResponse, error) {
+	var r GetMediaInfoResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetMediaInfo`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetMediaInfo == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.GetMediaInfo()`)
-	}
-
-	return r.Body.GetMediaInfo, nil
+	return &r, nil
 }
 
 type GetTransportInfoArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetTransportInfoArgs) Validate() error {
+	return nil
+}
+
 type GetTransportInfoResponse struct {
-	CurrentTransportState  string `xml:"CurrentTransportState"`
-	CurrentTransportStatus string `xml:"CurrentTransportStatus"`
-	CurrentSpeed           string `xml:"CurrentSpeed"`
+	CurrentTransportState  TransportState     `xml:"CurrentTransportState"`
+	CurrentTransportStatus string             `xml:"CurrentTransportStatus"`
+	CurrentSpeed           TransportPlaySpeed `xml:"CurrentSpeed"`
 }
 
 func (s *Service) GetTransportInfo(httpClient *http.Client, args *GetTransportInfoArgs) (*GetTransportInfoResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetTransportInfo`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetTransportInfo: args},
-		})
-	if err != nil {
+	return s.GetTransportInfoContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetTransportInfoContext(ctx context.Context, httpClient *http.Client, args *GetTransportInfoArgs) (*GetTransportInfoResponse, error) {
+	var r GetTransportInfoResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetTransportInfo`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetTransportInfo == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.GetTransportInfo()`)
-	}
-
-	return r.Body.GetTransportInfo, nil
+	return &r, nil
 }
 
 type GetPositionInfoArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetPositionInfoArgs) Validate() error {
+	return nil
+}
+
 type GetPositionInfoResponse struct {
 	Track         uint32 `xml:"Track"`
 	TrackDuration string `xml:"TrackDuration"`
@@ -629,27 +552,25 @@ type GetPositionInfoResponse struct {
 }
 
 func (s *Service) GetPositionInfo(httpClient *http.Client, args *GetPositionInfoArgs) (*GetPositionInfoResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetPositionInfo`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetPositionInfo: args},
-		})
-	if err != nil {
+	return s.GetPositionInfoContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetPositionInfoContext(ctx context.Context, httpClient *http.Client, args *GetPositionInfoArgs) (*GetPositionInfoResponse, error) {
+	var r GetPositionInfoResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetPositionInfo`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetPositionInfo == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.GetPositionInfo()`)
-	}
-
-	return r.Body.GetPositionInfo, nil
+	return &r, nil
 }
 
 type GetDeviceCapabilitiesArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetDeviceCapabilitiesArgs) Validate() error {
+	return nil
+}
+
 type GetDeviceCapabilitiesResponse struct {
 	PlayMedia       string `xml:"PlayMedia"`
 	RecMedia        string `xml:"RecMedia"`
@@ -657,235 +578,221 @@ type GetDeviceCapabilitiesResponse struct {
 }
 
 func (s *Service) GetDeviceCapabilities(httpClient *http.Client, args *GetDeviceCapabilitiesArgs) (*GetDeviceCapabilitiesResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetDeviceCapabilities`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetDeviceCapabilities: args},
-		})
-	if err != nil {
+	return s.GetDeviceCapabilitiesContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetDeviceCapabilitiesContext(ctx context.Context, httpClient *http.Client, args *GetDeviceCapabilitiesArgs) (*GetDeviceCapabilitiesResponse, error) {
+	var r GetDeviceCapabilitiesResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetDeviceCapabilities`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetDeviceCapabilities == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.GetDeviceCapabilities()`)
-	}
-
-	return r.Body.GetDeviceCapabilities, nil
+	return &r, nil
 }
 
 type GetTransportSettingsArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetTransportSettingsArgs) Validate() error {
+	return nil
+}
+
 type GetTransportSettingsResponse struct {
-	PlayMode       string `xml:"PlayMode"`
-	RecQualityMode string `xml:"RecQualityMode"`
+	PlayMode       PlayMode `xml:"PlayMode"`
+	RecQualityMode string   `xml:"RecQualityMode"`
 }
 
 func (s *Service) GetTransportSettings(httpClient *http.Client, args *GetTransportSettingsArgs) (*GetTransportSettingsResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetTransportSettings`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetTransportSettings: args},
-		})
-	if err != nil {
+	return s.GetTransportSettingsContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetTransportSettingsContext(ctx context.Context, httpClient *http.Client, args *GetTransportSettingsArgs) (*GetTransportSettingsResponse, error) {
+	var r GetTransportSettingsResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetTransportSettings`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetTransportSettings == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.GetTransportSettings()`)
-	}
-
-	return r.Body.GetTransportSettings, nil
+	return &r, nil
 }
 
 type GetCrossfadeModeArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetCrossfadeModeArgs) Validate() error {
+	return nil
+}
+
 type GetCrossfadeModeResponse struct {
 	CrossfadeMode bool `xml:"CrossfadeMode"`
 }
 
 func (s *Service) GetCrossfadeMode(httpClient *http.Client, args *GetCrossfadeModeArgs) (*GetCrossfadeModeResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetCrossfadeMode`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetCrossfadeMode: args},
-		})
-	if err != nil {
+	return s.GetCrossfadeModeContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetCrossfadeModeContext(ctx context.Context, httpClient *http.Client, args *GetCrossfadeModeArgs) (*GetCrossfadeModeResponse, error) {
+	var r GetCrossfadeModeResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetCrossfadeMode`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetCrossfadeMode == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.GetCrossfadeMode()`)
-	}
-
-	return r.Body.GetCrossfadeMode, nil
+	return &r, nil
 }
 
 type StopArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *StopArgs) Validate() error {
+	return nil
+}
+
 type StopResponse struct {
 }
 
 func (s *Service) Stop(httpClient *http.Client, args *StopArgs) (*StopResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`Stop`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{Stop: args},
-		})
-	if err != nil {
+	return s.StopContext(context.Background(), httpClient, args)
+}
+func (s *Service) StopContext(ctx context.Context, httpClient *http.Client, args *StopArgs) (*StopResponse, error) {
+	var r StopResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `Stop`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.Stop == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.Stop()`)
-	}
-
-	return r.Body.Stop, nil
+	return &r, nil
 }
 
 type PlayArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	// Allowed Value: 1
-	Speed string `xml:"Speed"`
+	Speed TransportPlaySpeed `xml:"Speed"`
+}
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *PlayArgs) Validate() error {
+	switch a.Speed {
+	case "", TransportPlaySpeed1:
+	default:
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `Play`, Argument: `Speed`, Value: a.Speed, Allowed: "one of 1"}
+	}
+	return nil
 }
+
 type PlayResponse struct {
 }
 
 func (s *Service) Play(httpClient *http.Client, args *PlayArgs) (*PlayResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`Play`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{Play: args},
-		})
-	if err != nil {
+	return s.PlayContext(context.Background(), httpClient, args)
+}
+func (s *Service) PlayContext(ctx context.Context, httpClient *http.Client, args *PlayArgs) (*PlayResponse, error) {
+	var r PlayResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `Play`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.Play == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.Play()`)
-	}
-
-	return r.Body.Play, nil
+	return &r, nil
 }
 
 type PauseArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *PauseArgs) Validate() error {
+	return nil
+}
+
 type PauseResponse struct {
 }
 
 func (s *Service) Pause(httpClient *http.Client, args *PauseArgs) (*PauseResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`Pause`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{Pause: args},
-		})
-	if err != nil {
+	return s.PauseContext(context.Background(), httpClient, args)
+}
+func (s *Service) PauseContext(ctx context.Context, httpClient *http.Client, args *PauseArgs) (*PauseResponse, error) {
+	var r PauseResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `Pause`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.Pause == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.Pause()`)
-	}
-
-	return r.Body.Pause, nil
+	return &r, nil
 }
 
 type SeekArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	// Allowed Value: TRACK_NR
 	// Allowed Value: REL_TIME
 	// Allowed Value: TIME_DELTA
-	Unit   string `xml:"Unit"`
-	Target string `xml:"Target"`
+	Unit   SeekUnit `xml:"Unit"`
+	Target string   `xml:"Target"`
+}
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SeekArgs) Validate() error {
+	switch a.Unit {
+	case "", SeekUnitTrackNR, SeekUnitRelTime, SeekUnitTimeDelta:
+	default:
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `Seek`, Argument: `Unit`, Value: a.Unit, Allowed: "one of TRACK_NR, REL_TIME, TIME_DELTA"}
+	}
+	return nil
 }
+
 type SeekResponse struct {
 }
 
 func (s *Service) Seek(httpClient *http.Client, args *SeekArgs) (*SeekResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`Seek`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{Seek: args},
-		})
-	if err != nil {
+	return s.SeekContext(context.Background(), httpClient, args)
+}
+func (s *Service) SeekContext(ctx context.Context, httpClient *http.Client, args *SeekArgs) (*SeekResponse, error) {
+	var r SeekResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `Seek`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.Seek == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.Seek()`)
-	}
-
-	return r.Body.Seek, nil
+	return &r, nil
 }
 
 type NextArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *NextArgs) Validate() error {
+	return nil
+}
+
 type NextResponse struct {
 }
 
 func (s *Service) Next(httpClient *http.Client, args *NextArgs) (*NextResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`Next`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{Next: args},
-		})
-	if err != nil {
+	return s.NextContext(context.Background(), httpClient, args)
+}
+func (s *Service) NextContext(ctx context.Context, httpClient *http.Client, args *NextArgs) (*NextResponse, error) {
+	var r NextResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `Next`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.Next == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.Next()`)
-	}
-
-	return r.Body.Next, nil
+	return &r, nil
 }
 
 type PreviousArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *PreviousArgs) Validate() error {
+	return nil
+}
+
 type PreviousResponse struct {
 }
 
 func (s *Service) Previous(httpClient *http.Client, args *PreviousArgs) (*PreviousResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`Previous`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{Previous: args},
-		})
-	if err != nil {
+	return s.PreviousContext(context.Background(), httpClient, args)
+}
+func (s *Service) PreviousContext(ctx context.Context, httpClient *http.Client, args *PreviousArgs) (*PreviousResponse, error) {
+	var r PreviousResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `Previous`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.Previous == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.Previous()`)
-	}
-
-	return r.Body.Previous, nil
+	return &r, nil
 }
 
 type SetPlayModeArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	// Allowed Value: NORMAL
 	// Allowed Value: REPEAT_ALL
@@ -893,163 +800,156 @@ type SetPlayModeArgs struct {
 	// Allowed Value: SHUFFLE_NOREPEAT
 	// Allowed Value: SHUFFLE
 	// Allowed Value: SHUFFLE_REPEAT_ONE
-	NewPlayMode string `xml:"NewPlayMode"`
+	NewPlayMode PlayMode `xml:"NewPlayMode"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetPlayModeArgs) Validate() error {
+	switch a.NewPlayMode {
+	case "", PlayModeNormal, PlayModeRepeatAll, PlayModeRepeatOne, PlayModeShuffleNoRepeat, PlayModeShuffle, PlayModeShuffleRepeatOne:
+	default:
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `SetPlayMode`, Argument: `NewPlayMode`, Value: a.NewPlayMode, Allowed: "one of NORMAL, REPEAT_ALL, REPEAT_ONE, SHUFFLE_NOREPEAT, SHUFFLE, SHUFFLE_REPEAT_ONE"}
+	}
+	return nil
+}
+
 type SetPlayModeResponse struct {
 }
 
 func (s *Service) SetPlayMode(httpClient *http.Client, args *SetPlayModeArgs) (*SetPlayModeResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetPlayMode`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetPlayMode: args},
-		})
-	if err != nil {
+	return s.SetPlayModeContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetPlayModeContext(ctx context.Context, httpClient *http.Client, args *SetPlayModeArgs) (*SetPlayModeResponse, error) {
+	var r SetPlayModeResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetPlayMode`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetPlayMode == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.SetPlayMode()`)
-	}
-
-	return r.Body.SetPlayMode, nil
+	return &r, nil
 }
 
 type SetCrossfadeModeArgs struct {
-	Xmlns         string `xml:"xmlns:u,attr"`
 	InstanceID    uint32 `xml:"InstanceID"`
 	CrossfadeMode bool   `xml:"CrossfadeMode"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetCrossfadeModeArgs) Validate() error {
+	return nil
+}
+
 type SetCrossfadeModeResponse struct {
 }
 
 func (s *Service) SetCrossfadeMode(httpClient *http.Client, args *SetCrossfadeModeArgs) (*SetCrossfadeModeResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetCrossfadeMode`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetCrossfadeMode: args},
-		})
-	if err != nil {
+	return s.SetCrossfadeModeContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetCrossfadeModeContext(ctx context.Context, httpClient *http.Client, args *SetCrossfadeModeArgs) (*SetCrossfadeModeResponse, error) {
+	var r SetCrossfadeModeResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetCrossfadeMode`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetCrossfadeMode == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.SetCrossfadeMode()`)
-	}
-
-	return r.Body.SetCrossfadeMode, nil
+	return &r, nil
 }
 
 type NotifyDeletedURIArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	DeletedURI string `xml:"DeletedURI"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *NotifyDeletedURIArgs) Validate() error {
+	return nil
+}
+
 type NotifyDeletedURIResponse struct {
 }
 
 func (s *Service) NotifyDeletedURI(httpClient *http.Client, args *NotifyDeletedURIArgs) (*NotifyDeletedURIResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`NotifyDeletedURI`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{NotifyDeletedURI: args},
-		})
-	if err != nil {
+	return s.NotifyDeletedURIContext(context.Background(), httpClient, args)
+}
+func (s *Service) NotifyDeletedURIContext(ctx context.Context, httpClient *http.Client, args *NotifyDeletedURIArgs) (*NotifyDeletedURIResponse, error) {
+	var r NotifyDeletedURIResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `NotifyDeletedURI`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.NotifyDeletedURI == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.NotifyDeletedURI()`)
-	}
-
-	return r.Body.NotifyDeletedURI, nil
+	return &r, nil
 }
 
 type GetCurrentTransportActionsArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetCurrentTransportActionsArgs) Validate() error {
+	return nil
+}
+
 type GetCurrentTransportActionsResponse struct {
 	Actions string `xml:"Actions"`
 }
 
 func (s *Service) GetCurrentTransportActions(httpClient *http.Client, args *GetCurrentTransportActionsArgs) (*GetCurrentTransportActionsResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetCurrentTransportActions`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetCurrentTransportActions: args},
-		})
-	if err != nil {
+	return s.GetCurrentTransportActionsContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetCurrentTransportActionsContext(ctx context.Context, httpClient *http.Client, args *GetCurrentTransportActionsArgs) (*GetCurrentTransportActionsResponse, error) {
+	var r GetCurrentTransportActionsResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetCurrentTransportActions`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetCurrentTransportActions == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.GetCurrentTransportActions()`)
-	}
-
-	return r.Body.GetCurrentTransportActions, nil
+	return &r, nil
 }
 
 type BecomeCoordinatorOfStandaloneGroupArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *BecomeCoordinatorOfStandaloneGroupArgs) Validate() error {
+	return nil
+}
+
 type BecomeCoordinatorOfStandaloneGroupResponse struct {
 	DelegatedGroupCoordinatorID string `xml:"DelegatedGroupCoordinatorID"`
 	NewGroupID                  string `xml:"NewGroupID"`
 }
 
 func (s *Service) BecomeCoordinatorOfStandaloneGroup(httpClient *http.Client, args *BecomeCoordinatorOfStandaloneGroupArgs) (*BecomeCoordinatorOfStandaloneGroupResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`BecomeCoordinatorOfStandaloneGroup`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{BecomeCoordinatorOfStandaloneGroup: args},
-		})
-	if err != nil {
+	return s.BecomeCoordinatorOfStandaloneGroupContext(context.Background(), httpClient, args)
+}
+func (s *Service) BecomeCoordinatorOfStandaloneGroupContext(ctx context.Context, httpClient *http.Client, args *BecomeCoordinatorOfStandaloneGroupArgs) (*BecomeCoordinatorOfStandaloneGroupResponse, error) {
+	var r BecomeCoordinatorOfStandaloneGroupResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `BecomeCoordinatorOfStandaloneGroup`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.BecomeCoordinatorOfStandaloneGroup == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.BecomeCoordinatorOfStandaloneGroup()`)
-	}
-
-	return r.Body.BecomeCoordinatorOfStandaloneGroup, nil
+	return &r, nil
 }
 
 type DelegateGroupCoordinationToArgs struct {
-	Xmlns          string `xml:"xmlns:u,attr"`
 	InstanceID     uint32 `xml:"InstanceID"`
 	NewCoordinator string `xml:"NewCoordinator"`
 	RejoinGroup    bool   `xml:"RejoinGroup"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *DelegateGroupCoordinationToArgs) Validate() error {
+	return nil
+}
+
 type DelegateGroupCoordinationToResponse struct {
 }
 
 func (s *Service) DelegateGroupCoordinationTo(httpClient *http.Client, args *DelegateGroupCoordinationToArgs) (*DelegateGroupCoordinationToResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`DelegateGroupCoordinationTo`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{DelegateGroupCoordinationTo: args},
-		})
-	if err != nil {
+	return s.DelegateGroupCoordinationToContext(context.Background(), httpClient, args)
+}
+func (s *Service) DelegateGroupCoordinationToContext(ctx context.Context, httpClient *http.Client, args *DelegateGroupCoordinationToArgs) (*DelegateGroupCoordinationToResponse, error) {
+	var r DelegateGroupCoordinationToResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `DelegateGroupCoordinationTo`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.DelegateGroupCoordinationTo == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.DelegateGroupCoordinationTo()`)
-	}
-
-	return r.Body.DelegateGroupCoordinationTo, nil
+	return &r, nil
 }
 
 type BecomeGroupCoordinatorArgs struct {
-	Xmlns                 string `xml:"xmlns:u,attr"`
 	InstanceID            uint32 `xml:"InstanceID"`
 	CurrentCoordinator    string `xml:"CurrentCoordinator"`
 	CurrentGroupID        string `xml:"CurrentGroupID"`
@@ -1063,29 +963,27 @@ type BecomeGroupCoordinatorArgs struct {
 	CurrentQueueTrackList string `xml:"CurrentQueueTrackList"`
 	CurrentVLIState       string `xml:"CurrentVLIState"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *BecomeGroupCoordinatorArgs) Validate() error {
+	return nil
+}
+
 type BecomeGroupCoordinatorResponse struct {
 }
 
 func (s *Service) BecomeGroupCoordinator(httpClient *http.Client, args *BecomeGroupCoordinatorArgs) (*BecomeGroupCoordinatorResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`BecomeGroupCoordinator`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{BecomeGroupCoordinator: args},
-		})
-	if err != nil {
+	return s.BecomeGroupCoordinatorContext(context.Background(), httpClient, args)
+}
+func (s *Service) BecomeGroupCoordinatorContext(ctx context.Context, httpClient *http.Client, args *BecomeGroupCoordinatorArgs) (*BecomeGroupCoordinatorResponse, error) {
+	var r BecomeGroupCoordinatorResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `BecomeGroupCoordinator`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.BecomeGroupCoordinator == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.BecomeGroupCoordinator()`)
-	}
-
-	return r.Body.BecomeGroupCoordinator, nil
+	return &r, nil
 }
 
 type BecomeGroupCoordinatorAndSourceArgs struct {
-	Xmlns                 string `xml:"xmlns:u,attr"`
 	InstanceID            uint32 `xml:"InstanceID"`
 	CurrentCoordinator    string `xml:"CurrentCoordinator"`
 	CurrentGroupID        string `xml:"CurrentGroupID"`
@@ -1100,138 +998,128 @@ type BecomeGroupCoordinatorAndSourceArgs struct {
 	CurrentSourceState    string `xml:"CurrentSourceState"`
 	ResumePlayback        bool   `xml:"ResumePlayback"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *BecomeGroupCoordinatorAndSourceArgs) Validate() error {
+	return nil
+}
+
 type BecomeGroupCoordinatorAndSourceResponse struct {
 }
 
 func (s *Service) BecomeGroupCoordinatorAndSource(httpClient *http.Client, args *BecomeGroupCoordinatorAndSourceArgs) (*BecomeGroupCoordinatorAndSourceResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`BecomeGroupCoordinatorAndSource`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{BecomeGroupCoordinatorAndSource: args},
-		})
-	if err != nil {
+	return s.BecomeGroupCoordinatorAndSourceContext(context.Background(), httpClient, args)
+}
+func (s *Service) BecomeGroupCoordinatorAndSourceContext(ctx context.Context, httpClient *http.Client, args *BecomeGroupCoordinatorAndSourceArgs) (*BecomeGroupCoordinatorAndSourceResponse, error) {
+	var r BecomeGroupCoordinatorAndSourceResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `BecomeGroupCoordinatorAndSource`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.BecomeGroupCoordinatorAndSource == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.BecomeGroupCoordinatorAndSource()`)
-	}
-
-	return r.Body.BecomeGroupCoordinatorAndSource, nil
+	return &r, nil
 }
 
 type ChangeCoordinatorArgs struct {
-	Xmlns                 string `xml:"xmlns:u,attr"`
 	InstanceID            uint32 `xml:"InstanceID"`
 	CurrentCoordinator    string `xml:"CurrentCoordinator"`
 	NewCoordinator        string `xml:"NewCoordinator"`
 	NewTransportSettings  string `xml:"NewTransportSettings"`
 	CurrentAVTransportURI string `xml:"CurrentAVTransportURI"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *ChangeCoordinatorArgs) Validate() error {
+	return nil
+}
+
 type ChangeCoordinatorResponse struct {
 }
 
 func (s *Service) ChangeCoordinator(httpClient *http.Client, args *ChangeCoordinatorArgs) (*ChangeCoordinatorResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`ChangeCoordinator`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{ChangeCoordinator: args},
-		})
-	if err != nil {
+	return s.ChangeCoordinatorContext(context.Background(), httpClient, args)
+}
+func (s *Service) ChangeCoordinatorContext(ctx context.Context, httpClient *http.Client, args *ChangeCoordinatorArgs) (*ChangeCoordinatorResponse, error) {
+	var r ChangeCoordinatorResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `ChangeCoordinator`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.ChangeCoordinator == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.ChangeCoordinator()`)
-	}
-
-	return r.Body.ChangeCoordinator, nil
+	return &r, nil
 }
 
 type ChangeTransportSettingsArgs struct {
-	Xmlns                 string `xml:"xmlns:u,attr"`
 	InstanceID            uint32 `xml:"InstanceID"`
 	NewTransportSettings  string `xml:"NewTransportSettings"`
 	CurrentAVTransportURI string `xml:"CurrentAVTransportURI"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *ChangeTransportSettingsArgs) Validate() error {
+	return nil
+}
+
 type ChangeTransportSettingsResponse struct {
 }
 
 func (s *Service) ChangeTransportSettings(httpClient *http.Client, args *ChangeTransportSettingsArgs) (*ChangeTransportSettingsResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`ChangeTransportSettings`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{ChangeTransportSettings: args},
-		})
-	if err != nil {
+	return s.ChangeTransportSettingsContext(context.Background(), httpClient, args)
+}
+func (s *Service) ChangeTransportSettingsContext(ctx context.Context, httpClient *http.Client, args *ChangeTransportSettingsArgs) (*ChangeTransportSettingsResponse, error) {
+	var r ChangeTransportSettingsResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `ChangeTransportSettings`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.ChangeTransportSettings == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.ChangeTransportSettings()`)
-	}
-
-	return r.Body.ChangeTransportSettings, nil
+	return &r, nil
 }
 
 type ConfigureSleepTimerArgs struct {
-	Xmlns                 string `xml:"xmlns:u,attr"`
 	InstanceID            uint32 `xml:"InstanceID"`
 	NewSleepTimerDuration string `xml:"NewSleepTimerDuration"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *ConfigureSleepTimerArgs) Validate() error {
+	return nil
+}
+
 type ConfigureSleepTimerResponse struct {
 }
 
 func (s *Service) ConfigureSleepTimer(httpClient *http.Client, args *ConfigureSleepTimerArgs) (*ConfigureSleepTimerResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`ConfigureSleepTimer`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{ConfigureSleepTimer: args},
-		})
-	if err != nil {
+	return s.ConfigureSleepTimerContext(context.Background(), httpClient, args)
+}
+func (s *Service) ConfigureSleepTimerContext(ctx context.Context, httpClient *http.Client, args *ConfigureSleepTimerArgs) (*ConfigureSleepTimerResponse, error) {
+	var r ConfigureSleepTimerResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `ConfigureSleepTimer`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.ConfigureSleepTimer == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.ConfigureSleepTimer()`)
-	}
-
-	return r.Body.ConfigureSleepTimer, nil
+	return &r, nil
 }
 
 type GetRemainingSleepTimerDurationArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetRemainingSleepTimerDurationArgs) Validate() error {
+	return nil
+}
+
 type GetRemainingSleepTimerDurationResponse struct {
 	RemainingSleepTimerDuration string `xml:"RemainingSleepTimerDuration"`
 	CurrentSleepTimerGeneration uint32 `xml:"CurrentSleepTimerGeneration"`
 }
 
 func (s *Service) GetRemainingSleepTimerDuration(httpClient *http.Client, args *GetRemainingSleepTimerDurationArgs) (*GetRemainingSleepTimerDurationResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetRemainingSleepTimerDuration`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetRemainingSleepTimerDuration: args},
-		})
-	if err != nil {
+	return s.GetRemainingSleepTimerDurationContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetRemainingSleepTimerDurationContext(ctx context.Context, httpClient *http.Client, args *GetRemainingSleepTimerDurationArgs) (*GetRemainingSleepTimerDurationResponse, error) {
+	var r GetRemainingSleepTimerDurationResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetRemainingSleepTimerDuration`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetRemainingSleepTimerDuration == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.GetRemainingSleepTimerDuration()`)
-	}
-
-	return r.Body.GetRemainingSleepTimerDuration, nil
+	return &r, nil
 }
 
 type RunAlarmArgs struct {
-	Xmlns           string `xml:"xmlns:u,attr"`
 	InstanceID      uint32 `xml:"InstanceID"`
 	AlarmID         uint32 `xml:"AlarmID"`
 	LoggedStartTime string `xml:"LoggedStartTime"`
@@ -1244,33 +1132,36 @@ type RunAlarmArgs struct {
 	// Allowed Value: SHUFFLE_NOREPEAT
 	// Allowed Value: SHUFFLE
 	// Allowed Value: SHUFFLE_REPEAT_ONE
-	PlayMode           string `xml:"PlayMode"`
-	Volume             uint16 `xml:"Volume"`
-	IncludeLinkedZones bool   `xml:"IncludeLinkedZones"`
+	PlayMode           PlayMode `xml:"PlayMode"`
+	Volume             uint16   `xml:"Volume"`
+	IncludeLinkedZones bool     `xml:"IncludeLinkedZones"`
+}
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *RunAlarmArgs) Validate() error {
+	switch a.PlayMode {
+	case "", PlayModeNormal, PlayModeRepeatAll, PlayModeRepeatOne, PlayModeShuffleNoRepeat, PlayModeShuffle, PlayModeShuffleRepeatOne:
+	default:
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `RunAlarm`, Argument: `PlayMode`, Value: a.PlayMode, Allowed: "one of NORMAL, REPEAT_ALL, REPEAT_ONE, SHUFFLE_NOREPEAT, SHUFFLE, SHUFFLE_REPEAT_ONE"}
+	}
+	return nil
 }
+
 type RunAlarmResponse struct {
 }
 
 func (s *Service) RunAlarm(httpClient *http.Client, args *RunAlarmArgs) (*RunAlarmResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`RunAlarm`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{RunAlarm: args},
-		})
-	if err != nil {
+	return s.RunAlarmContext(context.Background(), httpClient, args)
+}
+func (s *Service) RunAlarmContext(ctx context.Context, httpClient *http.Client, args *RunAlarmArgs) (*RunAlarmResponse, error) {
+	var r RunAlarmResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `RunAlarm`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.RunAlarm == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.RunAlarm()`)
-	}
-
-	return r.Body.RunAlarm, nil
+	return &r, nil
 }
 
 type StartAutoplayArgs struct {
-	Xmlns              string `xml:"xmlns:u,attr"`
 	InstanceID         uint32 `xml:"InstanceID"`
 	ProgramURI         string `xml:"ProgramURI"`
 	ProgramMetaData    string `xml:"ProgramMetaData"`
@@ -1278,31 +1169,35 @@ type StartAutoplayArgs struct {
 	IncludeLinkedZones bool   `xml:"IncludeLinkedZones"`
 	ResetVolumeAfter   bool   `xml:"ResetVolumeAfter"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *StartAutoplayArgs) Validate() error {
+	return nil
+}
+
 type StartAutoplayResponse struct {
 }
 
 func (s *Service) StartAutoplay(httpClient *http.Client, args *StartAutoplayArgs) (*StartAutoplayResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`StartAutoplay`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{StartAutoplay: args},
-		})
-	if err != nil {
+	return s.StartAutoplayContext(context.Background(), httpClient, args)
+}
+func (s *Service) StartAutoplayContext(ctx context.Context, httpClient *http.Client, args *StartAutoplayArgs) (*StartAutoplayResponse, error) {
+	var r StartAutoplayResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `StartAutoplay`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.StartAutoplay == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.StartAutoplay()`)
-	}
-
-	return r.Body.StartAutoplay, nil
+	return &r, nil
 }
 
 type GetRunningAlarmPropertiesArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetRunningAlarmPropertiesArgs) Validate() error {
+	return nil
+}
+
 type GetRunningAlarmPropertiesResponse struct {
 	AlarmID         uint32 `xml:"AlarmID"`
 	GroupID         string `xml:"GroupID"`
@@ -1310,70 +1205,645 @@ type GetRunningAlarmPropertiesResponse struct {
 }
 
 func (s *Service) GetRunningAlarmProperties(httpClient *http.Client, args *GetRunningAlarmPropertiesArgs) (*GetRunningAlarmPropertiesResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetRunningAlarmProperties`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetRunningAlarmProperties: args},
-		})
-	if err != nil {
+	return s.GetRunningAlarmPropertiesContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetRunningAlarmPropertiesContext(ctx context.Context, httpClient *http.Client, args *GetRunningAlarmPropertiesArgs) (*GetRunningAlarmPropertiesResponse, error) {
+	var r GetRunningAlarmPropertiesResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetRunningAlarmProperties`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetRunningAlarmProperties == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.GetRunningAlarmProperties()`)
-	}
-
-	return r.Body.GetRunningAlarmProperties, nil
+	return &r, nil
 }
 
 type SnoozeAlarmArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	Duration   string `xml:"Duration"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SnoozeAlarmArgs) Validate() error {
+	return nil
+}
+
 type SnoozeAlarmResponse struct {
 }
 
 func (s *Service) SnoozeAlarm(httpClient *http.Client, args *SnoozeAlarmArgs) (*SnoozeAlarmResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SnoozeAlarm`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SnoozeAlarm: args},
-		})
-	if err != nil {
+	return s.SnoozeAlarmContext(context.Background(), httpClient, args)
+}
+func (s *Service) SnoozeAlarmContext(ctx context.Context, httpClient *http.Client, args *SnoozeAlarmArgs) (*SnoozeAlarmResponse, error) {
+	var r SnoozeAlarmResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SnoozeAlarm`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SnoozeAlarm == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.SnoozeAlarm()`)
-	}
-
-	return r.Body.SnoozeAlarm, nil
+	return &r, nil
 }
 
 type EndDirectControlSessionArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *EndDirectControlSessionArgs) Validate() error {
+	return nil
+}
+
 type EndDirectControlSessionResponse struct {
 }
 
 func (s *Service) EndDirectControlSession(httpClient *http.Client, args *EndDirectControlSessionArgs) (*EndDirectControlSessionResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`EndDirectControlSession`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{EndDirectControlSession: args},
-		})
-	if err != nil {
+	return s.EndDirectControlSessionContext(context.Background(), httpClient, args)
+}
+func (s *Service) EndDirectControlSessionContext(ctx context.Context, httpClient *http.Client, args *EndDirectControlSessionArgs) (*EndDirectControlSessionResponse, error) {
+	var r EndDirectControlSessionResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `EndDirectControlSession`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.EndDirectControlSession == nil {
-		return nil, errors.New(`unexpected respose from service calling avtransport.EndDirectControlSession()`)
+	return &r, nil
+}
+
+// Client is the set of actions of the service. It is implemented by Service and Fake
+type Client interface {
+	SetAVTransportURI(httpClient *http.Client, args *SetAVTransportURIArgs) (*SetAVTransportURIResponse, error)
+	SetAVTransportURIContext(ctx context.Context, httpClient *http.Client, args *SetAVTransportURIArgs) (*SetAVTransportURIResponse, error)
+	SetNextAVTransportURI(httpClient *http.Client, args *SetNextAVTransportURIArgs) (*SetNextAVTransportURIResponse, error)
+	SetNextAVTransportURIContext(ctx context.Context, httpClient *http.Client, args *SetNextAVTransportURIArgs) (*SetNextAVTransportURIResponse, error)
+	AddURIToQueue(httpClient *http.Client, args *AddURIToQueueArgs) (*AddURIToQueueResponse, error)
+	AddURIToQueueContext(ctx context.Context, httpClient *http.Client, args *AddURIToQueueArgs) (*AddURIToQueueResponse, error)
+	AddMultipleURIsToQueue(httpClient *http.Client, args *AddMultipleURIsToQueueArgs) (*AddMultipleURIsToQueueResponse, error)
+	AddMultipleURIsToQueueContext(ctx context.Context, httpClient *http.Client, args *AddMultipleURIsToQueueArgs) (*AddMultipleURIsToQueueResponse, error)
+	ReorderTracksInQueue(httpClient *http.Client, args *ReorderTracksInQueueArgs) (*ReorderTracksInQueueResponse, error)
+	ReorderTracksInQueueContext(ctx context.Context, httpClient *http.Client, args *ReorderTracksInQueueArgs) (*ReorderTracksInQueueResponse, error)
+	RemoveTrackFromQueue(httpClient *http.Client, args *RemoveTrackFromQueueArgs) (*RemoveTrackFromQueueResponse, error)
+	RemoveTrackFromQueueContext(ctx context.Context, httpClient *http.Client, args *RemoveTrackFromQueueArgs) (*RemoveTrackFromQueueResponse, error)
+	RemoveTrackRangeFromQueue(httpClient *http.Client, args *RemoveTrackRangeFromQueueArgs) (*RemoveTrackRangeFromQueueResponse, error)
+	RemoveTrackRangeFromQueueContext(ctx context.Context, httpClient *http.Client, args *RemoveTrackRangeFromQueueArgs) (*RemoveTrackRangeFromQueueResponse, error)
+	RemoveAllTracksFromQueue(httpClient *http.Client, args *RemoveAllTracksFromQueueArgs) (*RemoveAllTracksFromQueueResponse, error)
+	RemoveAllTracksFromQueueContext(ctx context.Context, httpClient *http.Client, args *RemoveAllTracksFromQueueArgs) (*RemoveAllTracksFromQueueResponse, error)
+	SaveQueue(httpClient *http.Client, args *SaveQueueArgs) (*SaveQueueResponse, error)
+	SaveQueueContext(ctx context.Context, httpClient *http.Client, args *SaveQueueArgs) (*SaveQueueResponse, error)
+	BackupQueue(httpClient *http.Client, args *BackupQueueArgs) (*BackupQueueResponse, error)
+	BackupQueueContext(ctx context.Context, httpClient *http.Client, args *BackupQueueArgs) (*BackupQueueResponse, error)
+	CreateSavedQueue(httpClient *http.Client, args *CreateSavedQueueArgs) (*CreateSavedQueueResponse, error)
+	CreateSavedQueueContext(ctx context.Context, httpClient *http.Client, args *CreateSavedQueueArgs) (*CreateSavedQueueResponse, error)
+	AddURIToSavedQueue(httpClient *http.Client, args *AddURIToSavedQueueArgs) (*AddURIToSavedQueueResponse, error)
+	AddURIToSavedQueueContext(ctx context.Context, httpClient *http.Client, args *AddURIToSavedQueueArgs) (*AddURIToSavedQueueResponse, error)
+	ReorderTracksInSavedQueue(httpClient *http.Client, args *ReorderTracksInSavedQueueArgs) (*ReorderTracksInSavedQueueResponse, error)
+	ReorderTracksInSavedQueueContext(ctx context.Context, httpClient *http.Client, args *ReorderTracksInSavedQueueArgs) (*ReorderTracksInSavedQueueResponse, error)
+	GetMediaInfo(httpClient *http.Client, args *GetMediaInfoArgs) (*GetMediaInfoResponse, error)
+	GetMediaInfoContext(ctx context.Context, httpClient *http.Client, args *GetMediaInfoArgs) (*GetMediaInfoResponse, error)
+	GetTransportInfo(httpClient *http.Client, args *GetTransportInfoArgs) (*GetTransportInfoResponse, error)
+	GetTransportInfoContext(ctx context.Context, httpClient *http.Client, args *GetTransportInfoArgs) (*GetTransportInfoResponse, error)
+	GetPositionInfo(httpClient *http.Client, args *GetPositionInfoArgs) (*GetPositionInfoResponse, error)
+	GetPositionInfoContext(ctx context.Context, httpClient *http.Client, args *GetPositionInfoArgs) (*GetPositionInfoResponse, error)
+	GetDeviceCapabilities(httpClient *http.Client, args *GetDeviceCapabilitiesArgs) (*GetDeviceCapabilitiesResponse, error)
+	GetDeviceCapabilitiesContext(ctx context.Context, httpClient *http.Client, args *GetDeviceCapabilitiesArgs) (*GetDeviceCapabilitiesResponse, error)
+	GetTransportSettings(httpClient *http.Client, args *GetTransportSettingsArgs) (*GetTransportSettingsResponse, error)
+	GetTransportSettingsContext(ctx context.Context, httpClient *http.Client, args *GetTransportSettingsArgs) (*GetTransportSettingsResponse, error)
+	GetCrossfadeMode(httpClient *http.Client, args *GetCrossfadeModeArgs) (*GetCrossfadeModeResponse, error)
+	GetCrossfadeModeContext(ctx context.Context, httpClient *http.Client, args *GetCrossfadeModeArgs) (*GetCrossfadeModeResponse, error)
+	Stop(httpClient *http.Client, args *StopArgs) (*StopResponse, error)
+	StopContext(ctx context.Context, httpClient *http.Client, args *StopArgs) (*StopResponse, error)
+	Play(httpClient *http.Client, args *PlayArgs) (*PlayResponse, error)
+	PlayContext(ctx context.Context, httpClient *http.Client, args *PlayArgs) (*PlayResponse, error)
+	Pause(httpClient *http.Client, args *PauseArgs) (*PauseResponse, error)
+	PauseContext(ctx context.Context, httpClient *http.Client, args *PauseArgs) (*PauseResponse, error)
+	Seek(httpClient *http.Client, args *SeekArgs) (*SeekResponse, error)
+	SeekContext(ctx context.Context, httpClient *http.Client, args *SeekArgs) (*SeekResponse, error)
+	Next(httpClient *http.Client, args *NextArgs) (*NextResponse, error)
+	NextContext(ctx context.Context, httpClient *http.Client, args *NextArgs) (*NextResponse, error)
+	Previous(httpClient *http.Client, args *PreviousArgs) (*PreviousResponse, error)
+	PreviousContext(ctx context.Context, httpClient *http.Client, args *PreviousArgs) (*PreviousResponse, error)
+	SetPlayMode(httpClient *http.Client, args *SetPlayModeArgs) (*SetPlayModeResponse, error)
+	SetPlayModeContext(ctx context.Context, httpClient *http.Client, args *SetPlayModeArgs) (*SetPlayModeResponse, error)
+	SetCrossfadeMode(httpClient *http.Client, args *SetCrossfadeModeArgs) (*SetCrossfadeModeResponse, error)
+	SetCrossfadeModeContext(ctx context.Context, httpClient *http.Client, args *SetCrossfadeModeArgs) (*SetCrossfadeModeResponse, error)
+	NotifyDeletedURI(httpClient *http.Client, args *NotifyDeletedURIArgs) (*NotifyDeletedURIResponse, error)
+	NotifyDeletedURIContext(ctx context.Context, httpClient *http.Client, args *NotifyDeletedURIArgs) (*NotifyDeletedURIResponse, error)
+	GetCurrentTransportActions(httpClient *http.Client, args *GetCurrentTransportActionsArgs) (*GetCurrentTransportActionsResponse, error)
+	GetCurrentTransportActionsContext(ctx context.Context, httpClient *http.Client, args *GetCurrentTransportActionsArgs) (*GetCurrentTransportActionsResponse, error)
+	BecomeCoordinatorOfStandaloneGroup(httpClient *http.Client, args *BecomeCoordinatorOfStandaloneGroupArgs) (*BecomeCoordinatorOfStandaloneGroupResponse, error)
+	BecomeCoordinatorOfStandaloneGroupContext(ctx context.Context, httpClient *http.Client, args *BecomeCoordinatorOfStandaloneGroupArgs) (*BecomeCoordinatorOfStandaloneGroupResponse, error)
+	DelegateGroupCoordinationTo(httpClient *http.Client, args *DelegateGroupCoordinationToArgs) (*DelegateGroupCoordinationToResponse, error)
+	DelegateGroupCoordinationToContext(ctx context.Context, httpClient *http.Client, args *DelegateGroupCoordinationToArgs) (*DelegateGroupCoordinationToResponse, error)
+	BecomeGroupCoordinator(httpClient *http.Client, args *BecomeGroupCoordinatorArgs) (*BecomeGroupCoordinatorResponse, error)
+	BecomeGroupCoordinatorContext(ctx context.Context, httpClient *http.Client, args *BecomeGroupCoordinatorArgs) (*BecomeGroupCoordinatorResponse, error)
+	BecomeGroupCoordinatorAndSource(httpClient *http.Client, args *BecomeGroupCoordinatorAndSourceArgs) (*BecomeGroupCoordinatorAndSourceResponse, error)
+	BecomeGroupCoordinatorAndSourceContext(ctx context.Context, httpClient *http.Client, args *BecomeGroupCoordinatorAndSourceArgs) (*BecomeGroupCoordinatorAndSourceResponse, error)
+	ChangeCoordinator(httpClient *http.Client, args *ChangeCoordinatorArgs) (*ChangeCoordinatorResponse, error)
+	ChangeCoordinatorContext(ctx context.Context, httpClient *http.Client, args *ChangeCoordinatorArgs) (*ChangeCoordinatorResponse, error)
+	ChangeTransportSettings(httpClient *http.Client, args *ChangeTransportSettingsArgs) (*ChangeTransportSettingsResponse, error)
+	ChangeTransportSettingsContext(ctx context.Context, httpClient *http.Client, args *ChangeTransportSettingsArgs) (*ChangeTransportSettingsResponse, error)
+	ConfigureSleepTimer(httpClient *http.Client, args *ConfigureSleepTimerArgs) (*ConfigureSleepTimerResponse, error)
+	ConfigureSleepTimerContext(ctx context.Context, httpClient *http.Client, args *ConfigureSleepTimerArgs) (*ConfigureSleepTimerResponse, error)
+	GetRemainingSleepTimerDuration(httpClient *http.Client, args *GetRemainingSleepTimerDurationArgs) (*GetRemainingSleepTimerDurationResponse, error)
+	GetRemainingSleepTimerDurationContext(ctx context.Context, httpClient *http.Client, args *GetRemainingSleepTimerDurationArgs) (*GetRemainingSleepTimerDurationResponse, error)
+	RunAlarm(httpClient *http.Client, args *RunAlarmArgs) (*RunAlarmResponse, error)
+	RunAlarmContext(ctx context.Context, httpClient *http.Client, args *RunAlarmArgs) (*RunAlarmResponse, error)
+	StartAutoplay(httpClient *http.Client, args *StartAutoplayArgs) (*StartAutoplayResponse, error)
+	StartAutoplayContext(ctx context.Context, httpClient *http.Client, args *StartAutoplayArgs) (*StartAutoplayResponse, error)
+	GetRunningAlarmProperties(httpClient *http.Client, args *GetRunningAlarmPropertiesArgs) (*GetRunningAlarmPropertiesResponse, error)
+	GetRunningAlarmPropertiesContext(ctx context.Context, httpClient *http.Client, args *GetRunningAlarmPropertiesArgs) (*GetRunningAlarmPropertiesResponse, error)
+	SnoozeAlarm(httpClient *http.Client, args *SnoozeAlarmArgs) (*SnoozeAlarmResponse, error)
+	SnoozeAlarmContext(ctx context.Context, httpClient *http.Client, args *SnoozeAlarmArgs) (*SnoozeAlarmResponse, error)
+	EndDirectControlSession(httpClient *http.Client, args *EndDirectControlSessionArgs) (*EndDirectControlSessionResponse, error)
+	EndDirectControlSessionContext(ctx context.Context, httpClient *http.Client, args *EndDirectControlSessionArgs) (*EndDirectControlSessionResponse, error)
+}
+
+var _ Client = (*Service)(nil)
+var _ Client = (*Fake)(nil)
+
+// Call is an action received by a Fake
+type Call struct {
+	Action string
+	// Args is the *<Action>Args the action was called with
+	Args interface{}
+}
+
+// Fake is an in-memory Client for tests. It records every call, and answers
+// an action with its On<Action> func, or with an empty response if that is nil
+type Fake struct {
+	mu                                   sync.Mutex
+	calls                                []Call
+	OnSetAVTransportURI                  func(ctx context.Context, args *SetAVTransportURIArgs) (*SetAVTransportURIResponse, error)
+	OnSetNextAVTransportURI              func(ctx context.Context, args *SetNextAVTransportURIArgs) (*SetNextAVTransportURIResponse, error)
+	OnAddURIToQueue                      func(ctx context.Context, args *AddURIToQueueArgs) (*AddURIToQueueResponse, error)
+	OnAddMultipleURIsToQueue             func(ctx context.Context, args *AddMultipleURIsToQueueArgs) (*AddMultipleURIsToQueueResponse, error)
+	OnReorderTracksInQueue               func(ctx context.Context, args *ReorderTracksInQueueArgs) (*ReorderTracksInQueueResponse, error)
+	OnRemoveTrackFromQueue               func(ctx context.Context, args *RemoveTrackFromQueueArgs) (*RemoveTrackFromQueueResponse, error)
+	OnRemoveTrackRangeFromQueue          func(ctx context.Context, args *RemoveTrackRangeFromQueueArgs) (*RemoveTrackRangeFromQueueResponse, error)
+	OnRemoveAllTracksFromQueue           func(ctx context.Context, args *RemoveAllTracksFromQueueArgs) (*RemoveAllTracksFromQueueResponse, error)
+	OnSaveQueue                          func(ctx context.Context, args *SaveQueueArgs) (*SaveQueueResponse, error)
+	OnBackupQueue                        func(ctx context.Context, args *BackupQueueArgs) (*BackupQueueResponse, error)
+	OnCreateSavedQueue                   func(ctx context.Context, args *CreateSavedQueueArgs) (*CreateSavedQueueResponse, error)
+	OnAddURIToSavedQueue                 func(ctx context.Context, args *AddURIToSavedQueueArgs) (*AddURIToSavedQueueResponse, error)
+	OnReorderTracksInSavedQueue          func(ctx context.Context, args *ReorderTracksInSavedQueueArgs) (*ReorderTracksInSavedQueueResponse, error)
+	OnGetMediaInfo                       func(ctx context.Context, args *GetMediaInfoArgs) (*GetMediaInfoResponse, error)
+	OnGetTransportInfo                   func(ctx context.Context, args *GetTransportInfoArgs) (*GetTransportInfoResponse, error)
+	OnGetPositionInfo                    func(ctx context.Context, args *GetPositionInfoArgs) (*GetPositionInfoResponse, error)
+	OnGetDeviceCapabilities              func(ctx context.Context, args *GetDeviceCapabilitiesArgs) (*GetDeviceCapabilitiesResponse, error)
+	OnGetTransportSettings               func(ctx context.Context, args *GetTransportSettingsArgs) (*GetTransportSettingsResponse, error)
+	OnGetCrossfadeMode                   func(ctx context.Context, args *GetCrossfadeModeArgs) (*GetCrossfadeModeResponse, error)
+	OnStop                               func(ctx context.Context, args *StopArgs) (*StopResponse, error)
+	OnPlay                               func(ctx context.Context, args *PlayArgs) (*PlayResponse, error)
+	OnPause                              func(ctx context.Context, args *PauseArgs) (*PauseResponse, error)
+	OnSeek                               func(ctx context.Context, args *SeekArgs) (*SeekResponse, error)
+	OnNext                               func(ctx context.Context, args *NextArgs) (*NextResponse, error)
+	OnPrevious                           func(ctx context.Context, args *PreviousArgs) (*PreviousResponse, error)
+	OnSetPlayMode                        func(ctx context.Context, args *SetPlayModeArgs) (*SetPlayModeResponse, error)
+	OnSetCrossfadeMode                   func(ctx context.Context, args *SetCrossfadeModeArgs) (*SetCrossfadeModeResponse, error)
+	OnNotifyDeletedURI                   func(ctx context.Context, args *NotifyDeletedURIArgs) (*NotifyDeletedURIResponse, error)
+	OnGetCurrentTransportActions         func(ctx context.Context, args *GetCurrentTransportActionsArgs) (*GetCurrentTransportActionsResponse, error)
+	OnBecomeCoordinatorOfStandaloneGroup func(ctx context.Context, args *BecomeCoordinatorOfStandaloneGroupArgs) (*BecomeCoordinatorOfStandaloneGroupResponse, error)
+	OnDelegateGroupCoordinationTo        func(ctx context.Context, args *DelegateGroupCoordinationToArgs) (*DelegateGroupCoordinationToResponse, error)
+	OnBecomeGroupCoordinator             func(ctx context.Context, args *BecomeGroupCoordinatorArgs) (*BecomeGroupCoordinatorResponse, error)
+	OnBecomeGroupCoordinatorAndSource    func(ctx context.Context, args *BecomeGroupCoordinatorAndSourceArgs) (*BecomeGroupCoordinatorAndSourceResponse, error)
+	OnChangeCoordinator                  func(ctx context.Context, args *ChangeCoordinatorArgs) (*ChangeCoordinatorResponse, error)
+	OnChangeTransportSettings            func(ctx context.Context, args *ChangeTransportSettingsArgs) (*ChangeTransportSettingsResponse, error)
+	OnConfigureSleepTimer                func(ctx context.Context, args *ConfigureSleepTimerArgs) (*ConfigureSleepTimerResponse, error)
+	OnGetRemainingSleepTimerDuration     func(ctx context.Context, args *GetRemainingSleepTimerDurationArgs) (*GetRemainingSleepTimerDurationResponse, error)
+	OnRunAlarm                           func(ctx context.Context, args *RunAlarmArgs) (*RunAlarmResponse, error)
+	OnStartAutoplay                      func(ctx context.Context, args *StartAutoplayArgs) (*StartAutoplayResponse, error)
+	OnGetRunningAlarmProperties          func(ctx context.Context, args *GetRunningAlarmPropertiesArgs) (*GetRunningAlarmPropertiesResponse, error)
+	OnSnoozeAlarm                        func(ctx context.Context, args *SnoozeAlarmArgs) (*SnoozeAlarmResponse, error)
+	OnEndDirectControlSession            func(ctx context.Context, args *EndDirectControlSessionArgs) (*EndDirectControlSessionResponse, error)
+}
+
+// Calls returns the calls received so far, oldest first
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// Reset forgets the calls received so far
+func (f *Fake) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+}
+func (f *Fake) record(action string, args interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{Action: action, Args: args})
+}
+func (f *Fake) SetAVTransportURI(httpClient *http.Client, args *SetAVTransportURIArgs) (*SetAVTransportURIResponse, error) {
+	return f.SetAVTransportURIContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetAVTransportURIContext(ctx context.Context, httpClient *http.Client, args *SetAVTransportURIArgs) (*SetAVTransportURIResponse, error) {
+	f.record(`SetAVTransportURI`, args)
+	if f.OnSetAVTransportURI != nil {
+		return f.OnSetAVTransportURI(ctx, args)
+	}
+	return &SetAVTransportURIResponse{}, nil
+}
+func (f *Fake) SetNextAVTransportURI(httpClient *http.Client, args *SetNextAVTransportURIArgs) (*SetNextAVTransportURIResponse, error) {
+	return f.SetNextAVTransportURIContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetNextAVTransportURIContext(ctx context.Context, httpClient *http.Client, args *SetNextAVTransportURIArgs) (*SetNextAVTransportURIResponse, error) {
+	f.record(`SetNextAVTransportURI`, args)
+	if f.OnSetNextAVTransportURI != nil {
+		return f.OnSetNextAVTransportURI(ctx, args)
+	}
+	return &SetNextAVTransportURIResponse{}, nil
+}
+func (f *Fake) AddURIToQueue(httpClient *http.Client, args *AddURIToQueueArgs) (*AddURIToQueueResponse, error) {
+	return f.AddURIToQueueContext(context.Background(), httpClient, args)
+}
+func (f *Fake) AddURIToQueueContext(ctx context.Context, httpClient *http.Client, args *AddURIToQueueArgs) (*AddURIToQueueResponse, error) {
+	f.record(`AddURIToQueue`, args)
+	if f.OnAddURIToQueue != nil {
+		return f.OnAddURIToQueue(ctx, args)
+	}
+	return &AddURIToQueueResponse{}, nil
+}
+func (f *Fake) AddMultipleURIsToQueue(httpClient *http.Client, args *AddMultipleURIsToQueueArgs) (*AddMultipleURIsToQueueResponse, error) {
+	return f.AddMultipleURIsToQueueContext(context.Background(), httpClient, args)
+}
+func (f *Fake) AddMultipleURIsToQueueContext(ctx context.Context, httpClient *http.Client, args *AddMultipleURIsToQueueArgs) (*AddMultipleURIsToQueueResponse, error) {
+	f.record(`AddMultipleURIsToQueue`, args)
+	if f.OnAddMultipleURIsToQueue != nil {
+		return f.OnAddMultipleURIsToQueue(ctx, args)
+	}
+	return &AddMultipleURIsToQueueResponse{}, nil
+}
+func (f *Fake) ReorderTracksInQueue(httpClient *http.Client, args *ReorderTracksInQueueArgs) (*ReorderTracksInQueueResponse, error) {
+	return f.ReorderTracksInQueueContext(context.Background(), httpClient, args)
+}
+func (f *Fake) ReorderTracksInQueueContext(ctx context.Context, httpClient *http.Client, args *ReorderTracksInQueueArgs) (*ReorderTracksInQueueResponse, error) {
+	f.record(`ReorderTracksInQueue`, args)
+	if f.OnReorderTracksInQueue != nil {
+		return f.OnReorderTracksInQueue(ctx, args)
 	}
-
-	return r.Body.EndDirectControlSession, nil
+	return &ReorderTracksInQueueResponse{}, nil
+}
+func (f *Fake) RemoveTrackFromQueue(httpClient *http.Client, args *RemoveTrackFromQueueArgs) (*RemoveTrackFromQueueResponse, error) {
+	return f.RemoveTrackFromQueueContext(context.Background(), httpClient, args)
+}
+func (f *Fake) RemoveTrackFromQueueContext(ctx context.Context, httpClient *http.Client, args *RemoveTrackFromQueueArgs) (*RemoveTrackFromQueueResponse, error) {
+	f.record(`RemoveTrackFromQueue`, args)
+	if f.OnRemoveTrackFromQueue != nil {
+		return f.OnRemoveTrackFromQueue(ctx, args)
+	}
+	return &RemoveTrackFromQueueResponse{}, nil
+}
+func (f *Fake) RemoveTrackRangeFromQueue(httpClient *http.Client, args *RemoveTrackRangeFromQueueArgs) (*RemoveTrackRangeFromQueueResponse, error) {
+	return f.RemoveTrackRangeFromQueueContext(context.Background(), httpClient, args)
+}
+func (f *Fake) RemoveTrackRangeFromQueueContext(ctx context.Context, httpClient *http.Client, args *RemoveTrackRangeFromQueueArgs) (*RemoveTrackRangeFromQueueResponse, error) {
+	f.record(`RemoveTrackRangeFromQueue`, args)
+	if f.OnRemoveTrackRangeFromQueue != nil {
+		return f.OnRemoveTrackRangeFromQueue(ctx, args)
+	}
+	return &RemoveTrackRangeFromQueueResponse{}, nil
+}
+func (f *Fake) RemoveAllTracksFromQueue(httpClient *http.Client, args *RemoveAllTracksFromQueueArgs) (*RemoveAllTracksFromQueueResponse, error) {
+	return f.RemoveAllTracksFromQueueContext(context.Background(), httpClient, args)
+}
+func (f *Fake) RemoveAllTracksFromQueueContext(ctx context.Context, httpClient *http.Client, args *RemoveAllTracksFromQueueArgs) (*RemoveAllTracksFromQueueResponse, error) {
+	f.record(`RemoveAllTracksFromQueue`, args)
+	if f.OnRemoveAllTracksFromQueue != nil {
+		return f.OnRemoveAllTracksFromQueue(ctx, args)
+	}
+	return &RemoveAllTracksFromQueueResponse{}, nil
+}
+func (f *Fake) SaveQueue(httpClient *http.Client, args *SaveQueueArgs) (*SaveQueueResponse, error) {
+	return f.SaveQueueContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SaveQueueContext(ctx context.Context, httpClient *http.Client, args *SaveQueueArgs) (*SaveQueueResponse, error) {
+	f.record(`SaveQueue`, args)
+	if f.OnSaveQueue != nil {
+		return f.OnSaveQueue(ctx, args)
+	}
+	return &SaveQueueResponse{}, nil
+}
+func (f *Fake) BackupQueue(httpClient *http.Client, args *BackupQueueArgs) (*BackupQueueResponse, error) {
+	return f.BackupQueueContext(context.Background(), httpClient, args)
+}
+func (f *Fake) BackupQueueContext(ctx context.Context, httpClient *http.Client, args *BackupQueueArgs) (*BackupQueueResponse, error) {
+	f.record(`BackupQueue`, args)
+	if f.OnBackupQueue != nil {
+		return f.OnBackupQueue(ctx, args)
+	}
+	return &BackupQueueResponse{}, nil
+}
+func (f *Fake) CreateSavedQueue(httpClient *http.Client, args *CreateSavedQueueArgs) (*CreateSavedQueueResponse, error) {
+	return f.CreateSavedQueueContext(context.Background(), httpClient, args)
+}
+func (f *Fake) CreateSavedQueueContext(ctx context.Context, httpClient *http.Client, args *CreateSavedQueueArgs) (*CreateSavedQueueResponse, error) {
+	f.record(`CreateSavedQueue`, args)
+	if f.OnCreateSavedQueue != nil {
+		return f.OnCreateSavedQueue(ctx, args)
+	}
+	return &CreateSavedQueueResponse{}, nil
+}
+func (f *Fake) AddURIToSavedQueue(httpClient *http.Client, args *AddURIToSavedQueueArgs) (*AddURIToSavedQueueResponse, error) {
+	return f.AddURIToSavedQueueContext(context.Background(), httpClient, args)
+}
+func (f *Fake) AddURIToSavedQueueContext(ctx context.Context, httpClient *http.Client, args *AddURIToSavedQueueArgs) (*AddURIToSavedQueueResponse, error) {
+	f.record(`AddURIToSavedQueue`, args)
+	if f.OnAddURIToSavedQueue != nil {
+		return f.OnAddURIToSavedQueue(ctx, args)
+	}
+	return &AddURIToSavedQueueResponse{}, nil
+}
+func (f *Fake) ReorderTracksInSavedQueue(httpClient *http.Client, args *ReorderTracksInSavedQueueArgs) (*ReorderTracksInSavedQueueResponse, error) {
+	return f.ReorderTracksInSavedQueueContext(context.Background(), httpClient, args)
+}
+func (f *Fake) ReorderTracksInSavedQueueContext(ctx context.Context, httpClient *http.Client, args *ReorderTracksInSavedQueueArgs) (*ReorderTracksInSavedQueueResponse, error) {
+	f.record(`ReorderTracksInSavedQueue`, args)
+	if f.OnReorderTracksInSavedQueue != nil {
+		return f.OnReorderTracksInSavedQueue(ctx, args)
+	}
+	return &ReorderTracksInSavedQueueResponse{}, nil
+}
+func (f *Fake) GetMediaInfo(httpClient *http.Client, args *GetMediaInfoArgs) (*GetMediaInfoResponse, error) {
+	return f.GetMediaInfoContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetMediaInfoContext(ctx context.Context, httpClient *http.Client, args *GetMediaInfoArgs) (*GetMediaInfoResponse, error) {
+	f.record(`GetMediaInfo`, args)
+	if f.OnGetMediaInfo != nil {
+		return f.OnGetMediaInfo(ctx, args)
+	}
+	return &GetMediaInfoResponse{}, nil
+}
+func (f *Fake) GetTransportInfo(httpClient *http.Client, args *GetTransportInfoArgs) (*GetTransportInfoResponse, error) {
+	return f.GetTransportInfoContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetTransportInfoContext(ctx context.Context, httpClient *http.Client, args *GetTransportInfoArgs) (*GetTransportInfoResponse, error) {
+	f.record(`GetTransportInfo`, args)
+	if f.OnGetTransportInfo != nil {
+		return f.OnGetTransportInfo(ctx, args)
+	}
+	return &GetTransportInfoResponse{}, nil
+}
+func (f *Fake) GetPositionInfo(httpClient *http.Client, args *GetPositionInfoArgs) (*GetPositionInfoResponse, error) {
+	return f.GetPositionInfoContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetPositionInfoContext(ctx context.Context, httpClient *http.Client, args *GetPositionInfoArgs) (*GetPositionInfoResponse, error) {
+	f.record(`GetPositionInfo`, args)
+	if f.OnGetPositionInfo != nil {
+		return f.OnGetPositionInfo(ctx, args)
+	}
+	return &GetPositionInfoResponse{}, nil
+}
+func (f *Fake) GetDeviceCapabilities(httpClient *http.Client, args *GetDeviceCapabilitiesArgs) (*GetDeviceCapabilitiesResponse, error) {
+	return f.GetDeviceCapabilitiesContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetDeviceCapabilitiesContext(ctx context.Context, httpClient *http.Client, args *GetDeviceCapabilitiesArgs) (*GetDeviceCapabilitiesResponse, error) {
+	f.record(`GetDeviceCapabilities`, args)
+	if f.OnGetDeviceCapabilities != nil {
+		return f.OnGetDeviceCapabilities(ctx, args)
+	}
+	return &GetDeviceCapabilitiesResponse{}, nil
+}
+func (f *Fake) GetTransportSettings(httpClient *http.Client, args *GetTransportSettingsArgs) (*GetTransportSettingsResponse, error) {
+	return f.GetTransportSettingsContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetTransportSettingsContext(ctx context.Context, httpClient *http.Client, args *GetTransportSettingsArgs) (*GetTransportSettingsResponse, error) {
+	f.record(`GetTransportSettings`, args)
+	if f.OnGetTransportSettings != nil {
+		return f.OnGetTransportSettings(ctx, args)
+	}
+	return &GetTransportSettingsResponse{}, nil
+}
+func (f *Fake) GetCrossfadeMode(httpClient *http.Client, args *GetCrossfadeModeArgs) (*GetCrossfadeModeResponse, error) {
+	return f.GetCrossfadeModeContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetCrossfadeModeContext(ctx context.Context, httpClient *http.Client, args *GetCrossfadeModeArgs) (*GetCrossfadeModeResponse, error) {
+	f.record(`GetCrossfadeMode`, args)
+	if f.OnGetCrossfadeMode != nil {
+		return f.OnGetCrossfadeMode(ctx, args)
+	}
+	return &GetCrossfadeModeResponse{}, nil
+}
+func (f *Fake) Stop(httpClient *http.Client, args *StopArgs) (*StopResponse, error) {
+	return f.StopContext(context.Background(), httpClient, args)
+}
+func (f *Fake) StopContext(ctx context.Context, httpClient *http.Client, args *StopArgs) (*StopResponse, error) {
+	f.record(`Stop`, args)
+	if f.OnStop != nil {
+		return f.OnStop(ctx, args)
+	}
+	return &StopResponse{}, nil
+}
+func (f *Fake) Play(httpClient *http.Client, args *PlayArgs) (*PlayResponse, error) {
+	return f.PlayContext(context.Background(), httpClient, args)
+}
+func (f *Fake) PlayContext(ctx context.Context, httpClient *http.Client, args *PlayArgs) (*PlayResponse, error) {
+	f.record(`Play`, args)
+	if f.OnPlay != nil {
+		return f.OnPlay(ctx, args)
+	}
+	return &PlayResponse{}, nil
+}
+func (f *Fake) Pause(httpClient *http.Client, args *PauseArgs) (*PauseResponse, error) {
+	return f.PauseContext(context.Background(), httpClient, args)
+}
+func (f *Fake) PauseContext(ctx context.Context, httpClient *http.Client, args *PauseArgs) (*PauseResponse, error) {
+	f.record(`Pause`, args)
+	if f.OnPause != nil {
+		return f.OnPause(ctx, args)
+	}
+	return &PauseResponse{}, nil
+}
+func (f *Fake) Seek(httpClient *http.Client, args *SeekArgs) (*SeekResponse, error) {
+	return f.SeekContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SeekContext(ctx context.Context, httpClient *http.Client, args *SeekArgs) (*SeekResponse, error) {
+	f.record(`Seek`, args)
+	if f.OnSeek != nil {
+		return f.OnSeek(ctx, args)
+	}
+	return &SeekResponse{}, nil
+}
+func (f *Fake) Next(httpClient *http.Client, args *NextArgs) (*NextResponse, error) {
+	return f.NextContext(context.Background(), httpClient, args)
+}
+func (f *Fake) NextContext(ctx context.Context, httpClient *http.Client, args *NextArgs) (*NextResponse, error) {
+	f.record(`Next`, args)
+	if f.OnNext != nil {
+		return f.OnNext(ctx, args)
+	}
+	return &NextResponse{}, nil
+}
+func (f *Fake) Previous(httpClient *http.Client, args *PreviousArgs) (*PreviousResponse, error) {
+	return f.PreviousContext(context.Background(), httpClient, args)
+}
+func (f *Fake) PreviousContext(ctx context.Context, httpClient *http.Client, args *PreviousArgs) (*PreviousResponse, error) {
+	f.record(`Previous`, args)
+	if f.OnPrevious != nil {
+		return f.OnPrevious(ctx, args)
+	}
+	return &PreviousResponse{}, nil
+}
+func (f *Fake) SetPlayMode(httpClient *http.Client, args *SetPlayModeArgs) (*SetPlayModeResponse, error) {
+	return f.SetPlayModeContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetPlayModeContext(ctx context.Context, httpClient *http.Client, args *SetPlayModeArgs) (*SetPlayModeResponse, error) {
+	f.record(`SetPlayMode`, args)
+	if f.OnSetPlayMode != nil {
+		return f.OnSetPlayMode(ctx, args)
+	}
+	return &SetPlayModeResponse{}, nil
+}
+func (f *Fake) SetCrossfadeMode(httpClient *http.Client, args *SetCrossfadeModeArgs) (*SetCrossfadeModeResponse, error) {
+	return f.SetCrossfadeModeContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetCrossfadeModeContext(ctx context.Context, httpClient *http.Client, args *SetCrossfadeModeArgs) (*SetCrossfadeModeResponse, error) {
+	f.record(`SetCrossfadeMode`, args)
+	if f.OnSetCrossfadeMode != nil {
+		return f.OnSetCrossfadeMode(ctx, args)
+	}
+	return &SetCrossfadeModeResponse{}, nil
+}
+func (f *Fake) NotifyDeletedURI(httpClient *http.Client, args *NotifyDeletedURIArgs) (*NotifyDeletedURIResponse, error) {
+	return f.NotifyDeletedURIContext(context.Background(), httpClient, args)
+}
+func (f *Fake) NotifyDeletedURIContext(ctx context.Context, httpClient *http.Client, args *NotifyDeletedURIArgs) (*NotifyDeletedURIResponse, error) {
+	f.record(`NotifyDeletedURI`, args)
+	if f.OnNotifyDeletedURI != nil {
+		return f.OnNotifyDeletedURI(ctx, args)
+	}
+	return &NotifyDeletedURIResponse{}, nil
+}
+func (f *Fake) GetCurrentTransportActions(httpClient *http.Client, args *GetCurrentTransportActionsArgs) (*GetCurrentTransportActionsResponse, error) {
+	return f.GetCurrentTransportActionsContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetCurrentTransportActionsContext(ctx context.Context, httpClient *http.Client, args *GetCurrentTransportActionsArgs) (*GetCurrentTransportActionsResponse, error) {
+	f.record(`GetCurrentTransportActions`, args)
+	if f.OnGetCurrentTransportActions != nil {
+		return f.OnGetCurrentTransportActions(ctx, args)
+	}
+	return &GetCurrentTransportActionsResponse{}, nil
+}
+func (f *Fake) BecomeCoordinatorOfStandaloneGroup(httpClient *http.Client, args *BecomeCoordinatorOfStandaloneGroupArgs) (*BecomeCoordinatorOfStandaloneGroupResponse, error) {
+	return f.BecomeCoordinatorOfStandaloneGroupContext(context.Background(), httpClient, args)
+}
+func (f *Fake) BecomeCoordinatorOfStandaloneGroupContext(ctx context.Context, httpClient *http.Client, args *BecomeCoordinatorOfStandaloneGroupArgs) (*BecomeCoordinatorOfStandaloneGroupResponse, error) {
+	f.record(`BecomeCoordinatorOfStandaloneGroup`, args)
+	if f.OnBecomeCoordinatorOfStandaloneGroup != nil {
+		return f.OnBecomeCoordinatorOfStandaloneGroup(ctx, args)
+	}
+	return &BecomeCoordinatorOfStandaloneGroupResponse{}, nil
+}
+func (f *Fake) DelegateGroupCoordinationTo(httpClient *http.Client, args *DelegateGroupCoordinationToArgs) (*DelegateGroupCoordinationToResponse, error) {
+	return f.DelegateGroupCoordinationToContext(context.Background(), httpClient, args)
+}
+func (f *Fake) DelegateGroupCoordinationToContext(ctx context.Context, httpClient *http.Client, args *DelegateGroupCoordinationToArgs) (*DelegateGroupCoordinationToResponse, error) {
+	f.record(`DelegateGroupCoordinationTo`, args)
+	if f.OnDelegateGroupCoordinationTo != nil {
+		return f.OnDelegateGroupCoordinationTo(ctx, args)
+	}
+	return &DelegateGroupCoordinationToResponse{}, nil
+}
+func (f *Fake) BecomeGroupCoordinator(httpClient *http.Client, args *BecomeGroupCoordinatorArgs) (*BecomeGroupCoordinatorResponse, error) {
+	return f.BecomeGroupCoordinatorContext(context.Background(), httpClient, args)
+}
+func (f *Fake) BecomeGroupCoordinatorContext(ctx context.Context, httpClient *http.Client, args *BecomeGroupCoordinatorArgs) (*BecomeGroupCoordinatorResponse, error) {
+	f.record(`BecomeGroupCoordinator`, args)
+	if f.OnBecomeGroupCoordinator != nil {
+		return f.OnBecomeGroupCoordinator(ctx, args)
+	}
+	return &BecomeGroupCoordinatorResponse{}, nil
+}
+func (f *Fake) BecomeGroupCoordinatorAndSource(httpClient *http.Client, args *BecomeGroupCoordinatorAndSourceArgs) (*BecomeGroupCoordinatorAndSourceResponse, error) {
+	return f.BecomeGroupCoordinatorAndSourceContext(context.Background(), httpClient, args)
+}
+func (f *Fake) BecomeGroupCoordinatorAndSourceContext(ctx context.Context, httpClient *http.Client, args *BecomeGroupCoordinatorAndSourceArgs) (*BecomeGroupCoordinatorAndSourceResponse, error) {
+	f.record(`BecomeGroupCoordinatorAndSource`, args)
+	if f.OnBecomeGroupCoordinatorAndSource != nil {
+		return f.OnBecomeGroupCoordinatorAndSource(ctx, args)
+	}
+	return &BecomeGroupCoordinatorAndSourceResponse{}, nil
+}
+func (f *Fake) ChangeCoordinator(httpClient *http.Client, args *ChangeCoordinatorArgs) (*ChangeCoordinatorResponse, error) {
+	return f.ChangeCoordinatorContext(context.Background(), httpClient, args)
+}
+func (f *Fake) ChangeCoordinatorContext(ctx context.Context, httpClient *http.Client, args *ChangeCoordinatorArgs) (*ChangeCoordinatorResponse, error) {
+	f.record(`ChangeCoordinator`, args)
+	if f.OnChangeCoordinator != nil {
+		return f.OnChangeCoordinator(ctx, args)
+	}
+	return &ChangeCoordinatorResponse{}, nil
+}
+func (f *Fake) ChangeTransportSettings(httpClient *http.Client, args *ChangeTransportSettingsArgs) (*ChangeTransportSettingsResponse, error) {
+	return f.ChangeTransportSettingsContext(context.Background(), httpClient, args)
+}
+func (f *Fake) ChangeTransportSettingsContext(ctx context.Context, httpClient *http.Client, args *ChangeTransportSettingsArgs) (*ChangeTransportSettingsResponse, error) {
+	f.record(`ChangeTransportSettings`, args)
+	if f.OnChangeTransportSettings != nil {
+		return f.OnChangeTransportSettings(ctx, args)
+	}
+	return &ChangeTransportSettingsResponse{}, nil
+}
+func (f *Fake) ConfigureSleepTimer(httpClient *http.Client, args *ConfigureSleepTimerArgs) (*ConfigureSleepTimerResponse, error) {
+	return f.ConfigureSleepTimerContext(context.Background(), httpClient, args)
+}
+func (f *Fake) ConfigureSleepTimerContext(ctx context.Context, httpClient *http.Client, args *ConfigureSleepTimerArgs) (*ConfigureSleepTimerResponse, error) {
+	f.record(`ConfigureSleepTimer`, args)
+	if f.OnConfigureSleepTimer != nil {
+		return f.OnConfigureSleepTimer(ctx, args)
+	}
+	return &ConfigureSleepTimerResponse{}, nil
+}
+func (f *Fake) GetRemainingSleepTimerDuration(httpClient *http.Client, args *GetRemainingSleepTimerDurationArgs) (*GetRemainingSleepTimerDurationResponse, error) {
+	return f.GetRemainingSleepTimerDurationContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetRemainingSleepTimerDurationContext(ctx context.Context, httpClient *http.Client, args *GetRemainingSleepTimerDurationArgs) (*GetRemainingSleepTimerDurationResponse, error) {
+	f.record(`GetRemainingSleepTimerDuration`, args)
+	if f.OnGetRemainingSleepTimerDuration != nil {
+		return f.OnGetRemainingSleepTimerDuration(ctx, args)
+	}
+	return &GetRemainingSleepTimerDurationResponse{}, nil
+}
+func (f *Fake) RunAlarm(httpClient *http.Client, args *RunAlarmArgs) (*RunAlarmResponse, error) {
+	return f.RunAlarmContext(context.Background(), httpClient, args)
+}
+func (f *Fake) RunAlarmContext(ctx context.Context, httpClient *http.Client, args *RunAlarmArgs) (*RunAlarmResponse, error) {
+	f.record(`RunAlarm`, args)
+	if f.OnRunAlarm != nil {
+		return f.OnRunAlarm(ctx, args)
+	}
+	return &RunAlarmResponse{}, nil
+}
+func (f *Fake) StartAutoplay(httpClient *http.Client, args *StartAutoplayArgs) (*StartAutoplayResponse, error) {
+	return f.StartAutoplayContext(context.Background(), httpClient, args)
+}
+func (f *Fake) StartAutoplayContext(ctx context.Context, httpClient *http.Client, args *StartAutoplayArgs) (*StartAutoplayResponse, error) {
+	f.record(`StartAutoplay`, args)
+	if f.OnStartAutoplay != nil {
+		return f.OnStartAutoplay(ctx, args)
+	}
+	return &StartAutoplayResponse{}, nil
+}
+func (f *Fake) GetRunningAlarmProperties(httpClient *http.Client, args *GetRunningAlarmPropertiesArgs) (*GetRunningAlarmPropertiesResponse, error) {
+	return f.GetRunningAlarmPropertiesContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetRunningAlarmPropertiesContext(ctx context.Context, httpClient *http.Client, args *GetRunningAlarmPropertiesArgs) (*GetRunningAlarmPropertiesResponse, error) {
+	f.record(`GetRunningAlarmProperties`, args)
+	if f.OnGetRunningAlarmProperties != nil {
+		return f.OnGetRunningAlarmProperties(ctx, args)
+	}
+	return &GetRunningAlarmPropertiesResponse{}, nil
+}
+func (f *Fake) SnoozeAlarm(httpClient *http.Client, args *SnoozeAlarmArgs) (*SnoozeAlarmResponse, error) {
+	return f.SnoozeAlarmContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SnoozeAlarmContext(ctx context.Context, httpClient *http.Client, args *SnoozeAlarmArgs) (*SnoozeAlarmResponse, error) {
+	f.record(`SnoozeAlarm`, args)
+	if f.OnSnoozeAlarm != nil {
+		return f.OnSnoozeAlarm(ctx, args)
+	}
+	return &SnoozeAlarmResponse{}, nil
+}
+func (f *Fake) EndDirectControlSession(httpClient *http.Client, args *EndDirectControlSessionArgs) (*EndDirectControlSessionResponse, error) {
+	return f.EndDirectControlSessionContext(context.Background(), httpClient, args)
+}
+func (f *Fake) EndDirectControlSessionContext(ctx context.Context, httpClient *http.Client, args *EndDirectControlSessionArgs) (*EndDirectControlSessionResponse, error) {
+	f.record(`EndDirectControlSession`, args)
+	if f.OnEndDirectControlSession != nil {
+		return f.OnEndDirectControlSession(ctx, args)
+	}
+	return &EndDirectControlSessionResponse{}, nil
 }
@@ -0,0 +1,35 @@
+package avtransport
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/szatmary/sonos/soap"
+)
+
+// ParseDuration parses the H:MM:SS times and durations of the AVTransport
+// service, such as the TrackDuration and RelTime of GetPositionInfo. A
+// fraction of a second may follow the seconds. Streams without a length
+// report "NOT_IMPLEMENTED" or nothing, which give 0.
+func ParseDuration(s string) (time.Duration, error) {
+	if s == "" || s == "NOT_IMPLEMENTED" {
+		return 0, nil
+	}
+	d, err := soap.ParseHMS(s)
+	if err != nil {
+		return 0, fmt.Errorf("duration %v", err)
+	}
+	return d, nil
+}
+
+// FormatDuration formats d as the H:MM:SS seek targets of the AVTransport
+// service expect, such as the Target of a REL_TIME Seek. Fractions of a
+// second are dropped.
+func FormatDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	seconds := int64(d / time.Second)
+	return fmt.Sprintf("%s%d:%02d:%02d", sign, seconds/3600, seconds/60%60, seconds%60)
+}
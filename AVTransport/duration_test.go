@@ -0,0 +1,75 @@
+package avtransport
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		s string
+		d time.Duration
+	}{
+		{"0:00:00", 0},
+		{"0:01:30", 90 * time.Second},
+		{"00:03:43", 3*time.Minute + 43*time.Second},
+		{"1:02:03", time.Hour + 2*time.Minute + 3*time.Second},
+		{"27:00:00", 27 * time.Hour},
+		{"1:02:03.500", time.Hour + 2*time.Minute + 3500*time.Millisecond},
+		{"-0:00:10", -10 * time.Second},
+		{"+0:00:10", 10 * time.Second},
+		{"", 0},
+		{"NOT_IMPLEMENTED", 0},
+	}
+	for _, test := range tests {
+		d, err := ParseDuration(test.s)
+		if err != nil {
+			t.Errorf("ParseDuration(%q): %v", test.s, err)
+			continue
+		}
+		if d != test.d {
+			t.Errorf("ParseDuration(%q) = %v, want %v", test.s, d, test.d)
+		}
+	}
+}
+
+func TestParseDurationErrors(t *testing.T) {
+	for _, s := range []string{"10:00", "1:2:3:4", "a:00:00", "0:60:00", "0:00:60", "0:00:-1", "0:xx:00", "NOT_SUPPORTED",
+		"--0:00:10", "+-0:00:10", "-+0:00:10", "++0:00:10", "-", "0:00:+5", "0:00:1e1", "0:00:Inf", "0:00:.5", "0:-1:00", "+0:00:10 "} {
+		if d, err := ParseDuration(s); err == nil {
+			t.Errorf("ParseDuration(%q) = %v, want an error", s, d)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d time.Duration
+		s string
+	}{
+		{0, "0:00:00"},
+		{90 * time.Second, "0:01:30"},
+		{time.Hour + 2*time.Minute + 3*time.Second, "1:02:03"},
+		{25 * time.Hour, "25:00:00"},
+		{3*time.Second + 999*time.Millisecond, "0:00:03"},
+		{-90 * time.Second, "-0:01:30"},
+	}
+	for _, test := range tests {
+		if s := FormatDuration(test.d); s != test.s {
+			t.Errorf("FormatDuration(%v) = %q, want %q", test.d, s, test.s)
+		}
+	}
+}
+
+func TestDurationRoundTrip(t *testing.T) {
+	for _, d := range []time.Duration{0, time.Second, 59*time.Minute + 59*time.Second, 100 * time.Hour, -time.Minute} {
+		parsed, err := ParseDuration(FormatDuration(d))
+		if err != nil {
+			t.Errorf("ParseDuration(FormatDuration(%v)): %v", d, err)
+			continue
+		}
+		if parsed != d {
+			t.Errorf("ParseDuration(FormatDuration(%v)) = %v", d, parsed)
+		}
+	}
+}
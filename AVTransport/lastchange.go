@@ -0,0 +1,151 @@
+package avtransport
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+
+	"github.com/szatmary/sonos/didl"
+	"github.com/szatmary/sonos/internal/lastchange"
+)
+
+// LastChange is the content of an AVTransport LastChange event, e.g. the
+// "LastChange" property of a gena.Event. Only the state variables that
+// changed are sent, the others are left nil.
+type LastChange struct {
+	InstanceID uint32
+
+	TransportState        *TransportState
+	TransportStatus       *string
+	TransportPlaySpeed    *TransportPlaySpeed
+	PlaybackStorageMedium *PlaybackStorageMedium
+	CurrentPlayMode       *PlayMode
+	CurrentCrossfadeMode  *bool
+	// CurrentTransportActions is a comma separated list such as
+	// "Set, Stop, Pause, Play, X_DLNA_SeekTime"
+	CurrentTransportActions *string
+	CurrentValidPlayModes   *string
+
+	NumberOfTracks       *uint32
+	CurrentTrack         *uint32
+	CurrentSection       *uint32
+	CurrentTrackURI      *string
+	CurrentTrackDuration *string
+	CurrentTrackMetaData *didl.Lite
+	NextTrackURI         *string
+	NextTrackMetaData    *didl.Lite
+
+	AVTransportURI               *string
+	AVTransportURIMetaData       *didl.Lite
+	NextAVTransportURI           *string
+	NextAVTransportURIMetaData   *didl.Lite
+	EnqueuedTransportURI         *string
+	EnqueuedTransportURIMetaData *didl.Lite
+
+	SleepTimerGeneration *uint32
+	AlarmRunning         *bool
+	SnoozeRunning        *bool
+	RestartPending       *bool
+
+	// Other holds the state variables not listed above by name
+	Other map[string]string
+}
+
+type lastChangeEvent struct {
+	XMLName   xml.Name `xml:"Event"`
+	Instances []struct {
+		ID        uint32 `xml:"val,attr"`
+		Variables []struct {
+			XMLName xml.Name
+			Val     string `xml:"val,attr"`
+		} `xml:",any"`
+	} `xml:"InstanceID"`
+}
+
+// DecodeLastChange decodes the LastChange document of an event. Sonos players
+// only have InstanceID 0, so only the first instance is returned. A state
+// variable whose value cannot be decoded is left nil, and the others are
+// still returned, along with an error naming each variable left out.
+func DecodeLastChange(lastChange string) (*LastChange, error) {
+	var event lastChangeEvent
+	if err := xml.Unmarshal([]byte(lastChange), &event); err != nil {
+		return nil, err
+	}
+	if len(event.Instances) == 0 {
+		return nil, errors.New("LastChange without InstanceID")
+	}
+
+	instance := event.Instances[0]
+	l := &LastChange{InstanceID: instance.ID}
+	var errs []error
+	for _, v := range instance.Variables {
+		if err := l.set(v.XMLName.Local, v.Val); err != nil {
+			errs = append(errs, fmt.Errorf("LastChange %s: %v", v.XMLName.Local, err))
+		}
+	}
+	return l, errors.Join(errs...)
+}
+
+func (l *LastChange) set(name, val string) error {
+	var err error
+	switch name {
+	case "TransportState":
+		l.TransportState = (*TransportState)(&val)
+	case "TransportStatus":
+		l.TransportStatus = &val
+	case "TransportPlaySpeed":
+		l.TransportPlaySpeed = (*TransportPlaySpeed)(&val)
+	case "PlaybackStorageMedium":
+		l.PlaybackStorageMedium = (*PlaybackStorageMedium)(&val)
+	case "CurrentPlayMode":
+		l.CurrentPlayMode = (*PlayMode)(&val)
+	case "CurrentCrossfadeMode":
+		l.CurrentCrossfadeMode, err = lastchange.Bool(val)
+	case "CurrentTransportActions":
+		l.CurrentTransportActions = &val
+	case "CurrentValidPlayModes":
+		l.CurrentValidPlayModes = &val
+	case "NumberOfTracks":
+		l.NumberOfTracks, err = lastchange.Uint32(val)
+	case "CurrentTrack":
+		l.CurrentTrack, err = lastchange.Uint32(val)
+	case "CurrentSection":
+		l.CurrentSection, err = lastchange.Uint32(val)
+	case "CurrentTrackURI":
+		l.CurrentTrackURI = &val
+	case "CurrentTrackDuration":
+		l.CurrentTrackDuration = &val
+	case "CurrentTrackMetaData":
+		l.CurrentTrackMetaData, err = didl.Decode(val)
+	case "NextTrackURI":
+		l.NextTrackURI = &val
+	case "NextTrackMetaData":
+		l.NextTrackMetaData, err = didl.Decode(val)
+	case "AVTransportURI":
+		l.AVTransportURI = &val
+	case "AVTransportURIMetaData":
+		l.AVTransportURIMetaData, err = didl.Decode(val)
+	case "NextAVTransportURI":
+		l.NextAVTransportURI = &val
+	case "NextAVTransportURIMetaData":
+		l.NextAVTransportURIMetaData, err = didl.Decode(val)
+	case "EnqueuedTransportURI":
+		l.EnqueuedTransportURI = &val
+	case "EnqueuedTransportURIMetaData":
+		l.EnqueuedTransportURIMetaData, err = didl.Decode(val)
+	case "SleepTimerGeneration":
+		l.SleepTimerGeneration, err = lastchange.Uint32(val)
+	case "AlarmRunning":
+		l.AlarmRunning, err = lastchange.Bool(val)
+	case "SnoozeRunning":
+		l.SnoozeRunning, err = lastchange.Bool(val)
+	case "RestartPending":
+		l.RestartPending, err = lastchange.Bool(val)
+	default:
+		if l.Other == nil {
+			l.Other = make(map[string]string)
+		}
+		l.Other[name] = val
+	}
+	return err
+}
@@ -0,0 +1,155 @@
+package avtransport
+
+import (
+	"strings"
+	"testing"
+)
+
+// lastChange is the LastChange property of an AVTransport event as sent by a
+// Sonos player while playing its queue. The metadata is escaped once more as
+// it sits in an attribute.
+const lastChange = `<Event xmlns="urn:schemas-upnp-org:metadata-1-0/AVT/" xmlns:r="urn:schemas-rinconnetworks-com:metadata-1-0/">` +
+	`<InstanceID val="0">` +
+	`<TransportState val="PLAYING"/>` +
+	`<CurrentPlayMode val="SHUFFLE"/>` +
+	`<CurrentCrossfadeMode val="1"/>` +
+	`<NumberOfTracks val="12"/>` +
+	`<CurrentTrack val="3"/>` +
+	`<CurrentSection val="0"/>` +
+	`<CurrentTrackURI val="x-sonos-http:librarytrack%3aa.1234.mp4?sid=204&amp;flags=8224&amp;sn=1"/>` +
+	`<CurrentTrackDuration val="0:03:20"/>` +
+	`<CurrentTrackMetaData val="&lt;DIDL-Lite xmlns:dc=&quot;http://purl.org/dc/elements/1.1/&quot; xmlns:upnp=&quot;urn:schemas-upnp-org:metadata-1-0/upnp/&quot; xmlns:r=&quot;urn:schemas-rinconnetworks-com:metadata-1-0/&quot; xmlns=&quot;urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/&quot;&gt;&lt;item id=&quot;-1&quot; parentID=&quot;-1&quot; restricted=&quot;true&quot;&gt;&lt;dc:title&gt;Tom &amp;amp; Jerry&lt;/dc:title&gt;&lt;dc:creator&gt;The Artist&lt;/dc:creator&gt;&lt;/item&gt;&lt;/DIDL-Lite&gt;"/>` +
+	`<r:NextTrackURI val=""/>` +
+	`<r:NextTrackMetaData val=""/>` +
+	`<r:EnqueuedTransportURI val="x-rincon-playlist:RINCON_000E58000000001400#A:ALBUMARTIST/The%20Artist"/>` +
+	`<r:EnqueuedTransportURIMetaData val="NOT_IMPLEMENTED"/>` +
+	`<PlaybackStorageMedium val="NETWORK"/>` +
+	`<AVTransportURI val="x-rincon-queue:RINCON_000E58000000001400#0"/>` +
+	`<AVTransportURIMetaData val=""/>` +
+	`<CurrentTransportActions val="Set, Stop, Pause, Play, X_DLNA_SeekTime, Next, Previous, X_DLNA_SeekTrackNr"/>` +
+	`<r:CurrentValidPlayModes val="SHUFFLE,REPEAT,REPEATONE,CROSSFADE"/>` +
+	`<r:SleepTimerGeneration val="0"/>` +
+	`<r:AlarmRunning val="0"/>` +
+	`<r:SnoozeRunning val="0"/>` +
+	`<r:RestartPending val="0"/>` +
+	`<r:DirectControlClientID val=""/>` +
+	`</InstanceID></Event>`
+
+func TestDecodeLastChange(t *testing.T) {
+	l, err := DecodeLastChange(lastChange)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l.InstanceID != 0 {
+		t.Errorf("InstanceID = %d", l.InstanceID)
+	}
+	if l.TransportState == nil || *l.TransportState != TransportStatePlaying {
+		t.Errorf("TransportState = %v", l.TransportState)
+	}
+	if l.CurrentPlayMode == nil || *l.CurrentPlayMode != PlayModeShuffle {
+		t.Errorf("CurrentPlayMode = %v", l.CurrentPlayMode)
+	}
+	if l.PlaybackStorageMedium == nil || *l.PlaybackStorageMedium != PlaybackStorageMediumNetwork {
+		t.Errorf("PlaybackStorageMedium = %v", l.PlaybackStorageMedium)
+	}
+	if l.CurrentCrossfadeMode == nil || !*l.CurrentCrossfadeMode {
+		t.Errorf("CurrentCrossfadeMode = %v", l.CurrentCrossfadeMode)
+	}
+	if l.NumberOfTracks == nil || *l.NumberOfTracks != 12 {
+		t.Errorf("NumberOfTracks = %v", l.NumberOfTracks)
+	}
+	if l.CurrentTrack == nil || *l.CurrentTrack != 3 {
+		t.Errorf("CurrentTrack = %v", l.CurrentTrack)
+	}
+	if want := "x-sonos-http:librarytrack%3aa.1234.mp4?sid=204&flags=8224&sn=1"; l.CurrentTrackURI == nil || *l.CurrentTrackURI != want {
+		t.Errorf("CurrentTrackURI = %v, want %s", l.CurrentTrackURI, want)
+	}
+	if l.CurrentTrackDuration == nil || *l.CurrentTrackDuration != "0:03:20" {
+		t.Errorf("CurrentTrackDuration = %v", l.CurrentTrackDuration)
+	}
+	if m := l.CurrentTrackMetaData; m == nil || len(m.Items) != 1 || m.Items[0].Title != "Tom & Jerry" || m.Items[0].Creator != "The Artist" {
+		t.Errorf("CurrentTrackMetaData = %+v", m)
+	}
+
+	// Empty and NOT_IMPLEMENTED metadata is reported as changed, but empty
+	if m := l.NextTrackMetaData; m == nil || len(m.Items) != 0 {
+		t.Errorf("NextTrackMetaData = %+v, want an empty document", m)
+	}
+	if m := l.EnqueuedTransportURIMetaData; m == nil || len(m.Items) != 0 {
+		t.Errorf("EnqueuedTransportURIMetaData = %+v, want an empty document", m)
+	}
+	if l.NextTrackURI == nil || *l.NextTrackURI != "" {
+		t.Errorf("NextTrackURI = %v, want empty", l.NextTrackURI)
+	}
+
+	if l.AlarmRunning == nil || *l.AlarmRunning {
+		t.Errorf("AlarmRunning = %v", l.AlarmRunning)
+	}
+	if l.SleepTimerGeneration == nil || *l.SleepTimerGeneration != 0 {
+		t.Errorf("SleepTimerGeneration = %v", l.SleepTimerGeneration)
+	}
+	if v, ok := l.Other["DirectControlClientID"]; !ok || v != "" {
+		t.Errorf("Other = %v, want DirectControlClientID", l.Other)
+	}
+	if len(l.Other) != 1 {
+		t.Errorf("Other = %v, want only DirectControlClientID", l.Other)
+	}
+}
+
+// Variables that did not change are left nil
+func TestDecodeLastChangePartial(t *testing.T) {
+	l, err := DecodeLastChange(`<Event xmlns="urn:schemas-upnp-org:metadata-1-0/AVT/"><InstanceID val="0"><TransportState val="PAUSED_PLAYBACK"/></InstanceID></Event>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.TransportState == nil || *l.TransportState != TransportStatePausedPlayback {
+		t.Errorf("TransportState = %v", l.TransportState)
+	}
+	if l.CurrentTrack != nil || l.CurrentTrackMetaData != nil || l.CurrentPlayMode != nil || l.Other != nil {
+		t.Errorf("unchanged variables are set: %+v", l)
+	}
+}
+
+func TestDecodeLastChangeErrors(t *testing.T) {
+	for _, lastChange := range []string{
+		``,
+		`<Event xmlns="urn:schemas-upnp-org:metadata-1-0/AVT/"></Event>`,
+		`<Event><InstanceID val="0"><NumberOfTracks val="many"/></InstanceID></Event>`,
+		`<Event><InstanceID val="0"><AlarmRunning val="maybe"/></InstanceID></Event>`,
+		`<Event><InstanceID val="0"><CurrentTrackMetaData val="&lt;DIDL-Lite&gt;&lt;item&gt;"/></InstanceID></Event>`,
+	} {
+		if l, err := DecodeLastChange(lastChange); err == nil {
+			t.Errorf("DecodeLastChange(%q) = %+v, want an error", lastChange, l)
+		}
+	}
+}
+
+// A malformed variable is left out without losing the others
+func TestDecodeLastChangeMalformed(t *testing.T) {
+	l, err := DecodeLastChange(`<Event xmlns="urn:schemas-upnp-org:metadata-1-0/AVT/"><InstanceID val="0">` +
+		`<TransportState val="PLAYING"/>` +
+		`<NumberOfTracks val="many"/>` +
+		`<CurrentTrack val="3"/>` +
+		`<AlarmRunning val="maybe"/>` +
+		`<SnoozeRunning val="yes"/>` +
+		`</InstanceID></Event>`)
+	if err == nil || !strings.Contains(err.Error(), "NumberOfTracks") || !strings.Contains(err.Error(), "AlarmRunning") {
+		t.Errorf("DecodeLastChange = %v, want an error naming NumberOfTracks and AlarmRunning", err)
+	}
+	if l == nil {
+		t.Fatal("DecodeLastChange = nil, want the variables that could be decoded")
+	}
+	if l.TransportState == nil || *l.TransportState != TransportStatePlaying {
+		t.Errorf("TransportState = %v", l.TransportState)
+	}
+	if l.CurrentTrack == nil || *l.CurrentTrack != 3 {
+		t.Errorf("CurrentTrack = %v", l.CurrentTrack)
+	}
+	if l.SnoozeRunning == nil || !*l.SnoozeRunning {
+		t.Errorf("SnoozeRunning = %v", l.SnoozeRunning)
+	}
+	if l.NumberOfTracks != nil || l.AlarmRunning != nil {
+		t.Errorf("malformed variables are set: NumberOfTracks %v, AlarmRunning %v", l.NumberOfTracks, l.AlarmRunning)
+	}
+}
@@ -0,0 +1,32 @@
+package avtransport
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/szatmary/sonos/soap"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		args  soap.Validator
+		valid bool
+	}{
+		// Empty enumerated arguments are left for the speaker to check
+		{&PlayArgs{}, true},
+		{&SeekArgs{}, true},
+		{&SetPlayModeArgs{}, true},
+		{&PlayArgs{Speed: TransportPlaySpeed1}, true},
+		{&PlayArgs{Speed: "2"}, false},
+		{&SeekArgs{Unit: SeekUnitRelTime, Target: "0:01:00"}, true},
+		{&SeekArgs{Unit: "rel_time"}, false},
+		{&SetPlayModeArgs{NewPlayMode: "SHUFFLE_ALL"}, false},
+	}
+	for _, test := range tests {
+		err := test.args.Validate()
+		var argErr *soap.ArgumentError
+		if test.valid && err != nil || !test.valid && !errors.As(err, &argErr) {
+			t.Errorf("%#v.Validate() = %v, want valid %t", test.args, err, test.valid)
+		}
+	}
+}
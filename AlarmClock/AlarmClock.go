@@ -1,23 +1,48 @@
 package alarmclock
 
 import (
-	"bytes"
-	"encoding/xml"
-	"errors"
-	"io/ioutil"
+	"context"
 	"net/http"
 	"net/url"
+	"sync"
+
+	"github.com/szatmary/sonos/soap"
 )
 
+const _ServiceURN = "urn:schemas-upnp-org:service:AlarmClock:1"
+
+// ErrorCodes describes the error codes specific to this service
+var ErrorCodes = map[int]string{}
+
+func init() {
+	soap.RegisterErrorCodes(_ServiceURN, ErrorCodes)
+}
+
+// Recurrence holds the values allowed for the A_ARG_TYPE_Recurrence state variable
+type Recurrence string
+
 const (
-	_ServiceURN     = "urn:schemas-upnp-org:service:AlarmClock:1"
-	_EncodingSchema = "http://schemas.xmlsoap.org/soap/encoding/"
-	_EnvelopeSchema = "http://schemas.xmlsoap.org/soap/envelope/"
+	RecurrenceOnce     Recurrence = "ONCE"
+	RecurrenceWeekdays Recurrence = "WEEKDAYS"
+	RecurrenceWeekends Recurrence = "WEEKENDS"
+	RecurrenceDaily    Recurrence = "DAILY"
+)
+
+// AlarmPlayMode holds the values allowed for the A_ARG_TYPE_AlarmPlayMode state variable
+type AlarmPlayMode string
+
+const (
+	AlarmPlayModeNormal          AlarmPlayMode = "NORMAL"
+	AlarmPlayModeRepeatAll       AlarmPlayMode = "REPEAT_ALL"
+	AlarmPlayModeShuffleNoRepeat AlarmPlayMode = "SHUFFLE_NOREPEAT"
+	AlarmPlayModeShuffle         AlarmPlayMode = "SHUFFLE"
 )
 
 type Service struct {
 	ControlEndpoint *url.URL
 	EventEndpoint   *url.URL
+	// Client sends the actions, soap.DefaultClient is used if it is nil
+	Client *soap.Client
 }
 
 func NewService(deviceUrl *url.URL) *Service {
@@ -35,196 +60,110 @@ func NewService(deviceUrl *url.URL) *Service {
 	}
 }
 
-type Envelope struct {
-	XMLName       xml.Name `xml:"s:Envelope"`
-	Xmlns         string   `xml:"xmlns:s,attr"`
-	EncodingStyle string   `xml:"s:encodingStyle,attr"`
-	Body          Body     `xml:"s:Body"`
-}
-type Body struct {
-	XMLName                  xml.Name                      `xml:"s:Body"`
-	SetFormat                *SetFormatArgs                `xml:"u:SetFormat,omitempty"`
-	GetFormat                *GetFormatArgs                `xml:"u:GetFormat,omitempty"`
-	SetTimeZone              *SetTimeZoneArgs              `xml:"u:SetTimeZone,omitempty"`
-	GetTimeZone              *GetTimeZoneArgs              `xml:"u:GetTimeZone,omitempty"`
-	GetTimeZoneAndRule       *GetTimeZoneAndRuleArgs       `xml:"u:GetTimeZoneAndRule,omitempty"`
-	GetTimeZoneRule          *GetTimeZoneRuleArgs          `xml:"u:GetTimeZoneRule,omitempty"`
-	SetTimeServer            *SetTimeServerArgs            `xml:"u:SetTimeServer,omitempty"`
-	GetTimeServer            *GetTimeServerArgs            `xml:"u:GetTimeServer,omitempty"`
-	SetTimeNow               *SetTimeNowArgs               `xml:"u:SetTimeNow,omitempty"`
-	GetHouseholdTimeAtStamp  *GetHouseholdTimeAtStampArgs  `xml:"u:GetHouseholdTimeAtStamp,omitempty"`
-	GetTimeNow               *GetTimeNowArgs               `xml:"u:GetTimeNow,omitempty"`
-	CreateAlarm              *CreateAlarmArgs              `xml:"u:CreateAlarm,omitempty"`
-	UpdateAlarm              *UpdateAlarmArgs              `xml:"u:UpdateAlarm,omitempty"`
-	DestroyAlarm             *DestroyAlarmArgs             `xml:"u:DestroyAlarm,omitempty"`
-	ListAlarms               *ListAlarmsArgs               `xml:"u:ListAlarms,omitempty"`
-	SetDailyIndexRefreshTime *SetDailyIndexRefreshTimeArgs `xml:"u:SetDailyIndexRefreshTime,omitempty"`
-	GetDailyIndexRefreshTime *GetDailyIndexRefreshTimeArgs `xml:"u:GetDailyIndexRefreshTime,omitempty"`
-}
-type EnvelopeResponse struct {
-	XMLName       xml.Name     `xml:"Envelope"`
-	Xmlns         string       `xml:"xmlns:s,attr"`
-	EncodingStyle string       `xml:"encodingStyle,attr"`
-	Body          BodyResponse `xml:"Body"`
-}
-type BodyResponse struct {
-	XMLName                  xml.Name                          `xml:"Body"`
-	SetFormat                *SetFormatResponse                `xml:"SetFormatResponse,omitempty"`
-	GetFormat                *GetFormatResponse                `xml:"GetFormatResponse,omitempty"`
-	SetTimeZone              *SetTimeZoneResponse              `xml:"SetTimeZoneResponse,omitempty"`
-	GetTimeZone              *GetTimeZoneResponse              `xml:"GetTimeZoneResponse,omitempty"`
-	GetTimeZoneAndRule       *GetTimeZoneAndRuleResponse       `xml:"GetTimeZoneAndRuleResponse,omitempty"`
-	GetTimeZoneRule          *GetTimeZoneRuleResponse          `xml:"GetTimeZoneRuleResponse,omitempty"`
-	SetTimeServer            *SetTimeServerResponse            `xml:"SetTimeServerResponse,omitempty"`
-	GetTimeServer            *GetTimeServerResponse            `xml:"GetTimeServerResponse,omitempty"`
-	SetTimeNow               *SetTimeNowResponse               `xml:"SetTimeNowResponse,omitempty"`
-	GetHouseholdTimeAtStamp  *GetHouseholdTimeAtStampResponse  `xml:"GetHouseholdTimeAtStampResponse,omitempty"`
-	GetTimeNow               *GetTimeNowResponse               `xml:"GetTimeNowResponse,omitempty"`
-	CreateAlarm              *CreateAlarmResponse              `xml:"CreateAlarmResponse,omitempty"`
-	UpdateAlarm              *UpdateAlarmResponse              `xml:"UpdateAlarmResponse,omitempty"`
-	DestroyAlarm             *DestroyAlarmResponse             `xml:"DestroyAlarmResponse,omitempty"`
-	ListAlarms               *ListAlarmsResponse               `xml:"ListAlarmsResponse,omitempty"`
-	SetDailyIndexRefreshTime *SetDailyIndexRefreshTimeResponse `xml:"SetDailyIndexRefreshTimeResponse,omitempty"`
-	GetDailyIndexRefreshTime *GetDailyIndexRefreshTimeResponse `xml:"GetDailyIndexRefreshTimeResponse,omitempty"`
-}
-
-func (s *Service) exec(actionName string, httpClient *http.Client, envelope *Envelope) (*EnvelopeResponse, error) {
-	marshaled, err := xml.Marshal(envelope)
-	if err != nil {
-		return nil, err
-	}
-	postBody := []byte(`<?xml version="1.0"?>`)
-	postBody = append(postBody, marshaled...)
-	req, err := http.NewRequest(`POST`, s.ControlEndpoint.String(), bytes.NewBuffer(postBody))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set(`Content-Type`, `text/xml; charset="utf-8"`)
-	req.Header.Set(`SOAPAction`, _ServiceURN+`#`+actionName)
-	res, err := httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-	responseBody, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-	var envelopeResponse EnvelopeResponse
-	err = xml.Unmarshal(responseBody, &envelopeResponse)
-	if err != nil {
-		return nil, err
-	}
-	return &envelopeResponse, nil
-}
-
 type SetFormatArgs struct {
-	Xmlns             string `xml:"xmlns:u,attr"`
 	DesiredTimeFormat string `xml:"DesiredTimeFormat"`
 	DesiredDateFormat string `xml:"DesiredDateFormat"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetFormatArgs) Validate() error {
+	return nil
+}
+
 type SetFormatResponse struct {
 }
 
 func (s *Service) SetFormat(httpClient *http.Client, args *SetFormatArgs) (*SetFormatResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetFormat`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetFormat: args},
-		})
-	if err != nil {
+	return s.SetFormatContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetFormatContext(ctx context.Context, httpClient *http.Client, args *SetFormatArgs) (*SetFormatResponse, error) {
+	var r SetFormatResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetFormat`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetFormat == nil {
-		return nil, errors.New(`unexpected respose from service calling alarmclock.SetFormat()`)
-	}
-
-	return r.Body.SetFormat, nil
+	return &r, nil
 }
 
 type GetFormatArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetFormatArgs) Validate() error {
+	return nil
+}
+
 type GetFormatResponse struct {
 	CurrentTimeFormat string `xml:"CurrentTimeFormat"`
 	CurrentDateFormat string `xml:"CurrentDateFormat"`
 }
 
 func (s *Service) GetFormat(httpClient *http.Client, args *GetFormatArgs) (*GetFormatResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetFormat`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetFormat: args},
-		})
-	if err != nil {
+	return s.GetFormatContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetFormatContext(ctx context.Context, httpClient *http.Client, args *GetFormatArgs) (*GetFormatResponse, error) {
+	var r GetFormatResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetFormat`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetFormat == nil {
-		return nil, errors.New(`unexpected respose from service calling alarmclock.GetFormat()`)
-	}
-
-	return r.Body.GetFormat, nil
+	return &r, nil
 }
 
 type SetTimeZoneArgs struct {
-	Xmlns         string `xml:"xmlns:u,attr"`
-	Index         int32  `xml:"Index"`
-	AutoAdjustDst bool   `xml:"AutoAdjustDst"`
+	Index         int32 `xml:"Index"`
+	AutoAdjustDst bool  `xml:"AutoAdjustDst"`
+}
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetTimeZoneArgs) Validate() error {
+	return nil
 }
+
 type SetTimeZoneResponse struct {
 }
 
 func (s *Service) SetTimeZone(httpClient *http.Client, args *SetTimeZoneArgs) (*SetTimeZoneResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetTimeZone`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetTimeZone: args},
-		})
-	if err != nil {
+	return s.SetTimeZoneContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetTimeZoneContext(ctx context.Context, httpClient *http.Client, args *SetTimeZoneArgs) (*SetTimeZoneResponse, error) {
+	var r SetTimeZoneResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetTimeZone`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetTimeZone == nil {
-		return nil, errors.New(`unexpected respose from service calling alarmclock.SetTimeZone()`)
-	}
-
-	return r.Body.SetTimeZone, nil
+	return &r, nil
 }
 
 type GetTimeZoneArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetTimeZoneArgs) Validate() error {
+	return nil
+}
+
 type GetTimeZoneResponse struct {
 	Index         int32 `xml:"Index"`
 	AutoAdjustDst bool  `xml:"AutoAdjustDst"`
 }
 
 func (s *Service) GetTimeZone(httpClient *http.Client, args *GetTimeZoneArgs) (*GetTimeZoneResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetTimeZone`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetTimeZone: args},
-		})
-	if err != nil {
+	return s.GetTimeZoneContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetTimeZoneContext(ctx context.Context, httpClient *http.Client, args *GetTimeZoneArgs) (*GetTimeZoneResponse, error) {
+	var r GetTimeZoneResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetTimeZone`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetTimeZone == nil {
-		return nil, errors.New(`unexpected respose from service calling alarmclock.GetTimeZone()`)
-	}
-
-	return r.Body.GetTimeZone, nil
+	return &r, nil
 }
 
 type GetTimeZoneAndRuleArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetTimeZoneAndRuleArgs) Validate() error {
+	return nil
+}
+
 type GetTimeZoneAndRuleResponse struct {
 	Index           int32  `xml:"Index"`
 	AutoAdjustDst   bool   `xml:"AutoAdjustDst"`
@@ -232,154 +171,142 @@ type GetTimeZoneAndRuleResponse struct {
 }
 
 func (s *Service) GetTimeZoneAndRule(httpClient *http.Client, args *GetTimeZoneAndRuleArgs) (*GetTimeZoneAndRuleResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetTimeZoneAndRule`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetTimeZoneAndRule: args},
-		})
-	if err != nil {
+	return s.GetTimeZoneAndRuleContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetTimeZoneAndRuleContext(ctx context.Context, httpClient *http.Client, args *GetTimeZoneAndRuleArgs) (*GetTimeZoneAndRuleResponse, error) {
+	var r GetTimeZoneAndRuleResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetTimeZoneAndRule`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetTimeZoneAndRule == nil {
-		return nil, errors.New(`unexpected respose from service calling alarmclock.GetTimeZoneAndRule()`)
-	}
-
-	return r.Body.GetTimeZoneAndRule, nil
+	return &r, nil
 }
 
 type GetTimeZoneRuleArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
-	Index int32  `xml:"Index"`
+	Index int32 `xml:"Index"`
+}
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetTimeZoneRuleArgs) Validate() error {
+	return nil
 }
+
 type GetTimeZoneRuleResponse struct {
 	TimeZone string `xml:"TimeZone"`
 }
 
 func (s *Service) GetTimeZoneRule(httpClient *http.Client, args *GetTimeZoneRuleArgs) (*GetTimeZoneRuleResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetTimeZoneRule`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetTimeZoneRule: args},
-		})
-	if err != nil {
+	return s.GetTimeZoneRuleContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetTimeZoneRuleContext(ctx context.Context, httpClient *http.Client, args *GetTimeZoneRuleArgs) (*GetTimeZoneRuleResponse, error) {
+	var r GetTimeZoneRuleResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetTimeZoneRule`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetTimeZoneRule == nil {
-		return nil, errors.New(`unexpected respose from service calling alarmclock.GetTimeZoneRule()`)
-	}
-
-	return r.Body.GetTimeZoneRule, nil
+	return &r, nil
 }
 
 type SetTimeServerArgs struct {
-	Xmlns             string `xml:"xmlns:u,attr"`
 	DesiredTimeServer string `xml:"DesiredTimeServer"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetTimeServerArgs) Validate() error {
+	return nil
+}
+
 type SetTimeServerResponse struct {
 }
 
 func (s *Service) SetTimeServer(httpClient *http.Client, args *SetTimeServerArgs) (*SetTimeServerResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetTimeServer`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetTimeServer: args},
-		})
-	if err != nil {
+	return s.SetTimeServerContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetTimeServerContext(ctx context.Context, httpClient *http.Client, args *SetTimeServerArgs) (*SetTimeServerResponse, error) {
+	var r SetTimeServerResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetTimeServer`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetTimeServer == nil {
-		return nil, errors.New(`unexpected respose from service calling alarmclock.SetTimeServer()`)
-	}
-
-	return r.Body.SetTimeServer, nil
+	return &r, nil
 }
 
 type GetTimeServerArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetTimeServerArgs) Validate() error {
+	return nil
+}
+
 type GetTimeServerResponse struct {
 	CurrentTimeServer string `xml:"CurrentTimeServer"`
 }
 
 func (s *Service) GetTimeServer(httpClient *http.Client, args *GetTimeServerArgs) (*GetTimeServerResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetTimeServer`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetTimeServer: args},
-		})
-	if err != nil {
+	return s.GetTimeServerContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetTimeServerContext(ctx context.Context, httpClient *http.Client, args *GetTimeServerArgs) (*GetTimeServerResponse, error) {
+	var r GetTimeServerResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetTimeServer`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetTimeServer == nil {
-		return nil, errors.New(`unexpected respose from service calling alarmclock.GetTimeServer()`)
-	}
-
-	return r.Body.GetTimeServer, nil
+	return &r, nil
 }
 
 type SetTimeNowArgs struct {
-	Xmlns                  string `xml:"xmlns:u,attr"`
 	DesiredTime            string `xml:"DesiredTime"`
 	TimeZoneForDesiredTime string `xml:"TimeZoneForDesiredTime"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetTimeNowArgs) Validate() error {
+	return nil
+}
+
 type SetTimeNowResponse struct {
 }
 
 func (s *Service) SetTimeNow(httpClient *http.Client, args *SetTimeNowArgs) (*SetTimeNowResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetTimeNow`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetTimeNow: args},
-		})
-	if err != nil {
+	return s.SetTimeNowContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetTimeNowContext(ctx context.Context, httpClient *http.Client, args *SetTimeNowArgs) (*SetTimeNowResponse, error) {
+	var r SetTimeNowResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetTimeNow`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetTimeNow == nil {
-		return nil, errors.New(`unexpected respose from service calling alarmclock.SetTimeNow()`)
-	}
-
-	return r.Body.SetTimeNow, nil
+	return &r, nil
 }
 
 type GetHouseholdTimeAtStampArgs struct {
-	Xmlns     string `xml:"xmlns:u,attr"`
 	TimeStamp string `xml:"TimeStamp"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetHouseholdTimeAtStampArgs) Validate() error {
+	return nil
+}
+
 type GetHouseholdTimeAtStampResponse struct {
 	HouseholdUTCTime string `xml:"HouseholdUTCTime"`
 }
 
 func (s *Service) GetHouseholdTimeAtStamp(httpClient *http.Client, args *GetHouseholdTimeAtStampArgs) (*GetHouseholdTimeAtStampResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetHouseholdTimeAtStamp`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetHouseholdTimeAtStamp: args},
-		})
-	if err != nil {
+	return s.GetHouseholdTimeAtStampContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetHouseholdTimeAtStampContext(ctx context.Context, httpClient *http.Client, args *GetHouseholdTimeAtStampArgs) (*GetHouseholdTimeAtStampResponse, error) {
+	var r GetHouseholdTimeAtStampResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetHouseholdTimeAtStamp`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetHouseholdTimeAtStamp == nil {
-		return nil, errors.New(`unexpected respose from service calling alarmclock.GetHouseholdTimeAtStamp()`)
-	}
-
-	return r.Body.GetHouseholdTimeAtStamp, nil
+	return &r, nil
 }
 
 type GetTimeNowArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetTimeNowArgs) Validate() error {
+	return nil
+}
+
 type GetTimeNowResponse struct {
 	CurrentUTCTime        string `xml:"CurrentUTCTime"`
 	CurrentLocalTime      string `xml:"CurrentLocalTime"`
@@ -388,68 +315,63 @@ type GetTimeNowResponse struct {
 }
 
 func (s *Service) GetTimeNow(httpClient *http.Client, args *GetTimeNowArgs) (*GetTimeNowResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetTimeNow`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetTimeNow: args},
-		})
-	if err != nil {
+	return s.GetTimeNowContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetTimeNowContext(ctx context.Context, httpClient *http.Client, args *GetTimeNowArgs) (*GetTimeNowResponse, error) {
+	var r GetTimeNowResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetTimeNow`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetTimeNow == nil {
-		return nil, errors.New(`unexpected respose from service calling alarmclock.GetTimeNow()`)
-	}
-
-	return r.Body.GetTimeNow, nil
+	return &r, nil
 }
 
 type CreateAlarmArgs struct {
-	Xmlns          string `xml:"xmlns:u,attr"`
 	StartLocalTime string `xml:"StartLocalTime"`
 	Duration       string `xml:"Duration"`
 	// Allowed Value: ONCE
 	// Allowed Value: WEEKDAYS
 	// Allowed Value: WEEKENDS
 	// Allowed Value: DAILY
-	Recurrence      string `xml:"Recurrence"`
-	Enabled         bool   `xml:"Enabled"`
-	RoomUUID        string `xml:"RoomUUID"`
-	ProgramURI      string `xml:"ProgramURI"`
-	ProgramMetaData string `xml:"ProgramMetaData"`
+	Recurrence      Recurrence `xml:"Recurrence"`
+	Enabled         bool       `xml:"Enabled"`
+	RoomUUID        string     `xml:"RoomUUID"`
+	ProgramURI      string     `xml:"ProgramURI"`
+	ProgramMetaData string     `xml:"ProgramMetaData"`
 	// Allowed Value: NORMAL
 	// Allowed Value: REPEAT_ALL
 	// Allowed Value: SHUFFLE_NOREPEAT
 	// Allowed Value: SHUFFLE
-	PlayMode           string `xml:"PlayMode"`
-	Volume             uint16 `xml:"Volume"`
-	IncludeLinkedZones bool   `xml:"IncludeLinkedZones"`
+	PlayMode           AlarmPlayMode `xml:"PlayMode"`
+	Volume             uint16        `xml:"Volume"`
+	IncludeLinkedZones bool          `xml:"IncludeLinkedZones"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *CreateAlarmArgs) Validate() error {
+	switch a.PlayMode {
+	case "", AlarmPlayModeNormal, AlarmPlayModeRepeatAll, AlarmPlayModeShuffleNoRepeat, AlarmPlayModeShuffle:
+	default:
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `CreateAlarm`, Argument: `PlayMode`, Value: a.PlayMode, Allowed: "one of NORMAL, REPEAT_ALL, SHUFFLE_NOREPEAT, SHUFFLE"}
+	}
+	return nil
+}
+
 type CreateAlarmResponse struct {
 	AssignedID uint32 `xml:"AssignedID"`
 }
 
 func (s *Service) CreateAlarm(httpClient *http.Client, args *CreateAlarmArgs) (*CreateAlarmResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`CreateAlarm`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{CreateAlarm: args},
-		})
-	if err != nil {
+	return s.CreateAlarmContext(context.Background(), httpClient, args)
+}
+func (s *Service) CreateAlarmContext(ctx context.Context, httpClient *http.Client, args *CreateAlarmArgs) (*CreateAlarmResponse, error) {
+	var r CreateAlarmResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `CreateAlarm`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.CreateAlarm == nil {
-		return nil, errors.New(`unexpected respose from service calling alarmclock.CreateAlarm()`)
-	}
-
-	return r.Body.CreateAlarm, nil
+	return &r, nil
 }
 
 type UpdateAlarmArgs struct {
-	Xmlns          string `xml:"xmlns:u,attr"`
 	ID             uint32 `xml:"ID"`
 	StartLocalTime string `xml:"StartLocalTime"`
 	Duration       string `xml:"Duration"`
@@ -457,137 +379,394 @@ type UpdateAlarmArgs struct {
 	// Allowed Value: WEEKDAYS
 	// Allowed Value: WEEKENDS
 	// Allowed Value: DAILY
-	Recurrence      string `xml:"Recurrence"`
-	Enabled         bool   `xml:"Enabled"`
-	RoomUUID        string `xml:"RoomUUID"`
-	ProgramURI      string `xml:"ProgramURI"`
-	ProgramMetaData string `xml:"ProgramMetaData"`
+	Recurrence      Recurrence `xml:"Recurrence"`
+	Enabled         bool       `xml:"Enabled"`
+	RoomUUID        string     `xml:"RoomUUID"`
+	ProgramURI      string     `xml:"ProgramURI"`
+	ProgramMetaData string     `xml:"ProgramMetaData"`
 	// Allowed Value: NORMAL
 	// Allowed Value: REPEAT_ALL
 	// Allowed Value: SHUFFLE_NOREPEAT
 	// Allowed Value: SHUFFLE
-	PlayMode           string `xml:"PlayMode"`
-	Volume             uint16 `xml:"Volume"`
-	IncludeLinkedZones bool   `xml:"IncludeLinkedZones"`
+	PlayMode           AlarmPlayMode `xml:"PlayMode"`
+	Volume             uint16        `xml:"Volume"`
+	IncludeLinkedZones bool          `xml:"IncludeLinkedZones"`
+}
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *UpdateAlarmArgs) Validate() error {
+	switch a.PlayMode {
+	case "", AlarmPlayModeNormal, AlarmPlayModeRepeatAll, AlarmPlayModeShuffleNoRepeat, AlarmPlayModeShuffle:
+	default:
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `UpdateAlarm`, Argument: `PlayMode`, Value: a.PlayMode, Allowed: "one of NORMAL, REPEAT_ALL, SHUFFLE_NOREPEAT, SHUFFLE"}
+	}
+	return nil
 }
+
 type UpdateAlarmResponse struct {
 }
 
 func (s *Service) UpdateAlarm(httpClient *http.Client, args *UpdateAlarmArgs) (*UpdateAlarmResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`UpdateAlarm`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{UpdateAlarm: args},
-		})
-	if err != nil {
+	return s.UpdateAlarmContext(context.Background(), httpClient, args)
+}
+func (s *Service) UpdateAlarmContext(ctx context.Context, httpClient *http.Client, args *UpdateAlarmArgs) (*UpdateAlarmResponse, error) {
+	var r UpdateAlarmResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `UpdateAlarm`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.UpdateAlarm == nil {
-		return nil, errors.New(`unexpected respose from service calling alarmclock.UpdateAlarm()`)
-	}
-
-	return r.Body.UpdateAlarm, nil
+	return &r, nil
 }
 
 type DestroyAlarmArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
-	ID    uint32 `xml:"ID"`
+	ID uint32 `xml:"ID"`
+}
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *DestroyAlarmArgs) Validate() error {
+	return nil
 }
+
 type DestroyAlarmResponse struct {
 }
 
 func (s *Service) DestroyAlarm(httpClient *http.Client, args *DestroyAlarmArgs) (*DestroyAlarmResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`DestroyAlarm`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{DestroyAlarm: args},
-		})
-	if err != nil {
+	return s.DestroyAlarmContext(context.Background(), httpClient, args)
+}
+func (s *Service) DestroyAlarmContext(ctx context.Context, httpClient *http.Client, args *DestroyAlarmArgs) (*DestroyAlarmResponse, error) {
+	var r DestroyAlarmResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `DestroyAlarm`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.DestroyAlarm == nil {
-		return nil, errors.New(`unexpected respose from service calling alarmclock.DestroyAlarm()`)
-	}
-
-	return r.Body.DestroyAlarm, nil
+	return &r, nil
 }
 
 type ListAlarmsArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *ListAlarmsArgs) Validate() error {
+	return nil
+}
+
 type ListAlarmsResponse struct {
 	CurrentAlarmList        string `xml:"CurrentAlarmList"`
 	CurrentAlarmListVersion string `xml:"CurrentAlarmListVersion"`
 }
 
 func (s *Service) ListAlarms(httpClient *http.Client, args *ListAlarmsArgs) (*ListAlarmsResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`ListAlarms`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{ListAlarms: args},
-		})
-	if err != nil {
+	return s.ListAlarmsContext(context.Background(), httpClient, args)
+}
+func (s *Service) ListAlarmsContext(ctx context.Context, httpClient *http.Client, args *ListAlarmsArgs) (*ListAlarmsResponse, error) {
+	var r ListAlarmsResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `ListAlarms`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.ListAlarms == nil {
-		return nil, errors.New(`unexpected respose from service calling alarmclock.ListAlarms()`)
-	}
-
-	return r.Body.ListAlarms, nil
+	return &r, nil
 }
 
 type SetDailyIndexRefreshTimeArgs struct {
-	Xmlns                        string `xml:"xmlns:u,attr"`
 	DesiredDailyIndexRefreshTime string `xml:"DesiredDailyIndexRefreshTime"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetDailyIndexRefreshTimeArgs) Validate() error {
+	return nil
+}
+
 type SetDailyIndexRefreshTimeResponse struct {
 }
 
 func (s *Service) SetDailyIndexRefreshTime(httpClient *http.Client, args *SetDailyIndexRefreshTimeArgs) (*SetDailyIndexRefreshTimeResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetDailyIndexRefreshTime`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetDailyIndexRefreshTime: args},
-		})
-	if err != nil {
+	return s.SetDailyIndexRefreshTimeContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetDailyIndexRefreshTimeContext(ctx context.Context, httpClient *http.Client, args *SetDailyIndexRefreshTimeArgs) (*SetDailyIndexRefreshTimeResponse, error) {
+	var r SetDailyIndexRefreshTimeResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetDailyIndexRefreshTime`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetDailyIndexRefreshTime == nil {
-		return nil, errors.New(`unexpected respose from service calling alarmclock.SetDailyIndexRefreshTime()`)
-	}
-
-	return r.Body.SetDailyIndexRefreshTime, nil
+	return &r, nil
 }
 
 type GetDailyIndexRefreshTimeArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetDailyIndexRefreshTimeArgs) Validate() error {
+	return nil
+}
+
 type GetDailyIndexRefreshTimeResponse struct {
 	CurrentDailyIndexRefreshTime string `xml:"CurrentDailyIndexRefreshTime"`
 }
 
 func (s *Service) GetDailyIndexRefreshTime(httpClient *http.Client, args *GetDailyIndexRefreshTimeArgs) (*GetDailyIndexRefreshTimeResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetDailyIndexRefreshTime`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetDailyIndexRefreshTime: args},
-		})
-	if err != nil {
+	return s.GetDailyIndexRefreshTimeContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetDailyIndexRefreshTimeContext(ctx context.Context, httpClient *http.Client, args *GetDailyIndexRefreshTimeArgs) (*GetDailyIndexRefreshTimeResponse, error) {
+	var r GetDailyIndexRefreshTimeResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetDailyIndexRefreshTime`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetDailyIndexRefreshTime == nil {
-		return nil, errors.New(`unexpected respose from service calling alarmclock.GetDailyIndexRefreshTime()`)
+	return &r, nil
+}
+
+// Client is the set of actions of the service. It is implemented by Service and Fake
+type Client interface {
+	SetFormat(httpClient *http.Client, args *SetFormatArgs) (*SetFormatResponse, error)
+	SetFormatContext(ctx context.Context, httpClient *http.Client, args *SetFormatArgs) (*SetFormatResponse, error)
+	GetFormat(httpClient *http.Client, args *GetFormatArgs) (*GetFormatResponse, error)
+	GetFormatContext(ctx context.Context, httpClient *http.Client, args *GetFormatArgs) (*GetFormatResponse, error)
+	SetTimeZone(httpClient *http.Client, args *SetTimeZoneArgs) (*SetTimeZoneResponse, error)
+	SetTimeZoneContext(ctx context.Context, httpClient *http.Client, args *SetTimeZoneArgs) (*SetTimeZoneResponse, error)
+	GetTimeZone(httpClient *http.Client, args *GetTimeZoneArgs) (*GetTimeZoneResponse, error)
+	GetTimeZoneContext(ctx context.Context, httpClient *http.Client, args *GetTimeZoneArgs) (*GetTimeZoneResponse, error)
+	GetTimeZoneAndRule(httpClient *http.Client, args *GetTimeZoneAndRuleArgs) (*GetTimeZoneAndRuleResponse, error)
+	GetTimeZoneAndRuleContext(ctx context.Context, httpClient *http.Client, args *GetTimeZoneAndRuleArgs) (*GetTimeZoneAndRuleResponse, error)
+	GetTimeZoneRule(httpClient *http.Client, args *GetTimeZoneRuleArgs) (*GetTimeZoneRuleResponse, error)
+	GetTimeZoneRuleContext(ctx context.Context, httpClient *http.Client, args *GetTimeZoneRuleArgs) (*GetTimeZoneRuleResponse, error)
+	SetTimeServer(httpClient *http.Client, args *SetTimeServerArgs) (*SetTimeServerResponse, error)
+	SetTimeServerContext(ctx context.Context, httpClient *http.Client, args *SetTimeServerArgs) (*SetTimeServerResponse, error)
+	GetTimeServer(httpClient *http.Client, args *GetTimeServerArgs) (*GetTimeServerResponse, error)
+	GetTimeServerContext(ctx context.Context, httpClient *http.Client, args *GetTimeServerArgs) (*GetTimeServerResponse, error)
+	SetTimeNow(httpClient *http.Client, args *SetTimeNowArgs) (*SetTimeNowResponse, error)
+	SetTimeNowContext(ctx context.Context, httpClient *http.Client, args *SetTimeNowArgs) (*SetTimeNowResponse, error)
+	GetHouseholdTimeAtStamp(httpClient *http.Client, args *GetHouseholdTimeAtStampArgs) (*GetHouseholdTimeAtStampResponse, error)
+	GetHouseholdTimeAtStampContext(ctx context.Context, httpClient *http.Client, args *GetHouseholdTimeAtStampArgs) (*GetHouseholdTimeAtStampResponse, error)
+	GetTimeNow(httpClient *http.Client, args *GetTimeNowArgs) (*GetTimeNowResponse, error)
+	GetTimeNowContext(ctx context.Context, httpClient *http.Client, args *GetTimeNowArgs) (*GetTimeNowResponse, error)
+	CreateAlarm(httpClient *http.Client, args *CreateAlarmArgs) (*CreateAlarmResponse, error)
+	CreateAlarmContext(ctx context.Context, httpClient *http.Client, args *CreateAlarmArgs) (*CreateAlarmResponse, error)
+	UpdateAlarm(httpClient *http.Client, args *UpdateAlarmArgs) (*UpdateAlarmResponse, error)
+	UpdateAlarmContext(ctx context.Context, httpClient *http.Client, args *UpdateAlarmArgs) (*UpdateAlarmResponse, error)
+	DestroyAlarm(httpClient *http.Client, args *DestroyAlarmArgs) (*DestroyAlarmResponse, error)
+	DestroyAlarmContext(ctx context.Context, httpClient *http.Client, args *DestroyAlarmArgs) (*DestroyAlarmResponse, error)
+	ListAlarms(httpClient *http.Client, args *ListAlarmsArgs) (*ListAlarmsResponse, error)
+	ListAlarmsContext(ctx context.Context, httpClient *http.Client, args *ListAlarmsArgs) (*ListAlarmsResponse, error)
+	SetDailyIndexRefreshTime(httpClient *http.Client, args *SetDailyIndexRefreshTimeArgs) (*SetDailyIndexRefreshTimeResponse, error)
+	SetDailyIndexRefreshTimeContext(ctx context.Context, httpClient *http.Client, args *SetDailyIndexRefreshTimeArgs) (*SetDailyIndexRefreshTimeResponse, error)
+	GetDailyIndexRefreshTime(httpClient *http.Client, args *GetDailyIndexRefreshTimeArgs) (*GetDailyIndexRefreshTimeResponse, error)
+	GetDailyIndexRefreshTimeContext(ctx context.Context, httpClient *http.Client, args *GetDailyIndexRefreshTimeArgs) (*GetDailyIndexRefreshTimeResponse, error)
+}
+
+var _ Client = (*Service)(nil)
+var _ Client = (*Fake)(nil)
+
+// Call is an action received by a Fake
+type Call struct {
+	Action string
+	// Args is the *<Action>Args the action was called with
+	Args interface{}
+}
+
+// Fake is an in-memory Client for tests. It records every call, and answers
+// an action with its On<Action> func, or with an empty response if that is nil
+type Fake struct {
+	mu                         sync.Mutex
+	calls                      []Call
+	OnSetFormat                func(ctx context.Context, args *SetFormatArgs) (*SetFormatResponse, error)
+	OnGetFormat                func(ctx context.Context, args *GetFormatArgs) (*GetFormatResponse, error)
+	OnSetTimeZone              func(ctx context.Context, args *SetTimeZoneArgs) (*SetTimeZoneResponse, error)
+	OnGetTimeZone              func(ctx context.Context, args *GetTimeZoneArgs) (*GetTimeZoneResponse, error)
+	OnGetTimeZoneAndRule       func(ctx context.Context, args *GetTimeZoneAndRuleArgs) (*GetTimeZoneAndRuleResponse, error)
+	OnGetTimeZoneRule          func(ctx context.Context, args *GetTimeZoneRuleArgs) (*GetTimeZoneRuleResponse, error)
+	OnSetTimeServer            func(ctx context.Context, args *SetTimeServerArgs) (*SetTimeServerResponse, error)
+	OnGetTimeServer            func(ctx context.Context, args *GetTimeServerArgs) (*GetTimeServerResponse, error)
+	OnSetTimeNow               func(ctx context.Context, args *SetTimeNowArgs) (*SetTimeNowResponse, error)
+	OnGetHouseholdTimeAtStamp  func(ctx context.Context, args *GetHouseholdTimeAtStampArgs) (*GetHouseholdTimeAtStampResponse, error)
+	OnGetTimeNow               func(ctx context.Context, args *GetTimeNowArgs) (*GetTimeNowResponse, error)
+	OnCreateAlarm              func(ctx context.Context, args *CreateAlarmArgs) (*CreateAlarmResponse, error)
+	OnUpdateAlarm              func(ctx context.Context, args *UpdateAlarmArgs) (*UpdateAlarmResponse, error)
+	OnDestroyAlarm             func(ctx context.Context, args *DestroyAlarmArgs) (*DestroyAlarmResponse, error)
+	OnListAlarms               func(ctx context.Context, args *ListAlarmsArgs) (*ListAlarmsResponse, error)
+	OnSetDailyIndexRefreshTime func(ctx context.Context, args *SetDailyIndexRefreshTimeArgs) (*SetDailyIndexRefreshTimeResponse, error)
+	OnGetDailyIndexRefreshTime func(ctx context.Context, args *GetDailyIndexRefreshTimeArgs) (*GetDailyIndexRefreshTimeResponse, error)
+}
+
+// Calls returns the calls received so far, oldest first
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// Reset forgets the calls received so far
+func (f *Fake) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+}
+func (f *Fake) record(action string, args interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{Action: action, Args: args})
+}
+func (f *Fake) SetFormat(httpClient *http.Client, args *SetFormatArgs) (*SetFormatResponse, error) {
+	return f.SetFormatContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetFormatContext(ctx context.Context, httpClient *http.Client, args *SetFormatArgs) (*SetFormatResponse, error) {
+	f.record(`SetFormat`, args)
+	if f.OnSetFormat != nil {
+		return f.OnSetFormat(ctx, args)
+	}
+	return &SetFormatResponse{}, nil
+}
+func (f *Fake) GetFormat(httpClient *http.Client, args *GetFormatArgs) (*GetFormatResponse, error) {
+	return f.GetFormatContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetFormatContext(ctx context.Context, httpClient *http.Client, args *GetFormatArgs) (*GetFormatResponse, error) {
+	f.record(`GetFormat`, args)
+	if f.OnGetFormat != nil {
+		return f.OnGetFormat(ctx, args)
+	}
+	return &GetFormatResponse{}, nil
+}
+func (f *Fake) SetTimeZone(httpClient *http.Client, args *SetTimeZoneArgs) (*SetTimeZoneResponse, error) {
+	return f.SetTimeZoneContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetTimeZoneContext(ctx context.Context, httpClient *http.Client, args *SetTimeZoneArgs) (*SetTimeZoneResponse, error) {
+	f.record(`SetTimeZone`, args)
+	if f.OnSetTimeZone != nil {
+		return f.OnSetTimeZone(ctx, args)
+	}
+	return &SetTimeZoneResponse{}, nil
+}
+func (f *Fake) GetTimeZone(httpClient *http.Client, args *GetTimeZoneArgs) (*GetTimeZoneResponse, error) {
+	return f.GetTimeZoneContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetTimeZoneContext(ctx context.Context, httpClient *http.Client, args *GetTimeZoneArgs) (*GetTimeZoneResponse, error) {
+	f.record(`GetTimeZone`, args)
+	if f.OnGetTimeZone != nil {
+		return f.OnGetTimeZone(ctx, args)
 	}
-
-	return r.Body.GetDailyIndexRefreshTime, nil
+	return &GetTimeZoneResponse{}, nil
+}
+func (f *Fake) GetTimeZoneAndRule(httpClient *http.Client, args *GetTimeZoneAndRuleArgs) (*GetTimeZoneAndRuleResponse, error) {
+	return f.GetTimeZoneAndRuleContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetTimeZoneAndRuleContext(ctx context.Context, httpClient *http.Client, args *GetTimeZoneAndRuleArgs) (*GetTimeZoneAndRuleResponse, error) {
+	f.record(`GetTimeZoneAndRule`, args)
+	if f.OnGetTimeZoneAndRule != nil {
+		return f.OnGetTimeZoneAndRule(ctx, args)
+	}
+	return &GetTimeZoneAndRuleResponse{}, nil
+}
+func (f *Fake) GetTimeZoneRule(httpClient *http.Client, args *GetTimeZoneRuleArgs) (*GetTimeZoneRuleResponse, error) {
+	return f.GetTimeZoneRuleContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetTimeZoneRuleContext(ctx context.Context, httpClient *http.Client, args *GetTimeZoneRuleArgs) (*GetTimeZoneRuleResponse, error) {
+	f.record(`GetTimeZoneRule`, args)
+	if f.OnGetTimeZoneRule != nil {
+		return f.OnGetTimeZoneRule(ctx, args)
+	}
+	return &GetTimeZoneRuleResponse{}, nil
+}
+func (f *Fake) SetTimeServer(httpClient *http.Client, args *SetTimeServerArgs) (*SetTimeServerResponse, error) {
+	return f.SetTimeServerContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetTimeServerContext(ctx context.Context, httpClient *http.Client, args *SetTimeServerArgs) (*SetTimeServerResponse, error) {
+	f.record(`SetTimeServer`, args)
+	if f.OnSetTimeServer != nil {
+		return f.OnSetTimeServer(ctx, args)
+	}
+	return &SetTimeServerResponse{}, nil
+}
+func (f *Fake) GetTimeServer(httpClient *http.Client, args *GetTimeServerArgs) (*GetTimeServerResponse, error) {
+	return f.GetTimeServerContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetTimeServerContext(ctx context.Context, httpClient *http.Client, args *GetTimeServerArgs) (*GetTimeServerResponse, error) {
+	f.record(`GetTimeServer`, args)
+	if f.OnGetTimeServer != nil {
+		return f.OnGetTimeServer(ctx, args)
+	}
+	return &GetTimeServerResponse{}, nil
+}
+func (f *Fake) SetTimeNow(httpClient *http.Client, args *SetTimeNowArgs) (*SetTimeNowResponse, error) {
+	return f.SetTimeNowContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetTimeNowContext(ctx context.Context, httpClient *http.Client, args *SetTimeNowArgs) (*SetTimeNowResponse, error) {
+	f.record(`SetTimeNow`, args)
+	if f.OnSetTimeNow != nil {
+		return f.OnSetTimeNow(ctx, args)
+	}
+	return &SetTimeNowResponse{}, nil
+}
+func (f *Fake) GetHouseholdTimeAtStamp(httpClient *http.Client, args *GetHouseholdTimeAtStampArgs) (*GetHouseholdTimeAtStampResponse, error) {
+	return f.GetHouseholdTimeAtStampContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetHouseholdTimeAtStampContext(ctx context.Context, httpClient *http.Client, args *GetHouseholdTimeAtStampArgs) (*GetHouseholdTimeAtStampResponse, error) {
+	f.record(`GetHouseholdTimeAtStamp`, args)
+	if f.OnGetHouseholdTimeAtStamp != nil {
+		return f.OnGetHouseholdTimeAtStamp(ctx, args)
+	}
+	return &GetHouseholdTimeAtStampResponse{}, nil
+}
+func (f *Fake) GetTimeNow(httpClient *http.Client, args *GetTimeNowArgs) (*GetTimeNowResponse, error) {
+	return f.GetTimeNowContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetTimeNowContext(ctx context.Context, httpClient *http.Client, args *GetTimeNowArgs) (*GetTimeNowResponse, error) {
+	f.record(`GetTimeNow`, args)
+	if f.OnGetTimeNow != nil {
+		return f.OnGetTimeNow(ctx, args)
+	}
+	return &GetTimeNowResponse{}, nil
+}
+func (f *Fake) CreateAlarm(httpClient *http.Client, args *CreateAlarmArgs) (*CreateAlarmResponse, error) {
+	return f.CreateAlarmContext(context.Background(), httpClient, args)
+}
+func (f *Fake) CreateAlarmContext(ctx context.Context, httpClient *http.Client, args *CreateAlarmArgs) (*CreateAlarmResponse, error) {
+	f.record(`CreateAlarm`, args)
+	if f.OnCreateAlarm != nil {
+		return f.OnCreateAlarm(ctx, args)
+	}
+	return &CreateAlarmResponse{}, nil
+}
+func (f *Fake) UpdateAlarm(httpClient *http.Client, args *UpdateAlarmArgs) (*UpdateAlarmResponse, error) {
+	return f.UpdateAlarmContext(context.Background(), httpClient, args)
+}
+func (f *Fake) UpdateAlarmContext(ctx context.Context, httpClient *http.Client, args *UpdateAlarmArgs) (*UpdateAlarmResponse, error) {
+	f.record(`UpdateAlarm`, args)
+	if f.OnUpdateAlarm != nil {
+		return f.OnUpdateAlarm(ctx, args)
+	}
+	return &UpdateAlarmResponse{}, nil
+}
+func (f *Fake) DestroyAlarm(httpClient *http.Client, args *DestroyAlarmArgs) (*DestroyAlarmResponse, error) {
+	return f.DestroyAlarmContext(context.Background(), httpClient, args)
+}
+func (f *Fake) DestroyAlarmContext(ctx context.Context, httpClient *http.Client, args *DestroyAlarmArgs) (*DestroyAlarmResponse, error) {
+	f.record(`DestroyAlarm`, args)
+	if f.OnDestroyAlarm != nil {
+		return f.OnDestroyAlarm(ctx, args)
+	}
+	return &DestroyAlarmResponse{}, nil
+}
+func (f *Fake) ListAlarms(httpClient *http.Client, args *ListAlarmsArgs) (*ListAlarmsResponse, error) {
+	return f.ListAlarmsContext(context.Background(), httpClient, args)
+}
+func (f *Fake) ListAlarmsContext(ctx context.Context, httpClient *http.Client, args *ListAlarmsArgs) (*ListAlarmsResponse, error) {
+	f.record(`ListAlarms`, args)
+	if f.OnListAlarms != nil {
+		return f.OnListAlarms(ctx, args)
+	}
+	return &ListAlarmsResponse{}, nil
+}
+func (f *Fake) SetDailyIndexRefreshTime(httpClient *http.Client, args *SetDailyIndexRefreshTimeArgs) (*SetDailyIndexRefreshTimeResponse, error) {
+	return f.SetDailyIndexRefreshTimeContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetDailyIndexRefreshTimeContext(ctx context.Context, httpClient *http.Client, args *SetDailyIndexRefreshTimeArgs) (*SetDailyIndexRefreshTimeResponse, error) {
+	f.record(`SetDailyIndexRefreshTime`, args)
+	if f.OnSetDailyIndexRefreshTime != nil {
+		return f.OnSetDailyIndexRefreshTime(ctx, args)
+	}
+	return &SetDailyIndexRefreshTimeResponse{}, nil
+}
+func (f *Fake) GetDailyIndexRefreshTime(httpClient *http.Client, args *GetDailyIndexRefreshTimeArgs) (*GetDailyIndexRefreshTimeResponse, error) {
+	return f.GetDailyIndexRefreshTimeContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetDailyIndexRefreshTimeContext(ctx context.Context, httpClient *http.Client, args *GetDailyIndexRefreshTimeArgs) (*GetDailyIndexRefreshTimeResponse, error) {
+	f.record(`GetDailyIndexRefreshTime`, args)
+	if f.OnGetDailyIndexRefreshTime != nil {
+		return f.OnGetDailyIndexRefreshTime(ctx, args)
+	}
+	return &GetDailyIndexRefreshTimeResponse{}, nil
 }
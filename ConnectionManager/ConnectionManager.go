@@ -1,23 +1,55 @@
 package connectionmanager
 
 import (
-	"bytes"
-	"encoding/xml"
-	"errors"
-	"io/ioutil"
+	"context"
 	"net/http"
 	"net/url"
+	"sync"
+
+	"github.com/szatmary/sonos/soap"
 )
 
+const _ServiceURN = "urn:schemas-upnp-org:service:ConnectionManager:1"
+
+// ErrorCodes describes the error codes specific to this service
+var ErrorCodes = map[int]string{
+	701: "Incompatible protocol info",
+	702: "Incompatible directions",
+	703: "Insufficient network resources",
+	704: "Local restrictions",
+	705: "Access denied",
+	706: "Invalid connection reference",
+	707: "Not in network",
+}
+
+func init() {
+	soap.RegisterErrorCodes(_ServiceURN, ErrorCodes)
+}
+
+// ConnectionStatus holds the values allowed for the A_ARG_TYPE_ConnectionStatus state variable
+type ConnectionStatus string
+
 const (
-	_ServiceURN     = "urn:schemas-upnp-org:service:ConnectionManager:1"
-	_EncodingSchema = "http://schemas.xmlsoap.org/soap/encoding/"
-	_EnvelopeSchema = "http://schemas.xmlsoap.org/soap/envelope/"
+	ConnectionStatusOK                    ConnectionStatus = "OK"
+	ConnectionStatusContentFormatMismatch ConnectionStatus = "ContentFormatMismatch"
+	ConnectionStatusInsufficientBandwidth ConnectionStatus = "InsufficientBandwidth"
+	ConnectionStatusUnreliableChannel     ConnectionStatus = "UnreliableChannel"
+	ConnectionStatusUnknown               ConnectionStatus = "Unknown"
+)
+
+// Direction holds the values allowed for the A_ARG_TYPE_Direction state variable
+type Direction string
+
+const (
+	DirectionInput  Direction = "Input"
+	DirectionOutput Direction = "Output"
 )
 
 type Service struct {
 	ControlEndpoint *url.URL
 	EventEndpoint   *url.URL
+	// Client sends the actions, soap.DefaultClient is used if it is nil
+	Client *soap.Client
 }
 
 func NewService(deviceUrl *url.URL) *Service {
@@ -35,140 +67,158 @@ func NewService(deviceUrl *url.URL) *Service {
 	}
 }
 
-type Envelope struct {
-	XMLName       xml.Name `xml:"s:Envelope"`
-	Xmlns         string   `xml:"xmlns:s,attr"`
-	EncodingStyle string   `xml:"s:encodingStyle,attr"`
-	Body          Body     `xml:"s:Body"`
-}
-type Body struct {
-	XMLName                  xml.Name                      `xml:"s:Body"`
-	GetProtocolInfo          *GetProtocolInfoArgs          `xml:"u:GetProtocolInfo,omitempty"`
-	GetCurrentConnectionIDs  *GetCurrentConnectionIDsArgs  `xml:"u:GetCurrentConnectionIDs,omitempty"`
-	GetCurrentConnectionInfo *GetCurrentConnectionInfoArgs `xml:"u:GetCurrentConnectionInfo,omitempty"`
-}
-type EnvelopeResponse struct {
-	XMLName       xml.Name     `xml:"Envelope"`
-	Xmlns         string       `xml:"xmlns:s,attr"`
-	EncodingStyle string       `xml:"encodingStyle,attr"`
-	Body          BodyResponse `xml:"Body"`
-}
-type BodyResponse struct {
-	XMLName                  xml.Name                          `xml:"Body"`
-	GetProtocolInfo          *GetProtocolInfoResponse          `xml:"GetProtocolInfoResponse,omitempty"`
-	GetCurrentConnectionIDs  *GetCurrentConnectionIDsResponse  `xml:"GetCurrentConnectionIDsResponse,omitempty"`
-	GetCurrentConnectionInfo *GetCurrentConnectionInfoResponse `xml:"GetCurrentConnectionInfoResponse,omitempty"`
+type GetProtocolInfoArgs struct {
 }
 
-func (s *Service) exec(actionName string, httpClient *http.Client, envelope *Envelope) (*EnvelopeResponse, error) {
-	marshaled, err := xml.Marshal(envelope)
-	if err != nil {
-		return nil, err
-	}
-	postBody := []byte(`<?xml version="1.0"?>`)
-	postBody = append(postBody, marshaled...)
-	req, err := http.NewRequest(`POST`, s.ControlEndpoint.String(), bytes.NewBuffer(postBody))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set(`Content-Type`, `text/xml; charset="utf-8"`)
-	req.Header.Set(`SOAPAction`, _ServiceURN+`#`+actionName)
-	res, err := httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-	responseBody, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-	var envelopeResponse EnvelopeResponse
-	err = xml.Unmarshal(responseBody, &envelopeResponse)
-	if err != nil {
-		return nil, err
-	}
-	return &envelopeResponse, nil
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetProtocolInfoArgs) Validate() error {
+	return nil
 }
 
-type GetProtocolInfoArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
-}
 type GetProtocolInfoResponse struct {
 	Source string `xml:"Source"`
 	Sink   string `xml:"Sink"`
 }
 
 func (s *Service) GetProtocolInfo(httpClient *http.Client, args *GetProtocolInfoArgs) (*GetProtocolInfoResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetProtocolInfo`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetProtocolInfo: args},
-		})
-	if err != nil {
+	return s.GetProtocolInfoContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetProtocolInfoContext(ctx context.Context, httpClient *http.Client, args *GetProtocolInfoArgs) (*GetProtocolInfoResponse, error) {
+	var r GetProtocolInfoResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetProtocolInfo`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetProtocolInfo == nil {
-		return nil, errors.New(`unexpected respose from service calling connectionmanager.GetProtocolInfo()`)
-	}
-
-	return r.Body.GetProtocolInfo, nil
+	return &r, nil
 }
 
 type GetCurrentConnectionIDsArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetCurrentConnectionIDsArgs) Validate() error {
+	return nil
+}
+
 type GetCurrentConnectionIDsResponse struct {
 	ConnectionIDs string `xml:"ConnectionIDs"`
 }
 
 func (s *Service) GetCurrentConnectionIDs(httpClient *http.Client, args *GetCurrentConnectionIDsArgs) (*GetCurrentConnectionIDsResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetCurrentConnectionIDs`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetCurrentConnectionIDs: args},
-		})
-	if err != nil {
+	return s.GetCurrentConnectionIDsContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetCurrentConnectionIDsContext(ctx context.Context, httpClient *http.Client, args *GetCurrentConnectionIDsArgs) (*GetCurrentConnectionIDsResponse, error) {
+	var r GetCurrentConnectionIDsResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetCurrentConnectionIDs`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetCurrentConnectionIDs == nil {
-		return nil, errors.New(`unexpected respose from service calling connectionmanager.GetCurrentConnectionIDs()`)
-	}
-
-	return r.Body.GetCurrentConnectionIDs, nil
+	return &r, nil
 }
 
 type GetCurrentConnectionInfoArgs struct {
-	Xmlns        string `xml:"xmlns:u,attr"`
-	ConnectionID int32  `xml:"ConnectionID"`
+	ConnectionID int32 `xml:"ConnectionID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetCurrentConnectionInfoArgs) Validate() error {
+	return nil
+}
+
 type GetCurrentConnectionInfoResponse struct {
-	RcsID                 int32  `xml:"RcsID"`
-	AVTransportID         int32  `xml:"AVTransportID"`
-	ProtocolInfo          string `xml:"ProtocolInfo"`
-	PeerConnectionManager string `xml:"PeerConnectionManager"`
-	PeerConnectionID      int32  `xml:"PeerConnectionID"`
-	Direction             string `xml:"Direction"`
-	Status                string `xml:"Status"`
+	RcsID                 int32            `xml:"RcsID"`
+	AVTransportID         int32            `xml:"AVTransportID"`
+	ProtocolInfo          string           `xml:"ProtocolInfo"`
+	PeerConnectionManager string           `xml:"PeerConnectionManager"`
+	PeerConnectionID      int32            `xml:"PeerConnectionID"`
+	Direction             Direction        `xml:"Direction"`
+	Status                ConnectionStatus `xml:"Status"`
 }
 
 func (s *Service) GetCurrentConnectionInfo(httpClient *http.Client, args *GetCurrentConnectionInfoArgs) (*GetCurrentConnectionInfoResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetCurrentConnectionInfo`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetCurrentConnectionInfo: args},
-		})
-	if err != nil {
+	return s.GetCurrentConnectionInfoContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetCurrentConnectionInfoContext(ctx context.Context, httpClient *http.Client, args *GetCurrentConnectionInfoArgs) (*GetCurrentConnectionInfoResponse, error) {
+	var r GetCurrentConnectionInfoResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetCurrentConnectionInfo`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetCurrentConnectionInfo == nil {
-		return nil, errors.New(`unexpected respose from service calling connectionmanager.GetCurrentConnectionInfo()`)
-	}
+	return &r, nil
+}
+
+// Client is the set of actions of the service. It is implemented by Service and Fake
+type Client interface {
+	GetProtocolInfo(httpClient *http.Client, args *GetProtocolInfoArgs) (*GetProtocolInfoResponse, error)
+	GetProtocolInfoContext(ctx context.Context, httpClient *http.Client, args *GetProtocolInfoArgs) (*GetProtocolInfoResponse, error)
+	GetCurrentConnectionIDs(httpClient *http.Client, args *GetCurrentConnectionIDsArgs) (*GetCurrentConnectionIDsResponse, error)
+	GetCurrentConnectionIDsContext(ctx context.Context, httpClient *http.Client, args *GetCurrentConnectionIDsArgs) (*GetCurrentConnectionIDsResponse, error)
+	GetCurrentConnectionInfo(httpClient *http.Client, args *GetCurrentConnectionInfoArgs) (*GetCurrentConnectionInfoResponse, error)
+	GetCurrentConnectionInfoContext(ctx context.Context, httpClient *http.Client, args *GetCurrentConnectionInfoArgs) (*GetCurrentConnectionInfoResponse, error)
+}
 
-	return r.Body.GetCurrentConnectionInfo, nil
+var _ Client = (*Service)(nil)
+var _ Client = (*Fake)(nil)
+
+// Call is an action received by a Fake
+type Call struct {
+	Action string
+	// Args is the *<Action>Args the action was called with
+	Args interface{}
+}
+
+// Fake is an in-memory Client for tests. It records every call, and answers
+// an action with its On<Action> func, or with an empty response if that is nil
+type Fake struct {
+	mu                         sync.Mutex
+	calls                      []Call
+	OnGetProtocolInfo          func(ctx context.Context, args *GetProtocolInfoArgs) (*GetProtocolInfoResponse, error)
+	OnGetCurrentConnectionIDs  func(ctx context.Context, args *GetCurrentConnectionIDsArgs) (*GetCurrentConnectionIDsResponse, error)
+	OnGetCurrentConnectionInfo func(ctx context.Context, args *GetCurrentConnectionInfoArgs) (*GetCurrentConnectionInfoResponse, error)
+}
+
+// Calls returns the calls received so far, oldest first
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// Reset forgets the calls received so far
+func (f *Fake) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+}
+func (f *Fake) record(action string, args interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{Action: action, Args: args})
+}
+func (f *Fake) GetProtocolInfo(httpClient *http.Client, args *GetProtocolInfoArgs) (*GetProtocolInfoResponse, error) {
+	return f.GetProtocolInfoContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetProtocolInfoContext(ctx context.Context, httpClient *http.Client, args *GetProtocolInfoArgs) (*GetProtocolInfoResponse, error) {
+	f.record(`GetProtocolInfo`, args)
+	if f.OnGetProtocolInfo != nil {
+		return f.OnGetProtocolInfo(ctx, args)
+	}
+	return &GetProtocolInfoResponse{}, nil
+}
+func (f *Fake) GetCurrentConnectionIDs(httpClient *http.Client, args *GetCurrentConnectionIDsArgs) (*GetCurrentConnectionIDsResponse, error) {
+	return f.GetCurrentConnectionIDsContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetCurrentConnectionIDsContext(ctx context.Context, httpClient *http.Client, args *GetCurrentConnectionIDsArgs) (*GetCurrentConnectionIDsResponse, error) {
+	f.record(`GetCurrentConnectionIDs`, args)
+	if f.OnGetCurrentConnectionIDs != nil {
+		return f.OnGetCurrentConnectionIDs(ctx, args)
+	}
+	return &GetCurrentConnectionIDsResponse{}, nil
+}
+func (f *Fake) GetCurrentConnectionInfo(httpClient *http.Client, args *GetCurrentConnectionInfoArgs) (*GetCurrentConnectionInfoResponse, error) {
+	return f.GetCurrentConnectionInfoContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetCurrentConnectionInfoContext(ctx context.Context, httpClient *http.Client, args *GetCurrentConnectionInfoArgs) (*GetCurrentConnectionInfoResponse, error) {
+	f.record(`GetCurrentConnectionInfo`, args)
+	if f.OnGetCurrentConnectionInfo != nil {
+		return f.OnGetCurrentConnectionInfo(ctx, args)
+	}
+	return &GetCurrentConnectionInfoResponse{}, nil
 }
@@ -1,23 +1,56 @@
 package contentdirectory
 
 import (
-	"bytes"
-	"encoding/xml"
-	"errors"
-	"io/ioutil"
+	"context"
 	"net/http"
 	"net/url"
+	"sync"
+
+	"github.com/szatmary/sonos/soap"
 )
 
+const _ServiceURN = "urn:schemas-upnp-org:service:ContentDirectory:1"
+
+// ErrorCodes describes the error codes specific to this service
+var ErrorCodes = map[int]string{
+	701: "No such object",
+	702: "Invalid CurrentTagValue",
+	703: "Invalid NewTagValue",
+	704: "Required tag",
+	705: "Read only tag",
+	706: "Parameter mismatch",
+	708: "Unsupported or invalid search criteria",
+	709: "Unsupported or invalid sort criteria",
+	710: "No such container",
+	711: "Restricted object",
+	712: "Bad metadata",
+	713: "Restricted parent object",
+	714: "No such source resource",
+	715: "Resource access denied",
+	716: "Transfer busy",
+	717: "No such file transfer",
+	718: "No such destination resource",
+	719: "Destination resource access denied",
+	720: "Cannot process the request",
+}
+
+func init() {
+	soap.RegisterErrorCodes(_ServiceURN, ErrorCodes)
+}
+
+// BrowseFlag holds the values allowed for the A_ARG_TYPE_BrowseFlag state variable
+type BrowseFlag string
+
 const (
-	_ServiceURN     = "urn:schemas-upnp-org:service:ContentDirectory:1"
-	_EncodingSchema = "http://schemas.xmlsoap.org/soap/encoding/"
-	_EnvelopeSchema = "http://schemas.xmlsoap.org/soap/envelope/"
+	BrowseFlagBrowseMetadata       BrowseFlag = "BrowseMetadata"
+	BrowseFlagBrowseDirectChildren BrowseFlag = "BrowseDirectChildren"
 )
 
 type Service struct {
 	ControlEndpoint *url.URL
 	EventEndpoint   *url.URL
+	// Client sends the actions, soap.DefaultClient is used if it is nil
+	Client *soap.Client
 }
 
 func NewService(deviceUrl *url.URL) *Service {
@@ -35,223 +68,142 @@ func NewService(deviceUrl *url.URL) *Service {
 	}
 }
 
-type Envelope struct {
-	XMLName       xml.Name `xml:"s:Envelope"`
-	Xmlns         string   `xml:"xmlns:s,attr"`
-	EncodingStyle string   `xml:"s:encodingStyle,attr"`
-	Body          Body     `xml:"s:Body"`
-}
-type Body struct {
-	XMLName                     xml.Name                         `xml:"s:Body"`
-	GetSearchCapabilities       *GetSearchCapabilitiesArgs       `xml:"u:GetSearchCapabilities,omitempty"`
-	GetSortCapabilities         *GetSortCapabilitiesArgs         `xml:"u:GetSortCapabilities,omitempty"`
-	GetSystemUpdateID           *GetSystemUpdateIDArgs           `xml:"u:GetSystemUpdateID,omitempty"`
-	GetAlbumArtistDisplayOption *GetAlbumArtistDisplayOptionArgs `xml:"u:GetAlbumArtistDisplayOption,omitempty"`
-	GetLastIndexChange          *GetLastIndexChangeArgs          `xml:"u:GetLastIndexChange,omitempty"`
-	Browse                      *BrowseArgs                      `xml:"u:Browse,omitempty"`
-	FindPrefix                  *FindPrefixArgs                  `xml:"u:FindPrefix,omitempty"`
-	GetAllPrefixLocations       *GetAllPrefixLocationsArgs       `xml:"u:GetAllPrefixLocations,omitempty"`
-	CreateObject                *CreateObjectArgs                `xml:"u:CreateObject,omitempty"`
-	UpdateObject                *UpdateObjectArgs                `xml:"u:UpdateObject,omitempty"`
-	DestroyObject               *DestroyObjectArgs               `xml:"u:DestroyObject,omitempty"`
-	RefreshShareIndex           *RefreshShareIndexArgs           `xml:"u:RefreshShareIndex,omitempty"`
-	RequestResort               *RequestResortArgs               `xml:"u:RequestResort,omitempty"`
-	GetShareIndexInProgress     *GetShareIndexInProgressArgs     `xml:"u:GetShareIndexInProgress,omitempty"`
-	GetBrowseable               *GetBrowseableArgs               `xml:"u:GetBrowseable,omitempty"`
-	SetBrowseable               *SetBrowseableArgs               `xml:"u:SetBrowseable,omitempty"`
-}
-type EnvelopeResponse struct {
-	XMLName       xml.Name     `xml:"Envelope"`
-	Xmlns         string       `xml:"xmlns:s,attr"`
-	EncodingStyle string       `xml:"encodingStyle,attr"`
-	Body          BodyResponse `xml:"Body"`
-}
-type BodyResponse struct {
-	XMLName                     xml.Name                             `xml:"Body"`
-	GetSearchCapabilities       *GetSearchCapabilitiesResponse       `xml:"GetSearchCapabilitiesResponse,omitempty"`
-	GetSortCapabilities         *GetSortCapabilitiesResponse         `xml:"GetSortCapabilitiesResponse,omitempty"`
-	GetSystemUpdateID           *GetSystemUpdateIDResponse           `xml:"GetSystemUpdateIDResponse,omitempty"`
-	GetAlbumArtistDisplayOption *GetAlbumArtistDisplayOptionResponse `xml:"GetAlbumArtistDisplayOptionResponse,omitempty"`
-	GetLastIndexChange          *GetLastIndexChangeResponse          `xml:"GetLastIndexChangeResponse,omitempty"`
-	Browse                      *BrowseResponse                      `xml:"BrowseResponse,omitempty"`
-	FindPrefix                  *FindPrefixResponse                  `xml:"FindPrefixResponse,omitempty"`
-	GetAllPrefixLocations       *GetAllPrefixLocationsResponse       `xml:"GetAllPrefixLocationsResponse,omitempty"`
-	CreateObject                *CreateObjectResponse                `xml:"CreateObjectResponse,omitempty"`
-	UpdateObject                *UpdateObjectResponse                `xml:"UpdateObjectResponse,omitempty"`
-	DestroyObject               *DestroyObjectResponse               `xml:"DestroyObjectResponse,omitempty"`
-	RefreshShareIndex           *RefreshShareIndexResponse           `xml:"RefreshShareIndexResponse,omitempty"`
-	RequestResort               *RequestResortResponse               `xml:"RequestResortResponse,omitempty"`
-	GetShareIndexInProgress     *GetShareIndexInProgressResponse     `xml:"GetShareIndexInProgressResponse,omitempty"`
-	GetBrowseable               *GetBrowseableResponse               `xml:"GetBrowseableResponse,omitempty"`
-	SetBrowseable               *SetBrowseableResponse               `xml:"SetBrowseableResponse,omitempty"`
-}
-
-func (s *Service) exec(actionName string, httpClient *http.Client, envelope *Envelope) (*EnvelopeResponse, error) {
-	marshaled, err := xml.Marshal(envelope)
-	if err != nil {
-		return nil, err
-	}
-	postBody := []byte(`<?xml version="1.0"?>`)
-	postBody = append(postBody, marshaled...)
-	req, err := http.NewRequest(`POST`, s.ControlEndpoint.String(), bytes.NewBuffer(postBody))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set(`Content-Type`, `text/xml; charset="utf-8"`)
-	req.Header.Set(`SOAPAction`, _ServiceURN+`#`+actionName)
-	res, err := httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-	responseBody, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-	var envelopeResponse EnvelopeResponse
-	err = xml.Unmarshal(responseBody, &envelopeResponse)
-	if err != nil {
-		return nil, err
-	}
-	return &envelopeResponse, nil
+type GetSearchCapabilitiesArgs struct {
 }
 
-type GetSearchCapabilitiesArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetSearchCapabilitiesArgs) Validate() error {
+	return nil
 }
+
 type GetSearchCapabilitiesResponse struct {
 	SearchCaps string `xml:"SearchCaps"`
 }
 
 func (s *Service) GetSearchCapabilities(httpClient *http.Client, args *GetSearchCapabilitiesArgs) (*GetSearchCapabilitiesResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetSearchCapabilities`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetSearchCapabilities: args},
-		})
-	if err != nil {
+	return s.GetSearchCapabilitiesContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetSearchCapabilitiesContext(ctx context.Context, httpClient *http.Client, args *GetSearchCapabilitiesArgs) (*GetSearchCapabilitiesResponse, error) {
+	var r GetSearchCapabilitiesResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetSearchCapabilities`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetSearchCapabilities == nil {
-		return nil, errors.New(`unexpected respose from service calling contentdirectory.GetSearchCapabilities()`)
-	}
-
-	return r.Body.GetSearchCapabilities, nil
+	return &r, nil
 }
 
 type GetSortCapabilitiesArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetSortCapabilitiesArgs) Validate() error {
+	return nil
+}
+
 type GetSortCapabilitiesResponse struct {
 	SortCaps string `xml:"SortCaps"`
 }
 
 func (s *Service) GetSortCapabilities(httpClient *http.Client, args *GetSortCapabilitiesArgs) (*GetSortCapabilitiesResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetSortCapabilities`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetSortCapabilities: args},
-		})
-	if err != nil {
+	return s.GetSortCapabilitiesContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetSortCapabilitiesContext(ctx context.Context, httpClient *http.Client, args *GetSortCapabilitiesArgs) (*GetSortCapabilitiesResponse, error) {
+	var r GetSortCapabilitiesResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetSortCapabilities`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetSortCapabilities == nil {
-		return nil, errors.New(`unexpected respose from service calling contentdirectory.GetSortCapabilities()`)
-	}
-
-	return r.Body.GetSortCapabilities, nil
+	return &r, nil
 }
 
 type GetSystemUpdateIDArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetSystemUpdateIDArgs) Validate() error {
+	return nil
+}
+
 type GetSystemUpdateIDResponse struct {
 	Id uint32 `xml:"Id"`
 }
 
 func (s *Service) GetSystemUpdateID(httpClient *http.Client, args *GetSystemUpdateIDArgs) (*GetSystemUpdateIDResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetSystemUpdateID`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetSystemUpdateID: args},
-		})
-	if err != nil {
+	return s.GetSystemUpdateIDContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetSystemUpdateIDContext(ctx context.Context, httpClient *http.Client, args *GetSystemUpdateIDArgs) (*GetSystemUpdateIDResponse, error) {
+	var r GetSystemUpdateIDResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetSystemUpdateID`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetSystemUpdateID == nil {
-		return nil, errors.New(`unexpected respose from service calling contentdirectory.GetSystemUpdateID()`)
-	}
-
-	return r.Body.GetSystemUpdateID, nil
+	return &r, nil
 }
 
 type GetAlbumArtistDisplayOptionArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetAlbumArtistDisplayOptionArgs) Validate() error {
+	return nil
+}
+
 type GetAlbumArtistDisplayOptionResponse struct {
 	AlbumArtistDisplayOption string `xml:"AlbumArtistDisplayOption"`
 }
 
 func (s *Service) GetAlbumArtistDisplayOption(httpClient *http.Client, args *GetAlbumArtistDisplayOptionArgs) (*GetAlbumArtistDisplayOptionResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetAlbumArtistDisplayOption`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetAlbumArtistDisplayOption: args},
-		})
-	if err != nil {
+	return s.GetAlbumArtistDisplayOptionContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetAlbumArtistDisplayOptionContext(ctx context.Context, httpClient *http.Client, args *GetAlbumArtistDisplayOptionArgs) (*GetAlbumArtistDisplayOptionResponse, error) {
+	var r GetAlbumArtistDisplayOptionResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetAlbumArtistDisplayOption`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetAlbumArtistDisplayOption == nil {
-		return nil, errors.New(`unexpected respose from service calling contentdirectory.GetAlbumArtistDisplayOption()`)
-	}
-
-	return r.Body.GetAlbumArtistDisplayOption, nil
+	return &r, nil
 }
 
 type GetLastIndexChangeArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetLastIndexChangeArgs) Validate() error {
+	return nil
+}
+
 type GetLastIndexChangeResponse struct {
 	LastIndexChange string `xml:"LastIndexChange"`
 }
 
 func (s *Service) GetLastIndexChange(httpClient *http.Client, args *GetLastIndexChangeArgs) (*GetLastIndexChangeResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetLastIndexChange`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetLastIndexChange: args},
-		})
-	if err != nil {
+	return s.GetLastIndexChangeContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetLastIndexChangeContext(ctx context.Context, httpClient *http.Client, args *GetLastIndexChangeArgs) (*GetLastIndexChangeResponse, error) {
+	var r GetLastIndexChangeResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetLastIndexChange`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetLastIndexChange == nil {
-		return nil, errors.New(`unexpected respose from service calling contentdirectory.GetLastIndexChange()`)
-	}
-
-	return r.Body.GetLastIndexChange, nil
+	return &r, nil
 }
 
 type BrowseArgs struct {
-	Xmlns    string `xml:"xmlns:u,attr"`
 	ObjectID string `xml:"ObjectID"`
 	// Allowed Value: BrowseMetadata
 	// Allowed Value: BrowseDirectChildren
-	BrowseFlag     string `xml:"BrowseFlag"`
-	Filter         string `xml:"Filter"`
-	StartingIndex  uint32 `xml:"StartingIndex"`
-	RequestedCount uint32 `xml:"RequestedCount"`
-	SortCriteria   string `xml:"SortCriteria"`
+	BrowseFlag     BrowseFlag `xml:"BrowseFlag"`
+	Filter         string     `xml:"Filter"`
+	StartingIndex  uint32     `xml:"StartingIndex"`
+	RequestedCount uint32     `xml:"RequestedCount"`
+	SortCriteria   string     `xml:"SortCriteria"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *BrowseArgs) Validate() error {
+	switch a.BrowseFlag {
+	case "", BrowseFlagBrowseMetadata, BrowseFlagBrowseDirectChildren:
+	default:
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `Browse`, Argument: `BrowseFlag`, Value: a.BrowseFlag, Allowed: "one of BrowseMetadata, BrowseDirectChildren"}
+	}
+	return nil
+}
+
 type BrowseResponse struct {
 	Result         string `xml:"Result"`
 	NumberReturned uint32 `xml:"NumberReturned"`
@@ -260,55 +212,51 @@ type BrowseResponse struct {
 }
 
 func (s *Service) Browse(httpClient *http.Client, args *BrowseArgs) (*BrowseResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`Browse`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{Browse: args},
-		})
-	if err != nil {
+	return s.BrowseContext(context.Background(), httpClient, args)
+}
+func (s *Service) BrowseContext(ctx context.Context, httpClient *http.Client, args *BrowseArgs) (*BrowseResponse, error) {
+	var r BrowseResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `Browse`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.Browse == nil {
-		return nil, errors.New(`unexpected respose from service calling contentdirectory.Browse()`)
-	}
-
-	return r.Body.Browse, nil
+	return &r, nil
 }
 
 type FindPrefixArgs struct {
-	Xmlns    string `xml:"xmlns:u,attr"`
 	ObjectID string `xml:"ObjectID"`
 	Prefix   string `xml:"Prefix"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *FindPrefixArgs) Validate() error {
+	return nil
+}
+
 type FindPrefixResponse struct {
 	StartingIndex uint32 `xml:"StartingIndex"`
 	UpdateID      uint32 `xml:"UpdateID"`
 }
 
 func (s *Service) FindPrefix(httpClient *http.Client, args *FindPrefixArgs) (*FindPrefixResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`FindPrefix`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{FindPrefix: args},
-		})
-	if err != nil {
+	return s.FindPrefixContext(context.Background(), httpClient, args)
+}
+func (s *Service) FindPrefixContext(ctx context.Context, httpClient *http.Client, args *FindPrefixArgs) (*FindPrefixResponse, error) {
+	var r FindPrefixResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `FindPrefix`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.FindPrefix == nil {
-		return nil, errors.New(`unexpected respose from service calling contentdirectory.FindPrefix()`)
-	}
-
-	return r.Body.FindPrefix, nil
+	return &r, nil
 }
 
 type GetAllPrefixLocationsArgs struct {
-	Xmlns    string `xml:"xmlns:u,attr"`
 	ObjectID string `xml:"ObjectID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetAllPrefixLocationsArgs) Validate() error {
+	return nil
+}
+
 type GetAllPrefixLocationsResponse struct {
 	TotalPrefixes     uint32 `xml:"TotalPrefixes"`
 	PrefixAndIndexCSV string `xml:"PrefixAndIndexCSV"`
@@ -316,224 +264,449 @@ type GetAllPrefixLocationsResponse struct {
 }
 
 func (s *Service) GetAllPrefixLocations(httpClient *http.Client, args *GetAllPrefixLocationsArgs) (*GetAllPrefixLocationsResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetAllPrefixLocations`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetAllPrefixLocations: args},
-		})
-	if err != nil {
+	return s.GetAllPrefixLocationsContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetAllPrefixLocationsContext(ctx context.Context, httpClient *http.Client, args *GetAllPrefixLocationsArgs) (*GetAllPrefixLocationsResponse, error) {
+	var r GetAllPrefixLocationsResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetAllPrefixLocations`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetAllPrefixLocations == nil {
-		return nil, errors.New(`unexpected respose from service calling contentdirectory.GetAllPrefixLocations()`)
-	}
-
-	return r.Body.GetAllPrefixLocations, nil
+	return &r, nil
 }
 
 type CreateObjectArgs struct {
-	Xmlns       string `xml:"xmlns:u,attr"`
 	ContainerID string `xml:"ContainerID"`
 	Elements    string `xml:"Elements"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *CreateObjectArgs) Validate() error {
+	return nil
+}
+
 type CreateObjectResponse struct {
 	ObjectID string `xml:"ObjectID"`
 	Result   string `xml:"Result"`
 }
 
 func (s *Service) CreateObject(httpClient *http.Client, args *CreateObjectArgs) (*CreateObjectResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`CreateObject`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{CreateObject: args},
-		})
-	if err != nil {
+	return s.CreateObjectContext(context.Background(), httpClient, args)
+}
+func (s *Service) CreateObjectContext(ctx context.Context, httpClient *http.Client, args *CreateObjectArgs) (*CreateObjectResponse, error) {
+	var r CreateObjectResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `CreateObject`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.CreateObject == nil {
-		return nil, errors.New(`unexpected respose from service calling contentdirectory.CreateObject()`)
-	}
-
-	return r.Body.CreateObject, nil
+	return &r, nil
 }
 
 type UpdateObjectArgs struct {
-	Xmlns           string `xml:"xmlns:u,attr"`
 	ObjectID        string `xml:"ObjectID"`
 	CurrentTagValue string `xml:"CurrentTagValue"`
 	NewTagValue     string `xml:"NewTagValue"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *UpdateObjectArgs) Validate() error {
+	return nil
+}
+
 type UpdateObjectResponse struct {
 }
 
 func (s *Service) UpdateObject(httpClient *http.Client, args *UpdateObjectArgs) (*UpdateObjectResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`UpdateObject`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{UpdateObject: args},
-		})
-	if err != nil {
+	return s.UpdateObjectContext(context.Background(), httpClient, args)
+}
+func (s *Service) UpdateObjectContext(ctx context.Context, httpClient *http.Client, args *UpdateObjectArgs) (*UpdateObjectResponse, error) {
+	var r UpdateObjectResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `UpdateObject`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.UpdateObject == nil {
-		return nil, errors.New(`unexpected respose from service calling contentdirectory.UpdateObject()`)
-	}
-
-	return r.Body.UpdateObject, nil
+	return &r, nil
 }
 
 type DestroyObjectArgs struct {
-	Xmlns    string `xml:"xmlns:u,attr"`
 	ObjectID string `xml:"ObjectID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *DestroyObjectArgs) Validate() error {
+	return nil
+}
+
 type DestroyObjectResponse struct {
 }
 
 func (s *Service) DestroyObject(httpClient *http.Client, args *DestroyObjectArgs) (*DestroyObjectResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`DestroyObject`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{DestroyObject: args},
-		})
-	if err != nil {
+	return s.DestroyObjectContext(context.Background(), httpClient, args)
+}
+func (s *Service) DestroyObjectContext(ctx context.Context, httpClient *http.Client, args *DestroyObjectArgs) (*DestroyObjectResponse, error) {
+	var r DestroyObjectResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `DestroyObject`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.DestroyObject == nil {
-		return nil, errors.New(`unexpected respose from service calling contentdirectory.DestroyObject()`)
-	}
-
-	return r.Body.DestroyObject, nil
+	return &r, nil
 }
 
 type RefreshShareIndexArgs struct {
-	Xmlns                    string `xml:"xmlns:u,attr"`
 	AlbumArtistDisplayOption string `xml:"AlbumArtistDisplayOption"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *RefreshShareIndexArgs) Validate() error {
+	return nil
+}
+
 type RefreshShareIndexResponse struct {
 }
 
 func (s *Service) RefreshShareIndex(httpClient *http.Client, args *RefreshShareIndexArgs) (*RefreshShareIndexResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`RefreshShareIndex`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{RefreshShareIndex: args},
-		})
-	if err != nil {
+	return s.RefreshShareIndexContext(context.Background(), httpClient, args)
+}
+func (s *Service) RefreshShareIndexContext(ctx context.Context, httpClient *http.Client, args *RefreshShareIndexArgs) (*RefreshShareIndexResponse, error) {
+	var r RefreshShareIndexResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `RefreshShareIndex`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.RefreshShareIndex == nil {
-		return nil, errors.New(`unexpected respose from service calling contentdirectory.RefreshShareIndex()`)
-	}
-
-	return r.Body.RefreshShareIndex, nil
+	return &r, nil
 }
 
 type RequestResortArgs struct {
-	Xmlns     string `xml:"xmlns:u,attr"`
 	SortOrder string `xml:"SortOrder"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *RequestResortArgs) Validate() error {
+	return nil
+}
+
 type RequestResortResponse struct {
 }
 
 func (s *Service) RequestResort(httpClient *http.Client, args *RequestResortArgs) (*RequestResortResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`RequestResort`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{RequestResort: args},
-		})
-	if err != nil {
+	return s.RequestResortContext(context.Background(), httpClient, args)
+}
+func (s *Service) RequestResortContext(ctx context.Context, httpClient *http.Client, args *RequestResortArgs) (*RequestResortResponse, error) {
+	var r RequestResortResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `RequestResort`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.RequestResort == nil {
-		return nil, errors.New(`unexpected respose from service calling contentdirectory.RequestResort()`)
-	}
-
-	return r.Body.RequestResort, nil
+	return &r, nil
 }
 
 type GetShareIndexInProgressArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetShareIndexInProgressArgs) Validate() error {
+	return nil
+}
+
 type GetShareIndexInProgressResponse struct {
 	IsIndexing bool `xml:"IsIndexing"`
 }
 
 func (s *Service) GetShareIndexInProgress(httpClient *http.Client, args *GetShareIndexInProgressArgs) (*GetShareIndexInProgressResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetShareIndexInProgress`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetShareIndexInProgress: args},
-		})
-	if err != nil {
+	return s.GetShareIndexInProgressContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetShareIndexInProgressContext(ctx context.Context, httpClient *http.Client, args *GetShareIndexInProgressArgs) (*GetShareIndexInProgressResponse, error) {
+	var r GetShareIndexInProgressResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetShareIndexInProgress`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetShareIndexInProgress == nil {
-		return nil, errors.New(`unexpected respose from service calling contentdirectory.GetShareIndexInProgress()`)
-	}
-
-	return r.Body.GetShareIndexInProgress, nil
+	return &r, nil
 }
 
 type GetBrowseableArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetBrowseableArgs) Validate() error {
+	return nil
+}
+
 type GetBrowseableResponse struct {
 	IsBrowseable bool `xml:"IsBrowseable"`
 }
 
 func (s *Service) GetBrowseable(httpClient *http.Client, args *GetBrowseableArgs) (*GetBrowseableResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetBrowseable`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetBrowseable: args},
-		})
-	if err != nil {
+	return s.GetBrowseableContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetBrowseableContext(ctx context.Context, httpClient *http.Client, args *GetBrowseableArgs) (*GetBrowseableResponse, error) {
+	var r GetBrowseableResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetBrowseable`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetBrowseable == nil {
-		return nil, errors.New(`unexpected respose from service calling contentdirectory.GetBrowseable()`)
-	}
-
-	return r.Body.GetBrowseable, nil
+	return &r, nil
 }
 
 type SetBrowseableArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
-	Browseable bool   `xml:"Browseable"`
+	Browseable bool `xml:"Browseable"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetBrowseableArgs) Validate() error {
+	return nil
+}
+
 type SetBrowseableResponse struct {
 }
 
 func (s *Service) SetBrowseable(httpClient *http.Client, args *SetBrowseableArgs) (*SetBrowseableResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetBrowseable`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetBrowseable: args},
-		})
-	if err != nil {
+	return s.SetBrowseableContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetBrowseableContext(ctx context.Context, httpClient *http.Client, args *SetBrowseableArgs) (*SetBrowseableResponse, error) {
+	var r SetBrowseableResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetBrowseable`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetBrowseable == nil {
-		return nil, errors.New(`unexpected respose from service calling contentdirectory.SetBrowseable()`)
+	return &r, nil
+}
+
+// Client is the set of actions of the service. It is implemented by Service and Fake
+type Client interface {
+	GetSearchCapabilities(httpClient *http.Client, args *GetSearchCapabilitiesArgs) (*GetSearchCapabilitiesResponse, error)
+	GetSearchCapabilitiesContext(ctx context.Context, httpClient *http.Client, args *GetSearchCapabilitiesArgs) (*GetSearchCapabilitiesResponse, error)
+	GetSortCapabilities(httpClient *http.Client, args *GetSortCapabilitiesArgs) (*GetSortCapabilitiesResponse, error)
+	GetSortCapabilitiesContext(ctx context.Context, httpClient *http.Client, args *GetSortCapabilitiesArgs) (*GetSortCapabilitiesResponse, error)
+	GetSystemUpdateID(httpClient *http.Client, args *GetSystemUpdateIDArgs) (*GetSystemUpdateIDResponse, error)
+	GetSystemUpdateIDContext(ctx context.Context, httpClient *http.Client, args *GetSystemUpdateIDArgs) (*GetSystemUpdateIDResponse, error)
+	GetAlbumArtistDisplayOption(httpClient *http.Client, args *GetAlbumArtistDisplayOptionArgs) (*GetAlbumArtistDisplayOptionResponse, error)
+	GetAlbumArtistDisplayOptionContext(ctx context.Context, httpClient *http.Client, args *GetAlbumArtistDisplayOptionArgs) (*GetAlbumArtistDisplayOptionResponse, error)
+	GetLastIndexChange(httpClient *http.Client, args *GetLastIndexChangeArgs) (*GetLastIndexChangeResponse, error)
+	GetLastIndexChangeContext(ctx context.Context, httpClient *http.Client, args *GetLastIndexChangeArgs) (*GetLastIndexChangeResponse, error)
+	Browse(httpClient *http.Client, args *BrowseArgs) (*BrowseResponse, error)
+	BrowseContext(ctx context.Context, httpClient *http.Client, args *BrowseArgs) (*BrowseResponse, error)
+	FindPrefix(httpClient *http.Client, args *FindPrefixArgs) (*FindPrefixResponse, error)
+	FindPrefixContext(ctx context.Context, httpClient *http.Client, args *FindPrefixArgs) (*FindPrefixResponse, error)
+	GetAllPrefixLocations(httpClient *http.Client, args *GetAllPrefixLocationsArgs) (*GetAllPrefixLocationsResponse, error)
+	GetAllPrefixLocationsContext(ctx context.Context, httpClient *http.Client, args *GetAllPrefixLocationsArgs) (*GetAllPrefixLocationsResponse, error)
+	CreateObject(httpClient *http.Client, args *CreateObjectArgs) (*CreateObjectResponse, error)
+	CreateObjectContext(ctx context.Context, httpClient *http.Client, args *CreateObjectArgs) (*CreateObjectResponse, error)
+	UpdateObject(httpClient *http.Client, args *UpdateObjectArgs) (*UpdateObjectResponse, error)
+	UpdateObjectContext(ctx context.Context, httpClient *http.Client, args *UpdateObjectArgs) (*UpdateObjectResponse, error)
+	DestroyObject(httpClient *http.Client, args *DestroyObjectArgs) (*DestroyObjectResponse, error)
+	DestroyObjectContext(ctx context.Context, httpClient *http.Client, args *DestroyObjectArgs) (*DestroyObjectResponse, error)
+	RefreshShareIndex(httpClient *http.Client, args *RefreshShareIndexArgs) (*RefreshShareIndexResponse, error)
+	RefreshShareIndexContext(ctx context.Context, httpClient *http.Client, args *RefreshShareIndexArgs) (*RefreshShareIndexResponse, error)
+	RequestResort(httpClient *http.Client, args *RequestResortArgs) (*RequestResortResponse, error)
+	RequestResortContext(ctx context.Context, httpClient *http.Client, args *RequestResortArgs) (*RequestResortResponse, error)
+	GetShareIndexInProgress(httpClient *http.Client, args *GetShareIndexInProgressArgs) (*GetShareIndexInProgressResponse, error)
+	GetShareIndexInProgressContext(ctx context.Context, httpClient *http.Client, args *GetShareIndexInProgressArgs) (*GetShareIndexInProgressResponse, error)
+	GetBrowseable(httpClient *http.Client, args *GetBrowseableArgs) (*GetBrowseableResponse, error)
+	GetBrowseableContext(ctx context.Context, httpClient *http.Client, args *GetBrowseableArgs) (*GetBrowseableResponse, error)
+	SetBrowseable(httpClient *http.Client, args *SetBrowseableArgs) (*SetBrowseableResponse, error)
+	SetBrowseableContext(ctx context.Context, httpClient *http.Client, args *SetBrowseableArgs) (*SetBrowseableResponse, error)
+}
+
+var _ Client = (*Service)(nil)
+var _ Client = (*Fake)(nil)
+
+// Call is an action received by a Fake
+type Call struct {
+	Action string
+	// Args is the *<Action>Args the action was called with
+	Args interface{}
+}
+
+// Fake is an in-memory Client for tests. It records every call, and answers
+// an action with its On<Action> func, or with an empty response if that is nil
+type Fake struct {
+	mu                            sync.Mutex
+	calls                         []Call
+	OnGetSearchCapabilities       func(ctx context.Context, args *GetSearchCapabilitiesArgs) (*GetSearchCapabilitiesResponse, error)
+	OnGetSortCapabilities         func(ctx context.Context, args *GetSortCapabilitiesArgs) (*GetSortCapabilitiesResponse, error)
+	OnGetSystemUpdateID           func(ctx context.Context, args *GetSystemUpdateIDArgs) (*GetSystemUpdateIDResponse, error)
+	OnGetAlbumArtistDisplayOption func(ctx context.Context, args *GetAlbumArtistDisplayOptionArgs) (*GetAlbumArtistDisplayOptionResponse, error)
+	OnGetLastIndexChange          func(ctx context.Context, args *GetLastIndexChangeArgs) (*GetLastIndexChangeResponse, error)
+	OnBrowse                      func(ctx context.Context, args *BrowseArgs) (*BrowseResponse, error)
+	OnFindPrefix                  func(ctx context.Context, args *FindPrefixArgs) (*FindPrefixResponse, error)
+	OnGetAllPrefixLocations       func(ctx context.Context, args *GetAllPrefixLocationsArgs) (*GetAllPrefixLocationsResponse, error)
+	OnCreateObject                func(ctx context.Context, args *CreateObjectArgs) (*CreateObjectResponse, error)
+	OnUpdateObject                func(ctx context.Context, args *UpdateObjectArgs) (*UpdateObjectResponse, error)
+	OnDestroyObject               func(ctx context.Context, args *DestroyObjectArgs) (*DestroyObjectResponse, error)
+	OnRefreshShareIndex           func(ctx context.Context, args *RefreshShareIndexArgs) (*RefreshShareIndexResponse, error)
+	OnRequestResort               func(ctx context.Context, args *RequestResortArgs) (*RequestResortResponse, error)
+	OnGetShareIndexInProgress     func(ctx context.Context, args *GetShareIndexInProgressArgs) (*GetShareIndexInProgressResponse, error)
+	OnGetBrowseable               func(ctx context.Context, args *GetBrowseableArgs) (*GetBrowseableResponse, error)
+	OnSetBrowseable               func(ctx context.Context, args *SetBrowseableArgs) (*SetBrowseableResponse, error)
+}
+
+// Calls returns the calls received so far, oldest first
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// Reset forgets the calls received so far
+func (f *Fake) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+}
+func (f *Fake) record(action string, args interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{Action: action, Args: args})
+}
+func (f *Fake) GetSearchCapabilities(httpClient *http.Client, args *GetSearchCapabilitiesArgs) (*GetSearchCapabilitiesResponse, error) {
+	return f.GetSearchCapabilitiesContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetSearchCapabilitiesContext(ctx context.Context, httpClient *http.Client, args *GetSearchCapabilitiesArgs) (*GetSearchCapabilitiesResponse, error) {
+	f.record(`GetSearchCapabilities`, args)
+	if f.OnGetSearchCapabilities != nil {
+		return f.OnGetSearchCapabilities(ctx, args)
+	}
+	return &GetSearchCapabilitiesResponse{}, nil
+}
+func (f *Fake) GetSortCapabilities(httpClient *http.Client, args *GetSortCapabilitiesArgs) (*GetSortCapabilitiesResponse, error) {
+	return f.GetSortCapabilitiesContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetSortCapabilitiesContext(ctx context.Context, httpClient *http.Client, args *GetSortCapabilitiesArgs) (*GetSortCapabilitiesResponse, error) {
+	f.record(`GetSortCapabilities`, args)
+	if f.OnGetSortCapabilities != nil {
+		return f.OnGetSortCapabilities(ctx, args)
+	}
+	return &GetSortCapabilitiesResponse{}, nil
+}
+func (f *Fake) GetSystemUpdateID(httpClient *http.Client, args *GetSystemUpdateIDArgs) (*GetSystemUpdateIDResponse, error) {
+	return f.GetSystemUpdateIDContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetSystemUpdateIDContext(ctx context.Context, httpClient *http.Client, args *GetSystemUpdateIDArgs) (*GetSystemUpdateIDResponse, error) {
+	f.record(`GetSystemUpdateID`, args)
+	if f.OnGetSystemUpdateID != nil {
+		return f.OnGetSystemUpdateID(ctx, args)
+	}
+	return &GetSystemUpdateIDResponse{}, nil
+}
+func (f *Fake) GetAlbumArtistDisplayOption(httpClient *http.Client, args *GetAlbumArtistDisplayOptionArgs) (*GetAlbumArtistDisplayOptionResponse, error) {
+	return f.GetAlbumArtistDisplayOptionContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetAlbumArtistDisplayOptionContext(ctx context.Context, httpClient *http.Client, args *GetAlbumArtistDisplayOptionArgs) (*GetAlbumArtistDisplayOptionResponse, error) {
+	f.record(`GetAlbumArtistDisplayOption`, args)
+	if f.OnGetAlbumArtistDisplayOption != nil {
+		return f.OnGetAlbumArtistDisplayOption(ctx, args)
 	}
-
-	return r.Body.SetBrowseable, nil
+	return &GetAlbumArtistDisplayOptionResponse{}, nil
+}
+func (f *Fake) GetLastIndexChange(httpClient *http.Client, args *GetLastIndexChangeArgs) (*GetLastIndexChangeResponse, error) {
+	return f.GetLastIndexChangeContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetLastIndexChangeContext(ctx context.Context, httpClient *http.Client, args *GetLastIndexChangeArgs) (*GetLastIndexChangeResponse, error) {
+	f.record(`GetLastIndexChange`, args)
+	if f.OnGetLastIndexChange != nil {
+		return f.OnGetLastIndexChange(ctx, args)
+	}
+	return &GetLastIndexChangeResponse{}, nil
+}
+func (f *Fake) Browse(httpClient *http.Client, args *BrowseArgs) (*BrowseResponse, error) {
+	return f.BrowseContext(context.Background(), httpClient, args)
+}
+func (f *Fake) BrowseContext(ctx context.Context, httpClient *http.Client, args *BrowseArgs) (*BrowseResponse, error) {
+	f.record(`Browse`, args)
+	if f.OnBrowse != nil {
+		return f.OnBrowse(ctx, args)
+	}
+	return &BrowseResponse{}, nil
+}
+func (f *Fake) FindPrefix(httpClient *http.Client, args *FindPrefixArgs) (*FindPrefixResponse, error) {
+	return f.FindPrefixContext(context.Background(), httpClient, args)
+}
+func (f *Fake) FindPrefixContext(ctx context.Context, httpClient *http.Client, args *FindPrefixArgs) (*FindPrefixResponse, error) {
+	f.record(`FindPrefix`, args)
+	if f.OnFindPrefix != nil {
+		return f.OnFindPrefix(ctx, args)
+	}
+	return &FindPrefixResponse{}, nil
+}
+func (f *Fake) GetAllPrefixLocations(httpClient *http.Client, args *GetAllPrefixLocationsArgs) (*GetAllPrefixLocationsResponse, error) {
+	return f.GetAllPrefixLocationsContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetAllPrefixLocationsContext(ctx context.Context, httpClient *http.Client, args *GetAllPrefixLocationsArgs) (*GetAllPrefixLocationsResponse, error) {
+	f.record(`GetAllPrefixLocations`, args)
+	if f.OnGetAllPrefixLocations != nil {
+		return f.OnGetAllPrefixLocations(ctx, args)
+	}
+	return &GetAllPrefixLocationsResponse{}, nil
+}
+func (f *Fake) CreateObject(httpClient *http.Client, args *CreateObjectArgs) (*CreateObjectResponse, error) {
+	return f.CreateObjectContext(context.Background(), httpClient, args)
+}
+func (f *Fake) CreateObjectContext(ctx context.Context, httpClient *http.Client, args *CreateObjectArgs) (*CreateObjectResponse, error) {
+	f.record(`CreateObject`, args)
+	if f.OnCreateObject != nil {
+		return f.OnCreateObject(ctx, args)
+	}
+	return &CreateObjectResponse{}, nil
+}
+func (f *Fake) UpdateObject(httpClient *http.Client, args *UpdateObjectArgs) (*UpdateObjectResponse, error) {
+	return f.UpdateObjectContext(context.Background(), httpClient, args)
+}
+func (f *Fake) UpdateObjectContext(ctx context.Context, httpClient *http.Client, args *UpdateObjectArgs) (*UpdateObjectResponse, error) {
+	f.record(`UpdateObject`, args)
+	if f.OnUpdateObject != nil {
+		return f.OnUpdateObject(ctx, args)
+	}
+	return &UpdateObjectResponse{}, nil
+}
+func (f *Fake) DestroyObject(httpClient *http.Client, args *DestroyObjectArgs) (*DestroyObjectResponse, error) {
+	return f.DestroyObjectContext(context.Background(), httpClient, args)
+}
+func (f *Fake) DestroyObjectContext(ctx context.Context, httpClient *http.Client, args *DestroyObjectArgs) (*DestroyObjectResponse, error) {
+	f.record(`DestroyObject`, args)
+	if f.OnDestroyObject != nil {
+		return f.OnDestroyObject(ctx, args)
+	}
+	return &DestroyObjectResponse{}, nil
+}
+func (f *Fake) RefreshShareIndex(httpClient *http.Client, args *RefreshShareIndexArgs) (*RefreshShareIndexResponse, error) {
+	return f.RefreshShareIndexContext(context.Background(), httpClient, args)
+}
+func (f *Fake) RefreshShareIndexContext(ctx context.Context, httpClient *http.Client, args *RefreshShareIndexArgs) (*RefreshShareIndexResponse, error) {
+	f.record(`RefreshShareIndex`, args)
+	if f.OnRefreshShareIndex != nil {
+		return f.OnRefreshShareIndex(ctx, args)
+	}
+	return &RefreshShareIndexResponse{}, nil
+}
+func (f *Fake) RequestResort(httpClient *http.Client, args *RequestResortArgs) (*RequestResortResponse, error) {
+	return f.RequestResortContext(context.Background(), httpClient, args)
+}
+func (f *Fake) RequestResortContext(ctx context.Context, httpClient *http.Client, args *RequestResortArgs) (*RequestResortResponse, error) {
+	f.record(`RequestResort`, args)
+	if f.OnRequestResort != nil {
+		return f.OnRequestResort(ctx, args)
+	}
+	return &RequestResortResponse{}, nil
+}
+func (f *Fake) GetShareIndexInProgress(httpClient *http.Client, args *GetShareIndexInProgressArgs) (*GetShareIndexInProgressResponse, error) {
+	return f.GetShareIndexInProgressContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetShareIndexInProgressContext(ctx context.Context, httpClient *http.Client, args *GetShareIndexInProgressArgs) (*GetShareIndexInProgressResponse, error) {
+	f.record(`GetShareIndexInProgress`, args)
+	if f.OnGetShareIndexInProgress != nil {
+		return f.OnGetShareIndexInProgress(ctx, args)
+	}
+	return &GetShareIndexInProgressResponse{}, nil
+}
+func (f *Fake) GetBrowseable(httpClient *http.Client, args *GetBrowseableArgs) (*GetBrowseableResponse, error) {
+	return f.GetBrowseableContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetBrowseableContext(ctx context.Context, httpClient *http.Client, args *GetBrowseableArgs) (*GetBrowseableResponse, error) {
+	f.record(`GetBrowseable`, args)
+	if f.OnGetBrowseable != nil {
+		return f.OnGetBrowseable(ctx, args)
+	}
+	return &GetBrowseableResponse{}, nil
+}
+func (f *Fake) SetBrowseable(httpClient *http.Client, args *SetBrowseableArgs) (*SetBrowseableResponse, error) {
+	return f.SetBrowseableContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetBrowseableContext(ctx context.Context, httpClient *http.Client, args *SetBrowseableArgs) (*SetBrowseableResponse, error) {
+	f.record(`SetBrowseable`, args)
+	if f.OnSetBrowseable != nil {
+		return f.OnSetBrowseable(ctx, args)
+	}
+	return &SetBrowseableResponse{}, nil
 }
@@ -0,0 +1,60 @@
+package contentdirectory
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ContainerUpdate is the UpdateID a container changed to.
+type ContainerUpdate struct {
+	// Container is the ID of the container, e.g. "Q:0", "SQ:" or "A:ALBUM"
+	Container string
+	UpdateID  uint32
+}
+
+// ParseContainerUpdateIDs parses the ContainerUpdateIDs state variable, a
+// comma separated list of container and UpdateID pairs such as "Q:0,12,SQ:,3".
+func ParseContainerUpdateIDs(value string) ([]ContainerUpdate, error) {
+	if value == "" {
+		return nil, nil
+	}
+	fields := strings.Split(value, ",")
+	if len(fields)%2 != 0 {
+		return nil, fmt.Errorf("ContainerUpdateIDs %q: odd number of fields", value)
+	}
+	var updates []ContainerUpdate
+	for i := 0; i < len(fields); i += 2 {
+		updateID, err := strconv.ParseUint(fields[i+1], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("ContainerUpdateIDs %q: %v", value, err)
+		}
+		updates = append(updates, ContainerUpdate{Container: fields[i], UpdateID: uint32(updateID)})
+	}
+	return updates, nil
+}
+
+// UpdateIDContainers maps the state variables that track a whole collection to
+// the container holding it.
+var UpdateIDContainers = map[string]string{
+	"SavedQueuesUpdateID":    "SQ:",
+	"FavoritesUpdateID":      "FV:2",
+	"RadioFavoritesUpdateID": "R:0/0",
+	"ShareListUpdateID":      "S:",
+}
+
+// ParseUpdateID parses the state variables that track a whole collection,
+// such as SavedQueuesUpdateID. They hold the player that made the last change
+// and the UpdateID, e.g. "RINCON_000E58A0A1B201400,7". SystemUpdateID, which
+// is a plain number, is accepted too.
+func ParseUpdateID(value string) (player string, updateID uint32, err error) {
+	player, id, ok := strings.Cut(value, ",")
+	if !ok {
+		player, id = "", value
+	}
+	u, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return "", 0, fmt.Errorf("UpdateID %q: %v", value, err)
+	}
+	return player, uint32(u), nil
+}
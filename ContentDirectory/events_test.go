@@ -0,0 +1,67 @@
+package contentdirectory
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseContainerUpdateIDs(t *testing.T) {
+	tests := []struct {
+		value   string
+		updates []ContainerUpdate
+	}{
+		{"", nil},
+		{"Q:0,12", []ContainerUpdate{{"Q:0", 12}}},
+		{"Q:0,12,SQ:,3", []ContainerUpdate{{"Q:0", 12}, {"SQ:", 3}}},
+		{"A:ALBUM,4294967295", []ContainerUpdate{{"A:ALBUM", 4294967295}}},
+	}
+	for _, test := range tests {
+		updates, err := ParseContainerUpdateIDs(test.value)
+		if err != nil {
+			t.Errorf("ParseContainerUpdateIDs(%q): %v", test.value, err)
+			continue
+		}
+		if !reflect.DeepEqual(updates, test.updates) {
+			t.Errorf("ParseContainerUpdateIDs(%q) = %v, want %v", test.value, updates, test.updates)
+		}
+	}
+}
+
+func TestParseContainerUpdateIDsErrors(t *testing.T) {
+	for _, value := range []string{"Q:0", "Q:0,12,SQ:", "Q:0,twelve", "Q:0,-1", "Q:0,4294967296"} {
+		if updates, err := ParseContainerUpdateIDs(value); err == nil {
+			t.Errorf("ParseContainerUpdateIDs(%q) = %v, want an error", value, updates)
+		}
+	}
+}
+
+func TestParseUpdateID(t *testing.T) {
+	tests := []struct {
+		value    string
+		player   string
+		updateID uint32
+	}{
+		{"RINCON_000E58A0A1B201400,7", "RINCON_000E58A0A1B201400", 7},
+		{"RINCON_000E58A0A1B201400,0", "RINCON_000E58A0A1B201400", 0},
+		// SystemUpdateID
+		{"153", "", 153},
+	}
+	for _, test := range tests {
+		player, updateID, err := ParseUpdateID(test.value)
+		if err != nil {
+			t.Errorf("ParseUpdateID(%q): %v", test.value, err)
+			continue
+		}
+		if player != test.player || updateID != test.updateID {
+			t.Errorf("ParseUpdateID(%q) = %q, %d, want %q, %d", test.value, player, updateID, test.player, test.updateID)
+		}
+	}
+}
+
+func TestParseUpdateIDErrors(t *testing.T) {
+	for _, value := range []string{"", "RINCON_000E58A0A1B201400", "RINCON_000E58A0A1B201400,", "RINCON_000E58A0A1B201400,x"} {
+		if player, updateID, err := ParseUpdateID(value); err == nil {
+			t.Errorf("ParseUpdateID(%q) = %q, %d, want an error", value, player, updateID)
+		}
+	}
+}
@@ -1,23 +1,44 @@
 package deviceproperties
 
 import (
-	"bytes"
-	"encoding/xml"
-	"errors"
-	"io/ioutil"
+	"context"
 	"net/http"
 	"net/url"
+	"sync"
+
+	"github.com/szatmary/sonos/soap"
+)
+
+const _ServiceURN = "urn:schemas-upnp-org:service:DeviceProperties:1"
+
+// ErrorCodes describes the error codes specific to this service
+var ErrorCodes = map[int]string{}
+
+func init() {
+	soap.RegisterErrorCodes(_ServiceURN, ErrorCodes)
+}
+
+// LEDState holds the values allowed for the LEDState state variable
+type LEDState string
+
+const (
+	LEDStateOn  LEDState = "On"
+	LEDStateOff LEDState = "Off"
 )
 
+// ButtonLockState holds the values allowed for the ButtonLockState state variable
+type ButtonLockState string
+
 const (
-	_ServiceURN     = "urn:schemas-upnp-org:service:DeviceProperties:1"
-	_EncodingSchema = "http://schemas.xmlsoap.org/soap/encoding/"
-	_EnvelopeSchema = "http://schemas.xmlsoap.org/soap/envelope/"
+	ButtonLockStateOn  ButtonLockState = "On"
+	ButtonLockStateOff ButtonLockState = "Off"
 )
 
 type Service struct {
 	ControlEndpoint *url.URL
 	EventEndpoint   *url.URL
+	// Client sends the actions, soap.DefaultClient is used if it is nil
+	Client *soap.Client
 }
 
 func NewService(deviceUrl *url.URL) *Service {
@@ -35,288 +56,185 @@ func NewService(deviceUrl *url.URL) *Service {
 	}
 }
 
-type Envelope struct {
-	XMLName       xml.Name `xml:"s:Envelope"`
-	Xmlns         string   `xml:"xmlns:s,attr"`
-	EncodingStyle string   `xml:"s:encodingStyle,attr"`
-	Body          Body     `xml:"s:Body"`
-}
-type Body struct {
-	XMLName                xml.Name                    `xml:"s:Body"`
-	SetLEDState            *SetLEDStateArgs            `xml:"u:SetLEDState,omitempty"`
-	GetLEDState            *GetLEDStateArgs            `xml:"u:GetLEDState,omitempty"`
-	AddBondedZones         *AddBondedZonesArgs         `xml:"u:AddBondedZones,omitempty"`
-	RemoveBondedZones      *RemoveBondedZonesArgs      `xml:"u:RemoveBondedZones,omitempty"`
-	CreateStereoPair       *CreateStereoPairArgs       `xml:"u:CreateStereoPair,omitempty"`
-	SeparateStereoPair     *SeparateStereoPairArgs     `xml:"u:SeparateStereoPair,omitempty"`
-	SetZoneAttributes      *SetZoneAttributesArgs      `xml:"u:SetZoneAttributes,omitempty"`
-	GetZoneAttributes      *GetZoneAttributesArgs      `xml:"u:GetZoneAttributes,omitempty"`
-	GetHouseholdID         *GetHouseholdIDArgs         `xml:"u:GetHouseholdID,omitempty"`
-	GetZoneInfo            *GetZoneInfoArgs            `xml:"u:GetZoneInfo,omitempty"`
-	SetAutoplayLinkedZones *SetAutoplayLinkedZonesArgs `xml:"u:SetAutoplayLinkedZones,omitempty"`
-	GetAutoplayLinkedZones *GetAutoplayLinkedZonesArgs `xml:"u:GetAutoplayLinkedZones,omitempty"`
-	SetAutoplayRoomUUID    *SetAutoplayRoomUUIDArgs    `xml:"u:SetAutoplayRoomUUID,omitempty"`
-	GetAutoplayRoomUUID    *GetAutoplayRoomUUIDArgs    `xml:"u:GetAutoplayRoomUUID,omitempty"`
-	SetAutoplayVolume      *SetAutoplayVolumeArgs      `xml:"u:SetAutoplayVolume,omitempty"`
-	GetAutoplayVolume      *GetAutoplayVolumeArgs      `xml:"u:GetAutoplayVolume,omitempty"`
-	SetUseAutoplayVolume   *SetUseAutoplayVolumeArgs   `xml:"u:SetUseAutoplayVolume,omitempty"`
-	GetUseAutoplayVolume   *GetUseAutoplayVolumeArgs   `xml:"u:GetUseAutoplayVolume,omitempty"`
-	AddHTSatellite         *AddHTSatelliteArgs         `xml:"u:AddHTSatellite,omitempty"`
-	RemoveHTSatellite      *RemoveHTSatelliteArgs      `xml:"u:RemoveHTSatellite,omitempty"`
-	EnterConfigMode        *EnterConfigModeArgs        `xml:"u:EnterConfigMode,omitempty"`
-	ExitConfigMode         *ExitConfigModeArgs         `xml:"u:ExitConfigMode,omitempty"`
-	GetButtonState         *GetButtonStateArgs         `xml:"u:GetButtonState,omitempty"`
-	SetButtonLockState     *SetButtonLockStateArgs     `xml:"u:SetButtonLockState,omitempty"`
-	GetButtonLockState     *GetButtonLockStateArgs     `xml:"u:GetButtonLockState,omitempty"`
-}
-type EnvelopeResponse struct {
-	XMLName       xml.Name     `xml:"Envelope"`
-	Xmlns         string       `xml:"xmlns:s,attr"`
-	EncodingStyle string       `xml:"encodingStyle,attr"`
-	Body          BodyResponse `xml:"Body"`
-}
-type BodyResponse struct {
-	XMLName                xml.Name                        `xml:"Body"`
-	SetLEDState            *SetLEDStateResponse            `xml:"SetLEDStateResponse,omitempty"`
-	GetLEDState            *GetLEDStateResponse            `xml:"GetLEDStateResponse,omitempty"`
-	AddBondedZones         *AddBondedZonesResponse         `xml:"AddBondedZonesResponse,omitempty"`
-	RemoveBondedZones      *RemoveBondedZonesResponse      `xml:"RemoveBondedZonesResponse,omitempty"`
-	CreateStereoPair       *CreateStereoPairResponse       `xml:"CreateStereoPairResponse,omitempty"`
-	SeparateStereoPair     *SeparateStereoPairResponse     `xml:"SeparateStereoPairResponse,omitempty"`
-	SetZoneAttributes      *SetZoneAttributesResponse      `xml:"SetZoneAttributesResponse,omitempty"`
-	GetZoneAttributes      *GetZoneAttributesResponse      `xml:"GetZoneAttributesResponse,omitempty"`
-	GetHouseholdID         *GetHouseholdIDResponse         `xml:"GetHouseholdIDResponse,omitempty"`
-	GetZoneInfo            *GetZoneInfoResponse            `xml:"GetZoneInfoResponse,omitempty"`
-	SetAutoplayLinkedZones *SetAutoplayLinkedZonesResponse `xml:"SetAutoplayLinkedZonesResponse,omitempty"`
-	GetAutoplayLinkedZones *GetAutoplayLinkedZonesResponse `xml:"GetAutoplayLinkedZonesResponse,omitempty"`
-	SetAutoplayRoomUUID    *SetAutoplayRoomUUIDResponse    `xml:"SetAutoplayRoomUUIDResponse,omitempty"`
-	GetAutoplayRoomUUID    *GetAutoplayRoomUUIDResponse    `xml:"GetAutoplayRoomUUIDResponse,omitempty"`
-	SetAutoplayVolume      *SetAutoplayVolumeResponse      `xml:"SetAutoplayVolumeResponse,omitempty"`
-	GetAutoplayVolume      *GetAutoplayVolumeResponse      `xml:"GetAutoplayVolumeResponse,omitempty"`
-	SetUseAutoplayVolume   *SetUseAutoplayVolumeResponse   `xml:"SetUseAutoplayVolumeResponse,omitempty"`
-	GetUseAutoplayVolume   *GetUseAutoplayVolumeResponse   `xml:"GetUseAutoplayVolumeResponse,omitempty"`
-	AddHTSatellite         *AddHTSatelliteResponse         `xml:"AddHTSatelliteResponse,omitempty"`
-	RemoveHTSatellite      *RemoveHTSatelliteResponse      `xml:"RemoveHTSatelliteResponse,omitempty"`
-	EnterConfigMode        *EnterConfigModeResponse        `xml:"EnterConfigModeResponse,omitempty"`
-	ExitConfigMode         *ExitConfigModeResponse         `xml:"ExitConfigModeResponse,omitempty"`
-	GetButtonState         *GetButtonStateResponse         `xml:"GetButtonStateResponse,omitempty"`
-	SetButtonLockState     *SetButtonLockStateResponse     `xml:"SetButtonLockStateResponse,omitempty"`
-	GetButtonLockState     *GetButtonLockStateResponse     `xml:"GetButtonLockStateResponse,omitempty"`
-}
-
-func (s *Service) exec(actionName string, httpClient *http.Client, envelope *Envelope) (*EnvelopeResponse, error) {
-	marshaled, err := xml.Marshal(envelope)
-	if err != nil {
-		return nil, err
-	}
-	postBody := []byte(`<?xml version="1.0"?>`)
-	postBody = append(postBody, marshaled...)
-	req, err := http.NewRequest(`POST`, s.ControlEndpoint.String(), bytes.NewBuffer(postBody))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set(`Content-Type`, `text/xml; charset="utf-8"`)
-	req.Header.Set(`SOAPAction`, _ServiceURN+`#`+actionName)
-	res, err := httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-	responseBody, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-	var envelopeResponse EnvelopeResponse
-	err = xml.Unmarshal(responseBody, &envelopeResponse)
-	if err != nil {
-		return nil, err
-	}
-	return &envelopeResponse, nil
-}
-
 type SetLEDStateArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 	// Allowed Value: On
 	// Allowed Value: Off
-	DesiredLEDState string `xml:"DesiredLEDState"`
+	DesiredLEDState LEDState `xml:"DesiredLEDState"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetLEDStateArgs) Validate() error {
+	switch a.DesiredLEDState {
+	case "", LEDStateOn, LEDStateOff:
+	default:
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `SetLEDState`, Argument: `DesiredLEDState`, Value: a.DesiredLEDState, Allowed: "one of On, Off"}
+	}
+	return nil
+}
+
 type SetLEDStateResponse struct {
 }
 
 func (s *Service) SetLEDState(httpClient *http.Client, args *SetLEDStateArgs) (*SetLEDStateResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetLEDState`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetLEDState: args},
-		})
-	if err != nil {
+	return s.SetLEDStateContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetLEDStateContext(ctx context.Context, httpClient *http.Client, args *SetLEDStateArgs) (*SetLEDStateResponse, error) {
+	var r SetLEDStateResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetLEDState`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetLEDState == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.SetLEDState()`)
-	}
-
-	return r.Body.SetLEDState, nil
+	return &r, nil
 }
 
 type GetLEDStateArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetLEDStateArgs) Validate() error {
+	return nil
+}
+
 type GetLEDStateResponse struct {
-	CurrentLEDState string `xml:"CurrentLEDState"`
+	CurrentLEDState LEDState `xml:"CurrentLEDState"`
 }
 
 func (s *Service) GetLEDState(httpClient *http.Client, args *GetLEDStateArgs) (*GetLEDStateResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetLEDState`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetLEDState: args},
-		})
-	if err != nil {
+	return s.GetLEDStateContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetLEDStateContext(ctx context.Context, httpClient *http.Client, args *GetLEDStateArgs) (*GetLEDStateResponse, error) {
+	var r GetLEDStateResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetLEDState`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetLEDState == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.GetLEDState()`)
-	}
-
-	return r.Body.GetLEDState, nil
+	return &r, nil
 }
 
 type AddBondedZonesArgs struct {
-	Xmlns         string `xml:"xmlns:u,attr"`
 	ChannelMapSet string `xml:"ChannelMapSet"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *AddBondedZonesArgs) Validate() error {
+	return nil
+}
+
 type AddBondedZonesResponse struct {
 }
 
 func (s *Service) AddBondedZones(httpClient *http.Client, args *AddBondedZonesArgs) (*AddBondedZonesResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`AddBondedZones`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{AddBondedZones: args},
-		})
-	if err != nil {
+	return s.AddBondedZonesContext(context.Background(), httpClient, args)
+}
+func (s *Service) AddBondedZonesContext(ctx context.Context, httpClient *http.Client, args *AddBondedZonesArgs) (*AddBondedZonesResponse, error) {
+	var r AddBondedZonesResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `AddBondedZones`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.AddBondedZones == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.AddBondedZones()`)
-	}
-
-	return r.Body.AddBondedZones, nil
+	return &r, nil
 }
 
 type RemoveBondedZonesArgs struct {
-	Xmlns         string `xml:"xmlns:u,attr"`
 	ChannelMapSet string `xml:"ChannelMapSet"`
 	KeepGrouped   bool   `xml:"KeepGrouped"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *RemoveBondedZonesArgs) Validate() error {
+	return nil
+}
+
 type RemoveBondedZonesResponse struct {
 }
 
 func (s *Service) RemoveBondedZones(httpClient *http.Client, args *RemoveBondedZonesArgs) (*RemoveBondedZonesResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`RemoveBondedZones`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{RemoveBondedZones: args},
-		})
-	if err != nil {
+	return s.RemoveBondedZonesContext(context.Background(), httpClient, args)
+}
+func (s *Service) RemoveBondedZonesContext(ctx context.Context, httpClient *http.Client, args *RemoveBondedZonesArgs) (*RemoveBondedZonesResponse, error) {
+	var r RemoveBondedZonesResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `RemoveBondedZones`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.RemoveBondedZones == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.RemoveBondedZones()`)
-	}
-
-	return r.Body.RemoveBondedZones, nil
+	return &r, nil
 }
 
 type CreateStereoPairArgs struct {
-	Xmlns         string `xml:"xmlns:u,attr"`
 	ChannelMapSet string `xml:"ChannelMapSet"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *CreateStereoPairArgs) Validate() error {
+	return nil
+}
+
 type CreateStereoPairResponse struct {
 }
 
 func (s *Service) CreateStereoPair(httpClient *http.Client, args *CreateStereoPairArgs) (*CreateStereoPairResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`CreateStereoPair`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{CreateStereoPair: args},
-		})
-	if err != nil {
+	return s.CreateStereoPairContext(context.Background(), httpClient, args)
+}
+func (s *Service) CreateStereoPairContext(ctx context.Context, httpClient *http.Client, args *CreateStereoPairArgs) (*CreateStereoPairResponse, error) {
+	var r CreateStereoPairResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `CreateStereoPair`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.CreateStereoPair == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.CreateStereoPair()`)
-	}
-
-	return r.Body.CreateStereoPair, nil
+	return &r, nil
 }
 
 type SeparateStereoPairArgs struct {
-	Xmlns         string `xml:"xmlns:u,attr"`
 	ChannelMapSet string `xml:"ChannelMapSet"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SeparateStereoPairArgs) Validate() error {
+	return nil
+}
+
 type SeparateStereoPairResponse struct {
 }
 
 func (s *Service) SeparateStereoPair(httpClient *http.Client, args *SeparateStereoPairArgs) (*SeparateStereoPairResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SeparateStereoPair`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SeparateStereoPair: args},
-		})
-	if err != nil {
+	return s.SeparateStereoPairContext(context.Background(), httpClient, args)
+}
+func (s *Service) SeparateStereoPairContext(ctx context.Context, httpClient *http.Client, args *SeparateStereoPairArgs) (*SeparateStereoPairResponse, error) {
+	var r SeparateStereoPairResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SeparateStereoPair`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SeparateStereoPair == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.SeparateStereoPair()`)
-	}
-
-	return r.Body.SeparateStereoPair, nil
+	return &r, nil
 }
 
 type SetZoneAttributesArgs struct {
-	Xmlns                string `xml:"xmlns:u,attr"`
 	DesiredZoneName      string `xml:"DesiredZoneName"`
 	DesiredIcon          string `xml:"DesiredIcon"`
 	DesiredConfiguration string `xml:"DesiredConfiguration"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetZoneAttributesArgs) Validate() error {
+	return nil
+}
+
 type SetZoneAttributesResponse struct {
 }
 
 func (s *Service) SetZoneAttributes(httpClient *http.Client, args *SetZoneAttributesArgs) (*SetZoneAttributesResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetZoneAttributes`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetZoneAttributes: args},
-		})
-	if err != nil {
+	return s.SetZoneAttributesContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetZoneAttributesContext(ctx context.Context, httpClient *http.Client, args *SetZoneAttributesArgs) (*SetZoneAttributesResponse, error) {
+	var r SetZoneAttributesResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetZoneAttributes`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetZoneAttributes == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.SetZoneAttributes()`)
-	}
-
-	return r.Body.SetZoneAttributes, nil
+	return &r, nil
 }
 
 type GetZoneAttributesArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetZoneAttributesArgs) Validate() error {
+	return nil
+}
+
 type GetZoneAttributesResponse struct {
 	CurrentZoneName      string `xml:"CurrentZoneName"`
 	CurrentIcon          string `xml:"CurrentIcon"`
@@ -324,51 +242,47 @@ type GetZoneAttributesResponse struct {
 }
 
 func (s *Service) GetZoneAttributes(httpClient *http.Client, args *GetZoneAttributesArgs) (*GetZoneAttributesResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetZoneAttributes`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetZoneAttributes: args},
-		})
-	if err != nil {
+	return s.GetZoneAttributesContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetZoneAttributesContext(ctx context.Context, httpClient *http.Client, args *GetZoneAttributesArgs) (*GetZoneAttributesResponse, error) {
+	var r GetZoneAttributesResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetZoneAttributes`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetZoneAttributes == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.GetZoneAttributes()`)
-	}
-
-	return r.Body.GetZoneAttributes, nil
+	return &r, nil
 }
 
 type GetHouseholdIDArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetHouseholdIDArgs) Validate() error {
+	return nil
+}
+
 type GetHouseholdIDResponse struct {
 	CurrentHouseholdID string `xml:"CurrentHouseholdID"`
 }
 
 func (s *Service) GetHouseholdID(httpClient *http.Client, args *GetHouseholdIDArgs) (*GetHouseholdIDResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetHouseholdID`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetHouseholdID: args},
-		})
-	if err != nil {
+	return s.GetHouseholdIDContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetHouseholdIDContext(ctx context.Context, httpClient *http.Client, args *GetHouseholdIDArgs) (*GetHouseholdIDResponse, error) {
+	var r GetHouseholdIDResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetHouseholdID`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetHouseholdID == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.GetHouseholdID()`)
-	}
-
-	return r.Body.GetHouseholdID, nil
+	return &r, nil
 }
 
 type GetZoneInfoArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetZoneInfoArgs) Validate() error {
+	return nil
+}
+
 type GetZoneInfoResponse struct {
 	SerialNumber           string `xml:"SerialNumber"`
 	SoftwareVersion        string `xml:"SoftwareVersion"`
@@ -383,407 +297,743 @@ type GetZoneInfoResponse struct {
 }
 
 func (s *Service) GetZoneInfo(httpClient *http.Client, args *GetZoneInfoArgs) (*GetZoneInfoResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetZoneInfo`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetZoneInfo: args},
-		})
-	if err != nil {
+	return s.GetZoneInfoContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetZoneInfoContext(ctx context.Context, httpClient *http.Client, args *GetZoneInfoArgs) (*GetZoneInfoResponse, error) {
+	var r GetZoneInfoResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetZoneInfo`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetZoneInfo == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.GetZoneInfo()`)
-	}
-
-	return r.Body.GetZoneInfo, nil
+	return &r, nil
 }
 
 type SetAutoplayLinkedZonesArgs struct {
-	Xmlns              string `xml:"xmlns:u,attr"`
 	IncludeLinkedZones bool   `xml:"IncludeLinkedZones"`
 	Source             string `xml:"Source"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetAutoplayLinkedZonesArgs) Validate() error {
+	return nil
+}
+
 type SetAutoplayLinkedZonesResponse struct {
 }
 
 func (s *Service) SetAutoplayLinkedZones(httpClient *http.Client, args *SetAutoplayLinkedZonesArgs) (*SetAutoplayLinkedZonesResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetAutoplayLinkedZones`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetAutoplayLinkedZones: args},
-		})
-	if err != nil {
+	return s.SetAutoplayLinkedZonesContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetAutoplayLinkedZonesContext(ctx context.Context, httpClient *http.Client, args *SetAutoplayLinkedZonesArgs) (*SetAutoplayLinkedZonesResponse, error) {
+	var r SetAutoplayLinkedZonesResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetAutoplayLinkedZones`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetAutoplayLinkedZones == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.SetAutoplayLinkedZones()`)
-	}
-
-	return r.Body.SetAutoplayLinkedZones, nil
+	return &r, nil
 }
 
 type GetAutoplayLinkedZonesArgs struct {
-	Xmlns  string `xml:"xmlns:u,attr"`
 	Source string `xml:"Source"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetAutoplayLinkedZonesArgs) Validate() error {
+	return nil
+}
+
 type GetAutoplayLinkedZonesResponse struct {
 	IncludeLinkedZones bool `xml:"IncludeLinkedZones"`
 }
 
 func (s *Service) GetAutoplayLinkedZones(httpClient *http.Client, args *GetAutoplayLinkedZonesArgs) (*GetAutoplayLinkedZonesResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetAutoplayLinkedZones`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetAutoplayLinkedZones: args},
-		})
-	if err != nil {
+	return s.GetAutoplayLinkedZonesContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetAutoplayLinkedZonesContext(ctx context.Context, httpClient *http.Client, args *GetAutoplayLinkedZonesArgs) (*GetAutoplayLinkedZonesResponse, error) {
+	var r GetAutoplayLinkedZonesResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetAutoplayLinkedZones`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetAutoplayLinkedZones == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.GetAutoplayLinkedZones()`)
-	}
-
-	return r.Body.GetAutoplayLinkedZones, nil
+	return &r, nil
 }
 
 type SetAutoplayRoomUUIDArgs struct {
-	Xmlns    string `xml:"xmlns:u,attr"`
 	RoomUUID string `xml:"RoomUUID"`
 	Source   string `xml:"Source"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetAutoplayRoomUUIDArgs) Validate() error {
+	return nil
+}
+
 type SetAutoplayRoomUUIDResponse struct {
 }
 
 func (s *Service) SetAutoplayRoomUUID(httpClient *http.Client, args *SetAutoplayRoomUUIDArgs) (*SetAutoplayRoomUUIDResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetAutoplayRoomUUID`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetAutoplayRoomUUID: args},
-		})
-	if err != nil {
+	return s.SetAutoplayRoomUUIDContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetAutoplayRoomUUIDContext(ctx context.Context, httpClient *http.Client, args *SetAutoplayRoomUUIDArgs) (*SetAutoplayRoomUUIDResponse, error) {
+	var r SetAutoplayRoomUUIDResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetAutoplayRoomUUID`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetAutoplayRoomUUID == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.SetAutoplayRoomUUID()`)
-	}
-
-	return r.Body.SetAutoplayRoomUUID, nil
+	return &r, nil
 }
 
 type GetAutoplayRoomUUIDArgs struct {
-	Xmlns  string `xml:"xmlns:u,attr"`
 	Source string `xml:"Source"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetAutoplayRoomUUIDArgs) Validate() error {
+	return nil
+}
+
 type GetAutoplayRoomUUIDResponse struct {
 	RoomUUID string `xml:"RoomUUID"`
 }
 
 func (s *Service) GetAutoplayRoomUUID(httpClient *http.Client, args *GetAutoplayRoomUUIDArgs) (*GetAutoplayRoomUUIDResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetAutoplayRoomUUID`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetAutoplayRoomUUID: args},
-		})
-	if err != nil {
+	return s.GetAutoplayRoomUUIDContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetAutoplayRoomUUIDContext(ctx context.Context, httpClient *http.Client, args *GetAutoplayRoomUUIDArgs) (*GetAutoplayRoomUUIDResponse, error) {
+	var r GetAutoplayRoomUUIDResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetAutoplayRoomUUID`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetAutoplayRoomUUID == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.GetAutoplayRoomUUID()`)
-	}
-
-	return r.Body.GetAutoplayRoomUUID, nil
+	return &r, nil
 }
 
 type SetAutoplayVolumeArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 	// Allowed Range: 0 -> 100 step: 1
 	Volume uint16 `xml:"Volume"`
 	Source string `xml:"Source"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetAutoplayVolumeArgs) Validate() error {
+	if a.Volume > 100 {
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `SetAutoplayVolume`, Argument: `Volume`, Value: a.Volume, Allowed: "0 to 100"}
+	}
+	return nil
+}
+
 type SetAutoplayVolumeResponse struct {
 }
 
 func (s *Service) SetAutoplayVolume(httpClient *http.Client, args *SetAutoplayVolumeArgs) (*SetAutoplayVolumeResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetAutoplayVolume`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetAutoplayVolume: args},
-		})
-	if err != nil {
+	return s.SetAutoplayVolumeContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetAutoplayVolumeContext(ctx context.Context, httpClient *http.Client, args *SetAutoplayVolumeArgs) (*SetAutoplayVolumeResponse, error) {
+	var r SetAutoplayVolumeResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetAutoplayVolume`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetAutoplayVolume == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.SetAutoplayVolume()`)
-	}
-
-	return r.Body.SetAutoplayVolume, nil
+	return &r, nil
 }
 
 type GetAutoplayVolumeArgs struct {
-	Xmlns  string `xml:"xmlns:u,attr"`
 	Source string `xml:"Source"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetAutoplayVolumeArgs) Validate() error {
+	return nil
+}
+
 type GetAutoplayVolumeResponse struct {
 	CurrentVolume uint16 `xml:"CurrentVolume"`
 }
 
 func (s *Service) GetAutoplayVolume(httpClient *http.Client, args *GetAutoplayVolumeArgs) (*GetAutoplayVolumeResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetAutoplayVolume`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetAutoplayVolume: args},
-		})
-	if err != nil {
+	return s.GetAutoplayVolumeContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetAutoplayVolumeContext(ctx context.Context, httpClient *http.Client, args *GetAutoplayVolumeArgs) (*GetAutoplayVolumeResponse, error) {
+	var r GetAutoplayVolumeResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetAutoplayVolume`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetAutoplayVolume == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.GetAutoplayVolume()`)
-	}
-
-	return r.Body.GetAutoplayVolume, nil
+	return &r, nil
 }
 
 type SetUseAutoplayVolumeArgs struct {
-	Xmlns     string `xml:"xmlns:u,attr"`
 	UseVolume bool   `xml:"UseVolume"`
 	Source    string `xml:"Source"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetUseAutoplayVolumeArgs) Validate() error {
+	return nil
+}
+
 type SetUseAutoplayVolumeResponse struct {
 }
 
 func (s *Service) SetUseAutoplayVolume(httpClient *http.Client, args *SetUseAutoplayVolumeArgs) (*SetUseAutoplayVolumeResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetUseAutoplayVolume`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetUseAutoplayVolume: args},
-		})
-	if err != nil {
+	return s.SetUseAutoplayVolumeContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetUseAutoplayVolumeContext(ctx context.Context, httpClient *http.Client, args *SetUseAutoplayVolumeArgs) (*SetUseAutoplayVolumeResponse, error) {
+	var r SetUseAutoplayVolumeResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetUseAutoplayVolume`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetUseAutoplayVolume == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.SetUseAutoplayVolume()`)
-	}
-
-	return r.Body.SetUseAutoplayVolume, nil
+	return &r, nil
 }
 
 type GetUseAutoplayVolumeArgs struct {
-	Xmlns  string `xml:"xmlns:u,attr"`
 	Source string `xml:"Source"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetUseAutoplayVolumeArgs) Validate() error {
+	return nil
+}
+
 type GetUseAutoplayVolumeResponse struct {
 	UseVolume bool `xml:"UseVolume"`
 }
 
 func (s *Service) GetUseAutoplayVolume(httpClient *http.Client, args *GetUseAutoplayVolumeArgs) (*GetUseAutoplayVolumeResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetUseAutoplayVolume`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetUseAutoplayVolume: args},
-		})
-	if err != nil {
+	return s.GetUseAutoplayVolumeContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetUseAutoplayVolumeContext(ctx context.Context, httpClient *http.Client, args *GetUseAutoplayVolumeArgs) (*GetUseAutoplayVolumeResponse, error) {
+	var r GetUseAutoplayVolumeResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetUseAutoplayVolume`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetUseAutoplayVolume == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.GetUseAutoplayVolume()`)
-	}
-
-	return r.Body.GetUseAutoplayVolume, nil
+	return &r, nil
 }
 
 type AddHTSatelliteArgs struct {
-	Xmlns           string `xml:"xmlns:u,attr"`
 	HTSatChanMapSet string `xml:"HTSatChanMapSet"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *AddHTSatelliteArgs) Validate() error {
+	return nil
+}
+
 type AddHTSatelliteResponse struct {
 }
 
 func (s *Service) AddHTSatellite(httpClient *http.Client, args *AddHTSatelliteArgs) (*AddHTSatelliteResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`AddHTSatellite`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{AddHTSatellite: args},
-		})
-	if err != nil {
+	return s.AddHTSatelliteContext(context.Background(), httpClient, args)
+}
+func (s *Service) AddHTSatelliteContext(ctx context.Context, httpClient *http.Client, args *AddHTSatelliteArgs) (*AddHTSatelliteResponse, error) {
+	var r AddHTSatelliteResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `AddHTSatellite`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.AddHTSatellite == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.AddHTSatellite()`)
-	}
-
-	return r.Body.AddHTSatellite, nil
+	return &r, nil
 }
 
 type RemoveHTSatelliteArgs struct {
-	Xmlns       string `xml:"xmlns:u,attr"`
 	SatRoomUUID string `xml:"SatRoomUUID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *RemoveHTSatelliteArgs) Validate() error {
+	return nil
+}
+
 type RemoveHTSatelliteResponse struct {
 }
 
 func (s *Service) RemoveHTSatellite(httpClient *http.Client, args *RemoveHTSatelliteArgs) (*RemoveHTSatelliteResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`RemoveHTSatellite`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{RemoveHTSatellite: args},
-		})
-	if err != nil {
+	return s.RemoveHTSatelliteContext(context.Background(), httpClient, args)
+}
+func (s *Service) RemoveHTSatelliteContext(ctx context.Context, httpClient *http.Client, args *RemoveHTSatelliteArgs) (*RemoveHTSatelliteResponse, error) {
+	var r RemoveHTSatelliteResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `RemoveHTSatellite`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.RemoveHTSatellite == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.RemoveHTSatellite()`)
-	}
-
-	return r.Body.RemoveHTSatellite, nil
+	return &r, nil
 }
 
 type EnterConfigModeArgs struct {
-	Xmlns   string `xml:"xmlns:u,attr"`
 	Mode    string `xml:"Mode"`
 	Options string `xml:"Options"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *EnterConfigModeArgs) Validate() error {
+	return nil
+}
+
 type EnterConfigModeResponse struct {
 	State string `xml:"State"`
 }
 
 func (s *Service) EnterConfigMode(httpClient *http.Client, args *EnterConfigModeArgs) (*EnterConfigModeResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`EnterConfigMode`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{EnterConfigMode: args},
-		})
-	if err != nil {
+	return s.EnterConfigModeContext(context.Background(), httpClient, args)
+}
+func (s *Service) EnterConfigModeContext(ctx context.Context, httpClient *http.Client, args *EnterConfigModeArgs) (*EnterConfigModeResponse, error) {
+	var r EnterConfigModeResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `EnterConfigMode`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.EnterConfigMode == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.EnterConfigMode()`)
-	}
-
-	return r.Body.EnterConfigMode, nil
+	return &r, nil
 }
 
 type ExitConfigModeArgs struct {
-	Xmlns   string `xml:"xmlns:u,attr"`
 	Options string `xml:"Options"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *ExitConfigModeArgs) Validate() error {
+	return nil
+}
+
 type ExitConfigModeResponse struct {
 }
 
 func (s *Service) ExitConfigMode(httpClient *http.Client, args *ExitConfigModeArgs) (*ExitConfigModeResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`ExitConfigMode`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{ExitConfigMode: args},
-		})
-	if err != nil {
+	return s.ExitConfigModeContext(context.Background(), httpClient, args)
+}
+func (s *Service) ExitConfigModeContext(ctx context.Context, httpClient *http.Client, args *ExitConfigModeArgs) (*ExitConfigModeResponse, error) {
+	var r ExitConfigModeResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `ExitConfigMode`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.ExitConfigMode == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.ExitConfigMode()`)
-	}
-
-	return r.Body.ExitConfigMode, nil
+	return &r, nil
 }
 
 type GetButtonStateArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetButtonStateArgs) Validate() error {
+	return nil
+}
+
 type GetButtonStateResponse struct {
 	State string `xml:"State"`
 }
 
 func (s *Service) GetButtonState(httpClient *http.Client, args *GetButtonStateArgs) (*GetButtonStateResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetButtonState`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetButtonState: args},
-		})
-	if err != nil {
+	return s.GetButtonStateContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetButtonStateContext(ctx context.Context, httpClient *http.Client, args *GetButtonStateArgs) (*GetButtonStateResponse, error) {
+	var r GetButtonStateResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetButtonState`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetButtonState == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.GetButtonState()`)
-	}
-
-	return r.Body.GetButtonState, nil
+	return &r, nil
 }
 
 type SetButtonLockStateArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 	// Allowed Value: On
 	// Allowed Value: Off
-	DesiredButtonLockState string `xml:"DesiredButtonLockState"`
+	DesiredButtonLockState ButtonLockState `xml:"DesiredButtonLockState"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetButtonLockStateArgs) Validate() error {
+	switch a.DesiredButtonLockState {
+	case "", ButtonLockStateOn, ButtonLockStateOff:
+	default:
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `SetButtonLockState`, Argument: `DesiredButtonLockState`, Value: a.DesiredButtonLockState, Allowed: "one of On, Off"}
+	}
+	return nil
+}
+
 type SetButtonLockStateResponse struct {
 }
 
 func (s *Service) SetButtonLockState(httpClient *http.Client, args *SetButtonLockStateArgs) (*SetButtonLockStateResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetButtonLockState`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetButtonLockState: args},
-		})
-	if err != nil {
+	return s.SetButtonLockStateContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetButtonLockStateContext(ctx context.Context, httpClient *http.Client, args *SetButtonLockStateArgs) (*SetButtonLockStateResponse, error) {
+	var r SetButtonLockStateResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetButtonLockState`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetButtonLockState == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.SetButtonLockState()`)
-	}
-
-	return r.Body.SetButtonLockState, nil
+	return &r, nil
 }
 
 type GetButtonLockStateArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetButtonLockStateArgs) Validate() error {
+	return nil
+}
+
 type GetButtonLockStateResponse struct {
-	CurrentButtonLockState string `xml:"CurrentButtonLockState"`
+	CurrentButtonLockState ButtonLockState `xml:"CurrentButtonLockState"`
 }
 
 func (s *Service) GetButtonLockState(httpClient *http.Client, args *GetButtonLockStateArgs) (*GetButtonLockStateResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetButtonLockState`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetButtonLockState: args},
-		})
-	if err != nil {
+	return s.GetButtonLockStateContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetButtonLockStateContext(ctx context.Context, httpClient *http.Client, args *GetButtonLockStateArgs) (*GetButtonLockStateResponse, error) {
+	var r GetButtonLockStateResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetButtonLockState`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetButtonLockState == nil {
-		return nil, errors.New(`unexpected respose from service calling deviceproperties.GetButtonLockState()`)
+	return &r, nil
+}
+
+// Client is the set of actions of the service. It is implemented by Service and Fake
+type Client interface {
+	SetLEDState(httpClient *http.Client, args *SetLEDStateArgs) (*SetLEDStateResponse, error)
+	SetLEDStateContext(ctx context.Context, httpClient *http.Client, args *SetLEDStateArgs) (*SetLEDStateResponse, error)
+	GetLEDState(httpClient *http.Client, args *GetLEDStateArgs) (*GetLEDStateResponse, error)
+	GetLEDStateContext(ctx context.Context, httpClient *http.Client, args *GetLEDStateArgs) (*GetLEDStateResponse, error)
+	AddBondedZones(httpClient *http.Client, args *AddBondedZonesArgs) (*AddBondedZonesResponse, error)
+	AddBondedZonesContext(ctx context.Context, httpClient *http.Client, args *AddBondedZonesArgs) (*AddBondedZonesResponse, error)
+	RemoveBondedZones(httpClient *http.Client, args *RemoveBondedZonesArgs) (*RemoveBondedZonesResponse, error)
+	RemoveBondedZonesContext(ctx context.Context, httpClient *http.Client, args *RemoveBondedZonesArgs) (*RemoveBondedZonesResponse, error)
+	CreateStereoPair(httpClient *http.Client, args *CreateStereoPairArgs) (*CreateStereoPairResponse, error)
+	CreateStereoPairContext(ctx context.Context, httpClient *http.Client, args *CreateStereoPairArgs) (*CreateStereoPairResponse, error)
+	SeparateStereoPair(httpClient *http.Client, args *SeparateStereoPairArgs) (*SeparateStereoPairResponse, error)
+	SeparateStereoPairContext(ctx context.Context, httpClient *http.Client, args *SeparateStereoPairArgs) (*SeparateStereoPairResponse, error)
+	SetZoneAttributes(httpClient *http.Client, args *SetZoneAttributesArgs) (*SetZoneAttributesResponse, error)
+	SetZoneAttributesContext(ctx context.Context, httpClient *http.Client, args *SetZoneAttributesArgs) (*SetZoneAttributesResponse, error)
+	GetZoneAttributes(httpClient *http.Client, args *GetZoneAttributesArgs) (*GetZoneAttributesResponse, error)
+	GetZoneAttributesContext(ctx context.Context, httpClient *http.Client, args *GetZoneAttributesArgs) (*GetZoneAttributesResponse, error)
+	GetHouseholdID(httpClient *http.Client, args *GetHouseholdIDArgs) (*GetHouseholdIDResponse, error)
+	GetHouseholdIDContext(ctx context.Context, httpClient *http.Client, args *GetHouseholdIDArgs) (*GetHouseholdIDResponse, error)
+	GetZoneInfo(httpClient *http.Client, args *GetZoneInfoArgs) (*GetZoneInfoResponse, error)
+	GetZoneInfoContext(ctx context.Context, httpClient *http.Client, args *GetZoneInfoArgs) (*GetZoneInfoResponse, error)
+	SetAutoplayLinkedZones(httpClient *http.Client, args *SetAutoplayLinkedZonesArgs) (*SetAutoplayLinkedZonesResponse, error)
+	SetAutoplayLinkedZonesContext(ctx context.Context, httpClient *http.Client, args *SetAutoplayLinkedZonesArgs) (*SetAutoplayLinkedZonesResponse, error)
+	GetAutoplayLinkedZones(httpClient *http.Client, args *GetAutoplayLinkedZonesArgs) (*GetAutoplayLinkedZonesResponse, error)
+	GetAutoplayLinkedZonesContext(ctx context.Context, httpClient *http.Client, args *GetAutoplayLinkedZonesArgs) (*GetAutoplayLinkedZonesResponse, error)
+	SetAutoplayRoomUUID(httpClient *http.Client, args *SetAutoplayRoomUUIDArgs) (*SetAutoplayRoomUUIDResponse, error)
+	SetAutoplayRoomUUIDContext(ctx context.Context, httpClient *http.Client, args *SetAutoplayRoomUUIDArgs) (*SetAutoplayRoomUUIDResponse, error)
+	GetAutoplayRoomUUID(httpClient *http.Client, args *GetAutoplayRoomUUIDArgs) (*GetAutoplayRoomUUIDResponse, error)
+	GetAutoplayRoomUUIDContext(ctx context.Context, httpClient *http.Client, args *GetAutoplayRoomUUIDArgs) (*GetAutoplayRoomUUIDResponse, error)
+	SetAutoplayVolume(httpClient *http.Client, args *SetAutoplayVolumeArgs) (*SetAutoplayVolumeResponse, error)
+	SetAutoplayVolumeContext(ctx context.Context, httpClient *http.Client, args *SetAutoplayVolumeArgs) (*SetAutoplayVolumeResponse, error)
+	GetAutoplayVolume(httpClient *http.Client, args *GetAutoplayVolumeArgs) (*GetAutoplayVolumeResponse, error)
+	GetAutoplayVolumeContext(ctx context.Context, httpClient *http.Client, args *GetAutoplayVolumeArgs) (*GetAutoplayVolumeResponse, error)
+	SetUseAutoplayVolume(httpClient *http.Client, args *SetUseAutoplayVolumeArgs) (*SetUseAutoplayVolumeResponse, error)
+	SetUseAutoplayVolumeContext(ctx context.Context, httpClient *http.Client, args *SetUseAutoplayVolumeArgs) (*SetUseAutoplayVolumeResponse, error)
+	GetUseAutoplayVolume(httpClient *http.Client, args *GetUseAutoplayVolumeArgs) (*GetUseAutoplayVolumeResponse, error)
+	GetUseAutoplayVolumeContext(ctx context.Context, httpClient *http.Client, args *GetUseAutoplayVolumeArgs) (*GetUseAutoplayVolumeResponse, error)
+	AddHTSatellite(httpClient *http.Client, args *AddHTSatelliteArgs) (*AddHTSatelliteResponse, error)
+	AddHTSatelliteContext(ctx context.Context, httpClient *http.Client, args *AddHTSatelliteArgs) (*AddHTSatelliteResponse, error)
+	RemoveHTSatellite(httpClient *http.Client, args *RemoveHTSatelliteArgs) (*RemoveHTSatelliteResponse, error)
+	RemoveHTSatelliteContext(ctx context.Context, httpClient *http.Client, args *RemoveHTSatelliteArgs) (*RemoveHTSatelliteResponse, error)
+	EnterConfigMode(httpClient *http.Client, args *EnterConfigModeArgs) (*EnterConfigModeResponse, error)
+	EnterConfigModeContext(ctx context.Context, httpClient *http.Client, args *EnterConfigModeArgs) (*EnterConfigModeResponse, error)
+	ExitConfigMode(httpClient *http.Client, args *ExitConfigModeArgs) (*ExitConfigModeResponse, error)
+	ExitConfigModeContext(ctx context.Context, httpClient *http.Client, args *ExitConfigModeArgs) (*ExitConfigModeResponse, error)
+	GetButtonState(httpClient *http.Client, args *GetButtonStateArgs) (*GetButtonStateResponse, error)
+	GetButtonStateContext(ctx context.Context, httpClient *http.Client, args *GetButtonStateArgs) (*GetButtonStateResponse, error)
+	SetButtonLockState(httpClient *http.Client, args *SetButtonLockStateArgs) (*SetButtonLockStateResponse, error)
+	SetButtonLockStateContext(ctx context.Context, httpClient *http.Client, args *SetButtonLockStateArgs) (*SetButtonLockStateResponse, error)
+	GetButtonLockState(httpClient *http.Client, args *GetButtonLockStateArgs) (*GetButtonLockStateResponse, error)
+	GetButtonLockStateContext(ctx context.Context, httpClient *http.Client, args *GetButtonLockStateArgs) (*GetButtonLockStateResponse, error)
+}
+
+var _ Client = (*Service)(nil)
+var _ Client = (*Fake)(nil)
+
+// Call is an action received by a Fake
+type Call struct {
+	Action string
+	// Args is the *<Action>Args the action was called with
+	Args interface{}
+}
+
+// Fake is an in-memory Client for tests. It records every call, and answers
+// an action with its On<Action> func, or with an empty response if that is nil
+type Fake struct {
+	mu                       sync.Mutex
+	calls                    []Call
+	OnSetLEDState            func(ctx context.Context, args *SetLEDStateArgs) (*SetLEDStateResponse, error)
+	OnGetLEDState            func(ctx context.Context, args *GetLEDStateArgs) (*GetLEDStateResponse, error)
+	OnAddBondedZones         func(ctx context.Context, args *AddBondedZonesArgs) (*AddBondedZonesResponse, error)
+	OnRemoveBondedZones      func(ctx context.Context, args *RemoveBondedZonesArgs) (*RemoveBondedZonesResponse, error)
+	OnCreateStereoPair       func(ctx context.Context, args *CreateStereoPairArgs) (*CreateStereoPairResponse, error)
+	OnSeparateStereoPair     func(ctx context.Context, args *SeparateStereoPairArgs) (*SeparateStereoPairResponse, error)
+	OnSetZoneAttributes      func(ctx context.Context, args *SetZoneAttributesArgs) (*SetZoneAttributesResponse, error)
+	OnGetZoneAttributes      func(ctx context.Context, args *GetZoneAttributesArgs) (*GetZoneAttributesResponse, error)
+	OnGetHouseholdID         func(ctx context.Context, args *GetHouseholdIDArgs) (*GetHouseholdIDResponse, error)
+	OnGetZoneInfo            func(ctx context.Context, args *GetZoneInfoArgs) (*GetZoneInfoResponse, error)
+	OnSetAutoplayLinkedZones func(ctx context.Context, args *SetAutoplayLinkedZonesArgs) (*SetAutoplayLinkedZonesResponse, error)
+	OnGetAutoplayLinkedZones func(ctx context.Context, args *GetAutoplayLinkedZonesArgs) (*GetAutoplayLinkedZonesResponse, error)
+	OnSetAutoplayRoomUUID    func(ctx context.Context, args *SetAutoplayRoomUUIDArgs) (*SetAutoplayRoomUUIDResponse, error)
+	OnGetAutoplayRoomUUID    func(ctx context.Context, args *GetAutoplayRoomUUIDArgs) (*GetAutoplayRoomUUIDResponse, error)
+	OnSetAutoplayVolume      func(ctx context.Context, args *SetAutoplayVolumeArgs) (*SetAutoplayVolumeResponse, error)
+	OnGetAutoplayVolume      func(ctx context.Context, args *GetAutoplayVolumeArgs) (*GetAutoplayVolumeResponse, error)
+	OnSetUseAutoplayVolume   func(ctx context.Context, args *SetUseAutoplayVolumeArgs) (*SetUseAutoplayVolumeResponse, error)
+	OnGetUseAutoplayVolume   func(ctx context.Context, args *GetUseAutoplayVolumeArgs) (*GetUseAutoplayVolumeResponse, error)
+	OnAddHTSatellite         func(ctx context.Context, args *AddHTSatelliteArgs) (*AddHTSatelliteResponse, error)
+	OnRemoveHTSatellite      func(ctx context.Context, args *RemoveHTSatelliteArgs) (*RemoveHTSatelliteResponse, error)
+	OnEnterConfigMode        func(ctx context.Context, args *EnterConfigModeArgs) (*EnterConfigModeResponse, error)
+	OnExitConfigMode         func(ctx context.Context, args *ExitConfigModeArgs) (*ExitConfigModeResponse, error)
+	OnGetButtonState         func(ctx context.Context, args *GetButtonStateArgs) (*GetButtonStateResponse, error)
+	OnSetButtonLockState     func(ctx context.Context, args *SetButtonLockStateArgs) (*SetButtonLockStateResponse, error)
+	OnGetButtonLockState     func(ctx context.Context, args *GetButtonLockStateArgs) (*GetButtonLockStateResponse, error)
+}
+
+// Calls returns the calls received so far, oldest first
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// Reset forgets the calls received so far
+func (f *Fake) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+}
+func (f *Fake) record(action string, args interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{Action: action, Args: args})
+}
+func (f *Fake) SetLEDState(httpClient *http.Client, args *SetLEDStateArgs) (*SetLEDStateResponse, error) {
+	return f.SetLEDStateContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetLEDStateContext(ctx context.Context, httpClient *http.Client, args *SetLEDStateArgs) (*SetLEDStateResponse, error) {
+	f.record(`SetLEDState`, args)
+	if f.OnSetLEDState != nil {
+		return f.OnSetLEDState(ctx, args)
 	}
-
-	return r.Body.GetButtonLockState, nil
+	return &SetLEDStateResponse{}, nil
+}
+func (f *Fake) GetLEDState(httpClient *http.Client, args *GetLEDStateArgs) (*GetLEDStateResponse, error) {
+	return f.GetLEDStateContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetLEDStateContext(ctx context.Context, httpClient *http.Client, args *GetLEDStateArgs) (*GetLEDStateResponse, error) {
+	f.record(`GetLEDState`, args)
+	if f.OnGetLEDState != nil {
+		return f.OnGetLEDState(ctx, args)
+	}
+	return &GetLEDStateResponse{}, nil
+}
+func (f *Fake) AddBondedZones(httpClient *http.Client, args *AddBondedZonesArgs) (*AddBondedZonesResponse, error) {
+	return f.AddBondedZonesContext(context.Background(), httpClient, args)
+}
+func (f *Fake) AddBondedZonesContext(ctx context.Context, httpClient *http.Client, args *AddBondedZonesArgs) (*AddBondedZonesResponse, error) {
+	f.record(`AddBondedZones`, args)
+	if f.OnAddBondedZones != nil {
+		return f.OnAddBondedZones(ctx, args)
+	}
+	return &AddBondedZonesResponse{}, nil
+}
+func (f *Fake) RemoveBondedZones(httpClient *http.Client, args *RemoveBondedZonesArgs) (*RemoveBondedZonesResponse, error) {
+	return f.RemoveBondedZonesContext(context.Background(), httpClient, args)
+}
+func (f *Fake) RemoveBondedZonesContext(ctx context.Context, httpClient *http.Client, args *RemoveBondedZonesArgs) (*RemoveBondedZonesResponse, error) {
+	f.record(`RemoveBondedZones`, args)
+	if f.OnRemoveBondedZones != nil {
+		return f.OnRemoveBondedZones(ctx, args)
+	}
+	return &RemoveBondedZonesResponse{}, nil
+}
+func (f *Fake) CreateStereoPair(httpClient *http.Client, args *CreateStereoPairArgs) (*CreateStereoPairResponse, error) {
+	return f.CreateStereoPairContext(context.Background(), httpClient, args)
+}
+func (f *Fake) CreateStereoPairContext(ctx context.Context, httpClient *http.Client, args *CreateStereoPairArgs) (*CreateStereoPairResponse, error) {
+	f.record(`CreateStereoPair`, args)
+	if f.OnCreateStereoPair != nil {
+		return f.OnCreateStereoPair(ctx, args)
+	}
+	return &CreateStereoPairResponse{}, nil
+}
+func (f *Fake) SeparateStereoPair(httpClient *http.Client, args *SeparateStereoPairArgs) (*SeparateStereoPairResponse, error) {
+	return f.SeparateStereoPairContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SeparateStereoPairContext(ctx context.Context, httpClient *http.Client, args *SeparateStereoPairArgs) (*SeparateStereoPairResponse, error) {
+	f.record(`SeparateStereoPair`, args)
+	if f.OnSeparateStereoPair != nil {
+		return f.OnSeparateStereoPair(ctx, args)
+	}
+	return &SeparateStereoPairResponse{}, nil
+}
+func (f *Fake) SetZoneAttributes(httpClient *http.Client, args *SetZoneAttributesArgs) (*SetZoneAttributesResponse, error) {
+	return f.SetZoneAttributesContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetZoneAttributesContext(ctx context.Context, httpClient *http.Client, args *SetZoneAttributesArgs) (*SetZoneAttributesResponse, error) {
+	f.record(`SetZoneAttributes`, args)
+	if f.OnSetZoneAttributes != nil {
+		return f.OnSetZoneAttributes(ctx, args)
+	}
+	return &SetZoneAttributesResponse{}, nil
+}
+func (f *Fake) GetZoneAttributes(httpClient *http.Client, args *GetZoneAttributesArgs) (*GetZoneAttributesResponse, error) {
+	return f.GetZoneAttributesContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetZoneAttributesContext(ctx context.Context, httpClient *http.Client, args *GetZoneAttributesArgs) (*GetZoneAttributesResponse, error) {
+	f.record(`GetZoneAttributes`, args)
+	if f.OnGetZoneAttributes != nil {
+		return f.OnGetZoneAttributes(ctx, args)
+	}
+	return &GetZoneAttributesResponse{}, nil
+}
+func (f *Fake) GetHouseholdID(httpClient *http.Client, args *GetHouseholdIDArgs) (*GetHouseholdIDResponse, error) {
+	return f.GetHouseholdIDContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetHouseholdIDContext(ctx context.Context, httpClient *http.Client, args *GetHouseholdIDArgs) (*GetHouseholdIDResponse, error) {
+	f.record(`GetHouseholdID`, args)
+	if f.OnGetHouseholdID != nil {
+		return f.OnGetHouseholdID(ctx, args)
+	}
+	return &GetHouseholdIDResponse{}, nil
+}
+func (f *Fake) GetZoneInfo(httpClient *http.Client, args *GetZoneInfoArgs) (*GetZoneInfoResponse, error) {
+	return f.GetZoneInfoContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetZoneInfoContext(ctx context.Context, httpClient *http.Client, args *GetZoneInfoArgs) (*GetZoneInfoResponse, error) {
+	f.record(`GetZoneInfo`, args)
+	if f.OnGetZoneInfo != nil {
+		return f.OnGetZoneInfo(ctx, args)
+	}
+	return &GetZoneInfoResponse{}, nil
+}
+func (f *Fake) SetAutoplayLinkedZones(httpClient *http.Client, args *SetAutoplayLinkedZonesArgs) (*SetAutoplayLinkedZonesResponse, error) {
+	return f.SetAutoplayLinkedZonesContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetAutoplayLinkedZonesContext(ctx context.Context, httpClient *http.Client, args *SetAutoplayLinkedZonesArgs) (*SetAutoplayLinkedZonesResponse, error) {
+	f.record(`SetAutoplayLinkedZones`, args)
+	if f.OnSetAutoplayLinkedZones != nil {
+		return f.OnSetAutoplayLinkedZones(ctx, args)
+	}
+	return &SetAutoplayLinkedZonesResponse{}, nil
+}
+func (f *Fake) GetAutoplayLinkedZones(httpClient *http.Client, args *GetAutoplayLinkedZonesArgs) (*GetAutoplayLinkedZonesResponse, error) {
+	return f.GetAutoplayLinkedZonesContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetAutoplayLinkedZonesContext(ctx context.Context, httpClient *http.Client, args *GetAutoplayLinkedZonesArgs) (*GetAutoplayLinkedZonesResponse, error) {
+	f.record(`GetAutoplayLinkedZones`, args)
+	if f.OnGetAutoplayLinkedZones != nil {
+		return f.OnGetAutoplayLinkedZones(ctx, args)
+	}
+	return &GetAutoplayLinkedZonesResponse{}, nil
+}
+func (f *Fake) SetAutoplayRoomUUID(httpClient *http.Client, args *SetAutoplayRoomUUIDArgs) (*SetAutoplayRoomUUIDResponse, error) {
+	return f.SetAutoplayRoomUUIDContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetAutoplayRoomUUIDContext(ctx context.Context, httpClient *http.Client, args *SetAutoplayRoomUUIDArgs) (*SetAutoplayRoomUUIDResponse, error) {
+	f.record(`SetAutoplayRoomUUID`, args)
+	if f.OnSetAutoplayRoomUUID != nil {
+		return f.OnSetAutoplayRoomUUID(ctx, args)
+	}
+	return &SetAutoplayRoomUUIDResponse{}, nil
+}
+func (f *Fake) GetAutoplayRoomUUID(httpClient *http.Client, args *GetAutoplayRoomUUIDArgs) (*GetAutoplayRoomUUIDResponse, error) {
+	return f.GetAutoplayRoomUUIDContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetAutoplayRoomUUIDContext(ctx context.Context, httpClient *http.Client, args *GetAutoplayRoomUUIDArgs) (*GetAutoplayRoomUUIDResponse, error) {
+	f.record(`GetAutoplayRoomUUID`, args)
+	if f.OnGetAutoplayRoomUUID != nil {
+		return f.OnGetAutoplayRoomUUID(ctx, args)
+	}
+	return &GetAutoplayRoomUUIDResponse{}, nil
+}
+func (f *Fake) SetAutoplayVolume(httpClient *http.Client, args *SetAutoplayVolumeArgs) (*SetAutoplayVolumeResponse, error) {
+	return f.SetAutoplayVolumeContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetAutoplayVolumeContext(ctx context.Context, httpClient *http.Client, args *SetAutoplayVolumeArgs) (*SetAutoplayVolumeResponse, error) {
+	f.record(`SetAutoplayVolume`, args)
+	if f.OnSetAutoplayVolume != nil {
+		return f.OnSetAutoplayVolume(ctx, args)
+	}
+	return &SetAutoplayVolumeResponse{}, nil
+}
+func (f *Fake) GetAutoplayVolume(httpClient *http.Client, args *GetAutoplayVolumeArgs) (*GetAutoplayVolumeResponse, error) {
+	return f.GetAutoplayVolumeContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetAutoplayVolumeContext(ctx context.Context, httpClient *http.Client, args *GetAutoplayVolumeArgs) (*GetAutoplayVolumeResponse, error) {
+	f.record(`GetAutoplayVolume`, args)
+	if f.OnGetAutoplayVolume != nil {
+		return f.OnGetAutoplayVolume(ctx, args)
+	}
+	return &GetAutoplayVolumeResponse{}, nil
+}
+func (f *Fake) SetUseAutoplayVolume(httpClient *http.Client, args *SetUseAutoplayVolumeArgs) (*SetUseAutoplayVolumeResponse, error) {
+	return f.SetUseAutoplayVolumeContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetUseAutoplayVolumeContext(ctx context.Context, httpClient *http.Client, args *SetUseAutoplayVolumeArgs) (*SetUseAutoplayVolumeResponse, error) {
+	f.record(`SetUseAutoplayVolume`, args)
+	if f.OnSetUseAutoplayVolume != nil {
+		return f.OnSetUseAutoplayVolume(ctx, args)
+	}
+	return &SetUseAutoplayVolumeResponse{}, nil
+}
+func (f *Fake) GetUseAutoplayVolume(httpClient *http.Client, args *GetUseAutoplayVolumeArgs) (*GetUseAutoplayVolumeResponse, error) {
+	return f.GetUseAutoplayVolumeContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetUseAutoplayVolumeContext(ctx context.Context, httpClient *http.Client, args *GetUseAutoplayVolumeArgs) (*GetUseAutoplayVolumeResponse, error) {
+	f.record(`GetUseAutoplayVolume`, args)
+	if f.OnGetUseAutoplayVolume != nil {
+		return f.OnGetUseAutoplayVolume(ctx, args)
+	}
+	return &GetUseAutoplayVolumeResponse{}, nil
+}
+func (f *Fake) AddHTSatellite(httpClient *http.Client, args *AddHTSatelliteArgs) (*AddHTSatelliteResponse, error) {
+	return f.AddHTSatelliteContext(context.Background(), httpClient, args)
+}
+func (f *Fake) AddHTSatelliteContext(ctx context.Context, httpClient *http.Client, args *AddHTSatelliteArgs) (*AddHTSatelliteResponse, error) {
+	f.record(`AddHTSatellite`, args)
+	if f.OnAddHTSatellite != nil {
+		return f.OnAddHTSatellite(ctx, args)
+	}
+	return &AddHTSatelliteResponse{}, nil
+}
+func (f *Fake) RemoveHTSatellite(httpClient *http.Client, args *RemoveHTSatelliteArgs) (*RemoveHTSatelliteResponse, error) {
+	return f.RemoveHTSatelliteContext(context.Background(), httpClient, args)
+}
+func (f *Fake) RemoveHTSatelliteContext(ctx context.Context, httpClient *http.Client, args *RemoveHTSatelliteArgs) (*RemoveHTSatelliteResponse, error) {
+	f.record(`RemoveHTSatellite`, args)
+	if f.OnRemoveHTSatellite != nil {
+		return f.OnRemoveHTSatellite(ctx, args)
+	}
+	return &RemoveHTSatelliteResponse{}, nil
+}
+func (f *Fake) EnterConfigMode(httpClient *http.Client, args *EnterConfigModeArgs) (*EnterConfigModeResponse, error) {
+	return f.EnterConfigModeContext(context.Background(), httpClient, args)
+}
+func (f *Fake) EnterConfigModeContext(ctx context.Context, httpClient *http.Client, args *EnterConfigModeArgs) (*EnterConfigModeResponse, error) {
+	f.record(`EnterConfigMode`, args)
+	if f.OnEnterConfigMode != nil {
+		return f.OnEnterConfigMode(ctx, args)
+	}
+	return &EnterConfigModeResponse{}, nil
+}
+func (f *Fake) ExitConfigMode(httpClient *http.Client, args *ExitConfigModeArgs) (*ExitConfigModeResponse, error) {
+	return f.ExitConfigModeContext(context.Background(), httpClient, args)
+}
+func (f *Fake) ExitConfigModeContext(ctx context.Context, httpClient *http.Client, args *ExitConfigModeArgs) (*ExitConfigModeResponse, error) {
+	f.record(`ExitConfigMode`, args)
+	if f.OnExitConfigMode != nil {
+		return f.OnExitConfigMode(ctx, args)
+	}
+	return &ExitConfigModeResponse{}, nil
+}
+func (f *Fake) GetButtonState(httpClient *http.Client, args *GetButtonStateArgs) (*GetButtonStateResponse, error) {
+	return f.GetButtonStateContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetButtonStateContext(ctx context.Context, httpClient *http.Client, args *GetButtonStateArgs) (*GetButtonStateResponse, error) {
+	f.record(`GetButtonState`, args)
+	if f.OnGetButtonState != nil {
+		return f.OnGetButtonState(ctx, args)
+	}
+	return &GetButtonStateResponse{}, nil
+}
+func (f *Fake) SetButtonLockState(httpClient *http.Client, args *SetButtonLockStateArgs) (*SetButtonLockStateResponse, error) {
+	return f.SetButtonLockStateContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetButtonLockStateContext(ctx context.Context, httpClient *http.Client, args *SetButtonLockStateArgs) (*SetButtonLockStateResponse, error) {
+	f.record(`SetButtonLockState`, args)
+	if f.OnSetButtonLockState != nil {
+		return f.OnSetButtonLockState(ctx, args)
+	}
+	return &SetButtonLockStateResponse{}, nil
+}
+func (f *Fake) GetButtonLockState(httpClient *http.Client, args *GetButtonLockStateArgs) (*GetButtonLockStateResponse, error) {
+	return f.GetButtonLockStateContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetButtonLockStateContext(ctx context.Context, httpClient *http.Client, args *GetButtonLockStateArgs) (*GetButtonLockStateResponse, error) {
+	f.record(`GetButtonLockState`, args)
+	if f.OnGetButtonLockState != nil {
+		return f.OnGetButtonLockState(ctx, args)
+	}
+	return &GetButtonLockStateResponse{}, nil
 }
@@ -1,23 +1,28 @@
 package groupmanagement
 
 import (
-	"bytes"
-	"encoding/xml"
-	"errors"
-	"io/ioutil"
+	"context"
 	"net/http"
 	"net/url"
-)
+	"sync"
 
-const (
-	_ServiceURN     = "urn:schemas-upnp-org:service:GroupManagement:1"
-	_EncodingSchema = "http://schemas.xmlsoap.org/soap/encoding/"
-	_EnvelopeSchema = "http://schemas.xmlsoap.org/soap/envelope/"
+	"github.com/szatmary/sonos/soap"
 )
 
+const _ServiceURN = "urn:schemas-upnp-org:service:GroupManagement:1"
+
+// ErrorCodes describes the error codes specific to this service
+var ErrorCodes = map[int]string{}
+
+func init() {
+	soap.RegisterErrorCodes(_ServiceURN, ErrorCodes)
+}
+
 type Service struct {
 	ControlEndpoint *url.URL
 	EventEndpoint   *url.URL
+	// Client sends the actions, soap.DefaultClient is used if it is nil
+	Client *soap.Client
 }
 
 func NewService(deviceUrl *url.URL) *Service {
@@ -35,68 +40,16 @@ func NewService(deviceUrl *url.URL) *Service {
 	}
 }
 
-type Envelope struct {
-	XMLName       xml.Name `xml:"s:Envelope"`
-	Xmlns         string   `xml:"xmlns:s,attr"`
-	EncodingStyle string   `xml:"s:encodingStyle,attr"`
-	Body          Body     `xml:"s:Body"`
-}
-type Body struct {
-	XMLName                    xml.Name                        `xml:"s:Body"`
-	AddMember                  *AddMemberArgs                  `xml:"u:AddMember,omitempty"`
-	RemoveMember               *RemoveMemberArgs               `xml:"u:RemoveMember,omitempty"`
-	ReportTrackBufferingResult *ReportTrackBufferingResultArgs `xml:"u:ReportTrackBufferingResult,omitempty"`
-	SetSourceAreaIds           *SetSourceAreaIdsArgs           `xml:"u:SetSourceAreaIds,omitempty"`
-}
-type EnvelopeResponse struct {
-	XMLName       xml.Name     `xml:"Envelope"`
-	Xmlns         string       `xml:"xmlns:s,attr"`
-	EncodingStyle string       `xml:"encodingStyle,attr"`
-	Body          BodyResponse `xml:"Body"`
-}
-type BodyResponse struct {
-	XMLName                    xml.Name                            `xml:"Body"`
-	AddMember                  *AddMemberResponse                  `xml:"AddMemberResponse,omitempty"`
-	RemoveMember               *RemoveMemberResponse               `xml:"RemoveMemberResponse,omitempty"`
-	ReportTrackBufferingResult *ReportTrackBufferingResultResponse `xml:"ReportTrackBufferingResultResponse,omitempty"`
-	SetSourceAreaIds           *SetSourceAreaIdsResponse           `xml:"SetSourceAreaIdsResponse,omitempty"`
-}
-
-func (s *Service) exec(actionName string, httpClient *http.Client, envelope *Envelope) (*EnvelopeResponse, error) {
-	marshaled, err := xml.Marshal(envelope)
-	if err != nil {
-		return nil, err
-	}
-	postBody := []byte(`<?xml version="1.0"?>`)
-	postBody = append(postBody, marshaled...)
-	req, err := http.NewRequest(`POST`, s.ControlEndpoint.String(), bytes.NewBuffer(postBody))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set(`Content-Type`, `text/xml; charset="utf-8"`)
-	req.Header.Set(`SOAPAction`, _ServiceURN+`#`+actionName)
-	res, err := httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-	responseBody, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-	var envelopeResponse EnvelopeResponse
-	err = xml.Unmarshal(responseBody, &envelopeResponse)
-	if err != nil {
-		return nil, err
-	}
-	return &envelopeResponse, nil
-}
-
 type AddMemberArgs struct {
-	Xmlns    string `xml:"xmlns:u,attr"`
 	MemberID string `xml:"MemberID"`
 	BootSeq  uint32 `xml:"BootSeq"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *AddMemberArgs) Validate() error {
+	return nil
+}
+
 type AddMemberResponse struct {
 	CurrentTransportSettings string `xml:"CurrentTransportSettings"`
 	CurrentURI               string `xml:"CurrentURI"`
@@ -106,95 +59,174 @@ type AddMemberResponse struct {
 }
 
 func (s *Service) AddMember(httpClient *http.Client, args *AddMemberArgs) (*AddMemberResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`AddMember`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{AddMember: args},
-		})
-	if err != nil {
+	return s.AddMemberContext(context.Background(), httpClient, args)
+}
+func (s *Service) AddMemberContext(ctx context.Context, httpClient *http.Client, args *AddMemberArgs) (*AddMemberResponse, error) {
+	var r AddMemberResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `AddMember`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.AddMember == nil {
-		return nil, errors.New(`unexpected respose from service calling groupmanagement.AddMember()`)
-	}
-
-	return r.Body.AddMember, nil
+	return &r, nil
 }
 
 type RemoveMemberArgs struct {
-	Xmlns    string `xml:"xmlns:u,attr"`
 	MemberID string `xml:"MemberID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *RemoveMemberArgs) Validate() error {
+	return nil
+}
+
 type RemoveMemberResponse struct {
 }
 
 func (s *Service) RemoveMember(httpClient *http.Client, args *RemoveMemberArgs) (*RemoveMemberResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`RemoveMember`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{RemoveMember: args},
-		})
-	if err != nil {
+	return s.RemoveMemberContext(context.Background(), httpClient, args)
+}
+func (s *Service) RemoveMemberContext(ctx context.Context, httpClient *http.Client, args *RemoveMemberArgs) (*RemoveMemberResponse, error) {
+	var r RemoveMemberResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `RemoveMember`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.RemoveMember == nil {
-		return nil, errors.New(`unexpected respose from service calling groupmanagement.RemoveMember()`)
-	}
-
-	return r.Body.RemoveMember, nil
+	return &r, nil
 }
 
 type ReportTrackBufferingResultArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	MemberID   string `xml:"MemberID"`
 	ResultCode int32  `xml:"ResultCode"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *ReportTrackBufferingResultArgs) Validate() error {
+	return nil
+}
+
 type ReportTrackBufferingResultResponse struct {
 }
 
 func (s *Service) ReportTrackBufferingResult(httpClient *http.Client, args *ReportTrackBufferingResultArgs) (*ReportTrackBufferingResultResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`ReportTrackBufferingResult`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{ReportTrackBufferingResult: args},
-		})
-	if err != nil {
+	return s.ReportTrackBufferingResultContext(context.Background(), httpClient, args)
+}
+func (s *Service) ReportTrackBufferingResultContext(ctx context.Context, httpClient *http.Client, args *ReportTrackBufferingResultArgs) (*ReportTrackBufferingResultResponse, error) {
+	var r ReportTrackBufferingResultResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `ReportTrackBufferingResult`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.ReportTrackBufferingResult == nil {
-		return nil, errors.New(`unexpected respose from service calling groupmanagement.ReportTrackBufferingResult()`)
-	}
-
-	return r.Body.ReportTrackBufferingResult, nil
+	return &r, nil
 }
 
 type SetSourceAreaIdsArgs struct {
-	Xmlns                string `xml:"xmlns:u,attr"`
 	DesiredSourceAreaIds string `xml:"DesiredSourceAreaIds"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetSourceAreaIdsArgs) Validate() error {
+	return nil
+}
+
 type SetSourceAreaIdsResponse struct {
 }
 
 func (s *Service) SetSourceAreaIds(httpClient *http.Client, args *SetSourceAreaIdsArgs) (*SetSourceAreaIdsResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetSourceAreaIds`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetSourceAreaIds: args},
-		})
-	if err != nil {
+	return s.SetSourceAreaIdsContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetSourceAreaIdsContext(ctx context.Context, httpClient *http.Client, args *SetSourceAreaIdsArgs) (*SetSourceAreaIdsResponse, error) {
+	var r SetSourceAreaIdsResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetSourceAreaIds`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetSourceAreaIds == nil {
-		return nil, errors.New(`unexpected respose from service calling groupmanagement.SetSourceAreaIds()`)
-	}
+	return &r, nil
+}
+
+// Client is the set of actions of the service. It is implemented by Service and Fake
+type Client interface {
+	AddMember(httpClient *http.Client, args *AddMemberArgs) (*AddMemberResponse, error)
+	AddMemberContext(ctx context.Context, httpClient *http.Client, args *AddMemberArgs) (*AddMemberResponse, error)
+	RemoveMember(httpClient *http.Client, args *RemoveMemberArgs) (*RemoveMemberResponse, error)
+	RemoveMemberContext(ctx context.Context, httpClient *http.Client, args *RemoveMemberArgs) (*RemoveMemberResponse, error)
+	ReportTrackBufferingResult(httpClient *http.Client, args *ReportTrackBufferingResultArgs) (*ReportTrackBufferingResultResponse, error)
+	ReportTrackBufferingResultContext(ctx context.Context, httpClient *http.Client, args *ReportTrackBufferingResultArgs) (*ReportTrackBufferingResultResponse, error)
+	SetSourceAreaIds(httpClient *http.Client, args *SetSourceAreaIdsArgs) (*SetSourceAreaIdsResponse, error)
+	SetSourceAreaIdsContext(ctx context.Context, httpClient *http.Client, args *SetSourceAreaIdsArgs) (*SetSourceAreaIdsResponse, error)
+}
+
+var _ Client = (*Service)(nil)
+var _ Client = (*Fake)(nil)
+
+// Call is an action received by a Fake
+type Call struct {
+	Action string
+	// Args is the *<Action>Args the action was called with
+	Args interface{}
+}
+
+// Fake is an in-memory Client for tests. It records every call, and answers
+// an action with its On<Action> func, or with an empty response if that is nil
+type Fake struct {
+	mu                           sync.Mutex
+	calls                        []Call
+	OnAddMember                  func(ctx context.Context, args *AddMemberArgs) (*AddMemberResponse, error)
+	OnRemoveMember               func(ctx context.Context, args *RemoveMemberArgs) (*RemoveMemberResponse, error)
+	OnReportTrackBufferingResult func(ctx context.Context, args *ReportTrackBufferingResultArgs) (*ReportTrackBufferingResultResponse, error)
+	OnSetSourceAreaIds           func(ctx context.Context, args *SetSourceAreaIdsArgs) (*SetSourceAreaIdsResponse, error)
+}
 
-	return r.Body.SetSourceAreaIds, nil
+// Calls returns the calls received so far, oldest first
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// Reset forgets the calls received so far
+func (f *Fake) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+}
+func (f *Fake) record(action string, args interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{Action: action, Args: args})
+}
+func (f *Fake) AddMember(httpClient *http.Client, args *AddMemberArgs) (*AddMemberResponse, error) {
+	return f.AddMemberContext(context.Background(), httpClient, args)
+}
+func (f *Fake) AddMemberContext(ctx context.Context, httpClient *http.Client, args *AddMemberArgs) (*AddMemberResponse, error) {
+	f.record(`AddMember`, args)
+	if f.OnAddMember != nil {
+		return f.OnAddMember(ctx, args)
+	}
+	return &AddMemberResponse{}, nil
+}
+func (f *Fake) RemoveMember(httpClient *http.Client, args *RemoveMemberArgs) (*RemoveMemberResponse, error) {
+	return f.RemoveMemberContext(context.Background(), httpClient, args)
+}
+func (f *Fake) RemoveMemberContext(ctx context.Context, httpClient *http.Client, args *RemoveMemberArgs) (*RemoveMemberResponse, error) {
+	f.record(`RemoveMember`, args)
+	if f.OnRemoveMember != nil {
+		return f.OnRemoveMember(ctx, args)
+	}
+	return &RemoveMemberResponse{}, nil
+}
+func (f *Fake) ReportTrackBufferingResult(httpClient *http.Client, args *ReportTrackBufferingResultArgs) (*ReportTrackBufferingResultResponse, error) {
+	return f.ReportTrackBufferingResultContext(context.Background(), httpClient, args)
+}
+func (f *Fake) ReportTrackBufferingResultContext(ctx context.Context, httpClient *http.Client, args *ReportTrackBufferingResultArgs) (*ReportTrackBufferingResultResponse, error) {
+	f.record(`ReportTrackBufferingResult`, args)
+	if f.OnReportTrackBufferingResult != nil {
+		return f.OnReportTrackBufferingResult(ctx, args)
+	}
+	return &ReportTrackBufferingResultResponse{}, nil
+}
+func (f *Fake) SetSourceAreaIds(httpClient *http.Client, args *SetSourceAreaIdsArgs) (*SetSourceAreaIdsResponse, error) {
+	return f.SetSourceAreaIdsContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetSourceAreaIdsContext(ctx context.Context, httpClient *http.Client, args *SetSourceAreaIdsArgs) (*SetSourceAreaIdsResponse, error) {
+	f.record(`SetSourceAreaIds`, args)
+	if f.OnSetSourceAreaIds != nil {
+		return f.OnSetSourceAreaIds(ctx, args)
+	}
+	return &SetSourceAreaIdsResponse{}, nil
 }
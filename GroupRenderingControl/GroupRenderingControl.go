@@ -1,23 +1,28 @@
 package grouprenderingcontrol
 
 import (
-	"bytes"
-	"encoding/xml"
-	"errors"
-	"io/ioutil"
+	"context"
 	"net/http"
 	"net/url"
-)
+	"sync"
 
-const (
-	_ServiceURN     = "urn:schemas-upnp-org:service:GroupRenderingControl:1"
-	_EncodingSchema = "http://schemas.xmlsoap.org/soap/encoding/"
-	_EnvelopeSchema = "http://schemas.xmlsoap.org/soap/envelope/"
+	"github.com/szatmary/sonos/soap"
 )
 
+const _ServiceURN = "urn:schemas-upnp-org:service:GroupRenderingControl:1"
+
+// ErrorCodes describes the error codes specific to this service
+var ErrorCodes = map[int]string{}
+
+func init() {
+	soap.RegisterErrorCodes(_ServiceURN, ErrorCodes)
+}
+
 type Service struct {
 	ControlEndpoint *url.URL
 	EventEndpoint   *url.URL
+	// Client sends the actions, soap.DefaultClient is used if it is nil
+	Client *soap.Client
 }
 
 func NewService(deviceUrl *url.URL) *Service {
@@ -35,220 +40,268 @@ func NewService(deviceUrl *url.URL) *Service {
 	}
 }
 
-type Envelope struct {
-	XMLName       xml.Name `xml:"s:Envelope"`
-	Xmlns         string   `xml:"xmlns:s,attr"`
-	EncodingStyle string   `xml:"s:encodingStyle,attr"`
-	Body          Body     `xml:"s:Body"`
-}
-type Body struct {
-	XMLName                xml.Name                    `xml:"s:Body"`
-	GetGroupMute           *GetGroupMuteArgs           `xml:"u:GetGroupMute,omitempty"`
-	SetGroupMute           *SetGroupMuteArgs           `xml:"u:SetGroupMute,omitempty"`
-	GetGroupVolume         *GetGroupVolumeArgs         `xml:"u:GetGroupVolume,omitempty"`
-	SetGroupVolume         *SetGroupVolumeArgs         `xml:"u:SetGroupVolume,omitempty"`
-	SetRelativeGroupVolume *SetRelativeGroupVolumeArgs `xml:"u:SetRelativeGroupVolume,omitempty"`
-	SnapshotGroupVolume    *SnapshotGroupVolumeArgs    `xml:"u:SnapshotGroupVolume,omitempty"`
-}
-type EnvelopeResponse struct {
-	XMLName       xml.Name     `xml:"Envelope"`
-	Xmlns         string       `xml:"xmlns:s,attr"`
-	EncodingStyle string       `xml:"encodingStyle,attr"`
-	Body          BodyResponse `xml:"Body"`
-}
-type BodyResponse struct {
-	XMLName                xml.Name                        `xml:"Body"`
-	GetGroupMute           *GetGroupMuteResponse           `xml:"GetGroupMuteResponse,omitempty"`
-	SetGroupMute           *SetGroupMuteResponse           `xml:"SetGroupMuteResponse,omitempty"`
-	GetGroupVolume         *GetGroupVolumeResponse         `xml:"GetGroupVolumeResponse,omitempty"`
-	SetGroupVolume         *SetGroupVolumeResponse         `xml:"SetGroupVolumeResponse,omitempty"`
-	SetRelativeGroupVolume *SetRelativeGroupVolumeResponse `xml:"SetRelativeGroupVolumeResponse,omitempty"`
-	SnapshotGroupVolume    *SnapshotGroupVolumeResponse    `xml:"SnapshotGroupVolumeResponse,omitempty"`
-}
-
-func (s *Service) exec(actionName string, httpClient *http.Client, envelope *Envelope) (*EnvelopeResponse, error) {
-	marshaled, err := xml.Marshal(envelope)
-	if err != nil {
-		return nil, err
-	}
-	postBody := []byte(`<?xml version="1.0"?>`)
-	postBody = append(postBody, marshaled...)
-	req, err := http.NewRequest(`POST`, s.ControlEndpoint.String(), bytes.NewBuffer(postBody))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set(`Content-Type`, `text/xml; charset="utf-8"`)
-	req.Header.Set(`SOAPAction`, _ServiceURN+`#`+actionName)
-	res, err := httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-	responseBody, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-	var envelopeResponse EnvelopeResponse
-	err = xml.Unmarshal(responseBody, &envelopeResponse)
-	if err != nil {
-		return nil, err
-	}
-	return &envelopeResponse, nil
-}
-
 type GetGroupMuteArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetGroupMuteArgs) Validate() error {
+	return nil
+}
+
 type GetGroupMuteResponse struct {
 	CurrentMute bool `xml:"CurrentMute"`
 }
 
 func (s *Service) GetGroupMute(httpClient *http.Client, args *GetGroupMuteArgs) (*GetGroupMuteResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetGroupMute`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetGroupMute: args},
-		})
-	if err != nil {
+	return s.GetGroupMuteContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetGroupMuteContext(ctx context.Context, httpClient *http.Client, args *GetGroupMuteArgs) (*GetGroupMuteResponse, error) {
+	var r GetGroupMuteResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetGroupMute`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetGroupMute == nil {
-		return nil, errors.New(`unexpected respose from service calling grouprenderingcontrol.GetGroupMute()`)
-	}
-
-	return r.Body.GetGroupMute, nil
+	return &r, nil
 }
 
 type SetGroupMuteArgs struct {
-	Xmlns       string `xml:"xmlns:u,attr"`
 	InstanceID  uint32 `xml:"InstanceID"`
 	DesiredMute bool   `xml:"DesiredMute"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetGroupMuteArgs) Validate() error {
+	return nil
+}
+
 type SetGroupMuteResponse struct {
 }
 
 func (s *Service) SetGroupMute(httpClient *http.Client, args *SetGroupMuteArgs) (*SetGroupMuteResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetGroupMute`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetGroupMute: args},
-		})
-	if err != nil {
+	return s.SetGroupMuteContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetGroupMuteContext(ctx context.Context, httpClient *http.Client, args *SetGroupMuteArgs) (*SetGroupMuteResponse, error) {
+	var r SetGroupMuteResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetGroupMute`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetGroupMute == nil {
-		return nil, errors.New(`unexpected respose from service calling grouprenderingcontrol.SetGroupMute()`)
-	}
-
-	return r.Body.SetGroupMute, nil
+	return &r, nil
 }
 
 type GetGroupVolumeArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetGroupVolumeArgs) Validate() error {
+	return nil
+}
+
 type GetGroupVolumeResponse struct {
 	CurrentVolume uint16 `xml:"CurrentVolume"`
 }
 
 func (s *Service) GetGroupVolume(httpClient *http.Client, args *GetGroupVolumeArgs) (*GetGroupVolumeResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetGroupVolume`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetGroupVolume: args},
-		})
-	if err != nil {
+	return s.GetGroupVolumeContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetGroupVolumeContext(ctx context.Context, httpClient *http.Client, args *GetGroupVolumeArgs) (*GetGroupVolumeResponse, error) {
+	var r GetGroupVolumeResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetGroupVolume`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetGroupVolume == nil {
-		return nil, errors.New(`unexpected respose from service calling grouprenderingcontrol.GetGroupVolume()`)
-	}
-
-	return r.Body.GetGroupVolume, nil
+	return &r, nil
 }
 
 type SetGroupVolumeArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	// Allowed Range: 0 -> 100 step: 1
 	DesiredVolume uint16 `xml:"DesiredVolume"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetGroupVolumeArgs) Validate() error {
+	if a.DesiredVolume > 100 {
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `SetGroupVolume`, Argument: `DesiredVolume`, Value: a.DesiredVolume, Allowed: "0 to 100"}
+	}
+	return nil
+}
+
 type SetGroupVolumeResponse struct {
 }
 
 func (s *Service) SetGroupVolume(httpClient *http.Client, args *SetGroupVolumeArgs) (*SetGroupVolumeResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetGroupVolume`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetGroupVolume: args},
-		})
-	if err != nil {
+	return s.SetGroupVolumeContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetGroupVolumeContext(ctx context.Context, httpClient *http.Client, args *SetGroupVolumeArgs) (*SetGroupVolumeResponse, error) {
+	var r SetGroupVolumeResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetGroupVolume`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetGroupVolume == nil {
-		return nil, errors.New(`unexpected respose from service calling grouprenderingcontrol.SetGroupVolume()`)
-	}
-
-	return r.Body.SetGroupVolume, nil
+	return &r, nil
 }
 
 type SetRelativeGroupVolumeArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	Adjustment int32  `xml:"Adjustment"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetRelativeGroupVolumeArgs) Validate() error {
+	return nil
+}
+
 type SetRelativeGroupVolumeResponse struct {
 	NewVolume uint16 `xml:"NewVolume"`
 }
 
 func (s *Service) SetRelativeGroupVolume(httpClient *http.Client, args *SetRelativeGroupVolumeArgs) (*SetRelativeGroupVolumeResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetRelativeGroupVolume`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetRelativeGroupVolume: args},
-		})
-	if err != nil {
+	return s.SetRelativeGroupVolumeContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetRelativeGroupVolumeContext(ctx context.Context, httpClient *http.Client, args *SetRelativeGroupVolumeArgs) (*SetRelativeGroupVolumeResponse, error) {
+	var r SetRelativeGroupVolumeResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetRelativeGroupVolume`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetRelativeGroupVolume == nil {
-		return nil, errors.New(`unexpected respose from service calling grouprenderingcontrol.SetRelativeGroupVolume()`)
-	}
-
-	return r.Body.SetRelativeGroupVolume, nil
+	return &r, nil
 }
 
 type SnapshotGroupVolumeArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SnapshotGroupVolumeArgs) Validate() error {
+	return nil
+}
+
 type SnapshotGroupVolumeResponse struct {
 }
 
 func (s *Service) SnapshotGroupVolume(httpClient *http.Client, args *SnapshotGroupVolumeArgs) (*SnapshotGroupVolumeResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SnapshotGroupVolume`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SnapshotGroupVolume: args},
-		})
-	if err != nil {
+	return s.SnapshotGroupVolumeContext(context.Background(), httpClient, args)
+}
+func (s *Service) SnapshotGroupVolumeContext(ctx context.Context, httpClient *http.Client, args *SnapshotGroupVolumeArgs) (*SnapshotGroupVolumeResponse, error) {
+	var r SnapshotGroupVolumeResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SnapshotGroupVolume`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SnapshotGroupVolume == nil {
-		return nil, errors.New(`unexpected respose from service calling grouprenderingcontrol.SnapshotGroupVolume()`)
-	}
+	return &r, nil
+}
+
+// Client is the set of actions of the service. It is implemented by Service and Fake
+type Client interface {
+	GetGroupMute(httpClient *http.Client, args *GetGroupMuteArgs) (*GetGroupMuteResponse, error)
+	GetGroupMuteContext(ctx context.Context, httpClient *http.Client, args *GetGroupMuteArgs) (*GetGroupMuteResponse, error)
+	SetGroupMute(httpClient *http.Client, args *SetGroupMuteArgs) (*SetGroupMuteResponse, error)
+	SetGroupMuteContext(ctx context.Context, httpClient *http.Client, args *SetGroupMuteArgs) (*SetGroupMuteResponse, error)
+	GetGroupVolume(httpClient *http.Client, args *GetGroupVolumeArgs) (*GetGroupVolumeResponse, error)
+	GetGroupVolumeContext(ctx context.Context, httpClient *http.Client, args *GetGroupVolumeArgs) (*GetGroupVolumeResponse, error)
+	SetGroupVolume(httpClient *http.Client, args *SetGroupVolumeArgs) (*SetGroupVolumeResponse, error)
+	SetGroupVolumeContext(ctx context.Context, httpClient *http.Client, args *SetGroupVolumeArgs) (*SetGroupVolumeResponse, error)
+	SetRelativeGroupVolume(httpClient *http.Client, args *SetRelativeGroupVolumeArgs) (*SetRelativeGroupVolumeResponse, error)
+	SetRelativeGroupVolumeContext(ctx context.Context, httpClient *http.Client, args *SetRelativeGroupVolumeArgs) (*SetRelativeGroupVolumeResponse, error)
+	SnapshotGroupVolume(httpClient *http.Client, args *SnapshotGroupVolumeArgs) (*SnapshotGroupVolumeResponse, error)
+	SnapshotGroupVolumeContext(ctx context.Context, httpClient *http.Client, args *SnapshotGroupVolumeArgs) (*SnapshotGroupVolumeResponse, error)
+}
+
+var _ Client = (*Service)(nil)
+var _ Client = (*Fake)(nil)
 
-	return r.Body.SnapshotGroupVolume, nil
+// Call is an action received by a Fake
+type Call struct {
+	Action string
+	// Args is the *<Action>Args the action was called with
+	Args interface{}
+}
+
+// Fake is an in-memory Client for tests. It records every call, and answers
+// an action with its On<Action> func, or with an empty response if that is nil
+type Fake struct {
+	mu                       sync.Mutex
+	calls                    []Call
+	OnGetGroupMute           func(ctx context.Context, args *GetGroupMuteArgs) (*GetGroupMuteResponse, error)
+	OnSetGroupMute           func(ctx context.Context, args *SetGroupMuteArgs) (*SetGroupMuteResponse, error)
+	OnGetGroupVolume         func(ctx context.Context, args *GetGroupVolumeArgs) (*GetGroupVolumeResponse, error)
+	OnSetGroupVolume         func(ctx context.Context, args *SetGroupVolumeArgs) (*SetGroupVolumeResponse, error)
+	OnSetRelativeGroupVolume func(ctx context.Context, args *SetRelativeGroupVolumeArgs) (*SetRelativeGroupVolumeResponse, error)
+	OnSnapshotGroupVolume    func(ctx context.Context, args *SnapshotGroupVolumeArgs) (*SnapshotGroupVolumeResponse, error)
+}
+
+// Calls returns the calls received so far, oldest first
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// Reset forgets the calls received so far
+func (f *Fake) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+}
+func (f *Fake) record(action string, args interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{Action: action, Args: args})
+}
+func (f *Fake) GetGroupMute(httpClient *http.Client, args *GetGroupMuteArgs) (*GetGroupMuteResponse, error) {
+	return f.GetGroupMuteContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetGroupMuteContext(ctx context.Context, httpClient *http.Client, args *GetGroupMuteArgs) (*GetGroupMuteResponse, error) {
+	f.record(`GetGroupMute`, args)
+	if f.OnGetGroupMute != nil {
+		return f.OnGetGroupMute(ctx, args)
+	}
+	return &GetGroupMuteResponse{}, nil
+}
+func (f *Fake) SetGroupMute(httpClient *http.Client, args *SetGroupMuteArgs) (*SetGroupMuteResponse, error) {
+	return f.SetGroupMuteContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetGroupMuteContext(ctx context.Context, httpClient *http.Client, args *SetGroupMuteArgs) (*SetGroupMuteResponse, error) {
+	f.record(`SetGroupMute`, args)
+	if f.OnSetGroupMute != nil {
+		return f.OnSetGroupMute(ctx, args)
+	}
+	return &SetGroupMuteResponse{}, nil
+}
+func (f *Fake) GetGroupVolume(httpClient *http.Client, args *GetGroupVolumeArgs) (*GetGroupVolumeResponse, error) {
+	return f.GetGroupVolumeContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetGroupVolumeContext(ctx context.Context, httpClient *http.Client, args *GetGroupVolumeArgs) (*GetGroupVolumeResponse, error) {
+	f.record(`GetGroupVolume`, args)
+	if f.OnGetGroupVolume != nil {
+		return f.OnGetGroupVolume(ctx, args)
+	}
+	return &GetGroupVolumeResponse{}, nil
+}
+func (f *Fake) SetGroupVolume(httpClient *http.Client, args *SetGroupVolumeArgs) (*SetGroupVolumeResponse, error) {
+	return f.SetGroupVolumeContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetGroupVolumeContext(ctx context.Context, httpClient *http.Client, args *SetGroupVolumeArgs) (*SetGroupVolumeResponse, error) {
+	f.record(`SetGroupVolume`, args)
+	if f.OnSetGroupVolume != nil {
+		return f.OnSetGroupVolume(ctx, args)
+	}
+	return &SetGroupVolumeResponse{}, nil
+}
+func (f *Fake) SetRelativeGroupVolume(httpClient *http.Client, args *SetRelativeGroupVolumeArgs) (*SetRelativeGroupVolumeResponse, error) {
+	return f.SetRelativeGroupVolumeContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetRelativeGroupVolumeContext(ctx context.Context, httpClient *http.Client, args *SetRelativeGroupVolumeArgs) (*SetRelativeGroupVolumeResponse, error) {
+	f.record(`SetRelativeGroupVolume`, args)
+	if f.OnSetRelativeGroupVolume != nil {
+		return f.OnSetRelativeGroupVolume(ctx, args)
+	}
+	return &SetRelativeGroupVolumeResponse{}, nil
+}
+func (f *Fake) SnapshotGroupVolume(httpClient *http.Client, args *SnapshotGroupVolumeArgs) (*SnapshotGroupVolumeResponse, error) {
+	return f.SnapshotGroupVolumeContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SnapshotGroupVolumeContext(ctx context.Context, httpClient *http.Client, args *SnapshotGroupVolumeArgs) (*SnapshotGroupVolumeResponse, error) {
+	f.record(`SnapshotGroupVolume`, args)
+	if f.OnSnapshotGroupVolume != nil {
+		return f.OnSnapshotGroupVolume(ctx, args)
+	}
+	return &SnapshotGroupVolumeResponse{}, nil
 }
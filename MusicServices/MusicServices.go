@@ -1,23 +1,28 @@
 package musicservices
 
 import (
-	"bytes"
-	"encoding/xml"
-	"errors"
-	"io/ioutil"
+	"context"
 	"net/http"
 	"net/url"
-)
+	"sync"
 
-const (
-	_ServiceURN     = "urn:schemas-upnp-org:service:MusicServices:1"
-	_EncodingSchema = "http://schemas.xmlsoap.org/soap/encoding/"
-	_EnvelopeSchema = "http://schemas.xmlsoap.org/soap/envelope/"
+	"github.com/szatmary/sonos/soap"
 )
 
+const _ServiceURN = "urn:schemas-upnp-org:service:MusicServices:1"
+
+// ErrorCodes describes the error codes specific to this service
+var ErrorCodes = map[int]string{}
+
+func init() {
+	soap.RegisterErrorCodes(_ServiceURN, ErrorCodes)
+}
+
 type Service struct {
 	ControlEndpoint *url.URL
 	EventEndpoint   *url.URL
+	// Client sends the actions, soap.DefaultClient is used if it is nil
+	Client *soap.Client
 }
 
 func NewService(deviceUrl *url.URL) *Service {
@@ -35,91 +40,39 @@ func NewService(deviceUrl *url.URL) *Service {
 	}
 }
 
-type Envelope struct {
-	XMLName       xml.Name `xml:"s:Envelope"`
-	Xmlns         string   `xml:"xmlns:s,attr"`
-	EncodingStyle string   `xml:"s:encodingStyle,attr"`
-	Body          Body     `xml:"s:Body"`
-}
-type Body struct {
-	XMLName                 xml.Name                     `xml:"s:Body"`
-	GetSessionId            *GetSessionIdArgs            `xml:"u:GetSessionId,omitempty"`
-	ListAvailableServices   *ListAvailableServicesArgs   `xml:"u:ListAvailableServices,omitempty"`
-	UpdateAvailableServices *UpdateAvailableServicesArgs `xml:"u:UpdateAvailableServices,omitempty"`
-}
-type EnvelopeResponse struct {
-	XMLName       xml.Name     `xml:"Envelope"`
-	Xmlns         string       `xml:"xmlns:s,attr"`
-	EncodingStyle string       `xml:"encodingStyle,attr"`
-	Body          BodyResponse `xml:"Body"`
-}
-type BodyResponse struct {
-	XMLName                 xml.Name                         `xml:"Body"`
-	GetSessionId            *GetSessionIdResponse            `xml:"GetSessionIdResponse,omitempty"`
-	ListAvailableServices   *ListAvailableServicesResponse   `xml:"ListAvailableServicesResponse,omitempty"`
-	UpdateAvailableServices *UpdateAvailableServicesResponse `xml:"UpdateAvailableServicesResponse,omitempty"`
-}
-
-func (s *Service) exec(actionName string, httpClient *http.Client, envelope *Envelope) (*EnvelopeResponse, error) {
-	marshaled, err := xml.Marshal(envelope)
-	if err != nil {
-		return nil, err
-	}
-	postBody := []byte(`<?xml version="1.0"?>`)
-	postBody = append(postBody, marshaled...)
-	req, err := http.NewRequest(`POST`, s.ControlEndpoint.String(), bytes.NewBuffer(postBody))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set(`Content-Type`, `text/xml; charset="utf-8"`)
-	req.Header.Set(`SOAPAction`, _ServiceURN+`#`+actionName)
-	res, err := httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-	responseBody, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-	var envelopeResponse EnvelopeResponse
-	err = xml.Unmarshal(responseBody, &envelopeResponse)
-	if err != nil {
-		return nil, err
-	}
-	return &envelopeResponse, nil
-}
-
 type GetSessionIdArgs struct {
-	Xmlns     string `xml:"xmlns:u,attr"`
 	ServiceId uint32 `xml:"ServiceId"`
 	Username  string `xml:"Username"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetSessionIdArgs) Validate() error {
+	return nil
+}
+
 type GetSessionIdResponse struct {
 	SessionId string `xml:"SessionId"`
 }
 
 func (s *Service) GetSessionId(httpClient *http.Client, args *GetSessionIdArgs) (*GetSessionIdResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetSessionId`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetSessionId: args},
-		})
-	if err != nil {
+	return s.GetSessionIdContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetSessionIdContext(ctx context.Context, httpClient *http.Client, args *GetSessionIdArgs) (*GetSessionIdResponse, error) {
+	var r GetSessionIdResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetSessionId`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetSessionId == nil {
-		return nil, errors.New(`unexpected respose from service calling musicservices.GetSessionId()`)
-	}
-
-	return r.Body.GetSessionId, nil
+	return &r, nil
 }
 
 type ListAvailableServicesArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *ListAvailableServicesArgs) Validate() error {
+	return nil
+}
+
 type ListAvailableServicesResponse struct {
 	AvailableServiceDescriptorList string `xml:"AvailableServiceDescriptorList"`
 	AvailableServiceTypeList       string `xml:"AvailableServiceTypeList"`
@@ -127,43 +80,113 @@ type ListAvailableServicesResponse struct {
 }
 
 func (s *Service) ListAvailableServices(httpClient *http.Client, args *ListAvailableServicesArgs) (*ListAvailableServicesResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`ListAvailableServices`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{ListAvailableServices: args},
-		})
-	if err != nil {
+	return s.ListAvailableServicesContext(context.Background(), httpClient, args)
+}
+func (s *Service) ListAvailableServicesContext(ctx context.Context, httpClient *http.Client, args *ListAvailableServicesArgs) (*ListAvailableServicesResponse, error) {
+	var r ListAvailableServicesResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `ListAvailableServices`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.ListAvailableServices == nil {
-		return nil, errors.New(`unexpected respose from service calling musicservices.ListAvailableServices()`)
-	}
-
-	return r.Body.ListAvailableServices, nil
+	return &r, nil
 }
 
 type UpdateAvailableServicesArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *UpdateAvailableServicesArgs) Validate() error {
+	return nil
+}
+
 type UpdateAvailableServicesResponse struct {
 }
 
 func (s *Service) UpdateAvailableServices(httpClient *http.Client, args *UpdateAvailableServicesArgs) (*UpdateAvailableServicesResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`UpdateAvailableServices`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{UpdateAvailableServices: args},
-		})
-	if err != nil {
+	return s.UpdateAvailableServicesContext(context.Background(), httpClient, args)
+}
+func (s *Service) UpdateAvailableServicesContext(ctx context.Context, httpClient *http.Client, args *UpdateAvailableServicesArgs) (*UpdateAvailableServicesResponse, error) {
+	var r UpdateAvailableServicesResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `UpdateAvailableServices`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.UpdateAvailableServices == nil {
-		return nil, errors.New(`unexpected respose from service calling musicservices.UpdateAvailableServices()`)
-	}
+	return &r, nil
+}
+
+// Client is the set of actions of the service. It is implemented by Service and Fake
+type Client interface {
+	GetSessionId(httpClient *http.Client, args *GetSessionIdArgs) (*GetSessionIdResponse, error)
+	GetSessionIdContext(ctx context.Context, httpClient *http.Client, args *GetSessionIdArgs) (*GetSessionIdResponse, error)
+	ListAvailableServices(httpClient *http.Client, args *ListAvailableServicesArgs) (*ListAvailableServicesResponse, error)
+	ListAvailableServicesContext(ctx context.Context, httpClient *http.Client, args *ListAvailableServicesArgs) (*ListAvailableServicesResponse, error)
+	UpdateAvailableServices(httpClient *http.Client, args *UpdateAvailableServicesArgs) (*UpdateAvailableServicesResponse, error)
+	UpdateAvailableServicesContext(ctx context.Context, httpClient *http.Client, args *UpdateAvailableServicesArgs) (*UpdateAvailableServicesResponse, error)
+}
 
-	return r.Body.UpdateAvailableServices, nil
+var _ Client = (*Service)(nil)
+var _ Client = (*Fake)(nil)
+
+// Call is an action received by a Fake
+type Call struct {
+	Action string
+	// Args is the *<Action>Args the action was called with
+	Args interface{}
+}
+
+// Fake is an in-memory Client for tests. It records every call, and answers
+// an action with its On<Action> func, or with an empty response if that is nil
+type Fake struct {
+	mu                        sync.Mutex
+	calls                     []Call
+	OnGetSessionId            func(ctx context.Context, args *GetSessionIdArgs) (*GetSessionIdResponse, error)
+	OnListAvailableServices   func(ctx context.Context, args *ListAvailableServicesArgs) (*ListAvailableServicesResponse, error)
+	OnUpdateAvailableServices func(ctx context.Context, args *UpdateAvailableServicesArgs) (*UpdateAvailableServicesResponse, error)
+}
+
+// Calls returns the calls received so far, oldest first
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// Reset forgets the calls received so far
+func (f *Fake) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+}
+func (f *Fake) record(action string, args interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{Action: action, Args: args})
+}
+func (f *Fake) GetSessionId(httpClient *http.Client, args *GetSessionIdArgs) (*GetSessionIdResponse, error) {
+	return f.GetSessionIdContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetSessionIdContext(ctx context.Context, httpClient *http.Client, args *GetSessionIdArgs) (*GetSessionIdResponse, error) {
+	f.record(`GetSessionId`, args)
+	if f.OnGetSessionId != nil {
+		return f.OnGetSessionId(ctx, args)
+	}
+	return &GetSessionIdResponse{}, nil
+}
+func (f *Fake) ListAvailableServices(httpClient *http.Client, args *ListAvailableServicesArgs) (*ListAvailableServicesResponse, error) {
+	return f.ListAvailableServicesContext(context.Background(), httpClient, args)
+}
+func (f *Fake) ListAvailableServicesContext(ctx context.Context, httpClient *http.Client, args *ListAvailableServicesArgs) (*ListAvailableServicesResponse, error) {
+	f.record(`ListAvailableServices`, args)
+	if f.OnListAvailableServices != nil {
+		return f.OnListAvailableServices(ctx, args)
+	}
+	return &ListAvailableServicesResponse{}, nil
+}
+func (f *Fake) UpdateAvailableServices(httpClient *http.Client, args *UpdateAvailableServicesArgs) (*UpdateAvailableServicesResponse, error) {
+	return f.UpdateAvailableServicesContext(context.Background(), httpClient, args)
+}
+func (f *Fake) UpdateAvailableServicesContext(ctx context.Context, httpClient *http.Client, args *UpdateAvailableServicesArgs) (*UpdateAvailableServicesResponse, error) {
+	f.record(`UpdateAvailableServices`, args)
+	if f.OnUpdateAvailableServices != nil {
+		return f.OnUpdateAvailableServices(ctx, args)
+	}
+	return &UpdateAvailableServicesResponse{}, nil
 }
@@ -1,23 +1,28 @@
 package qplay
 
 import (
-	"bytes"
-	"encoding/xml"
-	"errors"
-	"io/ioutil"
+	"context"
 	"net/http"
 	"net/url"
-)
+	"sync"
 
-const (
-	_ServiceURN     = "urn:schemas-upnp-org:service:QPlay:1"
-	_EncodingSchema = "http://schemas.xmlsoap.org/soap/encoding/"
-	_EnvelopeSchema = "http://schemas.xmlsoap.org/soap/envelope/"
+	"github.com/szatmary/sonos/soap"
 )
 
+const _ServiceURN = "urn:schemas-tencent-com:service:QPlay:1"
+
+// ErrorCodes describes the error codes specific to this service
+var ErrorCodes = map[int]string{}
+
+func init() {
+	soap.RegisterErrorCodes(_ServiceURN, ErrorCodes)
+}
+
 type Service struct {
 	ControlEndpoint *url.URL
 	EventEndpoint   *url.URL
+	// Client sends the actions, soap.DefaultClient is used if it is nil
+	Client *soap.Client
 }
 
 func NewService(deviceUrl *url.URL) *Service {
@@ -35,61 +40,15 @@ func NewService(deviceUrl *url.URL) *Service {
 	}
 }
 
-type Envelope struct {
-	XMLName       xml.Name `xml:"s:Envelope"`
-	Xmlns         string   `xml:"xmlns:s,attr"`
-	EncodingStyle string   `xml:"s:encodingStyle,attr"`
-	Body          Body     `xml:"s:Body"`
-}
-type Body struct {
-	XMLName   xml.Name       `xml:"s:Body"`
-	QPlayAuth *QPlayAuthArgs `xml:"u:QPlayAuth,omitempty"`
-}
-type EnvelopeResponse struct {
-	XMLName       xml.Name     `xml:"Envelope"`
-	Xmlns         string       `xml:"xmlns:s,attr"`
-	EncodingStyle string       `xml:"encodingStyle,attr"`
-	Body          BodyResponse `xml:"Body"`
-}
-type BodyResponse struct {
-	XMLName   xml.Name           `xml:"Body"`
-	QPlayAuth *QPlayAuthResponse `xml:"QPlayAuthResponse,omitempty"`
+type QPlayAuthArgs struct {
+	Seed string `xml:"Seed"`
 }
 
-func (s *Service) exec(actionName string, httpClient *http.Client, envelope *Envelope) (*EnvelopeResponse, error) {
-	marshaled, err := xml.Marshal(envelope)
-	if err != nil {
-		return nil, err
-	}
-	postBody := []byte(`<?xml version="1.0"?>`)
-	postBody = append(postBody, marshaled...)
-	req, err := http.NewRequest(`POST`, s.ControlEndpoint.String(), bytes.NewBuffer(postBody))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set(`Content-Type`, `text/xml; charset="utf-8"`)
-	req.Header.Set(`SOAPAction`, _ServiceURN+`#`+actionName)
-	res, err := httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-	responseBody, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-	var envelopeResponse EnvelopeResponse
-	err = xml.Unmarshal(responseBody, &envelopeResponse)
-	if err != nil {
-		return nil, err
-	}
-	return &envelopeResponse, nil
+// Validate checks the arguments against the values and ranges the service allows
+func (a *QPlayAuthArgs) Validate() error {
+	return nil
 }
 
-type QPlayAuthArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
-	Seed  string `xml:"Seed"`
-}
 type QPlayAuthResponse struct {
 	Code string `xml:"Code"`
 	MID  string `xml:"MID"`
@@ -97,19 +56,65 @@ type QPlayAuthResponse struct {
 }
 
 func (s *Service) QPlayAuth(httpClient *http.Client, args *QPlayAuthArgs) (*QPlayAuthResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`QPlayAuth`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{QPlayAuth: args},
-		})
-	if err != nil {
+	return s.QPlayAuthContext(context.Background(), httpClient, args)
+}
+func (s *Service) QPlayAuthContext(ctx context.Context, httpClient *http.Client, args *QPlayAuthArgs) (*QPlayAuthResponse, error) {
+	var r QPlayAuthResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `QPlayAuth`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.QPlayAuth == nil {
-		return nil, errors.New(`unexpected respose from service calling qplay.QPlayAuth()`)
-	}
+	return &r, nil
+}
+
+// Client is the set of actions of the service. It is implemented by Service and Fake
+type Client interface {
+	QPlayAuth(httpClient *http.Client, args *QPlayAuthArgs) (*QPlayAuthResponse, error)
+	QPlayAuthContext(ctx context.Context, httpClient *http.Client, args *QPlayAuthArgs) (*QPlayAuthResponse, error)
+}
+
+var _ Client = (*Service)(nil)
+var _ Client = (*Fake)(nil)
+
+// Call is an action received by a Fake
+type Call struct {
+	Action string
+	// Args is the *<Action>Args the action was called with
+	Args interface{}
+}
+
+// Fake is an in-memory Client for tests. It records every call, and answers
+// an action with its On<Action> func, or with an empty response if that is nil
+type Fake struct {
+	mu          sync.Mutex
+	calls       []Call
+	OnQPlayAuth func(ctx context.Context, args *QPlayAuthArgs) (*QPlayAuthResponse, error)
+}
+
+// Calls returns the calls received so far, oldest first
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
 
-	return r.Body.QPlayAuth, nil
+// Reset forgets the calls received so far
+func (f *Fake) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+}
+func (f *Fake) record(action string, args interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{Action: action, Args: args})
+}
+func (f *Fake) QPlayAuth(httpClient *http.Client, args *QPlayAuthArgs) (*QPlayAuthResponse, error) {
+	return f.QPlayAuthContext(context.Background(), httpClient, args)
+}
+func (f *Fake) QPlayAuthContext(ctx context.Context, httpClient *http.Client, args *QPlayAuthArgs) (*QPlayAuthResponse, error) {
+	f.record(`QPlayAuth`, args)
+	if f.OnQPlayAuth != nil {
+		return f.OnQPlayAuth(ctx, args)
+	}
+	return &QPlayAuthResponse{}, nil
 }
@@ -1,23 +1,30 @@
 package queue
 
 import (
-	"bytes"
-	"encoding/xml"
-	"errors"
-	"io/ioutil"
+	"context"
 	"net/http"
 	"net/url"
-)
+	"sync"
 
-const (
-	_ServiceURN     = "urn:schemas-upnp-org:service:Queue:1"
-	_EncodingSchema = "http://schemas.xmlsoap.org/soap/encoding/"
-	_EnvelopeSchema = "http://schemas.xmlsoap.org/soap/envelope/"
+	"github.com/szatmary/sonos/soap"
 )
 
+const _ServiceURN = "urn:schemas-sonos-com:service:Queue:1"
+
+// ErrorCodes describes the error codes specific to this service
+var ErrorCodes = map[int]string{
+	800: "Command not supported by this player, it may not be the group coordinator",
+}
+
+func init() {
+	soap.RegisterErrorCodes(_ServiceURN, ErrorCodes)
+}
+
 type Service struct {
 	ControlEndpoint *url.URL
 	EventEndpoint   *url.URL
+	// Client sends the actions, soap.DefaultClient is used if it is nil
+	Client *soap.Client
 }
 
 func NewService(deviceUrl *url.URL) *Service {
@@ -35,79 +42,7 @@ func NewService(deviceUrl *url.URL) *Service {
 	}
 }
 
-type Envelope struct {
-	XMLName       xml.Name `xml:"s:Envelope"`
-	Xmlns         string   `xml:"xmlns:s,attr"`
-	EncodingStyle string   `xml:"s:encodingStyle,attr"`
-	Body          Body     `xml:"s:Body"`
-}
-type Body struct {
-	XMLName             xml.Name                 `xml:"s:Body"`
-	AddURI              *AddURIArgs              `xml:"u:AddURI,omitempty"`
-	AddMultipleURIs     *AddMultipleURIsArgs     `xml:"u:AddMultipleURIs,omitempty"`
-	AttachQueue         *AttachQueueArgs         `xml:"u:AttachQueue,omitempty"`
-	Backup              *BackupArgs              `xml:"u:Backup,omitempty"`
-	Browse              *BrowseArgs              `xml:"u:Browse,omitempty"`
-	CreateQueue         *CreateQueueArgs         `xml:"u:CreateQueue,omitempty"`
-	RemoveAllTracks     *RemoveAllTracksArgs     `xml:"u:RemoveAllTracks,omitempty"`
-	RemoveTrackRange    *RemoveTrackRangeArgs    `xml:"u:RemoveTrackRange,omitempty"`
-	ReorderTracks       *ReorderTracksArgs       `xml:"u:ReorderTracks,omitempty"`
-	ReplaceAllTracks    *ReplaceAllTracksArgs    `xml:"u:ReplaceAllTracks,omitempty"`
-	SaveAsSonosPlaylist *SaveAsSonosPlaylistArgs `xml:"u:SaveAsSonosPlaylist,omitempty"`
-}
-type EnvelopeResponse struct {
-	XMLName       xml.Name     `xml:"Envelope"`
-	Xmlns         string       `xml:"xmlns:s,attr"`
-	EncodingStyle string       `xml:"encodingStyle,attr"`
-	Body          BodyResponse `xml:"Body"`
-}
-type BodyResponse struct {
-	XMLName             xml.Name                     `xml:"Body"`
-	AddURI              *AddURIResponse              `xml:"AddURIResponse,omitempty"`
-	AddMultipleURIs     *AddMultipleURIsResponse     `xml:"AddMultipleURIsResponse,omitempty"`
-	AttachQueue         *AttachQueueResponse         `xml:"AttachQueueResponse,omitempty"`
-	Backup              *BackupResponse              `xml:"BackupResponse,omitempty"`
-	Browse              *BrowseResponse              `xml:"BrowseResponse,omitempty"`
-	CreateQueue         *CreateQueueResponse         `xml:"CreateQueueResponse,omitempty"`
-	RemoveAllTracks     *RemoveAllTracksResponse     `xml:"RemoveAllTracksResponse,omitempty"`
-	RemoveTrackRange    *RemoveTrackRangeResponse    `xml:"RemoveTrackRangeResponse,omitempty"`
-	ReorderTracks       *ReorderTracksResponse       `xml:"ReorderTracksResponse,omitempty"`
-	ReplaceAllTracks    *ReplaceAllTracksResponse    `xml:"ReplaceAllTracksResponse,omitempty"`
-	SaveAsSonosPlaylist *SaveAsSonosPlaylistResponse `xml:"SaveAsSonosPlaylistResponse,omitempty"`
-}
-
-func (s *Service) exec(actionName string, httpClient *http.Client, envelope *Envelope) (*EnvelopeResponse, error) {
-	marshaled, err := xml.Marshal(envelope)
-	if err != nil {
-		return nil, err
-	}
-	postBody := []byte(`<?xml version="1.0"?>`)
-	postBody = append(postBody, marshaled...)
-	req, err := http.NewRequest(`POST`, s.ControlEndpoint.String(), bytes.NewBuffer(postBody))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set(`Content-Type`, `text/xml; charset="utf-8"`)
-	req.Header.Set(`SOAPAction`, _ServiceURN+`#`+actionName)
-	res, err := httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-	responseBody, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-	var envelopeResponse EnvelopeResponse
-	err = xml.Unmarshal(responseBody, &envelopeResponse)
-	if err != nil {
-		return nil, err
-	}
-	return &envelopeResponse, nil
-}
-
 type AddURIArgs struct {
-	Xmlns                           string `xml:"xmlns:u,attr"`
 	QueueID                         uint32 `xml:"QueueID"`
 	UpdateID                        uint32 `xml:"UpdateID"`
 	EnqueuedURI                     string `xml:"EnqueuedURI"`
@@ -115,6 +50,12 @@ type AddURIArgs struct {
 	DesiredFirstTrackNumberEnqueued uint32 `xml:"DesiredFirstTrackNumberEnqueued"`
 	EnqueueAsNext                   bool   `xml:"EnqueueAsNext"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *AddURIArgs) Validate() error {
+	return nil
+}
+
 type AddURIResponse struct {
 	FirstTrackNumberEnqueued uint32 `xml:"FirstTrackNumberEnqueued"`
 	NumTracksAdded           uint32 `xml:"NumTracksAdded"`
@@ -123,25 +64,17 @@ type AddURIResponse struct {
 }
 
 func (s *Service) AddURI(httpClient *http.Client, args *AddURIArgs) (*AddURIResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`AddURI`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{AddURI: args},
-		})
-	if err != nil {
+	return s.AddURIContext(context.Background(), httpClient, args)
+}
+func (s *Service) AddURIContext(ctx context.Context, httpClient *http.Client, args *AddURIArgs) (*AddURIResponse, error) {
+	var r AddURIResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `AddURI`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.AddURI == nil {
-		return nil, errors.New(`unexpected respose from service calling queue.AddURI()`)
-	}
-
-	return r.Body.AddURI, nil
+	return &r, nil
 }
 
 type AddMultipleURIsArgs struct {
-	Xmlns                           string `xml:"xmlns:u,attr"`
 	QueueID                         uint32 `xml:"QueueID"`
 	UpdateID                        uint32 `xml:"UpdateID"`
 	ContainerURI                    string `xml:"ContainerURI"`
@@ -151,6 +84,12 @@ type AddMultipleURIsArgs struct {
 	NumberOfURIs                    uint32 `xml:"NumberOfURIs"`
 	EnqueuedURIsAndMetaData         string `xml:"EnqueuedURIsAndMetaData"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *AddMultipleURIsArgs) Validate() error {
+	return nil
+}
+
 type AddMultipleURIsResponse struct {
 	FirstTrackNumberEnqueued uint32 `xml:"FirstTrackNumberEnqueued"`
 	NumTracksAdded           uint32 `xml:"NumTracksAdded"`
@@ -159,80 +98,74 @@ type AddMultipleURIsResponse struct {
 }
 
 func (s *Service) AddMultipleURIs(httpClient *http.Client, args *AddMultipleURIsArgs) (*AddMultipleURIsResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`AddMultipleURIs`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{AddMultipleURIs: args},
-		})
-	if err != nil {
+	return s.AddMultipleURIsContext(context.Background(), httpClient, args)
+}
+func (s *Service) AddMultipleURIsContext(ctx context.Context, httpClient *http.Client, args *AddMultipleURIsArgs) (*AddMultipleURIsResponse, error) {
+	var r AddMultipleURIsResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `AddMultipleURIs`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.AddMultipleURIs == nil {
-		return nil, errors.New(`unexpected respose from service calling queue.AddMultipleURIs()`)
-	}
-
-	return r.Body.AddMultipleURIs, nil
+	return &r, nil
 }
 
 type AttachQueueArgs struct {
-	Xmlns        string `xml:"xmlns:u,attr"`
 	QueueOwnerID string `xml:"QueueOwnerID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *AttachQueueArgs) Validate() error {
+	return nil
+}
+
 type AttachQueueResponse struct {
 	QueueID           uint32 `xml:"QueueID"`
 	QueueOwnerContext string `xml:"QueueOwnerContext"`
 }
 
 func (s *Service) AttachQueue(httpClient *http.Client, args *AttachQueueArgs) (*AttachQueueResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`AttachQueue`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{AttachQueue: args},
-		})
-	if err != nil {
+	return s.AttachQueueContext(context.Background(), httpClient, args)
+}
+func (s *Service) AttachQueueContext(ctx context.Context, httpClient *http.Client, args *AttachQueueArgs) (*AttachQueueResponse, error) {
+	var r AttachQueueResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `AttachQueue`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.AttachQueue == nil {
-		return nil, errors.New(`unexpected respose from service calling queue.AttachQueue()`)
-	}
-
-	return r.Body.AttachQueue, nil
+	return &r, nil
 }
 
 type BackupArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *BackupArgs) Validate() error {
+	return nil
+}
+
 type BackupResponse struct {
 }
 
 func (s *Service) Backup(httpClient *http.Client, args *BackupArgs) (*BackupResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`Backup`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{Backup: args},
-		})
-	if err != nil {
+	return s.BackupContext(context.Background(), httpClient, args)
+}
+func (s *Service) BackupContext(ctx context.Context, httpClient *http.Client, args *BackupArgs) (*BackupResponse, error) {
+	var r BackupResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `Backup`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.Backup == nil {
-		return nil, errors.New(`unexpected respose from service calling queue.Backup()`)
-	}
-
-	return r.Body.Backup, nil
+	return &r, nil
 }
 
 type BrowseArgs struct {
-	Xmlns          string `xml:"xmlns:u,attr"`
 	QueueID        uint32 `xml:"QueueID"`
 	StartingIndex  uint32 `xml:"StartingIndex"`
 	RequestedCount uint32 `xml:"RequestedCount"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *BrowseArgs) Validate() error {
+	return nil
+}
+
 type BrowseResponse struct {
 	Result         string `xml:"Result"`
 	NumberReturned uint32 `xml:"NumberReturned"`
@@ -241,139 +174,123 @@ type BrowseResponse struct {
 }
 
 func (s *Service) Browse(httpClient *http.Client, args *BrowseArgs) (*BrowseResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`Browse`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{Browse: args},
-		})
-	if err != nil {
+	return s.BrowseContext(context.Background(), httpClient, args)
+}
+func (s *Service) BrowseContext(ctx context.Context, httpClient *http.Client, args *BrowseArgs) (*BrowseResponse, error) {
+	var r BrowseResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `Browse`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.Browse == nil {
-		return nil, errors.New(`unexpected respose from service calling queue.Browse()`)
-	}
-
-	return r.Body.Browse, nil
+	return &r, nil
 }
 
 type CreateQueueArgs struct {
-	Xmlns             string `xml:"xmlns:u,attr"`
 	QueueOwnerID      string `xml:"QueueOwnerID"`
 	QueueOwnerContext string `xml:"QueueOwnerContext"`
 	QueuePolicy       string `xml:"QueuePolicy"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *CreateQueueArgs) Validate() error {
+	return nil
+}
+
 type CreateQueueResponse struct {
 	QueueID uint32 `xml:"QueueID"`
 }
 
 func (s *Service) CreateQueue(httpClient *http.Client, args *CreateQueueArgs) (*CreateQueueResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`CreateQueue`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{CreateQueue: args},
-		})
-	if err != nil {
+	return s.CreateQueueContext(context.Background(), httpClient, args)
+}
+func (s *Service) CreateQueueContext(ctx context.Context, httpClient *http.Client, args *CreateQueueArgs) (*CreateQueueResponse, error) {
+	var r CreateQueueResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `CreateQueue`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.CreateQueue == nil {
-		return nil, errors.New(`unexpected respose from service calling queue.CreateQueue()`)
-	}
-
-	return r.Body.CreateQueue, nil
+	return &r, nil
 }
 
 type RemoveAllTracksArgs struct {
-	Xmlns    string `xml:"xmlns:u,attr"`
 	QueueID  uint32 `xml:"QueueID"`
 	UpdateID uint32 `xml:"UpdateID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *RemoveAllTracksArgs) Validate() error {
+	return nil
+}
+
 type RemoveAllTracksResponse struct {
 	NewUpdateID uint32 `xml:"NewUpdateID"`
 }
 
 func (s *Service) RemoveAllTracks(httpClient *http.Client, args *RemoveAllTracksArgs) (*RemoveAllTracksResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`RemoveAllTracks`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{RemoveAllTracks: args},
-		})
-	if err != nil {
+	return s.RemoveAllTracksContext(context.Background(), httpClient, args)
+}
+func (s *Service) RemoveAllTracksContext(ctx context.Context, httpClient *http.Client, args *RemoveAllTracksArgs) (*RemoveAllTracksResponse, error) {
+	var r RemoveAllTracksResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `RemoveAllTracks`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.RemoveAllTracks == nil {
-		return nil, errors.New(`unexpected respose from service calling queue.RemoveAllTracks()`)
-	}
-
-	return r.Body.RemoveAllTracks, nil
+	return &r, nil
 }
 
 type RemoveTrackRangeArgs struct {
-	Xmlns          string `xml:"xmlns:u,attr"`
 	QueueID        uint32 `xml:"QueueID"`
 	UpdateID       uint32 `xml:"UpdateID"`
 	StartingIndex  uint32 `xml:"StartingIndex"`
 	NumberOfTracks uint32 `xml:"NumberOfTracks"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *RemoveTrackRangeArgs) Validate() error {
+	return nil
+}
+
 type RemoveTrackRangeResponse struct {
 	NewUpdateID uint32 `xml:"NewUpdateID"`
 }
 
 func (s *Service) RemoveTrackRange(httpClient *http.Client, args *RemoveTrackRangeArgs) (*RemoveTrackRangeResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`RemoveTrackRange`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{RemoveTrackRange: args},
-		})
-	if err != nil {
+	return s.RemoveTrackRangeContext(context.Background(), httpClient, args)
+}
+func (s *Service) RemoveTrackRangeContext(ctx context.Context, httpClient *http.Client, args *RemoveTrackRangeArgs) (*RemoveTrackRangeResponse, error) {
+	var r RemoveTrackRangeResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `RemoveTrackRange`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.RemoveTrackRange == nil {
-		return nil, errors.New(`unexpected respose from service calling queue.RemoveTrackRange()`)
-	}
-
-	return r.Body.RemoveTrackRange, nil
+	return &r, nil
 }
 
 type ReorderTracksArgs struct {
-	Xmlns          string `xml:"xmlns:u,attr"`
 	QueueID        uint32 `xml:"QueueID"`
 	StartingIndex  uint32 `xml:"StartingIndex"`
 	NumberOfTracks uint32 `xml:"NumberOfTracks"`
 	InsertBefore   uint32 `xml:"InsertBefore"`
 	UpdateID       uint32 `xml:"UpdateID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *ReorderTracksArgs) Validate() error {
+	return nil
+}
+
 type ReorderTracksResponse struct {
 	NewUpdateID uint32 `xml:"NewUpdateID"`
 }
 
 func (s *Service) ReorderTracks(httpClient *http.Client, args *ReorderTracksArgs) (*ReorderTracksResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`ReorderTracks`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{ReorderTracks: args},
-		})
-	if err != nil {
+	return s.ReorderTracksContext(context.Background(), httpClient, args)
+}
+func (s *Service) ReorderTracksContext(ctx context.Context, httpClient *http.Client, args *ReorderTracksArgs) (*ReorderTracksResponse, error) {
+	var r ReorderTracksResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `ReorderTracks`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.ReorderTracks == nil {
-		return nil, errors.New(`unexpected respose from service calling queue.ReorderTracks()`)
-	}
-
-	return r.Body.ReorderTracks, nil
+	return &r, nil
 }
 
 type ReplaceAllTracksArgs struct {
-	Xmlns                   string `xml:"xmlns:u,attr"`
 	QueueID                 uint32 `xml:"QueueID"`
 	UpdateID                uint32 `xml:"UpdateID"`
 	ContainerURI            string `xml:"ContainerURI"`
@@ -383,53 +300,233 @@ type ReplaceAllTracksArgs struct {
 	NumberOfURIs            uint32 `xml:"NumberOfURIs"`
 	EnqueuedURIsAndMetaData string `xml:"EnqueuedURIsAndMetaData"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *ReplaceAllTracksArgs) Validate() error {
+	return nil
+}
+
 type ReplaceAllTracksResponse struct {
 	NewQueueLength uint32 `xml:"NewQueueLength"`
 	NewUpdateID    uint32 `xml:"NewUpdateID"`
 }
 
 func (s *Service) ReplaceAllTracks(httpClient *http.Client, args *ReplaceAllTracksArgs) (*ReplaceAllTracksResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`ReplaceAllTracks`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{ReplaceAllTracks: args},
-		})
-	if err != nil {
+	return s.ReplaceAllTracksContext(context.Background(), httpClient, args)
+}
+func (s *Service) ReplaceAllTracksContext(ctx context.Context, httpClient *http.Client, args *ReplaceAllTracksArgs) (*ReplaceAllTracksResponse, error) {
+	var r ReplaceAllTracksResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `ReplaceAllTracks`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.ReplaceAllTracks == nil {
-		return nil, errors.New(`unexpected respose from service calling queue.ReplaceAllTracks()`)
-	}
-
-	return r.Body.ReplaceAllTracks, nil
+	return &r, nil
 }
 
 type SaveAsSonosPlaylistArgs struct {
-	Xmlns    string `xml:"xmlns:u,attr"`
 	QueueID  uint32 `xml:"QueueID"`
 	Title    string `xml:"Title"`
 	ObjectID string `xml:"ObjectID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SaveAsSonosPlaylistArgs) Validate() error {
+	return nil
+}
+
 type SaveAsSonosPlaylistResponse struct {
 	AssignedObjectID string `xml:"AssignedObjectID"`
 }
 
 func (s *Service) SaveAsSonosPlaylist(httpClient *http.Client, args *SaveAsSonosPlaylistArgs) (*SaveAsSonosPlaylistResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SaveAsSonosPlaylist`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SaveAsSonosPlaylist: args},
-		})
-	if err != nil {
+	return s.SaveAsSonosPlaylistContext(context.Background(), httpClient, args)
+}
+func (s *Service) SaveAsSonosPlaylistContext(ctx context.Context, httpClient *http.Client, args *SaveAsSonosPlaylistArgs) (*SaveAsSonosPlaylistResponse, error) {
+	var r SaveAsSonosPlaylistResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SaveAsSonosPlaylist`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SaveAsSonosPlaylist == nil {
-		return nil, errors.New(`unexpected respose from service calling queue.SaveAsSonosPlaylist()`)
+	return &r, nil
+}
+
+// Client is the set of actions of the service. It is implemented by Service and Fake
+type Client interface {
+	AddURI(httpClient *http.Client, args *AddURIArgs) (*AddURIResponse, error)
+	AddURIContext(ctx context.Context, httpClient *http.Client, args *AddURIArgs) (*AddURIResponse, error)
+	AddMultipleURIs(httpClient *http.Client, args *AddMultipleURIsArgs) (*AddMultipleURIsResponse, error)
+	AddMultipleURIsContext(ctx context.Context, httpClient *http.Client, args *AddMultipleURIsArgs) (*AddMultipleURIsResponse, error)
+	AttachQueue(httpClient *http.Client, args *AttachQueueArgs) (*AttachQueueResponse, error)
+	AttachQueueContext(ctx context.Context, httpClient *http.Client, args *AttachQueueArgs) (*AttachQueueResponse, error)
+	Backup(httpClient *http.Client, args *BackupArgs) (*BackupResponse, error)
+	BackupContext(ctx context.Context, httpClient *http.Client, args *BackupArgs) (*BackupResponse, error)
+	Browse(httpClient *http.Client, args *BrowseArgs) (*BrowseResponse, error)
+	BrowseContext(ctx context.Context, httpClient *http.Client, args *BrowseArgs) (*BrowseResponse, error)
+	CreateQueue(httpClient *http.Client, args *CreateQueueArgs) (*CreateQueueResponse, error)
+	CreateQueueContext(ctx context.Context, httpClient *http.Client, args *CreateQueueArgs) (*CreateQueueResponse, error)
+	RemoveAllTracks(httpClient *http.Client, args *RemoveAllTracksArgs) (*RemoveAllTracksResponse, error)
+	RemoveAllTracksContext(ctx context.Context, httpClient *http.Client, args *RemoveAllTracksArgs) (*RemoveAllTracksResponse, error)
+	RemoveTrackRange(httpClient *http.Client, args *RemoveTrackRangeArgs) (*RemoveTrackRangeResponse, error)
+	RemoveTrackRangeContext(ctx context.Context, httpClient *http.Client, args *RemoveTrackRangeArgs) (*RemoveTrackRangeResponse, error)
+	ReorderTracks(httpClient *http.Client, args *ReorderTracksArgs) (*ReorderTracksResponse, error)
+	ReorderTracksContext(ctx context.Context, httpClient *http.Client, args *ReorderTracksArgs) (*ReorderTracksResponse, error)
+	ReplaceAllTracks(httpClient *http.Client, args *ReplaceAllTracksArgs) (*ReplaceAllTracksResponse, error)
+	ReplaceAllTracksContext(ctx context.Context, httpClient *http.Client, args *ReplaceAllTracksArgs) (*ReplaceAllTracksResponse, error)
+	SaveAsSonosPlaylist(httpClient *http.Client, args *SaveAsSonosPlaylistArgs) (*SaveAsSonosPlaylistResponse, error)
+	SaveAsSonosPlaylistContext(ctx context.Context, httpClient *http.Client, args *SaveAsSonosPlaylistArgs) (*SaveAsSonosPlaylistResponse, error)
+}
+
+var _ Client = (*Service)(nil)
+var _ Client = (*Fake)(nil)
+
+// Call is an action received by a Fake
+type Call struct {
+	Action string
+	// Args is the *<Action>Args the action was called with
+	Args interface{}
+}
+
+// Fake is an in-memory Client for tests. It records every call, and answers
+// an action with its On<Action> func, or with an empty response if that is nil
+type Fake struct {
+	mu                    sync.Mutex
+	calls                 []Call
+	OnAddURI              func(ctx context.Context, args *AddURIArgs) (*AddURIResponse, error)
+	OnAddMultipleURIs     func(ctx context.Context, args *AddMultipleURIsArgs) (*AddMultipleURIsResponse, error)
+	OnAttachQueue         func(ctx context.Context, args *AttachQueueArgs) (*AttachQueueResponse, error)
+	OnBackup              func(ctx context.Context, args *BackupArgs) (*BackupResponse, error)
+	OnBrowse              func(ctx context.Context, args *BrowseArgs) (*BrowseResponse, error)
+	OnCreateQueue         func(ctx context.Context, args *CreateQueueArgs) (*CreateQueueResponse, error)
+	OnRemoveAllTracks     func(ctx context.Context, args *RemoveAllTracksArgs) (*RemoveAllTracksResponse, error)
+	OnRemoveTrackRange    func(ctx context.Context, args *RemoveTrackRangeArgs) (*RemoveTrackRangeResponse, error)
+	OnReorderTracks       func(ctx context.Context, args *ReorderTracksArgs) (*ReorderTracksResponse, error)
+	OnReplaceAllTracks    func(ctx context.Context, args *ReplaceAllTracksArgs) (*ReplaceAllTracksResponse, error)
+	OnSaveAsSonosPlaylist func(ctx context.Context, args *SaveAsSonosPlaylistArgs) (*SaveAsSonosPlaylistResponse, error)
+}
+
+// Calls returns the calls received so far, oldest first
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// Reset forgets the calls received so far
+func (f *Fake) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+}
+func (f *Fake) record(action string, args interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{Action: action, Args: args})
+}
+func (f *Fake) AddURI(httpClient *http.Client, args *AddURIArgs) (*AddURIResponse, error) {
+	return f.AddURIContext(context.Background(), httpClient, args)
+}
+func (f *Fake) AddURIContext(ctx context.Context, httpClient *http.Client, args *AddURIArgs) (*AddURIResponse, error) {
+	f.record(`AddURI`, args)
+	if f.OnAddURI != nil {
+		return f.OnAddURI(ctx, args)
 	}
-
-	return r.Body.SaveAsSonosPlaylist, nil
+	return &AddURIResponse{}, nil
+}
+func (f *Fake) AddMultipleURIs(httpClient *http.Client, args *AddMultipleURIsArgs) (*AddMultipleURIsResponse, error) {
+	return f.AddMultipleURIsContext(context.Background(), httpClient, args)
+}
+func (f *Fake) AddMultipleURIsContext(ctx context.Context, httpClient *http.Client, args *AddMultipleURIsArgs) (*AddMultipleURIsResponse, error) {
+	f.record(`AddMultipleURIs`, args)
+	if f.OnAddMultipleURIs != nil {
+		return f.OnAddMultipleURIs(ctx, args)
+	}
+	return &AddMultipleURIsResponse{}, nil
+}
+func (f *Fake) AttachQueue(httpClient *http.Client, args *AttachQueueArgs) (*AttachQueueResponse, error) {
+	return f.AttachQueueContext(context.Background(), httpClient, args)
+}
+func (f *Fake) AttachQueueContext(ctx context.Context, httpClient *http.Client, args *AttachQueueArgs) (*AttachQueueResponse, error) {
+	f.record(`AttachQueue`, args)
+	if f.OnAttachQueue != nil {
+		return f.OnAttachQueue(ctx, args)
+	}
+	return &AttachQueueResponse{}, nil
+}
+func (f *Fake) Backup(httpClient *http.Client, args *BackupArgs) (*BackupResponse, error) {
+	return f.BackupContext(context.Background(), httpClient, args)
+}
+func (f *Fake) BackupContext(ctx context.Context, httpClient *http.Client, args *BackupArgs) (*BackupResponse, error) {
+	f.record(`Backup`, args)
+	if f.OnBackup != nil {
+		return f.OnBackup(ctx, args)
+	}
+	return &BackupResponse{}, nil
+}
+func (f *Fake) Browse(httpClient *http.Client, args *BrowseArgs) (*BrowseResponse, error) {
+	return f.BrowseContext(context.Background(), httpClient, args)
+}
+func (f *Fake) BrowseContext(ctx context.Context, httpClient *http.Client, args *BrowseArgs) (*BrowseResponse, error) {
+	f.record(`Browse`, args)
+	if f.OnBrowse != nil {
+		return f.OnBrowse(ctx, args)
+	}
+	return &BrowseResponse{}, nil
+}
+func (f *Fake) CreateQueue(httpClient *http.Client, args *CreateQueueArgs) (*CreateQueueResponse, error) {
+	return f.CreateQueueContext(context.Background(), httpClient, args)
+}
+func (f *Fake) CreateQueueContext(ctx context.Context, httpClient *http.Client, args *CreateQueueArgs) (*CreateQueueResponse, error) {
+	f.record(`CreateQueue`, args)
+	if f.OnCreateQueue != nil {
+		return f.OnCreateQueue(ctx, args)
+	}
+	return &CreateQueueResponse{}, nil
+}
+func (f *Fake) RemoveAllTracks(httpClient *http.Client, args *RemoveAllTracksArgs) (*RemoveAllTracksResponse, error) {
+	return f.RemoveAllTracksContext(context.Background(), httpClient, args)
+}
+func (f *Fake) RemoveAllTracksContext(ctx context.Context, httpClient *http.Client, args *RemoveAllTracksArgs) (*RemoveAllTracksResponse, error) {
+	f.record(`RemoveAllTracks`, args)
+	if f.OnRemoveAllTracks != nil {
+		return f.OnRemoveAllTracks(ctx, args)
+	}
+	return &RemoveAllTracksResponse{}, nil
+}
+func (f *Fake) RemoveTrackRange(httpClient *http.Client, args *RemoveTrackRangeArgs) (*RemoveTrackRangeResponse, error) {
+	return f.RemoveTrackRangeContext(context.Background(), httpClient, args)
+}
+func (f *Fake) RemoveTrackRangeContext(ctx context.Context, httpClient *http.Client, args *RemoveTrackRangeArgs) (*RemoveTrackRangeResponse, error) {
+	f.record(`RemoveTrackRange`, args)
+	if f.OnRemoveTrackRange != nil {
+		return f.OnRemoveTrackRange(ctx, args)
+	}
+	return &RemoveTrackRangeResponse{}, nil
+}
+func (f *Fake) ReorderTracks(httpClient *http.Client, args *ReorderTracksArgs) (*ReorderTracksResponse, error) {
+	return f.ReorderTracksContext(context.Background(), httpClient, args)
+}
+func (f *Fake) ReorderTracksContext(ctx context.Context, httpClient *http.Client, args *ReorderTracksArgs) (*ReorderTracksResponse, error) {
+	f.record(`ReorderTracks`, args)
+	if f.OnReorderTracks != nil {
+		return f.OnReorderTracks(ctx, args)
+	}
+	return &ReorderTracksResponse{}, nil
+}
+func (f *Fake) ReplaceAllTracks(httpClient *http.Client, args *ReplaceAllTracksArgs) (*ReplaceAllTracksResponse, error) {
+	return f.ReplaceAllTracksContext(context.Background(), httpClient, args)
+}
+func (f *Fake) ReplaceAllTracksContext(ctx context.Context, httpClient *http.Client, args *ReplaceAllTracksArgs) (*ReplaceAllTracksResponse, error) {
+	f.record(`ReplaceAllTracks`, args)
+	if f.OnReplaceAllTracks != nil {
+		return f.OnReplaceAllTracks(ctx, args)
+	}
+	return &ReplaceAllTracksResponse{}, nil
+}
+func (f *Fake) SaveAsSonosPlaylist(httpClient *http.Client, args *SaveAsSonosPlaylistArgs) (*SaveAsSonosPlaylistResponse, error) {
+	return f.SaveAsSonosPlaylistContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SaveAsSonosPlaylistContext(ctx context.Context, httpClient *http.Client, args *SaveAsSonosPlaylistArgs) (*SaveAsSonosPlaylistResponse, error) {
+	f.record(`SaveAsSonosPlaylist`, args)
+	if f.OnSaveAsSonosPlaylist != nil {
+		return f.OnSaveAsSonosPlaylist(ctx, args)
+	}
+	return &SaveAsSonosPlaylistResponse{}, nil
 }
@@ -0,0 +1,75 @@
+package queue
+
+import (
+	"encoding/xml"
+	"strconv"
+)
+
+// LastChange is the content of a Queue LastChange event, e.g. the
+// "LastChange" property of a gena.Event.
+type LastChange struct {
+	Queues []QueueChange
+}
+
+// QueueChange describes the changes to one queue. Only the state variables
+// that changed are sent, the others are left nil.
+type QueueChange struct {
+	QueueID  uint32
+	UpdateID *uint32
+	Curated  *bool
+	// Other holds the state variables not listed above by name
+	Other map[string]string
+}
+
+// Container returns the ContentDirectory container of the queue, e.g. "Q:0".
+func (q *QueueChange) Container() string {
+	return "Q:" + strconv.FormatUint(uint64(q.QueueID), 10)
+}
+
+type lastChangeEvent struct {
+	XMLName xml.Name `xml:"Event"`
+	Queues  []struct {
+		ID        uint32 `xml:"val,attr"`
+		Variables []struct {
+			XMLName xml.Name
+			Val     string `xml:"val,attr"`
+		} `xml:",any"`
+	} `xml:"QueueID"`
+}
+
+// DecodeLastChange decodes the LastChange document of an event.
+func DecodeLastChange(lastChange string) (*LastChange, error) {
+	var event lastChangeEvent
+	if err := xml.Unmarshal([]byte(lastChange), &event); err != nil {
+		return nil, err
+	}
+
+	var l LastChange
+	for _, queue := range event.Queues {
+		q := QueueChange{QueueID: queue.ID}
+		for _, v := range queue.Variables {
+			switch v.XMLName.Local {
+			case "UpdateID":
+				updateID, err := strconv.ParseUint(v.Val, 10, 32)
+				if err != nil {
+					return nil, err
+				}
+				u := uint32(updateID)
+				q.UpdateID = &u
+			case "Curated":
+				curated, err := strconv.ParseBool(v.Val)
+				if err != nil {
+					return nil, err
+				}
+				q.Curated = &curated
+			default:
+				if q.Other == nil {
+					q.Other = make(map[string]string)
+				}
+				q.Other[v.XMLName.Local] = v.Val
+			}
+		}
+		l.Queues = append(l.Queues, q)
+	}
+	return &l, nil
+}
@@ -0,0 +1,37 @@
+package queue
+
+import "testing"
+
+func TestDecodeLastChange(t *testing.T) {
+	l, err := DecodeLastChange(`<Event xmlns="urn:schemas-sonos-com:metadata-1-0/Queue/">` +
+		`<QueueID val="0"><UpdateID val="12"/><Curated val="0"/></QueueID>` +
+		`<QueueID val="1"><UpdateID val="3"/><QueueOwnerID val="owner"/></QueueID>` +
+		`</Event>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l.Queues) != 2 {
+		t.Fatalf("Queues = %+v, want 2", l.Queues)
+	}
+
+	q := l.Queues[0]
+	if q.Container() != "Q:0" || q.UpdateID == nil || *q.UpdateID != 12 || q.Curated == nil || *q.Curated || q.Other != nil {
+		t.Errorf("Queues[0] = %+v", q)
+	}
+	q = l.Queues[1]
+	if q.Container() != "Q:1" || q.UpdateID == nil || *q.UpdateID != 3 || q.Curated != nil || q.Other["QueueOwnerID"] != "owner" {
+		t.Errorf("Queues[1] = %+v", q)
+	}
+}
+
+func TestDecodeLastChangeErrors(t *testing.T) {
+	for _, lastChange := range []string{
+		``,
+		`<Event><QueueID val="0"><UpdateID val="new"/></QueueID></Event>`,
+		`<Event><QueueID val="0"><Curated val="maybe"/></QueueID></Event>`,
+	} {
+		if l, err := DecodeLastChange(lastChange); err == nil {
+			t.Errorf("DecodeLastChange(%q) = %+v, want an error", lastChange, l)
+		}
+	}
+}
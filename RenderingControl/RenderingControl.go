@@ -1,23 +1,59 @@
 package renderingcontrol
 
 import (
-	"bytes"
-	"encoding/xml"
-	"errors"
-	"io/ioutil"
+	"context"
 	"net/http"
 	"net/url"
+	"sync"
+
+	"github.com/szatmary/sonos/soap"
+)
+
+const _ServiceURN = "urn:schemas-upnp-org:service:RenderingControl:1"
+
+// ErrorCodes describes the error codes specific to this service
+var ErrorCodes = map[int]string{
+	701: "Invalid name",
+	702: "Invalid InstanceID",
+}
+
+func init() {
+	soap.RegisterErrorCodes(_ServiceURN, ErrorCodes)
+}
+
+// Channel holds the values allowed for the A_ARG_TYPE_Channel state variable
+type Channel string
+
+const (
+	ChannelMaster Channel = "Master"
+	ChannelLF     Channel = "LF"
+	ChannelRF     Channel = "RF"
 )
 
+// MuteChannel holds the values allowed for the A_ARG_TYPE_MuteChannel state variable
+type MuteChannel string
+
 const (
-	_ServiceURN     = "urn:schemas-upnp-org:service:RenderingControl:1"
-	_EncodingSchema = "http://schemas.xmlsoap.org/soap/encoding/"
-	_EnvelopeSchema = "http://schemas.xmlsoap.org/soap/envelope/"
+	MuteChannelMaster      MuteChannel = "Master"
+	MuteChannelLF          MuteChannel = "LF"
+	MuteChannelRF          MuteChannel = "RF"
+	MuteChannelSpeakerOnly MuteChannel = "SpeakerOnly"
+)
+
+// RampType holds the values allowed for the A_ARG_TYPE_RampType state variable
+type RampType string
+
+const (
+	RampTypeSleepTimerRampType RampType = "SLEEP_TIMER_RAMP_TYPE"
+	RampTypeAlarmRampType      RampType = "ALARM_RAMP_TYPE"
+	RampTypeAutoplayRampType   RampType = "AUTOPLAY_RAMP_TYPE"
 )
 
 type Service struct {
 	ControlEndpoint *url.URL
 	EventEndpoint   *url.URL
+	// Client sends the actions, soap.DefaultClient is used if it is nil
+	Client *soap.Client
 }
 
 func NewService(deviceUrl *url.URL) *Service {
@@ -35,177 +71,83 @@ func NewService(deviceUrl *url.URL) *Service {
 	}
 }
 
-type Envelope struct {
-	XMLName       xml.Name `xml:"s:Envelope"`
-	Xmlns         string   `xml:"xmlns:s,attr"`
-	EncodingStyle string   `xml:"s:encodingStyle,attr"`
-	Body          Body     `xml:"s:Body"`
-}
-type Body struct {
-	XMLName                  xml.Name                      `xml:"s:Body"`
-	GetMute                  *GetMuteArgs                  `xml:"u:GetMute,omitempty"`
-	SetMute                  *SetMuteArgs                  `xml:"u:SetMute,omitempty"`
-	ResetBasicEQ             *ResetBasicEQArgs             `xml:"u:ResetBasicEQ,omitempty"`
-	ResetExtEQ               *ResetExtEQArgs               `xml:"u:ResetExtEQ,omitempty"`
-	GetVolume                *GetVolumeArgs                `xml:"u:GetVolume,omitempty"`
-	SetVolume                *SetVolumeArgs                `xml:"u:SetVolume,omitempty"`
-	SetRelativeVolume        *SetRelativeVolumeArgs        `xml:"u:SetRelativeVolume,omitempty"`
-	GetVolumeDB              *GetVolumeDBArgs              `xml:"u:GetVolumeDB,omitempty"`
-	SetVolumeDB              *SetVolumeDBArgs              `xml:"u:SetVolumeDB,omitempty"`
-	GetVolumeDBRange         *GetVolumeDBRangeArgs         `xml:"u:GetVolumeDBRange,omitempty"`
-	GetBass                  *GetBassArgs                  `xml:"u:GetBass,omitempty"`
-	SetBass                  *SetBassArgs                  `xml:"u:SetBass,omitempty"`
-	GetTreble                *GetTrebleArgs                `xml:"u:GetTreble,omitempty"`
-	SetTreble                *SetTrebleArgs                `xml:"u:SetTreble,omitempty"`
-	GetEQ                    *GetEQArgs                    `xml:"u:GetEQ,omitempty"`
-	SetEQ                    *SetEQArgs                    `xml:"u:SetEQ,omitempty"`
-	GetLoudness              *GetLoudnessArgs              `xml:"u:GetLoudness,omitempty"`
-	SetLoudness              *SetLoudnessArgs              `xml:"u:SetLoudness,omitempty"`
-	GetSupportsOutputFixed   *GetSupportsOutputFixedArgs   `xml:"u:GetSupportsOutputFixed,omitempty"`
-	GetOutputFixed           *GetOutputFixedArgs           `xml:"u:GetOutputFixed,omitempty"`
-	SetOutputFixed           *SetOutputFixedArgs           `xml:"u:SetOutputFixed,omitempty"`
-	GetHeadphoneConnected    *GetHeadphoneConnectedArgs    `xml:"u:GetHeadphoneConnected,omitempty"`
-	RampToVolume             *RampToVolumeArgs             `xml:"u:RampToVolume,omitempty"`
-	RestoreVolumePriorToRamp *RestoreVolumePriorToRampArgs `xml:"u:RestoreVolumePriorToRamp,omitempty"`
-	SetChannelMap            *SetChannelMapArgs            `xml:"u:SetChannelMap,omitempty"`
-	SetRoomCalibrationX      *SetRoomCalibrationXArgs      `xml:"u:SetRoomCalibrationX,omitempty"`
-	GetRoomCalibrationStatus *GetRoomCalibrationStatusArgs `xml:"u:GetRoomCalibrationStatus,omitempty"`
-	SetRoomCalibrationStatus *SetRoomCalibrationStatusArgs `xml:"u:SetRoomCalibrationStatus,omitempty"`
-}
-type EnvelopeResponse struct {
-	XMLName       xml.Name     `xml:"Envelope"`
-	Xmlns         string       `xml:"xmlns:s,attr"`
-	EncodingStyle string       `xml:"encodingStyle,attr"`
-	Body          BodyResponse `xml:"Body"`
-}
-type BodyResponse struct {
-	XMLName                  xml.Name                          `xml:"Body"`
-	GetMute                  *GetMuteResponse                  `xml:"GetMuteResponse,omitempty"`
-	SetMute                  *SetMuteResponse                  `xml:"SetMuteResponse,omitempty"`
-	ResetBasicEQ             *ResetBasicEQResponse             `xml:"ResetBasicEQResponse,omitempty"`
-	ResetExtEQ               *ResetExtEQResponse               `xml:"ResetExtEQResponse,omitempty"`
-	GetVolume                *GetVolumeResponse                `xml:"GetVolumeResponse,omitempty"`
-	SetVolume                *SetVolumeResponse                `xml:"SetVolumeResponse,omitempty"`
-	SetRelativeVolume        *SetRelativeVolumeResponse        `xml:"SetRelativeVolumeResponse,omitempty"`
-	GetVolumeDB              *GetVolumeDBResponse              `xml:"GetVolumeDBResponse,omitempty"`
-	SetVolumeDB              *SetVolumeDBResponse              `xml:"SetVolumeDBResponse,omitempty"`
-	GetVolumeDBRange         *GetVolumeDBRangeResponse         `xml:"GetVolumeDBRangeResponse,omitempty"`
-	GetBass                  *GetBassResponse                  `xml:"GetBassResponse,omitempty"`
-	SetBass                  *SetBassResponse                  `xml:"SetBassResponse,omitempty"`
-	GetTreble                *GetTrebleResponse                `xml:"GetTrebleResponse,omitempty"`
-	SetTreble                *SetTrebleResponse                `xml:"SetTrebleResponse,omitempty"`
-	GetEQ                    *GetEQResponse                    `xml:"GetEQResponse,omitempty"`
-	SetEQ                    *SetEQResponse                    `xml:"SetEQResponse,omitempty"`
-	GetLoudness              *GetLoudnessResponse              `xml:"GetLoudnessResponse,omitempty"`
-	SetLoudness              *SetLoudnessResponse              `xml:"SetLoudnessResponse,omitempty"`
-	GetSupportsOutputFixed   *GetSupportsOutputFixedResponse   `xml:"GetSupportsOutputFixedResponse,omitempty"`
-	GetOutputFixed           *GetOutputFixedResponse           `xml:"GetOutputFixedResponse,omitempty"`
-	SetOutputFixed           *SetOutputFixedResponse           `xml:"SetOutputFixedResponse,omitempty"`
-	GetHeadphoneConnected    *GetHeadphoneConnectedResponse    `xml:"GetHeadphoneConnectedResponse,omitempty"`
-	RampToVolume             *RampToVolumeResponse             `xml:"RampToVolumeResponse,omitempty"`
-	RestoreVolumePriorToRamp *RestoreVolumePriorToRampResponse `xml:"RestoreVolumePriorToRampResponse,omitempty"`
-	SetChannelMap            *SetChannelMapResponse            `xml:"SetChannelMapResponse,omitempty"`
-	SetRoomCalibrationX      *SetRoomCalibrationXResponse      `xml:"SetRoomCalibrationXResponse,omitempty"`
-	GetRoomCalibrationStatus *GetRoomCalibrationStatusResponse `xml:"GetRoomCalibrationStatusResponse,omitempty"`
-	SetRoomCalibrationStatus *SetRoomCalibrationStatusResponse `xml:"SetRoomCalibrationStatusResponse,omitempty"`
-}
-
-func (s *Service) exec(actionName string, httpClient *http.Client, envelope *Envelope) (*EnvelopeResponse, error) {
-	marshaled, err := xml.Marshal(envelope)
-	if err != nil {
-		return nil, err
-	}
-	postBody := []byte(`<?xml version="1.0"?>`)
-	postBody = append(postBody, marshaled...)
-	req, err := http.NewRequest(`POST`, s.ControlEndpoint.String(), bytes.NewBuffer(postBody))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set(`Content-Type`, `text/xml; charset="utf-8"`)
-	req.Header.Set(`SOAPAction`, _ServiceURN+`#`+actionName)
-	res, err := httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-	responseBody, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-	var envelopeResponse EnvelopeResponse
-	err = xml.Unmarshal(responseBody, &envelopeResponse)
-	if err != nil {
-		return nil, err
-	}
-	return &envelopeResponse, nil
-}
-
 type GetMuteArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	// Allowed Value: Master
 	// Allowed Value: LF
 	// Allowed Value: RF
 	// Allowed Value: SpeakerOnly
-	Channel string `xml:"Channel"`
+	Channel MuteChannel `xml:"Channel"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetMuteArgs) Validate() error {
+	switch a.Channel {
+	case "", MuteChannelMaster, MuteChannelLF, MuteChannelRF, MuteChannelSpeakerOnly:
+	default:
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `GetMute`, Argument: `Channel`, Value: a.Channel, Allowed: "one of Master, LF, RF, SpeakerOnly"}
+	}
+	return nil
+}
+
 type GetMuteResponse struct {
 	CurrentMute bool `xml:"CurrentMute"`
 }
 
 func (s *Service) GetMute(httpClient *http.Client, args *GetMuteArgs) (*GetMuteResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetMute`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetMute: args},
-		})
-	if err != nil {
+	return s.GetMuteContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetMuteContext(ctx context.Context, httpClient *http.Client, args *GetMuteArgs) (*GetMuteResponse, error) {
+	var r GetMuteResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetMute`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetMute == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.GetMute()`)
-	}
-
-	return r.Body.GetMute, nil
+	return &r, nil
 }
 
 type SetMuteArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	// Allowed Value: Master
 	// Allowed Value: LF
 	// Allowed Value: RF
 	// Allowed Value: SpeakerOnly
-	Channel     string `xml:"Channel"`
-	DesiredMute bool   `xml:"DesiredMute"`
+	Channel     MuteChannel `xml:"Channel"`
+	DesiredMute bool        `xml:"DesiredMute"`
+}
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetMuteArgs) Validate() error {
+	switch a.Channel {
+	case "", MuteChannelMaster, MuteChannelLF, MuteChannelRF, MuteChannelSpeakerOnly:
+	default:
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `SetMute`, Argument: `Channel`, Value: a.Channel, Allowed: "one of Master, LF, RF, SpeakerOnly"}
+	}
+	return nil
 }
+
 type SetMuteResponse struct {
 }
 
 func (s *Service) SetMute(httpClient *http.Client, args *SetMuteArgs) (*SetMuteResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetMute`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetMute: args},
-		})
-	if err != nil {
+	return s.SetMuteContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetMuteContext(ctx context.Context, httpClient *http.Client, args *SetMuteArgs) (*SetMuteResponse, error) {
+	var r SetMuteResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetMute`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetMute == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.SetMute()`)
-	}
-
-	return r.Body.SetMute, nil
+	return &r, nil
 }
 
 type ResetBasicEQArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *ResetBasicEQArgs) Validate() error {
+	return nil
+}
+
 type ResetBasicEQResponse struct {
 	Bass        int16  `xml:"Bass"`
 	Treble      int16  `xml:"Treble"`
@@ -215,726 +157,1140 @@ type ResetBasicEQResponse struct {
 }
 
 func (s *Service) ResetBasicEQ(httpClient *http.Client, args *ResetBasicEQArgs) (*ResetBasicEQResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`ResetBasicEQ`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{ResetBasicEQ: args},
-		})
-	if err != nil {
+	return s.ResetBasicEQContext(context.Background(), httpClient, args)
+}
+func (s *Service) ResetBasicEQContext(ctx context.Context, httpClient *http.Client, args *ResetBasicEQArgs) (*ResetBasicEQResponse, error) {
+	var r ResetBasicEQResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `ResetBasicEQ`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.ResetBasicEQ == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.ResetBasicEQ()`)
-	}
-
-	return r.Body.ResetBasicEQ, nil
+	return &r, nil
 }
 
 type ResetExtEQArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	EQType     string `xml:"EQType"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *ResetExtEQArgs) Validate() error {
+	return nil
+}
+
 type ResetExtEQResponse struct {
 }
 
 func (s *Service) ResetExtEQ(httpClient *http.Client, args *ResetExtEQArgs) (*ResetExtEQResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`ResetExtEQ`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{ResetExtEQ: args},
-		})
-	if err != nil {
+	return s.ResetExtEQContext(context.Background(), httpClient, args)
+}
+func (s *Service) ResetExtEQContext(ctx context.Context, httpClient *http.Client, args *ResetExtEQArgs) (*ResetExtEQResponse, error) {
+	var r ResetExtEQResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `ResetExtEQ`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.ResetExtEQ == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.ResetExtEQ()`)
-	}
-
-	return r.Body.ResetExtEQ, nil
+	return &r, nil
 }
 
 type GetVolumeArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	// Allowed Value: Master
 	// Allowed Value: LF
 	// Allowed Value: RF
-	Channel string `xml:"Channel"`
+	Channel Channel `xml:"Channel"`
+}
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetVolumeArgs) Validate() error {
+	switch a.Channel {
+	case "", ChannelMaster, ChannelLF, ChannelRF:
+	default:
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `GetVolume`, Argument: `Channel`, Value: a.Channel, Allowed: "one of Master, LF, RF"}
+	}
+	return nil
 }
+
 type GetVolumeResponse struct {
 	CurrentVolume uint16 `xml:"CurrentVolume"`
 }
 
 func (s *Service) GetVolume(httpClient *http.Client, args *GetVolumeArgs) (*GetVolumeResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetVolume`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetVolume: args},
-		})
-	if err != nil {
+	return s.GetVolumeContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetVolumeContext(ctx context.Context, httpClient *http.Client, args *GetVolumeArgs) (*GetVolumeResponse, error) {
+	var r GetVolumeResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetVolume`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetVolume == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.GetVolume()`)
-	}
-
-	return r.Body.GetVolume, nil
+	return &r, nil
 }
 
 type SetVolumeArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	// Allowed Value: Master
 	// Allowed Value: LF
 	// Allowed Value: RF
-	Channel string `xml:"Channel"`
+	Channel Channel `xml:"Channel"`
 	// Allowed Range: 0 -> 100 step: 1
 	DesiredVolume uint16 `xml:"DesiredVolume"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetVolumeArgs) Validate() error {
+	switch a.Channel {
+	case "", ChannelMaster, ChannelLF, ChannelRF:
+	default:
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `SetVolume`, Argument: `Channel`, Value: a.Channel, Allowed: "one of Master, LF, RF"}
+	}
+	if a.DesiredVolume > 100 {
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `SetVolume`, Argument: `DesiredVolume`, Value: a.DesiredVolume, Allowed: "0 to 100"}
+	}
+	return nil
+}
+
 type SetVolumeResponse struct {
 }
 
 func (s *Service) SetVolume(httpClient *http.Client, args *SetVolumeArgs) (*SetVolumeResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetVolume`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetVolume: args},
-		})
-	if err != nil {
+	return s.SetVolumeContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetVolumeContext(ctx context.Context, httpClient *http.Client, args *SetVolumeArgs) (*SetVolumeResponse, error) {
+	var r SetVolumeResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetVolume`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetVolume == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.SetVolume()`)
-	}
-
-	return r.Body.SetVolume, nil
+	return &r, nil
 }
 
 type SetRelativeVolumeArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	// Allowed Value: Master
 	// Allowed Value: LF
 	// Allowed Value: RF
-	Channel    string `xml:"Channel"`
-	Adjustment int32  `xml:"Adjustment"`
+	Channel    Channel `xml:"Channel"`
+	Adjustment int32   `xml:"Adjustment"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetRelativeVolumeArgs) Validate() error {
+	switch a.Channel {
+	case "", ChannelMaster, ChannelLF, ChannelRF:
+	default:
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `SetRelativeVolume`, Argument: `Channel`, Value: a.Channel, Allowed: "one of Master, LF, RF"}
+	}
+	return nil
+}
+
 type SetRelativeVolumeResponse struct {
 	NewVolume uint16 `xml:"NewVolume"`
 }
 
 func (s *Service) SetRelativeVolume(httpClient *http.Client, args *SetRelativeVolumeArgs) (*SetRelativeVolumeResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetRelativeVolume`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetRelativeVolume: args},
-		})
-	if err != nil {
+	return s.SetRelativeVolumeContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetRelativeVolumeContext(ctx context.Context, httpClient *http.Client, args *SetRelativeVolumeArgs) (*SetRelativeVolumeResponse, error) {
+	var r SetRelativeVolumeResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetRelativeVolume`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetRelativeVolume == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.SetRelativeVolume()`)
-	}
-
-	return r.Body.SetRelativeVolume, nil
+	return &r, nil
 }
 
 type GetVolumeDBArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	// Allowed Value: Master
 	// Allowed Value: LF
 	// Allowed Value: RF
-	Channel string `xml:"Channel"`
+	Channel Channel `xml:"Channel"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetVolumeDBArgs) Validate() error {
+	switch a.Channel {
+	case "", ChannelMaster, ChannelLF, ChannelRF:
+	default:
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `GetVolumeDB`, Argument: `Channel`, Value: a.Channel, Allowed: "one of Master, LF, RF"}
+	}
+	return nil
+}
+
 type GetVolumeDBResponse struct {
 	CurrentVolume int16 `xml:"CurrentVolume"`
 }
 
 func (s *Service) GetVolumeDB(httpClient *http.Client, args *GetVolumeDBArgs) (*GetVolumeDBResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetVolumeDB`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetVolumeDB: args},
-		})
-	if err != nil {
+	return s.GetVolumeDBContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetVolumeDBContext(ctx context.Context, httpClient *http.Client, args *GetVolumeDBArgs) (*GetVolumeDBResponse, error) {
+	var r GetVolumeDBResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetVolumeDB`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetVolumeDB == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.GetVolumeDB()`)
-	}
-
-	return r.Body.GetVolumeDB, nil
+	return &r, nil
 }
 
 type SetVolumeDBArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	// Allowed Value: Master
 	// Allowed Value: LF
 	// Allowed Value: RF
-	Channel       string `xml:"Channel"`
-	DesiredVolume int16  `xml:"DesiredVolume"`
+	Channel       Channel `xml:"Channel"`
+	DesiredVolume int16   `xml:"DesiredVolume"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetVolumeDBArgs) Validate() error {
+	switch a.Channel {
+	case "", ChannelMaster, ChannelLF, ChannelRF:
+	default:
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `SetVolumeDB`, Argument: `Channel`, Value: a.Channel, Allowed: "one of Master, LF, RF"}
+	}
+	return nil
+}
+
 type SetVolumeDBResponse struct {
 }
 
 func (s *Service) SetVolumeDB(httpClient *http.Client, args *SetVolumeDBArgs) (*SetVolumeDBResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetVolumeDB`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetVolumeDB: args},
-		})
-	if err != nil {
+	return s.SetVolumeDBContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetVolumeDBContext(ctx context.Context, httpClient *http.Client, args *SetVolumeDBArgs) (*SetVolumeDBResponse, error) {
+	var r SetVolumeDBResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetVolumeDB`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetVolumeDB == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.SetVolumeDB()`)
-	}
-
-	return r.Body.SetVolumeDB, nil
+	return &r, nil
 }
 
 type GetVolumeDBRangeArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	// Allowed Value: Master
 	// Allowed Value: LF
 	// Allowed Value: RF
-	Channel string `xml:"Channel"`
+	Channel Channel `xml:"Channel"`
+}
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetVolumeDBRangeArgs) Validate() error {
+	switch a.Channel {
+	case "", ChannelMaster, ChannelLF, ChannelRF:
+	default:
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `GetVolumeDBRange`, Argument: `Channel`, Value: a.Channel, Allowed: "one of Master, LF, RF"}
+	}
+	return nil
 }
+
 type GetVolumeDBRangeResponse struct {
 	MinValue int16 `xml:"MinValue"`
 	MaxValue int16 `xml:"MaxValue"`
 }
 
 func (s *Service) GetVolumeDBRange(httpClient *http.Client, args *GetVolumeDBRangeArgs) (*GetVolumeDBRangeResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetVolumeDBRange`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetVolumeDBRange: args},
-		})
-	if err != nil {
+	return s.GetVolumeDBRangeContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetVolumeDBRangeContext(ctx context.Context, httpClient *http.Client, args *GetVolumeDBRangeArgs) (*GetVolumeDBRangeResponse, error) {
+	var r GetVolumeDBRangeResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetVolumeDBRange`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetVolumeDBRange == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.GetVolumeDBRange()`)
-	}
-
-	return r.Body.GetVolumeDBRange, nil
+	return &r, nil
 }
 
 type GetBassArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetBassArgs) Validate() error {
+	return nil
+}
+
 type GetBassResponse struct {
 	CurrentBass int16 `xml:"CurrentBass"`
 }
 
 func (s *Service) GetBass(httpClient *http.Client, args *GetBassArgs) (*GetBassResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetBass`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetBass: args},
-		})
-	if err != nil {
+	return s.GetBassContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetBassContext(ctx context.Context, httpClient *http.Client, args *GetBassArgs) (*GetBassResponse, error) {
+	var r GetBassResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetBass`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetBass == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.GetBass()`)
-	}
-
-	return r.Body.GetBass, nil
+	return &r, nil
 }
 
 type SetBassArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	// Allowed Range: -10 -> 10 step: 1
 	DesiredBass int16 `xml:"DesiredBass"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetBassArgs) Validate() error {
+	if a.DesiredBass < -10 || a.DesiredBass > 10 {
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `SetBass`, Argument: `DesiredBass`, Value: a.DesiredBass, Allowed: "-10 to 10"}
+	}
+	return nil
+}
+
 type SetBassResponse struct {
 }
 
 func (s *Service) SetBass(httpClient *http.Client, args *SetBassArgs) (*SetBassResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetBass`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetBass: args},
-		})
-	if err != nil {
+	return s.SetBassContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetBassContext(ctx context.Context, httpClient *http.Client, args *SetBassArgs) (*SetBassResponse, error) {
+	var r SetBassResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetBass`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetBass == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.SetBass()`)
-	}
-
-	return r.Body.SetBass, nil
+	return &r, nil
 }
 
 type GetTrebleArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetTrebleArgs) Validate() error {
+	return nil
+}
+
 type GetTrebleResponse struct {
 	CurrentTreble int16 `xml:"CurrentTreble"`
 }
 
 func (s *Service) GetTreble(httpClient *http.Client, args *GetTrebleArgs) (*GetTrebleResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetTreble`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetTreble: args},
-		})
-	if err != nil {
+	return s.GetTrebleContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetTrebleContext(ctx context.Context, httpClient *http.Client, args *GetTrebleArgs) (*GetTrebleResponse, error) {
+	var r GetTrebleResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetTreble`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetTreble == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.GetTreble()`)
-	}
-
-	return r.Body.GetTreble, nil
+	return &r, nil
 }
 
 type SetTrebleArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	// Allowed Range: -10 -> 10 step: 1
 	DesiredTreble int16 `xml:"DesiredTreble"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetTrebleArgs) Validate() error {
+	if a.DesiredTreble < -10 || a.DesiredTreble > 10 {
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `SetTreble`, Argument: `DesiredTreble`, Value: a.DesiredTreble, Allowed: "-10 to 10"}
+	}
+	return nil
+}
+
 type SetTrebleResponse struct {
 }
 
 func (s *Service) SetTreble(httpClient *http.Client, args *SetTrebleArgs) (*SetTrebleResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetTreble`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetTreble: args},
-		})
-	if err != nil {
+	return s.SetTrebleContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetTrebleContext(ctx context.Context, httpClient *http.Client, args *SetTrebleArgs) (*SetTrebleResponse, error) {
+	var r SetTrebleResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetTreble`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetTreble == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.SetTreble()`)
-	}
-
-	return r.Body.SetTreble, nil
+	return &r, nil
 }
 
 type GetEQArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	EQType     string `xml:"EQType"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetEQArgs) Validate() error {
+	return nil
+}
+
 type GetEQResponse struct {
 	CurrentValue int16 `xml:"CurrentValue"`
 }
 
 func (s *Service) GetEQ(httpClient *http.Client, args *GetEQArgs) (*GetEQResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetEQ`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetEQ: args},
-		})
-	if err != nil {
+	return s.GetEQContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetEQContext(ctx context.Context, httpClient *http.Client, args *GetEQArgs) (*GetEQResponse, error) {
+	var r GetEQResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetEQ`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetEQ == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.GetEQ()`)
-	}
-
-	return r.Body.GetEQ, nil
+	return &r, nil
 }
 
 type SetEQArgs struct {
-	Xmlns        string `xml:"xmlns:u,attr"`
 	InstanceID   uint32 `xml:"InstanceID"`
 	EQType       string `xml:"EQType"`
 	DesiredValue int16  `xml:"DesiredValue"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetEQArgs) Validate() error {
+	return nil
+}
+
 type SetEQResponse struct {
 }
 
 func (s *Service) SetEQ(httpClient *http.Client, args *SetEQArgs) (*SetEQResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetEQ`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetEQ: args},
-		})
-	if err != nil {
+	return s.SetEQContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetEQContext(ctx context.Context, httpClient *http.Client, args *SetEQArgs) (*SetEQResponse, error) {
+	var r SetEQResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetEQ`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetEQ == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.SetEQ()`)
-	}
-
-	return r.Body.SetEQ, nil
+	return &r, nil
 }
 
 type GetLoudnessArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	// Allowed Value: Master
 	// Allowed Value: LF
 	// Allowed Value: RF
-	Channel string `xml:"Channel"`
+	Channel Channel `xml:"Channel"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetLoudnessArgs) Validate() error {
+	switch a.Channel {
+	case "", ChannelMaster, ChannelLF, ChannelRF:
+	default:
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `GetLoudness`, Argument: `Channel`, Value: a.Channel, Allowed: "one of Master, LF, RF"}
+	}
+	return nil
+}
+
 type GetLoudnessResponse struct {
 	CurrentLoudness bool `xml:"CurrentLoudness"`
 }
 
 func (s *Service) GetLoudness(httpClient *http.Client, args *GetLoudnessArgs) (*GetLoudnessResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetLoudness`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetLoudness: args},
-		})
-	if err != nil {
+	return s.GetLoudnessContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetLoudnessContext(ctx context.Context, httpClient *http.Client, args *GetLoudnessArgs) (*GetLoudnessResponse, error) {
+	var r GetLoudnessResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetLoudness`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetLoudness == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.GetLoudness()`)
-	}
-
-	return r.Body.GetLoudness, nil
+	return &r, nil
 }
 
 type SetLoudnessArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	// Allowed Value: Master
 	// Allowed Value: LF
 	// Allowed Value: RF
-	Channel         string `xml:"Channel"`
-	DesiredLoudness bool   `xml:"DesiredLoudness"`
+	Channel         Channel `xml:"Channel"`
+	DesiredLoudness bool    `xml:"DesiredLoudness"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetLoudnessArgs) Validate() error {
+	switch a.Channel {
+	case "", ChannelMaster, ChannelLF, ChannelRF:
+	default:
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `SetLoudness`, Argument: `Channel`, Value: a.Channel, Allowed: "one of Master, LF, RF"}
+	}
+	return nil
+}
+
 type SetLoudnessResponse struct {
 }
 
 func (s *Service) SetLoudness(httpClient *http.Client, args *SetLoudnessArgs) (*SetLoudnessResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetLoudness`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetLoudness: args},
-		})
-	if err != nil {
+	return s.SetLoudnessContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetLoudnessContext(ctx context.Context, httpClient *http.Client, args *SetLoudnessArgs) (*SetLoudnessResponse, error) {
+	var r SetLoudnessResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetLoudness`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetLoudness == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.SetLoudness()`)
-	}
-
-	return r.Body.SetLoudness, nil
+	return &r, nil
 }
 
 type GetSupportsOutputFixedArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetSupportsOutputFixedArgs) Validate() error {
+	return nil
+}
+
 type GetSupportsOutputFixedResponse struct {
 	CurrentSupportsFixed bool `xml:"CurrentSupportsFixed"`
 }
 
 func (s *Service) GetSupportsOutputFixed(httpClient *http.Client, args *GetSupportsOutputFixedArgs) (*GetSupportsOutputFixedResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetSupportsOutputFixed`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetSupportsOutputFixed: args},
-		})
-	if err != nil {
+	return s.GetSupportsOutputFixedContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetSupportsOutputFixedContext(ctx context.Context, httpClient *http.Client, args *GetSupportsOutputFixedArgs) (*GetSupportsOutputFixedResponse, error) {
+	var r GetSupportsOutputFixedResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetSupportsOutputFixed`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetSupportsOutputFixed == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.GetSupportsOutputFixed()`)
-	}
-
-	return r.Body.GetSupportsOutputFixed, nil
+	return &r, nil
 }
 
 type GetOutputFixedArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetOutputFixedArgs) Validate() error {
+	return nil
+}
+
 type GetOutputFixedResponse struct {
 	CurrentFixed bool `xml:"CurrentFixed"`
 }
 
 func (s *Service) GetOutputFixed(httpClient *http.Client, args *GetOutputFixedArgs) (*GetOutputFixedResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetOutputFixed`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetOutputFixed: args},
-		})
-	if err != nil {
+	return s.GetOutputFixedContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetOutputFixedContext(ctx context.Context, httpClient *http.Client, args *GetOutputFixedArgs) (*GetOutputFixedResponse, error) {
+	var r GetOutputFixedResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetOutputFixed`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetOutputFixed == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.GetOutputFixed()`)
-	}
-
-	return r.Body.GetOutputFixed, nil
+	return &r, nil
 }
 
 type SetOutputFixedArgs struct {
-	Xmlns        string `xml:"xmlns:u,attr"`
 	InstanceID   uint32 `xml:"InstanceID"`
 	DesiredFixed bool   `xml:"DesiredFixed"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetOutputFixedArgs) Validate() error {
+	return nil
+}
+
 type SetOutputFixedResponse struct {
 }
 
 func (s *Service) SetOutputFixed(httpClient *http.Client, args *SetOutputFixedArgs) (*SetOutputFixedResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetOutputFixed`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetOutputFixed: args},
-		})
-	if err != nil {
+	return s.SetOutputFixedContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetOutputFixedContext(ctx context.Context, httpClient *http.Client, args *SetOutputFixedArgs) (*SetOutputFixedResponse, error) {
+	var r SetOutputFixedResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetOutputFixed`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetOutputFixed == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.SetOutputFixed()`)
-	}
-
-	return r.Body.SetOutputFixed, nil
+	return &r, nil
 }
 
 type GetHeadphoneConnectedArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetHeadphoneConnectedArgs) Validate() error {
+	return nil
+}
+
 type GetHeadphoneConnectedResponse struct {
 	CurrentHeadphoneConnected bool `xml:"CurrentHeadphoneConnected"`
 }
 
 func (s *Service) GetHeadphoneConnected(httpClient *http.Client, args *GetHeadphoneConnectedArgs) (*GetHeadphoneConnectedResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetHeadphoneConnected`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetHeadphoneConnected: args},
-		})
-	if err != nil {
+	return s.GetHeadphoneConnectedContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetHeadphoneConnectedContext(ctx context.Context, httpClient *http.Client, args *GetHeadphoneConnectedArgs) (*GetHeadphoneConnectedResponse, error) {
+	var r GetHeadphoneConnectedResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetHeadphoneConnected`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetHeadphoneConnected == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.GetHeadphoneConnected()`)
-	}
-
-	return r.Body.GetHeadphoneConnected, nil
+	return &r, nil
 }
 
 type RampToVolumeArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	// Allowed Value: Master
 	// Allowed Value: LF
 	// Allowed Value: RF
-	Channel string `xml:"Channel"`
+	Channel Channel `xml:"Channel"`
 	// Allowed Value: SLEEP_TIMER_RAMP_TYPE
 	// Allowed Value: ALARM_RAMP_TYPE
 	// Allowed Value: AUTOPLAY_RAMP_TYPE
-	RampType string `xml:"RampType"`
+	RampType RampType `xml:"RampType"`
 	// Allowed Range: 0 -> 100 step: 1
 	DesiredVolume    uint16 `xml:"DesiredVolume"`
 	ResetVolumeAfter bool   `xml:"ResetVolumeAfter"`
 	ProgramURI       string `xml:"ProgramURI"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *RampToVolumeArgs) Validate() error {
+	switch a.Channel {
+	case "", ChannelMaster, ChannelLF, ChannelRF:
+	default:
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `RampToVolume`, Argument: `Channel`, Value: a.Channel, Allowed: "one of Master, LF, RF"}
+	}
+	switch a.RampType {
+	case "", RampTypeSleepTimerRampType, RampTypeAlarmRampType, RampTypeAutoplayRampType:
+	default:
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `RampToVolume`, Argument: `RampType`, Value: a.RampType, Allowed: "one of SLEEP_TIMER_RAMP_TYPE, ALARM_RAMP_TYPE, AUTOPLAY_RAMP_TYPE"}
+	}
+	if a.DesiredVolume > 100 {
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `RampToVolume`, Argument: `DesiredVolume`, Value: a.DesiredVolume, Allowed: "0 to 100"}
+	}
+	return nil
+}
+
 type RampToVolumeResponse struct {
 	RampTime uint32 `xml:"RampTime"`
 }
 
 func (s *Service) RampToVolume(httpClient *http.Client, args *RampToVolumeArgs) (*RampToVolumeResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`RampToVolume`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{RampToVolume: args},
-		})
-	if err != nil {
+	return s.RampToVolumeContext(context.Background(), httpClient, args)
+}
+func (s *Service) RampToVolumeContext(ctx context.Context, httpClient *http.Client, args *RampToVolumeArgs) (*RampToVolumeResponse, error) {
+	var r RampToVolumeResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `RampToVolume`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.RampToVolume == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.RampToVolume()`)
-	}
-
-	return r.Body.RampToVolume, nil
+	return &r, nil
 }
 
 type RestoreVolumePriorToRampArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	// Allowed Value: Master
 	// Allowed Value: LF
 	// Allowed Value: RF
-	Channel string `xml:"Channel"`
+	Channel Channel `xml:"Channel"`
+}
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *RestoreVolumePriorToRampArgs) Validate() error {
+	switch a.Channel {
+	case "", ChannelMaster, ChannelLF, ChannelRF:
+	default:
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `RestoreVolumePriorToRamp`, Argument: `Channel`, Value: a.Channel, Allowed: "one of Master, LF, RF"}
+	}
+	return nil
 }
+
 type RestoreVolumePriorToRampResponse struct {
 }
 
 func (s *Service) RestoreVolumePriorToRamp(httpClient *http.Client, args *RestoreVolumePriorToRampArgs) (*RestoreVolumePriorToRampResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`RestoreVolumePriorToRamp`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{RestoreVolumePriorToRamp: args},
-		})
-	if err != nil {
+	return s.RestoreVolumePriorToRampContext(context.Background(), httpClient, args)
+}
+func (s *Service) RestoreVolumePriorToRampContext(ctx context.Context, httpClient *http.Client, args *RestoreVolumePriorToRampArgs) (*RestoreVolumePriorToRampResponse, error) {
+	var r RestoreVolumePriorToRampResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `RestoreVolumePriorToRamp`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.RestoreVolumePriorToRamp == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.RestoreVolumePriorToRamp()`)
-	}
-
-	return r.Body.RestoreVolumePriorToRamp, nil
+	return &r, nil
 }
 
 type SetChannelMapArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	ChannelMap string `xml:"ChannelMap"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetChannelMapArgs) Validate() error {
+	return nil
+}
+
 type SetChannelMapResponse struct {
 }
 
 func (s *Service) SetChannelMap(httpClient *http.Client, args *SetChannelMapArgs) (*SetChannelMapResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetChannelMap`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetChannelMap: args},
-		})
-	if err != nil {
+	return s.SetChannelMapContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetChannelMapContext(ctx context.Context, httpClient *http.Client, args *SetChannelMapArgs) (*SetChannelMapResponse, error) {
+	var r SetChannelMapResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetChannelMap`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetChannelMap == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.SetChannelMap()`)
-	}
-
-	return r.Body.SetChannelMap, nil
+	return &r, nil
 }
 
 type SetRoomCalibrationXArgs struct {
-	Xmlns           string `xml:"xmlns:u,attr"`
 	InstanceID      uint32 `xml:"InstanceID"`
 	CalibrationID   string `xml:"CalibrationID"`
 	Coefficients    string `xml:"Coefficients"`
 	CalibrationMode string `xml:"CalibrationMode"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetRoomCalibrationXArgs) Validate() error {
+	return nil
+}
+
 type SetRoomCalibrationXResponse struct {
 }
 
 func (s *Service) SetRoomCalibrationX(httpClient *http.Client, args *SetRoomCalibrationXArgs) (*SetRoomCalibrationXResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetRoomCalibrationX`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetRoomCalibrationX: args},
-		})
-	if err != nil {
+	return s.SetRoomCalibrationXContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetRoomCalibrationXContext(ctx context.Context, httpClient *http.Client, args *SetRoomCalibrationXArgs) (*SetRoomCalibrationXResponse, error) {
+	var r SetRoomCalibrationXResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetRoomCalibrationX`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetRoomCalibrationX == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.SetRoomCalibrationX()`)
-	}
-
-	return r.Body.SetRoomCalibrationX, nil
+	return &r, nil
 }
 
 type GetRoomCalibrationStatusArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetRoomCalibrationStatusArgs) Validate() error {
+	return nil
+}
+
 type GetRoomCalibrationStatusResponse struct {
 	RoomCalibrationEnabled   bool `xml:"RoomCalibrationEnabled"`
 	RoomCalibrationAvailable bool `xml:"RoomCalibrationAvailable"`
 }
 
 func (s *Service) GetRoomCalibrationStatus(httpClient *http.Client, args *GetRoomCalibrationStatusArgs) (*GetRoomCalibrationStatusResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetRoomCalibrationStatus`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetRoomCalibrationStatus: args},
-		})
-	if err != nil {
+	return s.GetRoomCalibrationStatusContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetRoomCalibrationStatusContext(ctx context.Context, httpClient *http.Client, args *GetRoomCalibrationStatusArgs) (*GetRoomCalibrationStatusResponse, error) {
+	var r GetRoomCalibrationStatusResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetRoomCalibrationStatus`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetRoomCalibrationStatus == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.GetRoomCalibrationStatus()`)
-	}
-
-	return r.Body.GetRoomCalibrationStatus, nil
+	return &r, nil
 }
 
 type SetRoomCalibrationStatusArgs struct {
-	Xmlns                  string `xml:"xmlns:u,attr"`
 	InstanceID             uint32 `xml:"InstanceID"`
 	RoomCalibrationEnabled bool   `xml:"RoomCalibrationEnabled"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetRoomCalibrationStatusArgs) Validate() error {
+	return nil
+}
+
 type SetRoomCalibrationStatusResponse struct {
 }
 
 func (s *Service) SetRoomCalibrationStatus(httpClient *http.Client, args *SetRoomCalibrationStatusArgs) (*SetRoomCalibrationStatusResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetRoomCalibrationStatus`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetRoomCalibrationStatus: args},
-		})
-	if err != nil {
+	return s.SetRoomCalibrationStatusContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetRoomCalibrationStatusContext(ctx context.Context, httpClient *http.Client, args *SetRoomCalibrationStatusArgs) (*SetRoomCalibrationStatusResponse, error) {
+	var r SetRoomCalibrationStatusResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetRoomCalibrationStatus`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetRoomCalibrationStatus == nil {
-		return nil, errors.New(`unexpected respose from service calling renderingcontrol.SetRoomCalibrationStatus()`)
+	return &r, nil
+}
+
+// Client is the set of actions of the service. It is implemented by Service and Fake
+type Client interface {
+	GetMute(httpClient *http.Client, args *GetMuteArgs) (*GetMuteResponse, error)
+	GetMuteContext(ctx context.Context, httpClient *http.Client, args *GetMuteArgs) (*GetMuteResponse, error)
+	SetMute(httpClient *http.Client, args *SetMuteArgs) (*SetMuteResponse, error)
+	SetMuteContext(ctx context.Context, httpClient *http.Client, args *SetMuteArgs) (*SetMuteResponse, error)
+	ResetBasicEQ(httpClient *http.Client, args *ResetBasicEQArgs) (*ResetBasicEQResponse, error)
+	ResetBasicEQContext(ctx context.Context, httpClient *http.Client, args *ResetBasicEQArgs) (*ResetBasicEQResponse, error)
+	ResetExtEQ(httpClient *http.Client, args *ResetExtEQArgs) (*ResetExtEQResponse, error)
+	ResetExtEQContext(ctx context.Context, httpClient *http.Client, args *ResetExtEQArgs) (*ResetExtEQResponse, error)
+	GetVolume(httpClient *http.Client, args *GetVolumeArgs) (*GetVolumeResponse, error)
+	GetVolumeContext(ctx context.Context, httpClient *http.Client, args *GetVolumeArgs) (*GetVolumeResponse, error)
+	SetVolume(httpClient *http.Client, args *SetVolumeArgs) (*SetVolumeResponse, error)
+	SetVolumeContext(ctx context.Context, httpClient *http.Client, args *SetVolumeArgs) (*SetVolumeResponse, error)
+	SetRelativeVolume(httpClient *http.Client, args *SetRelativeVolumeArgs) (*SetRelativeVolumeResponse, error)
+	SetRelativeVolumeContext(ctx context.Context, httpClient *http.Client, args *SetRelativeVolumeArgs) (*SetRelativeVolumeResponse, error)
+	GetVolumeDB(httpClient *http.Client, args *GetVolumeDBArgs) (*GetVolumeDBResponse, error)
+	GetVolumeDBContext(ctx context.Context, httpClient *http.Client, args *GetVolumeDBArgs) (*GetVolumeDBResponse, error)
+	SetVolumeDB(httpClient *http.Client, args *SetVolumeDBArgs) (*SetVolumeDBResponse, error)
+	SetVolumeDBContext(ctx context.Context, httpClient *http.Client, args *SetVolumeDBArgs) (*SetVolumeDBResponse, error)
+	GetVolumeDBRange(httpClient *http.Client, args *GetVolumeDBRangeArgs) (*GetVolumeDBRangeResponse, error)
+	GetVolumeDBRangeContext(ctx context.Context, httpClient *http.Client, args *GetVolumeDBRangeArgs) (*GetVolumeDBRangeResponse, error)
+	GetBass(httpClient *http.Client, args *GetBassArgs) (*GetBassResponse, error)
+	GetBassContext(ctx context.Context, httpClient *http.Client, args *GetBassArgs) (*GetBassResponse, error)
+	SetBass(httpClient *http.Client, args *SetBassArgs) (*SetBassResponse, error)
+	SetBassContext(ctx context.Context, httpClient *http.Client, args *SetBassArgs) (*SetBassResponse, error)
+	GetTreble(httpClient *http.Client, args *GetTrebleArgs) (*GetTrebleResponse, error)
+	GetTrebleContext(ctx context.Context, httpClient *http.Client, args *GetTrebleArgs) (*GetTrebleResponse, error)
+	SetTreble(httpClient *http.Client, args *SetTrebleArgs) (*SetTrebleResponse, error)
+	SetTrebleContext(ctx context.Context, httpClient *http.Client, args *SetTrebleArgs) (*SetTrebleResponse, error)
+	GetEQ(httpClient *http.Client, args *GetEQArgs) (*GetEQResponse, error)
+	GetEQContext(ctx context.Context, httpClient *http.Client, args *GetEQArgs) (*GetEQResponse, error)
+	SetEQ(httpClient *http.Client, args *SetEQArgs) (*SetEQResponse, error)
+	SetEQContext(ctx context.Context, httpClient *http.Client, args *SetEQArgs) (*SetEQResponse, error)
+	GetLoudness(httpClient *http.Client, args *GetLoudnessArgs) (*GetLoudnessResponse, error)
+	GetLoudnessContext(ctx context.Context, httpClient *http.Client, args *GetLoudnessArgs) (*GetLoudnessResponse, error)
+	SetLoudness(httpClient *http.Client, args *SetLoudnessArgs) (*SetLoudnessResponse, error)
+	SetLoudnessContext(ctx context.Context, httpClient *http.Client, args *SetLoudnessArgs) (*SetLoudnessResponse, error)
+	GetSupportsOutputFixed(httpClient *http.Client, args *GetSupportsOutputFixedArgs) (*GetSupportsOutputFixedResponse, error)
+	GetSupportsOutputFixedContext(ctx context.Context, httpClient *http.Client, args *GetSupportsOutputFixedArgs) (*GetSupportsOutputFixedResponse, error)
+	GetOutputFixed(httpClient *http.Client, args *GetOutputFixedArgs) (*GetOutputFixedResponse, error)
+	GetOutputFixedContext(ctx context.Context, httpClient *http.Client, args *GetOutputFixedArgs) (*GetOutputFixedResponse, error)
+	SetOutputFixed(httpClient *http.Client, args *SetOutputFixedArgs) (*SetOutputFixedResponse, error)
+	SetOutputFixedContext(ctx context.Context, httpClient *http.Client, args *SetOutputFixedArgs) (*SetOutputFixedResponse, error)
+	GetHeadphoneConnected(httpClient *http.Client, args *GetHeadphoneConnectedArgs) (*GetHeadphoneConnectedResponse, error)
+	GetHeadphoneConnectedContext(ctx context.Context, httpClient *http.Client, args *GetHeadphoneConnectedArgs) (*GetHeadphoneConnectedResponse, error)
+	RampToVolume(httpClient *http.Client, args *RampToVolumeArgs) (*RampToVolumeResponse, error)
+	RampToVolumeContext(ctx context.Context, httpClient *http.Client, args *RampToVolumeArgs) (*RampToVolumeResponse, error)
+	RestoreVolumePriorToRamp(httpClient *http.Client, args *RestoreVolumePriorToRampArgs) (*RestoreVolumePriorToRampResponse, error)
+	RestoreVolumePriorToRampContext(ctx context.Context, httpClient *http.Client, args *RestoreVolumePriorToRampArgs) (*RestoreVolumePriorToRampResponse, error)
+	SetChannelMap(httpClient *http.Client, args *SetChannelMapArgs) (*SetChannelMapResponse, error)
+	SetChannelMapContext(ctx context.Context, httpClient *http.Client, args *SetChannelMapArgs) (*SetChannelMapResponse, error)
+	SetRoomCalibrationX(httpClient *http.Client, args *SetRoomCalibrationXArgs) (*SetRoomCalibrationXResponse, error)
+	SetRoomCalibrationXContext(ctx context.Context, httpClient *http.Client, args *SetRoomCalibrationXArgs) (*SetRoomCalibrationXResponse, error)
+	GetRoomCalibrationStatus(httpClient *http.Client, args *GetRoomCalibrationStatusArgs) (*GetRoomCalibrationStatusResponse, error)
+	GetRoomCalibrationStatusContext(ctx context.Context, httpClient *http.Client, args *GetRoomCalibrationStatusArgs) (*GetRoomCalibrationStatusResponse, error)
+	SetRoomCalibrationStatus(httpClient *http.Client, args *SetRoomCalibrationStatusArgs) (*SetRoomCalibrationStatusResponse, error)
+	SetRoomCalibrationStatusContext(ctx context.Context, httpClient *http.Client, args *SetRoomCalibrationStatusArgs) (*SetRoomCalibrationStatusResponse, error)
+}
+
+var _ Client = (*Service)(nil)
+var _ Client = (*Fake)(nil)
+
+// Call is an action received by a Fake
+type Call struct {
+	Action string
+	// Args is the *<Action>Args the action was called with
+	Args interface{}
+}
+
+// Fake is an in-memory Client for tests. It records every call, and answers
+// an action with its On<Action> func, or with an empty response if that is nil
+type Fake struct {
+	mu                         sync.Mutex
+	calls                      []Call
+	OnGetMute                  func(ctx context.Context, args *GetMuteArgs) (*GetMuteResponse, error)
+	OnSetMute                  func(ctx context.Context, args *SetMuteArgs) (*SetMuteResponse, error)
+	OnResetBasicEQ             func(ctx context.Context, args *ResetBasicEQArgs) (*ResetBasicEQResponse, error)
+	OnResetExtEQ               func(ctx context.Context, args *ResetExtEQArgs) (*ResetExtEQResponse, error)
+	OnGetVolume                func(ctx context.Context, args *GetVolumeArgs) (*GetVolumeResponse, error)
+	OnSetVolume                func(ctx context.Context, args *SetVolumeArgs) (*SetVolumeResponse, error)
+	OnSetRelativeVolume        func(ctx context.Context, args *SetRelativeVolumeArgs) (*SetRelativeVolumeResponse, error)
+	OnGetVolumeDB              func(ctx context.Context, args *GetVolumeDBArgs) (*GetVolumeDBResponse, error)
+	OnSetVolumeDB              func(ctx context.Context, args *SetVolumeDBArgs) (*SetVolumeDBResponse, error)
+	OnGetVolumeDBRange         func(ctx context.Context, args *GetVolumeDBRangeArgs) (*GetVolumeDBRangeResponse, error)
+	OnGetBass                  func(ctx context.Context, args *GetBassArgs) (*GetBassResponse, error)
+	OnSetBass                  func(ctx context.Context, args *SetBassArgs) (*SetBassResponse, error)
+	OnGetTreble                func(ctx context.Context, args *GetTrebleArgs) (*GetTrebleResponse, error)
+	OnSetTreble                func(ctx context.Context, args *SetTrebleArgs) (*SetTrebleResponse, error)
+	OnGetEQ                    func(ctx context.Context, args *GetEQArgs) (*GetEQResponse, error)
+	OnSetEQ                    func(ctx context.Context, args *SetEQArgs) (*SetEQResponse, error)
+	OnGetLoudness              func(ctx context.Context, args *GetLoudnessArgs) (*GetLoudnessResponse, error)
+	OnSetLoudness              func(ctx context.Context, args *SetLoudnessArgs) (*SetLoudnessResponse, error)
+	OnGetSupportsOutputFixed   func(ctx context.Context, args *GetSupportsOutputFixedArgs) (*GetSupportsOutputFixedResponse, error)
+	OnGetOutputFixed           func(ctx context.Context, args *GetOutputFixedArgs) (*GetOutputFixedResponse, error)
+	OnSetOutputFixed           func(ctx context.Context, args *SetOutputFixedArgs) (*SetOutputFixedResponse, error)
+	OnGetHeadphoneConnected    func(ctx context.Context, args *GetHeadphoneConnectedArgs) (*GetHeadphoneConnectedResponse, error)
+	OnRampToVolume             func(ctx context.Context, args *RampToVolumeArgs) (*RampToVolumeResponse, error)
+	OnRestoreVolumePriorToRamp func(ctx context.Context, args *RestoreVolumePriorToRampArgs) (*RestoreVolumePriorToRampResponse, error)
+	OnSetChannelMap            func(ctx context.Context, args *SetChannelMapArgs) (*SetChannelMapResponse, error)
+	OnSetRoomCalibrationX      func(ctx context.Context, args *SetRoomCalibrationXArgs) (*SetRoomCalibrationXResponse, error)
+	OnGetRoomCalibrationStatus func(ctx context.Context, args *GetRoomCalibrationStatusArgs) (*GetRoomCalibrationStatusResponse, error)
+	OnSetRoomCalibrationStatus func(ctx context.Context, args *SetRoomCalibrationStatusArgs) (*SetRoomCalibrationStatusResponse, error)
+}
+
+// Calls returns the calls received so far, oldest first
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// Reset forgets the calls received so far
+func (f *Fake) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+}
+func (f *Fake) record(action string, args interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{Action: action, Args: args})
+}
+func (f *Fake) GetMute(httpClient *http.Client, args *GetMuteArgs) (*GetMuteResponse, error) {
+	return f.GetMuteContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetMuteContext(ctx context.Context, httpClient *http.Client, args *GetMuteArgs) (*GetMuteResponse, error) {
+	f.record(`GetMute`, args)
+	if f.OnGetMute != nil {
+		return f.OnGetMute(ctx, args)
+	}
+	return &GetMuteResponse{}, nil
+}
+func (f *Fake) SetMute(httpClient *http.Client, args *SetMuteArgs) (*SetMuteResponse, error) {
+	return f.SetMuteContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetMuteContext(ctx context.Context, httpClient *http.Client, args *SetMuteArgs) (*SetMuteResponse, error) {
+	f.record(`SetMute`, args)
+	if f.OnSetMute != nil {
+		return f.OnSetMute(ctx, args)
+	}
+	return &SetMuteResponse{}, nil
+}
+func (f *Fake) ResetBasicEQ(httpClient *http.Client, args *ResetBasicEQArgs) (*ResetBasicEQResponse, error) {
+	return f.ResetBasicEQContext(context.Background(), httpClient, args)
+}
+func (f *Fake) ResetBasicEQContext(ctx context.Context, httpClient *http.Client, args *ResetBasicEQArgs) (*ResetBasicEQResponse, error) {
+	f.record(`ResetBasicEQ`, args)
+	if f.OnResetBasicEQ != nil {
+		return f.OnResetBasicEQ(ctx, args)
+	}
+	return &ResetBasicEQResponse{}, nil
+}
+func (f *Fake) ResetExtEQ(httpClient *http.Client, args *ResetExtEQArgs) (*ResetExtEQResponse, error) {
+	return f.ResetExtEQContext(context.Background(), httpClient, args)
+}
+func (f *Fake) ResetExtEQContext(ctx context.Context, httpClient *http.Client, args *ResetExtEQArgs) (*ResetExtEQResponse, error) {
+	f.record(`ResetExtEQ`, args)
+	if f.OnResetExtEQ != nil {
+		return f.OnResetExtEQ(ctx, args)
 	}
-
-	return r.Body.SetRoomCalibrationStatus, nil
+	return &ResetExtEQResponse{}, nil
+}
+func (f *Fake) GetVolume(httpClient *http.Client, args *GetVolumeArgs) (*GetVolumeResponse, error) {
+	return f.GetVolumeContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetVolumeContext(ctx context.Context, httpClient *http.Client, args *GetVolumeArgs) (*GetVolumeResponse, error) {
+	f.record(`GetVolume`, args)
+	if f.OnGetVolume != nil {
+		return f.OnGetVolume(ctx, args)
+	}
+	return &GetVolumeResponse{}, nil
+}
+func (f *Fake) SetVolume(httpClient *http.Client, args *SetVolumeArgs) (*SetVolumeResponse, error) {
+	return f.SetVolumeContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetVolumeContext(ctx context.Context, httpClient *http.Client, args *SetVolumeArgs) (*SetVolumeResponse, error) {
+	f.record(`SetVolume`, args)
+	if f.OnSetVolume != nil {
+		return f.OnSetVolume(ctx, args)
+	}
+	return &SetVolumeResponse{}, nil
+}
+func (f *Fake) SetRelativeVolume(httpClient *http.Client, args *SetRelativeVolumeArgs) (*SetRelativeVolumeResponse, error) {
+	return f.SetRelativeVolumeContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetRelativeVolumeContext(ctx context.Context, httpClient *http.Client, args *SetRelativeVolumeArgs) (*SetRelativeVolumeResponse, error) {
+	f.record(`SetRelativeVolume`, args)
+	if f.OnSetRelativeVolume != nil {
+		return f.OnSetRelativeVolume(ctx, args)
+	}
+	return &SetRelativeVolumeResponse{}, nil
+}
+func (f *Fake) GetVolumeDB(httpClient *http.Client, args *GetVolumeDBArgs) (*GetVolumeDBResponse, error) {
+	return f.GetVolumeDBContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetVolumeDBContext(ctx context.Context, httpClient *http.Client, args *GetVolumeDBArgs) (*GetVolumeDBResponse, error) {
+	f.record(`GetVolumeDB`, args)
+	if f.OnGetVolumeDB != nil {
+		return f.OnGetVolumeDB(ctx, args)
+	}
+	return &GetVolumeDBResponse{}, nil
+}
+func (f *Fake) SetVolumeDB(httpClient *http.Client, args *SetVolumeDBArgs) (*SetVolumeDBResponse, error) {
+	return f.SetVolumeDBContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetVolumeDBContext(ctx context.Context, httpClient *http.Client, args *SetVolumeDBArgs) (*SetVolumeDBResponse, error) {
+	f.record(`SetVolumeDB`, args)
+	if f.OnSetVolumeDB != nil {
+		return f.OnSetVolumeDB(ctx, args)
+	}
+	return &SetVolumeDBResponse{}, nil
+}
+func (f *Fake) GetVolumeDBRange(httpClient *http.Client, args *GetVolumeDBRangeArgs) (*GetVolumeDBRangeResponse, error) {
+	return f.GetVolumeDBRangeContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetVolumeDBRangeContext(ctx context.Context, httpClient *http.Client, args *GetVolumeDBRangeArgs) (*GetVolumeDBRangeResponse, error) {
+	f.record(`GetVolumeDBRange`, args)
+	if f.OnGetVolumeDBRange != nil {
+		return f.OnGetVolumeDBRange(ctx, args)
+	}
+	return &GetVolumeDBRangeResponse{}, nil
+}
+func (f *Fake) GetBass(httpClient *http.Client, args *GetBassArgs) (*GetBassResponse, error) {
+	return f.GetBassContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetBassContext(ctx context.Context, httpClient *http.Client, args *GetBassArgs) (*GetBassResponse, error) {
+	f.record(`GetBass`, args)
+	if f.OnGetBass != nil {
+		return f.OnGetBass(ctx, args)
+	}
+	return &GetBassResponse{}, nil
+}
+func (f *Fake) SetBass(httpClient *http.Client, args *SetBassArgs) (*SetBassResponse, error) {
+	return f.SetBassContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetBassContext(ctx context.Context, httpClient *http.Client, args *SetBassArgs) (*SetBassResponse, error) {
+	f.record(`SetBass`, args)
+	if f.OnSetBass != nil {
+		return f.OnSetBass(ctx, args)
+	}
+	return &SetBassResponse{}, nil
+}
+func (f *Fake) GetTreble(httpClient *http.Client, args *GetTrebleArgs) (*GetTrebleResponse, error) {
+	return f.GetTrebleContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetTrebleContext(ctx context.Context, httpClient *http.Client, args *GetTrebleArgs) (*GetTrebleResponse, error) {
+	f.record(`GetTreble`, args)
+	if f.OnGetTreble != nil {
+		return f.OnGetTreble(ctx, args)
+	}
+	return &GetTrebleResponse{}, nil
+}
+func (f *Fake) SetTreble(httpClient *http.Client, args *SetTrebleArgs) (*SetTrebleResponse, error) {
+	return f.SetTrebleContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetTrebleContext(ctx context.Context, httpClient *http.Client, args *SetTrebleArgs) (*SetTrebleResponse, error) {
+	f.record(`SetTreble`, args)
+	if f.OnSetTreble != nil {
+		return f.OnSetTreble(ctx, args)
+	}
+	return &SetTrebleResponse{}, nil
+}
+func (f *Fake) GetEQ(httpClient *http.Client, args *GetEQArgs) (*GetEQResponse, error) {
+	return f.GetEQContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetEQContext(ctx context.Context, httpClient *http.Client, args *GetEQArgs) (*GetEQResponse, error) {
+	f.record(`GetEQ`, args)
+	if f.OnGetEQ != nil {
+		return f.OnGetEQ(ctx, args)
+	}
+	return &GetEQResponse{}, nil
+}
+func (f *Fake) SetEQ(httpClient *http.Client, args *SetEQArgs) (*SetEQResponse, error) {
+	return f.SetEQContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetEQContext(ctx context.Context, httpClient *http.Client, args *SetEQArgs) (*SetEQResponse, error) {
+	f.record(`SetEQ`, args)
+	if f.OnSetEQ != nil {
+		return f.OnSetEQ(ctx, args)
+	}
+	return &SetEQResponse{}, nil
+}
+func (f *Fake) GetLoudness(httpClient *http.Client, args *GetLoudnessArgs) (*GetLoudnessResponse, error) {
+	return f.GetLoudnessContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetLoudnessContext(ctx context.Context, httpClient *http.Client, args *GetLoudnessArgs) (*GetLoudnessResponse, error) {
+	f.record(`GetLoudness`, args)
+	if f.OnGetLoudness != nil {
+		return f.OnGetLoudness(ctx, args)
+	}
+	return &GetLoudnessResponse{}, nil
+}
+func (f *Fake) SetLoudness(httpClient *http.Client, args *SetLoudnessArgs) (*SetLoudnessResponse, error) {
+	return f.SetLoudnessContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetLoudnessContext(ctx context.Context, httpClient *http.Client, args *SetLoudnessArgs) (*SetLoudnessResponse, error) {
+	f.record(`SetLoudness`, args)
+	if f.OnSetLoudness != nil {
+		return f.OnSetLoudness(ctx, args)
+	}
+	return &SetLoudnessResponse{}, nil
+}
+func (f *Fake) GetSupportsOutputFixed(httpClient *http.Client, args *GetSupportsOutputFixedArgs) (*GetSupportsOutputFixedResponse, error) {
+	return f.GetSupportsOutputFixedContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetSupportsOutputFixedContext(ctx context.Context, httpClient *http.Client, args *GetSupportsOutputFixedArgs) (*GetSupportsOutputFixedResponse, error) {
+	f.record(`GetSupportsOutputFixed`, args)
+	if f.OnGetSupportsOutputFixed != nil {
+		return f.OnGetSupportsOutputFixed(ctx, args)
+	}
+	return &GetSupportsOutputFixedResponse{}, nil
+}
+func (f *Fake) GetOutputFixed(httpClient *http.Client, args *GetOutputFixedArgs) (*GetOutputFixedResponse, error) {
+	return f.GetOutputFixedContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetOutputFixedContext(ctx context.Context, httpClient *http.Client, args *GetOutputFixedArgs) (*GetOutputFixedResponse, error) {
+	f.record(`GetOutputFixed`, args)
+	if f.OnGetOutputFixed != nil {
+		return f.OnGetOutputFixed(ctx, args)
+	}
+	return &GetOutputFixedResponse{}, nil
+}
+func (f *Fake) SetOutputFixed(httpClient *http.Client, args *SetOutputFixedArgs) (*SetOutputFixedResponse, error) {
+	return f.SetOutputFixedContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetOutputFixedContext(ctx context.Context, httpClient *http.Client, args *SetOutputFixedArgs) (*SetOutputFixedResponse, error) {
+	f.record(`SetOutputFixed`, args)
+	if f.OnSetOutputFixed != nil {
+		return f.OnSetOutputFixed(ctx, args)
+	}
+	return &SetOutputFixedResponse{}, nil
+}
+func (f *Fake) GetHeadphoneConnected(httpClient *http.Client, args *GetHeadphoneConnectedArgs) (*GetHeadphoneConnectedResponse, error) {
+	return f.GetHeadphoneConnectedContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetHeadphoneConnectedContext(ctx context.Context, httpClient *http.Client, args *GetHeadphoneConnectedArgs) (*GetHeadphoneConnectedResponse, error) {
+	f.record(`GetHeadphoneConnected`, args)
+	if f.OnGetHeadphoneConnected != nil {
+		return f.OnGetHeadphoneConnected(ctx, args)
+	}
+	return &GetHeadphoneConnectedResponse{}, nil
+}
+func (f *Fake) RampToVolume(httpClient *http.Client, args *RampToVolumeArgs) (*RampToVolumeResponse, error) {
+	return f.RampToVolumeContext(context.Background(), httpClient, args)
+}
+func (f *Fake) RampToVolumeContext(ctx context.Context, httpClient *http.Client, args *RampToVolumeArgs) (*RampToVolumeResponse, error) {
+	f.record(`RampToVolume`, args)
+	if f.OnRampToVolume != nil {
+		return f.OnRampToVolume(ctx, args)
+	}
+	return &RampToVolumeResponse{}, nil
+}
+func (f *Fake) RestoreVolumePriorToRamp(httpClient *http.Client, args *RestoreVolumePriorToRampArgs) (*RestoreVolumePriorToRampResponse, error) {
+	return f.RestoreVolumePriorToRampContext(context.Background(), httpClient, args)
+}
+func (f *Fake) RestoreVolumePriorToRampContext(ctx context.Context, httpClient *http.Client, args *RestoreVolumePriorToRampArgs) (*RestoreVolumePriorToRampResponse, error) {
+	f.record(`RestoreVolumePriorToRamp`, args)
+	if f.OnRestoreVolumePriorToRamp != nil {
+		return f.OnRestoreVolumePriorToRamp(ctx, args)
+	}
+	return &RestoreVolumePriorToRampResponse{}, nil
+}
+func (f *Fake) SetChannelMap(httpClient *http.Client, args *SetChannelMapArgs) (*SetChannelMapResponse, error) {
+	return f.SetChannelMapContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetChannelMapContext(ctx context.Context, httpClient *http.Client, args *SetChannelMapArgs) (*SetChannelMapResponse, error) {
+	f.record(`SetChannelMap`, args)
+	if f.OnSetChannelMap != nil {
+		return f.OnSetChannelMap(ctx, args)
+	}
+	return &SetChannelMapResponse{}, nil
+}
+func (f *Fake) SetRoomCalibrationX(httpClient *http.Client, args *SetRoomCalibrationXArgs) (*SetRoomCalibrationXResponse, error) {
+	return f.SetRoomCalibrationXContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetRoomCalibrationXContext(ctx context.Context, httpClient *http.Client, args *SetRoomCalibrationXArgs) (*SetRoomCalibrationXResponse, error) {
+	f.record(`SetRoomCalibrationX`, args)
+	if f.OnSetRoomCalibrationX != nil {
+		return f.OnSetRoomCalibrationX(ctx, args)
+	}
+	return &SetRoomCalibrationXResponse{}, nil
+}
+func (f *Fake) GetRoomCalibrationStatus(httpClient *http.Client, args *GetRoomCalibrationStatusArgs) (*GetRoomCalibrationStatusResponse, error) {
+	return f.GetRoomCalibrationStatusContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetRoomCalibrationStatusContext(ctx context.Context, httpClient *http.Client, args *GetRoomCalibrationStatusArgs) (*GetRoomCalibrationStatusResponse, error) {
+	f.record(`GetRoomCalibrationStatus`, args)
+	if f.OnGetRoomCalibrationStatus != nil {
+		return f.OnGetRoomCalibrationStatus(ctx, args)
+	}
+	return &GetRoomCalibrationStatusResponse{}, nil
+}
+func (f *Fake) SetRoomCalibrationStatus(httpClient *http.Client, args *SetRoomCalibrationStatusArgs) (*SetRoomCalibrationStatusResponse, error) {
+	return f.SetRoomCalibrationStatusContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetRoomCalibrationStatusContext(ctx context.Context, httpClient *http.Client, args *SetRoomCalibrationStatusArgs) (*SetRoomCalibrationStatusResponse, error) {
+	f.record(`SetRoomCalibrationStatus`, args)
+	if f.OnSetRoomCalibrationStatus != nil {
+		return f.OnSetRoomCalibrationStatus(ctx, args)
+	}
+	return &SetRoomCalibrationStatusResponse{}, nil
 }
@@ -0,0 +1,151 @@
+package renderingcontrol
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+
+	"github.com/szatmary/sonos/internal/lastchange"
+)
+
+// LastChange is the content of a RenderingControl LastChange event, e.g. the
+// "LastChange" property of a gena.Event. Only the state variables that
+// changed are sent, the others are left nil, and the per channel maps only
+// hold the channels that changed.
+type LastChange struct {
+	InstanceID uint32
+
+	Volume   map[Channel]uint16
+	Mute     map[MuteChannel]bool
+	Loudness map[Channel]bool
+
+	Bass               *int16
+	Treble             *int16
+	OutputFixed        *bool
+	HeadphoneConnected *bool
+	NightMode          *bool
+	// DialogLevel is the speech enhancement of home theater players
+	DialogLevel        *bool
+	SubEnabled         *bool
+	SubGain            *int16
+	SubPolarity        *uint16
+	SubCrossover       *uint16
+	SurroundEnabled    *bool
+	SurroundLevel      *int16
+	MusicSurroundLevel *int16
+	SpeakerSize        *int16
+	PresetNameList     *string
+
+	// Other holds the state variables not listed above, by name and then by
+	// channel, which is empty for variables that have none
+	Other map[string]map[string]string
+}
+
+type lastChangeEvent struct {
+	XMLName   xml.Name `xml:"Event"`
+	Instances []struct {
+		ID        uint32 `xml:"val,attr"`
+		Variables []struct {
+			XMLName xml.Name
+			Channel string `xml:"channel,attr"`
+			Val     string `xml:"val,attr"`
+		} `xml:",any"`
+	} `xml:"InstanceID"`
+}
+
+// DecodeLastChange decodes the LastChange document of an event. Sonos players
+// only have InstanceID 0, so only the first instance is returned. A state
+// variable or channel whose value cannot be decoded is left out, and the
+// others are still returned, along with an error naming each one left out.
+func DecodeLastChange(lastChange string) (*LastChange, error) {
+	var event lastChangeEvent
+	if err := xml.Unmarshal([]byte(lastChange), &event); err != nil {
+		return nil, err
+	}
+	if len(event.Instances) == 0 {
+		return nil, errors.New("LastChange without InstanceID")
+	}
+
+	instance := event.Instances[0]
+	l := &LastChange{InstanceID: instance.ID}
+	var errs []error
+	for _, v := range instance.Variables {
+		if err := l.set(v.XMLName.Local, v.Channel, v.Val); err != nil {
+			name := v.XMLName.Local
+			if v.Channel != "" {
+				name += " " + v.Channel
+			}
+			errs = append(errs, fmt.Errorf("LastChange %s: %v", name, err))
+		}
+	}
+	return l, errors.Join(errs...)
+}
+
+func (l *LastChange) set(name, channel, val string) error {
+	var err error
+	switch name {
+	case "Volume":
+		var volume *uint16
+		if volume, err = lastchange.Uint16(val); err == nil {
+			if l.Volume == nil {
+				l.Volume = make(map[Channel]uint16)
+			}
+			l.Volume[Channel(channel)] = *volume
+		}
+	case "Mute":
+		var mute *bool
+		if mute, err = lastchange.Bool(val); err == nil {
+			if l.Mute == nil {
+				l.Mute = make(map[MuteChannel]bool)
+			}
+			l.Mute[MuteChannel(channel)] = *mute
+		}
+	case "Loudness":
+		var loudness *bool
+		if loudness, err = lastchange.Bool(val); err == nil {
+			if l.Loudness == nil {
+				l.Loudness = make(map[Channel]bool)
+			}
+			l.Loudness[Channel(channel)] = *loudness
+		}
+	case "Bass":
+		l.Bass, err = lastchange.Int16(val)
+	case "Treble":
+		l.Treble, err = lastchange.Int16(val)
+	case "OutputFixed":
+		l.OutputFixed, err = lastchange.Bool(val)
+	case "HeadphoneConnected":
+		l.HeadphoneConnected, err = lastchange.Bool(val)
+	case "NightMode":
+		l.NightMode, err = lastchange.Bool(val)
+	case "DialogLevel":
+		l.DialogLevel, err = lastchange.Bool(val)
+	case "SubEnabled":
+		l.SubEnabled, err = lastchange.Bool(val)
+	case "SubGain":
+		l.SubGain, err = lastchange.Int16(val)
+	case "SubPolarity":
+		l.SubPolarity, err = lastchange.Uint16(val)
+	case "SubCrossover":
+		l.SubCrossover, err = lastchange.Uint16(val)
+	case "SurroundEnabled":
+		l.SurroundEnabled, err = lastchange.Bool(val)
+	case "SurroundLevel":
+		l.SurroundLevel, err = lastchange.Int16(val)
+	case "MusicSurroundLevel":
+		l.MusicSurroundLevel, err = lastchange.Int16(val)
+	case "SpeakerSize":
+		l.SpeakerSize, err = lastchange.Int16(val)
+	case "PresetNameList":
+		l.PresetNameList = &val
+	default:
+		if l.Other == nil {
+			l.Other = make(map[string]map[string]string)
+		}
+		if l.Other[name] == nil {
+			l.Other[name] = make(map[string]string)
+		}
+		l.Other[name][channel] = val
+	}
+	return err
+}
@@ -0,0 +1,161 @@
+package renderingcontrol
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// lastChange is the LastChange property of a RenderingControl event as sent
+// by a Sonos home theater player
+const lastChange = `<Event xmlns="urn:schemas-upnp-org:metadata-1-0/RCS/">` +
+	`<InstanceID val="0">` +
+	`<Volume channel="Master" val="23"/>` +
+	`<Volume channel="LF" val="100"/>` +
+	`<Volume channel="RF" val="100"/>` +
+	`<Mute channel="Master" val="0"/>` +
+	`<Mute channel="LF" val="0"/>` +
+	`<Mute channel="RF" val="0"/>` +
+	`<Mute channel="SpeakerOnly" val="1"/>` +
+	`<Bass val="-2"/>` +
+	`<Treble val="3"/>` +
+	`<Loudness channel="Master" val="1"/>` +
+	`<OutputFixed val="0"/>` +
+	`<HeadphoneConnected val="0"/>` +
+	`<SpeakerSize val="5"/>` +
+	`<SubGain val="-4"/>` +
+	`<SubCrossover val="0"/>` +
+	`<SubPolarity val="0"/>` +
+	`<SubEnabled val="1"/>` +
+	`<SonarEnabled val="0"/>` +
+	`<SonarCalibrationAvailable val="0"/>` +
+	`<NightMode val="1"/>` +
+	`<DialogLevel val="0"/>` +
+	`<SurroundEnabled val="1"/>` +
+	`<SurroundLevel val="0"/>` +
+	`<MusicSurroundLevel val="2"/>` +
+	`<PresetNameList val="FactoryDefaults"/>` +
+	`</InstanceID></Event>`
+
+func TestDecodeLastChange(t *testing.T) {
+	l, err := DecodeLastChange(lastChange)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := map[Channel]uint16{ChannelMaster: 23, ChannelLF: 100, ChannelRF: 100}; !reflect.DeepEqual(l.Volume, want) {
+		t.Errorf("Volume = %v, want %v", l.Volume, want)
+	}
+	if want := map[MuteChannel]bool{MuteChannelMaster: false, MuteChannelLF: false, MuteChannelRF: false, MuteChannelSpeakerOnly: true}; !reflect.DeepEqual(l.Mute, want) {
+		t.Errorf("Mute = %v, want %v", l.Mute, want)
+	}
+	if want := map[Channel]bool{ChannelMaster: true}; !reflect.DeepEqual(l.Loudness, want) {
+		t.Errorf("Loudness = %v, want %v", l.Loudness, want)
+	}
+
+	int16s := map[string]struct {
+		got  *int16
+		want int16
+	}{
+		"Bass":               {l.Bass, -2},
+		"Treble":             {l.Treble, 3},
+		"SpeakerSize":        {l.SpeakerSize, 5},
+		"SubGain":            {l.SubGain, -4},
+		"SurroundLevel":      {l.SurroundLevel, 0},
+		"MusicSurroundLevel": {l.MusicSurroundLevel, 2},
+	}
+	for name, v := range int16s {
+		if v.got == nil || *v.got != v.want {
+			t.Errorf("%s = %v, want %d", name, v.got, v.want)
+		}
+	}
+	bools := map[string]struct {
+		got  *bool
+		want bool
+	}{
+		"OutputFixed":        {l.OutputFixed, false},
+		"HeadphoneConnected": {l.HeadphoneConnected, false},
+		"SubEnabled":         {l.SubEnabled, true},
+		"NightMode":          {l.NightMode, true},
+		"DialogLevel":        {l.DialogLevel, false},
+		"SurroundEnabled":    {l.SurroundEnabled, true},
+	}
+	for name, v := range bools {
+		if v.got == nil || *v.got != v.want {
+			t.Errorf("%s = %v, want %t", name, v.got, v.want)
+		}
+	}
+	if l.SubCrossover == nil || *l.SubCrossover != 0 || l.SubPolarity == nil || *l.SubPolarity != 0 {
+		t.Errorf("SubCrossover = %v, SubPolarity = %v", l.SubCrossover, l.SubPolarity)
+	}
+	if l.PresetNameList == nil || *l.PresetNameList != "FactoryDefaults" {
+		t.Errorf("PresetNameList = %v", l.PresetNameList)
+	}
+
+	wantOther := map[string]map[string]string{
+		"SonarEnabled":              {"": "0"},
+		"SonarCalibrationAvailable": {"": "0"},
+	}
+	if !reflect.DeepEqual(l.Other, wantOther) {
+		t.Errorf("Other = %v, want %v", l.Other, wantOther)
+	}
+}
+
+// Only the channels that changed are in the maps, and variables that did not
+// change are left nil
+func TestDecodeLastChangePartial(t *testing.T) {
+	l, err := DecodeLastChange(`<Event xmlns="urn:schemas-upnp-org:metadata-1-0/RCS/"><InstanceID val="0"><Volume channel="Master" val="40"/></InstanceID></Event>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[Channel]uint16{ChannelMaster: 40}; !reflect.DeepEqual(l.Volume, want) {
+		t.Errorf("Volume = %v, want %v", l.Volume, want)
+	}
+	if l.Mute != nil || l.Loudness != nil || l.Bass != nil || l.Other != nil {
+		t.Errorf("unchanged variables are set: %+v", l)
+	}
+}
+
+func TestDecodeLastChangeErrors(t *testing.T) {
+	for _, lastChange := range []string{
+		``,
+		`<Event xmlns="urn:schemas-upnp-org:metadata-1-0/RCS/"></Event>`,
+		`<Event><InstanceID val="0"><Volume channel="Master" val="loud"/></InstanceID></Event>`,
+		`<Event><InstanceID val="0"><Volume channel="Master" val="70000"/></InstanceID></Event>`,
+		`<Event><InstanceID val="0"><Mute channel="Master" val="maybe"/></InstanceID></Event>`,
+		`<Event><InstanceID val="0"><Bass val="-40000"/></InstanceID></Event>`,
+	} {
+		if l, err := DecodeLastChange(lastChange); err == nil {
+			t.Errorf("DecodeLastChange(%q) = %+v, want an error", lastChange, l)
+		}
+	}
+}
+
+// A malformed variable or channel is left out without losing the others
+func TestDecodeLastChangeMalformed(t *testing.T) {
+	l, err := DecodeLastChange(`<Event xmlns="urn:schemas-upnp-org:metadata-1-0/RCS/"><InstanceID val="0">` +
+		`<Volume channel="Master" val="23"/>` +
+		`<Volume channel="LF" val="loud"/>` +
+		`<Mute channel="Master" val="no"/>` +
+		`<Bass val="-40000"/>` +
+		`<Treble val="-2"/>` +
+		`</InstanceID></Event>`)
+	if err == nil || !strings.Contains(err.Error(), "Volume LF") || !strings.Contains(err.Error(), "Bass") {
+		t.Errorf("DecodeLastChange = %v, want an error naming Volume LF and Bass", err)
+	}
+	if l == nil {
+		t.Fatal("DecodeLastChange = nil, want the variables that could be decoded")
+	}
+	if want := map[Channel]uint16{ChannelMaster: 23}; !reflect.DeepEqual(l.Volume, want) {
+		t.Errorf("Volume = %v, want %v", l.Volume, want)
+	}
+	if want := map[MuteChannel]bool{MuteChannelMaster: false}; !reflect.DeepEqual(l.Mute, want) {
+		t.Errorf("Mute = %v, want %v", l.Mute, want)
+	}
+	if l.Treble == nil || *l.Treble != -2 {
+		t.Errorf("Treble = %v", l.Treble)
+	}
+	if l.Bass != nil {
+		t.Errorf("Bass = %d, want it left out", *l.Bass)
+	}
+}
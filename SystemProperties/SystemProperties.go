@@ -1,23 +1,28 @@
 package systemproperties
 
 import (
-	"bytes"
-	"encoding/xml"
-	"errors"
-	"io/ioutil"
+	"context"
 	"net/http"
 	"net/url"
-)
+	"sync"
 
-const (
-	_ServiceURN     = "urn:schemas-upnp-org:service:SystemProperties:1"
-	_EncodingSchema = "http://schemas.xmlsoap.org/soap/encoding/"
-	_EnvelopeSchema = "http://schemas.xmlsoap.org/soap/envelope/"
+	"github.com/szatmary/sonos/soap"
 )
 
+const _ServiceURN = "urn:schemas-upnp-org:service:SystemProperties:1"
+
+// ErrorCodes describes the error codes specific to this service
+var ErrorCodes = map[int]string{}
+
+func init() {
+	soap.RegisterErrorCodes(_ServiceURN, ErrorCodes)
+}
+
 type Service struct {
 	ControlEndpoint *url.URL
 	EventEndpoint   *url.URL
+	// Client sends the actions, soap.DefaultClient is used if it is nil
+	Client *soap.Client
 }
 
 func NewService(deviceUrl *url.URL) *Service {
@@ -35,251 +40,155 @@ func NewService(deviceUrl *url.URL) *Service {
 	}
 }
 
-type Envelope struct {
-	XMLName       xml.Name `xml:"s:Envelope"`
-	Xmlns         string   `xml:"xmlns:s,attr"`
-	EncodingStyle string   `xml:"s:encodingStyle,attr"`
-	Body          Body     `xml:"s:Body"`
-}
-type Body struct {
-	XMLName                            xml.Name                                `xml:"s:Body"`
-	SetString                          *SetStringArgs                          `xml:"u:SetString,omitempty"`
-	GetString                          *GetStringArgs                          `xml:"u:GetString,omitempty"`
-	Remove                             *RemoveArgs                             `xml:"u:Remove,omitempty"`
-	GetWebCode                         *GetWebCodeArgs                         `xml:"u:GetWebCode,omitempty"`
-	ProvisionCredentialedTrialAccountX *ProvisionCredentialedTrialAccountXArgs `xml:"u:ProvisionCredentialedTrialAccountX,omitempty"`
-	AddAccountX                        *AddAccountXArgs                        `xml:"u:AddAccountX,omitempty"`
-	AddOAuthAccountX                   *AddOAuthAccountXArgs                   `xml:"u:AddOAuthAccountX,omitempty"`
-	RemoveAccount                      *RemoveAccountArgs                      `xml:"u:RemoveAccount,omitempty"`
-	EditAccountPasswordX               *EditAccountPasswordXArgs               `xml:"u:EditAccountPasswordX,omitempty"`
-	SetAccountNicknameX                *SetAccountNicknameXArgs                `xml:"u:SetAccountNicknameX,omitempty"`
-	RefreshAccountCredentialsX         *RefreshAccountCredentialsXArgs         `xml:"u:RefreshAccountCredentialsX,omitempty"`
-	EditAccountMd                      *EditAccountMdArgs                      `xml:"u:EditAccountMd,omitempty"`
-	DoPostUpdateTasks                  *DoPostUpdateTasksArgs                  `xml:"u:DoPostUpdateTasks,omitempty"`
-	ResetThirdPartyCredentials         *ResetThirdPartyCredentialsArgs         `xml:"u:ResetThirdPartyCredentials,omitempty"`
-	EnableRDM                          *EnableRDMArgs                          `xml:"u:EnableRDM,omitempty"`
-	GetRDM                             *GetRDMArgs                             `xml:"u:GetRDM,omitempty"`
-	ReplaceAccountX                    *ReplaceAccountXArgs                    `xml:"u:ReplaceAccountX,omitempty"`
-}
-type EnvelopeResponse struct {
-	XMLName       xml.Name     `xml:"Envelope"`
-	Xmlns         string       `xml:"xmlns:s,attr"`
-	EncodingStyle string       `xml:"encodingStyle,attr"`
-	Body          BodyResponse `xml:"Body"`
-}
-type BodyResponse struct {
-	XMLName                            xml.Name                                    `xml:"Body"`
-	SetString                          *SetStringResponse                          `xml:"SetStringResponse,omitempty"`
-	GetString                          *GetStringResponse                          `xml:"GetStringResponse,omitempty"`
-	Remove                             *RemoveResponse                             `xml:"RemoveResponse,omitempty"`
-	GetWebCode                         *GetWebCodeResponse                         `xml:"GetWebCodeResponse,omitempty"`
-	ProvisionCredentialedTrialAccountX *ProvisionCredentialedTrialAccountXResponse `xml:"ProvisionCredentialedTrialAccountXResponse,omitempty"`
-	AddAccountX                        *AddAccountXResponse                        `xml:"AddAccountXResponse,omitempty"`
-	AddOAuthAccountX                   *AddOAuthAccountXResponse                   `xml:"AddOAuthAccountXResponse,omitempty"`
-	RemoveAccount                      *RemoveAccountResponse                      `xml:"RemoveAccountResponse,omitempty"`
-	EditAccountPasswordX               *EditAccountPasswordXResponse               `xml:"EditAccountPasswordXResponse,omitempty"`
-	SetAccountNicknameX                *SetAccountNicknameXResponse                `xml:"SetAccountNicknameXResponse,omitempty"`
-	RefreshAccountCredentialsX         *RefreshAccountCredentialsXResponse         `xml:"RefreshAccountCredentialsXResponse,omitempty"`
-	EditAccountMd                      *EditAccountMdResponse                      `xml:"EditAccountMdResponse,omitempty"`
-	DoPostUpdateTasks                  *DoPostUpdateTasksResponse                  `xml:"DoPostUpdateTasksResponse,omitempty"`
-	ResetThirdPartyCredentials         *ResetThirdPartyCredentialsResponse         `xml:"ResetThirdPartyCredentialsResponse,omitempty"`
-	EnableRDM                          *EnableRDMResponse                          `xml:"EnableRDMResponse,omitempty"`
-	GetRDM                             *GetRDMResponse                             `xml:"GetRDMResponse,omitempty"`
-	ReplaceAccountX                    *ReplaceAccountXResponse                    `xml:"ReplaceAccountXResponse,omitempty"`
-}
-
-func (s *Service) exec(actionName string, httpClient *http.Client, envelope *Envelope) (*EnvelopeResponse, error) {
-	marshaled, err := xml.Marshal(envelope)
-	if err != nil {
-		return nil, err
-	}
-	postBody := []byte(`<?xml version="1.0"?>`)
-	postBody = append(postBody, marshaled...)
-	req, err := http.NewRequest(`POST`, s.ControlEndpoint.String(), bytes.NewBuffer(postBody))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set(`Content-Type`, `text/xml; charset="utf-8"`)
-	req.Header.Set(`SOAPAction`, _ServiceURN+`#`+actionName)
-	res, err := httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-	responseBody, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-	var envelopeResponse EnvelopeResponse
-	err = xml.Unmarshal(responseBody, &envelopeResponse)
-	if err != nil {
-		return nil, err
-	}
-	return &envelopeResponse, nil
-}
-
 type SetStringArgs struct {
-	Xmlns        string `xml:"xmlns:u,attr"`
 	VariableName string `xml:"VariableName"`
 	StringValue  string `xml:"StringValue"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetStringArgs) Validate() error {
+	return nil
+}
+
 type SetStringResponse struct {
 }
 
 func (s *Service) SetString(httpClient *http.Client, args *SetStringArgs) (*SetStringResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetString`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetString: args},
-		})
-	if err != nil {
+	return s.SetStringContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetStringContext(ctx context.Context, httpClient *http.Client, args *SetStringArgs) (*SetStringResponse, error) {
+	var r SetStringResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetString`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetString == nil {
-		return nil, errors.New(`unexpected respose from service calling systemproperties.SetString()`)
-	}
-
-	return r.Body.SetString, nil
+	return &r, nil
 }
 
 type GetStringArgs struct {
-	Xmlns        string `xml:"xmlns:u,attr"`
 	VariableName string `xml:"VariableName"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetStringArgs) Validate() error {
+	return nil
+}
+
 type GetStringResponse struct {
 	StringValue string `xml:"StringValue"`
 }
 
 func (s *Service) GetString(httpClient *http.Client, args *GetStringArgs) (*GetStringResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetString`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetString: args},
-		})
-	if err != nil {
+	return s.GetStringContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetStringContext(ctx context.Context, httpClient *http.Client, args *GetStringArgs) (*GetStringResponse, error) {
+	var r GetStringResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetString`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetString == nil {
-		return nil, errors.New(`unexpected respose from service calling systemproperties.GetString()`)
-	}
-
-	return r.Body.GetString, nil
+	return &r, nil
 }
 
 type RemoveArgs struct {
-	Xmlns        string `xml:"xmlns:u,attr"`
 	VariableName string `xml:"VariableName"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *RemoveArgs) Validate() error {
+	return nil
+}
+
 type RemoveResponse struct {
 }
 
 func (s *Service) Remove(httpClient *http.Client, args *RemoveArgs) (*RemoveResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`Remove`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{Remove: args},
-		})
-	if err != nil {
+	return s.RemoveContext(context.Background(), httpClient, args)
+}
+func (s *Service) RemoveContext(ctx context.Context, httpClient *http.Client, args *RemoveArgs) (*RemoveResponse, error) {
+	var r RemoveResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `Remove`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.Remove == nil {
-		return nil, errors.New(`unexpected respose from service calling systemproperties.Remove()`)
-	}
-
-	return r.Body.Remove, nil
+	return &r, nil
 }
 
 type GetWebCodeArgs struct {
-	Xmlns       string `xml:"xmlns:u,attr"`
 	AccountType uint32 `xml:"AccountType"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetWebCodeArgs) Validate() error {
+	return nil
+}
+
 type GetWebCodeResponse struct {
 	WebCode string `xml:"WebCode"`
 }
 
 func (s *Service) GetWebCode(httpClient *http.Client, args *GetWebCodeArgs) (*GetWebCodeResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetWebCode`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetWebCode: args},
-		})
-	if err != nil {
+	return s.GetWebCodeContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetWebCodeContext(ctx context.Context, httpClient *http.Client, args *GetWebCodeArgs) (*GetWebCodeResponse, error) {
+	var r GetWebCodeResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetWebCode`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetWebCode == nil {
-		return nil, errors.New(`unexpected respose from service calling systemproperties.GetWebCode()`)
-	}
-
-	return r.Body.GetWebCode, nil
+	return &r, nil
 }
 
 type ProvisionCredentialedTrialAccountXArgs struct {
-	Xmlns           string `xml:"xmlns:u,attr"`
 	AccountType     uint32 `xml:"AccountType"`
 	AccountID       string `xml:"AccountID"`
 	AccountPassword string `xml:"AccountPassword"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *ProvisionCredentialedTrialAccountXArgs) Validate() error {
+	return nil
+}
+
 type ProvisionCredentialedTrialAccountXResponse struct {
 	IsExpired  bool   `xml:"IsExpired"`
 	AccountUDN string `xml:"AccountUDN"`
 }
 
 func (s *Service) ProvisionCredentialedTrialAccountX(httpClient *http.Client, args *ProvisionCredentialedTrialAccountXArgs) (*ProvisionCredentialedTrialAccountXResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`ProvisionCredentialedTrialAccountX`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{ProvisionCredentialedTrialAccountX: args},
-		})
-	if err != nil {
+	return s.ProvisionCredentialedTrialAccountXContext(context.Background(), httpClient, args)
+}
+func (s *Service) ProvisionCredentialedTrialAccountXContext(ctx context.Context, httpClient *http.Client, args *ProvisionCredentialedTrialAccountXArgs) (*ProvisionCredentialedTrialAccountXResponse, error) {
+	var r ProvisionCredentialedTrialAccountXResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `ProvisionCredentialedTrialAccountX`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.ProvisionCredentialedTrialAccountX == nil {
-		return nil, errors.New(`unexpected respose from service calling systemproperties.ProvisionCredentialedTrialAccountX()`)
-	}
-
-	return r.Body.ProvisionCredentialedTrialAccountX, nil
+	return &r, nil
 }
 
 type AddAccountXArgs struct {
-	Xmlns           string `xml:"xmlns:u,attr"`
 	AccountType     uint32 `xml:"AccountType"`
 	AccountID       string `xml:"AccountID"`
 	AccountPassword string `xml:"AccountPassword"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *AddAccountXArgs) Validate() error {
+	return nil
+}
+
 type AddAccountXResponse struct {
 	AccountUDN string `xml:"AccountUDN"`
 }
 
 func (s *Service) AddAccountX(httpClient *http.Client, args *AddAccountXArgs) (*AddAccountXResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`AddAccountX`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{AddAccountX: args},
-		})
-	if err != nil {
+	return s.AddAccountXContext(context.Background(), httpClient, args)
+}
+func (s *Service) AddAccountXContext(ctx context.Context, httpClient *http.Client, args *AddAccountXArgs) (*AddAccountXResponse, error) {
+	var r AddAccountXResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `AddAccountX`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.AddAccountX == nil {
-		return nil, errors.New(`unexpected respose from service calling systemproperties.AddAccountX()`)
-	}
-
-	return r.Body.AddAccountX, nil
+	return &r, nil
 }
 
 type AddOAuthAccountXArgs struct {
-	Xmlns             string `xml:"xmlns:u,attr"`
 	AccountType       uint32 `xml:"AccountType"`
 	AccountToken      string `xml:"AccountToken"`
 	AccountKey        string `xml:"AccountKey"`
@@ -289,263 +198,243 @@ type AddOAuthAccountXArgs struct {
 	UserIdHashCode    string `xml:"UserIdHashCode"`
 	AccountTier       uint32 `xml:"AccountTier"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *AddOAuthAccountXArgs) Validate() error {
+	return nil
+}
+
 type AddOAuthAccountXResponse struct {
 	AccountUDN      string `xml:"AccountUDN"`
 	AccountNickname string `xml:"AccountNickname"`
 }
 
 func (s *Service) AddOAuthAccountX(httpClient *http.Client, args *AddOAuthAccountXArgs) (*AddOAuthAccountXResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`AddOAuthAccountX`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{AddOAuthAccountX: args},
-		})
-	if err != nil {
+	return s.AddOAuthAccountXContext(context.Background(), httpClient, args)
+}
+func (s *Service) AddOAuthAccountXContext(ctx context.Context, httpClient *http.Client, args *AddOAuthAccountXArgs) (*AddOAuthAccountXResponse, error) {
+	var r AddOAuthAccountXResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `AddOAuthAccountX`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.AddOAuthAccountX == nil {
-		return nil, errors.New(`unexpected respose from service calling systemproperties.AddOAuthAccountX()`)
-	}
-
-	return r.Body.AddOAuthAccountX, nil
+	return &r, nil
 }
 
 type RemoveAccountArgs struct {
-	Xmlns       string `xml:"xmlns:u,attr"`
 	AccountType uint32 `xml:"AccountType"`
 	AccountID   string `xml:"AccountID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *RemoveAccountArgs) Validate() error {
+	return nil
+}
+
 type RemoveAccountResponse struct {
 }
 
 func (s *Service) RemoveAccount(httpClient *http.Client, args *RemoveAccountArgs) (*RemoveAccountResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`RemoveAccount`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{RemoveAccount: args},
-		})
-	if err != nil {
+	return s.RemoveAccountContext(context.Background(), httpClient, args)
+}
+func (s *Service) RemoveAccountContext(ctx context.Context, httpClient *http.Client, args *RemoveAccountArgs) (*RemoveAccountResponse, error) {
+	var r RemoveAccountResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `RemoveAccount`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.RemoveAccount == nil {
-		return nil, errors.New(`unexpected respose from service calling systemproperties.RemoveAccount()`)
-	}
-
-	return r.Body.RemoveAccount, nil
+	return &r, nil
 }
 
 type EditAccountPasswordXArgs struct {
-	Xmlns              string `xml:"xmlns:u,attr"`
 	AccountType        uint32 `xml:"AccountType"`
 	AccountID          string `xml:"AccountID"`
 	NewAccountPassword string `xml:"NewAccountPassword"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *EditAccountPasswordXArgs) Validate() error {
+	return nil
+}
+
 type EditAccountPasswordXResponse struct {
 }
 
 func (s *Service) EditAccountPasswordX(httpClient *http.Client, args *EditAccountPasswordXArgs) (*EditAccountPasswordXResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`EditAccountPasswordX`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{EditAccountPasswordX: args},
-		})
-	if err != nil {
+	return s.EditAccountPasswordXContext(context.Background(), httpClient, args)
+}
+func (s *Service) EditAccountPasswordXContext(ctx context.Context, httpClient *http.Client, args *EditAccountPasswordXArgs) (*EditAccountPasswordXResponse, error) {
+	var r EditAccountPasswordXResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `EditAccountPasswordX`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.EditAccountPasswordX == nil {
-		return nil, errors.New(`unexpected respose from service calling systemproperties.EditAccountPasswordX()`)
-	}
-
-	return r.Body.EditAccountPasswordX, nil
+	return &r, nil
 }
 
 type SetAccountNicknameXArgs struct {
-	Xmlns           string `xml:"xmlns:u,attr"`
 	AccountUDN      string `xml:"AccountUDN"`
 	AccountNickname string `xml:"AccountNickname"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetAccountNicknameXArgs) Validate() error {
+	return nil
+}
+
 type SetAccountNicknameXResponse struct {
 }
 
 func (s *Service) SetAccountNicknameX(httpClient *http.Client, args *SetAccountNicknameXArgs) (*SetAccountNicknameXResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetAccountNicknameX`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetAccountNicknameX: args},
-		})
-	if err != nil {
+	return s.SetAccountNicknameXContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetAccountNicknameXContext(ctx context.Context, httpClient *http.Client, args *SetAccountNicknameXArgs) (*SetAccountNicknameXResponse, error) {
+	var r SetAccountNicknameXResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetAccountNicknameX`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetAccountNicknameX == nil {
-		return nil, errors.New(`unexpected respose from service calling systemproperties.SetAccountNicknameX()`)
-	}
-
-	return r.Body.SetAccountNicknameX, nil
+	return &r, nil
 }
 
 type RefreshAccountCredentialsXArgs struct {
-	Xmlns        string `xml:"xmlns:u,attr"`
 	AccountType  uint32 `xml:"AccountType"`
 	AccountUID   uint32 `xml:"AccountUID"`
 	AccountToken string `xml:"AccountToken"`
 	AccountKey   string `xml:"AccountKey"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *RefreshAccountCredentialsXArgs) Validate() error {
+	return nil
+}
+
 type RefreshAccountCredentialsXResponse struct {
 }
 
 func (s *Service) RefreshAccountCredentialsX(httpClient *http.Client, args *RefreshAccountCredentialsXArgs) (*RefreshAccountCredentialsXResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`RefreshAccountCredentialsX`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{RefreshAccountCredentialsX: args},
-		})
-	if err != nil {
+	return s.RefreshAccountCredentialsXContext(context.Background(), httpClient, args)
+}
+func (s *Service) RefreshAccountCredentialsXContext(ctx context.Context, httpClient *http.Client, args *RefreshAccountCredentialsXArgs) (*RefreshAccountCredentialsXResponse, error) {
+	var r RefreshAccountCredentialsXResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `RefreshAccountCredentialsX`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.RefreshAccountCredentialsX == nil {
-		return nil, errors.New(`unexpected respose from service calling systemproperties.RefreshAccountCredentialsX()`)
-	}
-
-	return r.Body.RefreshAccountCredentialsX, nil
+	return &r, nil
 }
 
 type EditAccountMdArgs struct {
-	Xmlns        string `xml:"xmlns:u,attr"`
 	AccountType  uint32 `xml:"AccountType"`
 	AccountID    string `xml:"AccountID"`
 	NewAccountMd string `xml:"NewAccountMd"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *EditAccountMdArgs) Validate() error {
+	return nil
+}
+
 type EditAccountMdResponse struct {
 }
 
 func (s *Service) EditAccountMd(httpClient *http.Client, args *EditAccountMdArgs) (*EditAccountMdResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`EditAccountMd`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{EditAccountMd: args},
-		})
-	if err != nil {
+	return s.EditAccountMdContext(context.Background(), httpClient, args)
+}
+func (s *Service) EditAccountMdContext(ctx context.Context, httpClient *http.Client, args *EditAccountMdArgs) (*EditAccountMdResponse, error) {
+	var r EditAccountMdResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `EditAccountMd`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.EditAccountMd == nil {
-		return nil, errors.New(`unexpected respose from service calling systemproperties.EditAccountMd()`)
-	}
-
-	return r.Body.EditAccountMd, nil
+	return &r, nil
 }
 
 type DoPostUpdateTasksArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *DoPostUpdateTasksArgs) Validate() error {
+	return nil
+}
+
 type DoPostUpdateTasksResponse struct {
 }
 
 func (s *Service) DoPostUpdateTasks(httpClient *http.Client, args *DoPostUpdateTasksArgs) (*DoPostUpdateTasksResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`DoPostUpdateTasks`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{DoPostUpdateTasks: args},
-		})
-	if err != nil {
+	return s.DoPostUpdateTasksContext(context.Background(), httpClient, args)
+}
+func (s *Service) DoPostUpdateTasksContext(ctx context.Context, httpClient *http.Client, args *DoPostUpdateTasksArgs) (*DoPostUpdateTasksResponse, error) {
+	var r DoPostUpdateTasksResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `DoPostUpdateTasks`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.DoPostUpdateTasks == nil {
-		return nil, errors.New(`unexpected respose from service calling systemproperties.DoPostUpdateTasks()`)
-	}
-
-	return r.Body.DoPostUpdateTasks, nil
+	return &r, nil
 }
 
 type ResetThirdPartyCredentialsArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *ResetThirdPartyCredentialsArgs) Validate() error {
+	return nil
+}
+
 type ResetThirdPartyCredentialsResponse struct {
 }
 
 func (s *Service) ResetThirdPartyCredentials(httpClient *http.Client, args *ResetThirdPartyCredentialsArgs) (*ResetThirdPartyCredentialsResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`ResetThirdPartyCredentials`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{ResetThirdPartyCredentials: args},
-		})
-	if err != nil {
+	return s.ResetThirdPartyCredentialsContext(context.Background(), httpClient, args)
+}
+func (s *Service) ResetThirdPartyCredentialsContext(ctx context.Context, httpClient *http.Client, args *ResetThirdPartyCredentialsArgs) (*ResetThirdPartyCredentialsResponse, error) {
+	var r ResetThirdPartyCredentialsResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `ResetThirdPartyCredentials`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.ResetThirdPartyCredentials == nil {
-		return nil, errors.New(`unexpected respose from service calling systemproperties.ResetThirdPartyCredentials()`)
-	}
-
-	return r.Body.ResetThirdPartyCredentials, nil
+	return &r, nil
 }
 
 type EnableRDMArgs struct {
-	Xmlns    string `xml:"xmlns:u,attr"`
-	RDMValue bool   `xml:"RDMValue"`
+	RDMValue bool `xml:"RDMValue"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *EnableRDMArgs) Validate() error {
+	return nil
+}
+
 type EnableRDMResponse struct {
 }
 
 func (s *Service) EnableRDM(httpClient *http.Client, args *EnableRDMArgs) (*EnableRDMResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`EnableRDM`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{EnableRDM: args},
-		})
-	if err != nil {
+	return s.EnableRDMContext(context.Background(), httpClient, args)
+}
+func (s *Service) EnableRDMContext(ctx context.Context, httpClient *http.Client, args *EnableRDMArgs) (*EnableRDMResponse, error) {
+	var r EnableRDMResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `EnableRDM`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.EnableRDM == nil {
-		return nil, errors.New(`unexpected respose from service calling systemproperties.EnableRDM()`)
-	}
-
-	return r.Body.EnableRDM, nil
+	return &r, nil
 }
 
 type GetRDMArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetRDMArgs) Validate() error {
+	return nil
+}
+
 type GetRDMResponse struct {
 	RDMValue bool `xml:"RDMValue"`
 }
 
 func (s *Service) GetRDM(httpClient *http.Client, args *GetRDMArgs) (*GetRDMResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetRDM`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetRDM: args},
-		})
-	if err != nil {
+	return s.GetRDMContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetRDMContext(ctx context.Context, httpClient *http.Client, args *GetRDMArgs) (*GetRDMResponse, error) {
+	var r GetRDMResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetRDM`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetRDM == nil {
-		return nil, errors.New(`unexpected respose from service calling systemproperties.GetRDM()`)
-	}
-
-	return r.Body.GetRDM, nil
+	return &r, nil
 }
 
 type ReplaceAccountXArgs struct {
-	Xmlns              string `xml:"xmlns:u,attr"`
 	AccountUDN         string `xml:"AccountUDN"`
 	NewAccountID       string `xml:"NewAccountID"`
 	NewAccountPassword string `xml:"NewAccountPassword"`
@@ -553,24 +442,284 @@ type ReplaceAccountXArgs struct {
 	AccountKey         string `xml:"AccountKey"`
 	OAuthDeviceID      string `xml:"OAuthDeviceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *ReplaceAccountXArgs) Validate() error {
+	return nil
+}
+
 type ReplaceAccountXResponse struct {
 	NewAccountUDN string `xml:"NewAccountUDN"`
 }
 
 func (s *Service) ReplaceAccountX(httpClient *http.Client, args *ReplaceAccountXArgs) (*ReplaceAccountXResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`ReplaceAccountX`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{ReplaceAccountX: args},
-		})
-	if err != nil {
+	return s.ReplaceAccountXContext(context.Background(), httpClient, args)
+}
+func (s *Service) ReplaceAccountXContext(ctx context.Context, httpClient *http.Client, args *ReplaceAccountXArgs) (*ReplaceAccountXResponse, error) {
+	var r ReplaceAccountXResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `ReplaceAccountX`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.ReplaceAccountX == nil {
-		return nil, errors.New(`unexpected respose from service calling systemproperties.ReplaceAccountX()`)
+	return &r, nil
+}
+
+// Client is the set of actions of the service. It is implemented by Service and Fake
+type Client interface {
+	SetString(httpClient *http.Client, args *SetStringArgs) (*SetStringResponse, error)
+	SetStringContext(ctx context.Context, httpClient *http.Client, args *SetStringArgs) (*SetStringResponse, error)
+	GetString(httpClient *http.Client, args *GetStringArgs) (*GetStringResponse, error)
+	GetStringContext(ctx context.Context, httpClient *http.Client, args *GetStringArgs) (*GetStringResponse, error)
+	Remove(httpClient *http.Client, args *RemoveArgs) (*RemoveResponse, error)
+	RemoveContext(ctx context.Context, httpClient *http.Client, args *RemoveArgs) (*RemoveResponse, error)
+	GetWebCode(httpClient *http.Client, args *GetWebCodeArgs) (*GetWebCodeResponse, error)
+	GetWebCodeContext(ctx context.Context, httpClient *http.Client, args *GetWebCodeArgs) (*GetWebCodeResponse, error)
+	ProvisionCredentialedTrialAccountX(httpClient *http.Client, args *ProvisionCredentialedTrialAccountXArgs) (*ProvisionCredentialedTrialAccountXResponse, error)
+	ProvisionCredentialedTrialAccountXContext(ctx context.Context, httpClient *http.Client, args *ProvisionCredentialedTrialAccountXArgs) (*ProvisionCredentialedTrialAccountXResponse, error)
+	AddAccountX(httpClient *http.Client, args *AddAccountXArgs) (*AddAccountXResponse, error)
+	AddAccountXContext(ctx context.Context, httpClient *http.Client, args *AddAccountXArgs) (*AddAccountXResponse, error)
+	AddOAuthAccountX(httpClient *http.Client, args *AddOAuthAccountXArgs) (*AddOAuthAccountXResponse, error)
+	AddOAuthAccountXContext(ctx context.Context, httpClient *http.Client, args *AddOAuthAccountXArgs) (*AddOAuthAccountXResponse, error)
+	RemoveAccount(httpClient *http.Client, args *RemoveAccountArgs) (*RemoveAccountResponse, error)
+	RemoveAccountContext(ctx context.Context, httpClient *http.Client, args *RemoveAccountArgs) (*RemoveAccountResponse, error)
+	EditAccountPasswordX(httpClient *http.Client, args *EditAccountPasswordXArgs) (*EditAccountPasswordXResponse, error)
+	EditAccountPasswordXContext(ctx context.Context, httpClient *http.Client, args *EditAccountPasswordXArgs) (*EditAccountPasswordXResponse, error)
+	SetAccountNicknameX(httpClient *http.Client, args *SetAccountNicknameXArgs) (*SetAccountNicknameXResponse, error)
+	SetAccountNicknameXContext(ctx context.Context, httpClient *http.Client, args *SetAccountNicknameXArgs) (*SetAccountNicknameXResponse, error)
+	RefreshAccountCredentialsX(httpClient *http.Client, args *RefreshAccountCredentialsXArgs) (*RefreshAccountCredentialsXResponse, error)
+	RefreshAccountCredentialsXContext(ctx context.Context, httpClient *http.Client, args *RefreshAccountCredentialsXArgs) (*RefreshAccountCredentialsXResponse, error)
+	EditAccountMd(httpClient *http.Client, args *EditAccountMdArgs) (*EditAccountMdResponse, error)
+	EditAccountMdContext(ctx context.Context, httpClient *http.Client, args *EditAccountMdArgs) (*EditAccountMdResponse, error)
+	DoPostUpdateTasks(httpClient *http.Client, args *DoPostUpdateTasksArgs) (*DoPostUpdateTasksResponse, error)
+	DoPostUpdateTasksContext(ctx context.Context, httpClient *http.Client, args *DoPostUpdateTasksArgs) (*DoPostUpdateTasksResponse, error)
+	ResetThirdPartyCredentials(httpClient *http.Client, args *ResetThirdPartyCredentialsArgs) (*ResetThirdPartyCredentialsResponse, error)
+	ResetThirdPartyCredentialsContext(ctx context.Context, httpClient *http.Client, args *ResetThirdPartyCredentialsArgs) (*ResetThirdPartyCredentialsResponse, error)
+	EnableRDM(httpClient *http.Client, args *EnableRDMArgs) (*EnableRDMResponse, error)
+	EnableRDMContext(ctx context.Context, httpClient *http.Client, args *EnableRDMArgs) (*EnableRDMResponse, error)
+	GetRDM(httpClient *http.Client, args *GetRDMArgs) (*GetRDMResponse, error)
+	GetRDMContext(ctx context.Context, httpClient *http.Client, args *GetRDMArgs) (*GetRDMResponse, error)
+	ReplaceAccountX(httpClient *http.Client, args *ReplaceAccountXArgs) (*ReplaceAccountXResponse, error)
+	ReplaceAccountXContext(ctx context.Context, httpClient *http.Client, args *ReplaceAccountXArgs) (*ReplaceAccountXResponse, error)
+}
+
+var _ Client = (*Service)(nil)
+var _ Client = (*Fake)(nil)
+
+// Call is an action received by a Fake
+type Call struct {
+	Action string
+	// Args is the *<Action>Args the action was called with
+	Args interface{}
+}
+
+// Fake is an in-memory Client for tests. It records every call, and answers
+// an action with its On<Action> func, or with an empty response if that is nil
+type Fake struct {
+	mu                                   sync.Mutex
+	calls                                []Call
+	OnSetString                          func(ctx context.Context, args *SetStringArgs) (*SetStringResponse, error)
+	OnGetString                          func(ctx context.Context, args *GetStringArgs) (*GetStringResponse, error)
+	OnRemove                             func(ctx context.Context, args *RemoveArgs) (*RemoveResponse, error)
+	OnGetWebCode                         func(ctx context.Context, args *GetWebCodeArgs) (*GetWebCodeResponse, error)
+	OnProvisionCredentialedTrialAccountX func(ctx context.Context, args *ProvisionCredentialedTrialAccountXArgs) (*ProvisionCredentialedTrialAccountXResponse, error)
+	OnAddAccountX                        func(ctx context.Context, args *AddAccountXArgs) (*AddAccountXResponse, error)
+	OnAddOAuthAccountX                   func(ctx context.Context, args *AddOAuthAccountXArgs) (*AddOAuthAccountXResponse, error)
+	OnRemoveAccount                      func(ctx context.Context, args *RemoveAccountArgs) (*RemoveAccountResponse, error)
+	OnEditAccountPasswordX               func(ctx context.Context, args *EditAccountPasswordXArgs) (*EditAccountPasswordXResponse, error)
+	OnSetAccountNicknameX                func(ctx context.Context, args *SetAccountNicknameXArgs) (*SetAccountNicknameXResponse, error)
+	OnRefreshAccountCredentialsX         func(ctx context.Context, args *RefreshAccountCredentialsXArgs) (*RefreshAccountCredentialsXResponse, error)
+	OnEditAccountMd                      func(ctx context.Context, args *EditAccountMdArgs) (*EditAccountMdResponse, error)
+	OnDoPostUpdateTasks                  func(ctx context.Context, args *DoPostUpdateTasksArgs) (*DoPostUpdateTasksResponse, error)
+	OnResetThirdPartyCredentials         func(ctx context.Context, args *ResetThirdPartyCredentialsArgs) (*ResetThirdPartyCredentialsResponse, error)
+	OnEnableRDM                          func(ctx context.Context, args *EnableRDMArgs) (*EnableRDMResponse, error)
+	OnGetRDM                             func(ctx context.Context, args *GetRDMArgs) (*GetRDMResponse, error)
+	OnReplaceAccountX                    func(ctx context.Context, args *ReplaceAccountXArgs) (*ReplaceAccountXResponse, error)
+}
+
+// Calls returns the calls received so far, oldest first
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// Reset forgets the calls received so far
+func (f *Fake) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+}
+func (f *Fake) record(action string, args interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{Action: action, Args: args})
+}
+func (f *Fake) SetString(httpClient *http.Client, args *SetStringArgs) (*SetStringResponse, error) {
+	return f.SetStringContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetStringContext(ctx context.Context, httpClient *http.Client, args *SetStringArgs) (*SetStringResponse, error) {
+	f.record(`SetString`, args)
+	if f.OnSetString != nil {
+		return f.OnSetString(ctx, args)
+	}
+	return &SetStringResponse{}, nil
+}
+func (f *Fake) GetString(httpClient *http.Client, args *GetStringArgs) (*GetStringResponse, error) {
+	return f.GetStringContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetStringContext(ctx context.Context, httpClient *http.Client, args *GetStringArgs) (*GetStringResponse, error) {
+	f.record(`GetString`, args)
+	if f.OnGetString != nil {
+		return f.OnGetString(ctx, args)
+	}
+	return &GetStringResponse{}, nil
+}
+func (f *Fake) Remove(httpClient *http.Client, args *RemoveArgs) (*RemoveResponse, error) {
+	return f.RemoveContext(context.Background(), httpClient, args)
+}
+func (f *Fake) RemoveContext(ctx context.Context, httpClient *http.Client, args *RemoveArgs) (*RemoveResponse, error) {
+	f.record(`Remove`, args)
+	if f.OnRemove != nil {
+		return f.OnRemove(ctx, args)
+	}
+	return &RemoveResponse{}, nil
+}
+func (f *Fake) GetWebCode(httpClient *http.Client, args *GetWebCodeArgs) (*GetWebCodeResponse, error) {
+	return f.GetWebCodeContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetWebCodeContext(ctx context.Context, httpClient *http.Client, args *GetWebCodeArgs) (*GetWebCodeResponse, error) {
+	f.record(`GetWebCode`, args)
+	if f.OnGetWebCode != nil {
+		return f.OnGetWebCode(ctx, args)
+	}
+	return &GetWebCodeResponse{}, nil
+}
+func (f *Fake) ProvisionCredentialedTrialAccountX(httpClient *http.Client, args *ProvisionCredentialedTrialAccountXArgs) (*ProvisionCredentialedTrialAccountXResponse, error) {
+	return f.ProvisionCredentialedTrialAccountXContext(context.Background(), httpClient, args)
+}
+func (f *Fake) ProvisionCredentialedTrialAccountXContext(ctx context.Context, httpClient *http.Client, args *ProvisionCredentialedTrialAccountXArgs) (*ProvisionCredentialedTrialAccountXResponse, error) {
+	f.record(`ProvisionCredentialedTrialAccountX`, args)
+	if f.OnProvisionCredentialedTrialAccountX != nil {
+		return f.OnProvisionCredentialedTrialAccountX(ctx, args)
 	}
-
-	return r.Body.ReplaceAccountX, nil
+	return &ProvisionCredentialedTrialAccountXResponse{}, nil
+}
+func (f *Fake) AddAccountX(httpClient *http.Client, args *AddAccountXArgs) (*AddAccountXResponse, error) {
+	return f.AddAccountXContext(context.Background(), httpClient, args)
+}
+func (f *Fake) AddAccountXContext(ctx context.Context, httpClient *http.Client, args *AddAccountXArgs) (*AddAccountXResponse, error) {
+	f.record(`AddAccountX`, args)
+	if f.OnAddAccountX != nil {
+		return f.OnAddAccountX(ctx, args)
+	}
+	return &AddAccountXResponse{}, nil
+}
+func (f *Fake) AddOAuthAccountX(httpClient *http.Client, args *AddOAuthAccountXArgs) (*AddOAuthAccountXResponse, error) {
+	return f.AddOAuthAccountXContext(context.Background(), httpClient, args)
+}
+func (f *Fake) AddOAuthAccountXContext(ctx context.Context, httpClient *http.Client, args *AddOAuthAccountXArgs) (*AddOAuthAccountXResponse, error) {
+	f.record(`AddOAuthAccountX`, args)
+	if f.OnAddOAuthAccountX != nil {
+		return f.OnAddOAuthAccountX(ctx, args)
+	}
+	return &AddOAuthAccountXResponse{}, nil
+}
+func (f *Fake) RemoveAccount(httpClient *http.Client, args *RemoveAccountArgs) (*RemoveAccountResponse, error) {
+	return f.RemoveAccountContext(context.Background(), httpClient, args)
+}
+func (f *Fake) RemoveAccountContext(ctx context.Context, httpClient *http.Client, args *RemoveAccountArgs) (*RemoveAccountResponse, error) {
+	f.record(`RemoveAccount`, args)
+	if f.OnRemoveAccount != nil {
+		return f.OnRemoveAccount(ctx, args)
+	}
+	return &RemoveAccountResponse{}, nil
+}
+func (f *Fake) EditAccountPasswordX(httpClient *http.Client, args *EditAccountPasswordXArgs) (*EditAccountPasswordXResponse, error) {
+	return f.EditAccountPasswordXContext(context.Background(), httpClient, args)
+}
+func (f *Fake) EditAccountPasswordXContext(ctx context.Context, httpClient *http.Client, args *EditAccountPasswordXArgs) (*EditAccountPasswordXResponse, error) {
+	f.record(`EditAccountPasswordX`, args)
+	if f.OnEditAccountPasswordX != nil {
+		return f.OnEditAccountPasswordX(ctx, args)
+	}
+	return &EditAccountPasswordXResponse{}, nil
+}
+func (f *Fake) SetAccountNicknameX(httpClient *http.Client, args *SetAccountNicknameXArgs) (*SetAccountNicknameXResponse, error) {
+	return f.SetAccountNicknameXContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetAccountNicknameXContext(ctx context.Context, httpClient *http.Client, args *SetAccountNicknameXArgs) (*SetAccountNicknameXResponse, error) {
+	f.record(`SetAccountNicknameX`, args)
+	if f.OnSetAccountNicknameX != nil {
+		return f.OnSetAccountNicknameX(ctx, args)
+	}
+	return &SetAccountNicknameXResponse{}, nil
+}
+func (f *Fake) RefreshAccountCredentialsX(httpClient *http.Client, args *RefreshAccountCredentialsXArgs) (*RefreshAccountCredentialsXResponse, error) {
+	return f.RefreshAccountCredentialsXContext(context.Background(), httpClient, args)
+}
+func (f *Fake) RefreshAccountCredentialsXContext(ctx context.Context, httpClient *http.Client, args *RefreshAccountCredentialsXArgs) (*RefreshAccountCredentialsXResponse, error) {
+	f.record(`RefreshAccountCredentialsX`, args)
+	if f.OnRefreshAccountCredentialsX != nil {
+		return f.OnRefreshAccountCredentialsX(ctx, args)
+	}
+	return &RefreshAccountCredentialsXResponse{}, nil
+}
+func (f *Fake) EditAccountMd(httpClient *http.Client, args *EditAccountMdArgs) (*EditAccountMdResponse, error) {
+	return f.EditAccountMdContext(context.Background(), httpClient, args)
+}
+func (f *Fake) EditAccountMdContext(ctx context.Context, httpClient *http.Client, args *EditAccountMdArgs) (*EditAccountMdResponse, error) {
+	f.record(`EditAccountMd`, args)
+	if f.OnEditAccountMd != nil {
+		return f.OnEditAccountMd(ctx, args)
+	}
+	return &EditAccountMdResponse{}, nil
+}
+func (f *Fake) DoPostUpdateTasks(httpClient *http.Client, args *DoPostUpdateTasksArgs) (*DoPostUpdateTasksResponse, error) {
+	return f.DoPostUpdateTasksContext(context.Background(), httpClient, args)
+}
+func (f *Fake) DoPostUpdateTasksContext(ctx context.Context, httpClient *http.Client, args *DoPostUpdateTasksArgs) (*DoPostUpdateTasksResponse, error) {
+	f.record(`DoPostUpdateTasks`, args)
+	if f.OnDoPostUpdateTasks != nil {
+		return f.OnDoPostUpdateTasks(ctx, args)
+	}
+	return &DoPostUpdateTasksResponse{}, nil
+}
+func (f *Fake) ResetThirdPartyCredentials(httpClient *http.Client, args *ResetThirdPartyCredentialsArgs) (*ResetThirdPartyCredentialsResponse, error) {
+	return f.ResetThirdPartyCredentialsContext(context.Background(), httpClient, args)
+}
+func (f *Fake) ResetThirdPartyCredentialsContext(ctx context.Context, httpClient *http.Client, args *ResetThirdPartyCredentialsArgs) (*ResetThirdPartyCredentialsResponse, error) {
+	f.record(`ResetThirdPartyCredentials`, args)
+	if f.OnResetThirdPartyCredentials != nil {
+		return f.OnResetThirdPartyCredentials(ctx, args)
+	}
+	return &ResetThirdPartyCredentialsResponse{}, nil
+}
+func (f *Fake) EnableRDM(httpClient *http.Client, args *EnableRDMArgs) (*EnableRDMResponse, error) {
+	return f.EnableRDMContext(context.Background(), httpClient, args)
+}
+func (f *Fake) EnableRDMContext(ctx context.Context, httpClient *http.Client, args *EnableRDMArgs) (*EnableRDMResponse, error) {
+	f.record(`EnableRDM`, args)
+	if f.OnEnableRDM != nil {
+		return f.OnEnableRDM(ctx, args)
+	}
+	return &EnableRDMResponse{}, nil
+}
+func (f *Fake) GetRDM(httpClient *http.Client, args *GetRDMArgs) (*GetRDMResponse, error) {
+	return f.GetRDMContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetRDMContext(ctx context.Context, httpClient *http.Client, args *GetRDMArgs) (*GetRDMResponse, error) {
+	f.record(`GetRDM`, args)
+	if f.OnGetRDM != nil {
+		return f.OnGetRDM(ctx, args)
+	}
+	return &GetRDMResponse{}, nil
+}
+func (f *Fake) ReplaceAccountX(httpClient *http.Client, args *ReplaceAccountXArgs) (*ReplaceAccountXResponse, error) {
+	return f.ReplaceAccountXContext(context.Background(), httpClient, args)
+}
+func (f *Fake) ReplaceAccountXContext(ctx context.Context, httpClient *http.Client, args *ReplaceAccountXArgs) (*ReplaceAccountXResponse, error) {
+	f.record(`ReplaceAccountX`, args)
+	if f.OnReplaceAccountX != nil {
+		return f.OnReplaceAccountX(ctx, args)
+	}
+	return &ReplaceAccountXResponse{}, nil
 }
@@ -1,23 +1,28 @@
 package virtuallinein
 
 import (
-	"bytes"
-	"encoding/xml"
-	"errors"
-	"io/ioutil"
+	"context"
 	"net/http"
 	"net/url"
-)
+	"sync"
 
-const (
-	_ServiceURN     = "urn:schemas-upnp-org:service:VirtualLineIn:1"
-	_EncodingSchema = "http://schemas.xmlsoap.org/soap/encoding/"
-	_EnvelopeSchema = "http://schemas.xmlsoap.org/soap/envelope/"
+	"github.com/szatmary/sonos/soap"
 )
 
+const _ServiceURN = "urn:schemas-upnp-org:service:VirtualLineIn:1"
+
+// ErrorCodes describes the error codes specific to this service
+var ErrorCodes = map[int]string{}
+
+func init() {
+	soap.RegisterErrorCodes(_ServiceURN, ErrorCodes)
+}
+
 type Service struct {
 	ControlEndpoint *url.URL
 	EventEndpoint   *url.URL
+	// Client sends the actions, soap.DefaultClient is used if it is nil
+	Client *soap.Client
 }
 
 func NewService(deviceUrl *url.URL) *Service {
@@ -35,272 +40,335 @@ func NewService(deviceUrl *url.URL) *Service {
 	}
 }
 
-type Envelope struct {
-	XMLName       xml.Name `xml:"s:Envelope"`
-	Xmlns         string   `xml:"xmlns:s,attr"`
-	EncodingStyle string   `xml:"s:encodingStyle,attr"`
-	Body          Body     `xml:"s:Body"`
-}
-type Body struct {
-	XMLName           xml.Name               `xml:"s:Body"`
-	StartTransmission *StartTransmissionArgs `xml:"u:StartTransmission,omitempty"`
-	StopTransmission  *StopTransmissionArgs  `xml:"u:StopTransmission,omitempty"`
-	Play              *PlayArgs              `xml:"u:Play,omitempty"`
-	Pause             *PauseArgs             `xml:"u:Pause,omitempty"`
-	Next              *NextArgs              `xml:"u:Next,omitempty"`
-	Previous          *PreviousArgs          `xml:"u:Previous,omitempty"`
-	Stop              *StopArgs              `xml:"u:Stop,omitempty"`
-	SetVolume         *SetVolumeArgs         `xml:"u:SetVolume,omitempty"`
-}
-type EnvelopeResponse struct {
-	XMLName       xml.Name     `xml:"Envelope"`
-	Xmlns         string       `xml:"xmlns:s,attr"`
-	EncodingStyle string       `xml:"encodingStyle,attr"`
-	Body          BodyResponse `xml:"Body"`
-}
-type BodyResponse struct {
-	XMLName           xml.Name                   `xml:"Body"`
-	StartTransmission *StartTransmissionResponse `xml:"StartTransmissionResponse,omitempty"`
-	StopTransmission  *StopTransmissionResponse  `xml:"StopTransmissionResponse,omitempty"`
-	Play              *PlayResponse              `xml:"PlayResponse,omitempty"`
-	Pause             *PauseResponse             `xml:"PauseResponse,omitempty"`
-	Next              *NextResponse              `xml:"NextResponse,omitempty"`
-	Previous          *PreviousResponse          `xml:"PreviousResponse,omitempty"`
-	Stop              *StopResponse              `xml:"StopResponse,omitempty"`
-	SetVolume         *SetVolumeResponse         `xml:"SetVolumeResponse,omitempty"`
-}
-
-func (s *Service) exec(actionName string, httpClient *http.Client, envelope *Envelope) (*EnvelopeResponse, error) {
-	marshaled, err := xml.Marshal(envelope)
-	if err != nil {
-		return nil, err
-	}
-	postBody := []byte(`<?xml version="1.0"?>`)
-	postBody = append(postBody, marshaled...)
-	req, err := http.NewRequest(`POST`, s.ControlEndpoint.String(), bytes.NewBuffer(postBody))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set(`Content-Type`, `text/xml; charset="utf-8"`)
-	req.Header.Set(`SOAPAction`, _ServiceURN+`#`+actionName)
-	res, err := httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-	responseBody, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-	var envelopeResponse EnvelopeResponse
-	err = xml.Unmarshal(responseBody, &envelopeResponse)
-	if err != nil {
-		return nil, err
-	}
-	return &envelopeResponse, nil
-}
-
 type StartTransmissionArgs struct {
-	Xmlns         string `xml:"xmlns:u,attr"`
 	InstanceID    uint32 `xml:"InstanceID"`
 	CoordinatorID string `xml:"CoordinatorID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *StartTransmissionArgs) Validate() error {
+	return nil
+}
+
 type StartTransmissionResponse struct {
 	CurrentTransportSettings string `xml:"CurrentTransportSettings"`
 }
 
 func (s *Service) StartTransmission(httpClient *http.Client, args *StartTransmissionArgs) (*StartTransmissionResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`StartTransmission`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{StartTransmission: args},
-		})
-	if err != nil {
+	return s.StartTransmissionContext(context.Background(), httpClient, args)
+}
+func (s *Service) StartTransmissionContext(ctx context.Context, httpClient *http.Client, args *StartTransmissionArgs) (*StartTransmissionResponse, error) {
+	var r StartTransmissionResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `StartTransmission`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.StartTransmission == nil {
-		return nil, errors.New(`unexpected respose from service calling virtuallinein.StartTransmission()`)
-	}
-
-	return r.Body.StartTransmission, nil
+	return &r, nil
 }
 
 type StopTransmissionArgs struct {
-	Xmlns         string `xml:"xmlns:u,attr"`
 	InstanceID    uint32 `xml:"InstanceID"`
 	CoordinatorID string `xml:"CoordinatorID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *StopTransmissionArgs) Validate() error {
+	return nil
+}
+
 type StopTransmissionResponse struct {
 }
 
 func (s *Service) StopTransmission(httpClient *http.Client, args *StopTransmissionArgs) (*StopTransmissionResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`StopTransmission`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{StopTransmission: args},
-		})
-	if err != nil {
+	return s.StopTransmissionContext(context.Background(), httpClient, args)
+}
+func (s *Service) StopTransmissionContext(ctx context.Context, httpClient *http.Client, args *StopTransmissionArgs) (*StopTransmissionResponse, error) {
+	var r StopTransmissionResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `StopTransmission`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.StopTransmission == nil {
-		return nil, errors.New(`unexpected respose from service calling virtuallinein.StopTransmission()`)
-	}
-
-	return r.Body.StopTransmission, nil
+	return &r, nil
 }
 
 type PlayArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 	Speed      string `xml:"Speed"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *PlayArgs) Validate() error {
+	return nil
+}
+
 type PlayResponse struct {
 }
 
 func (s *Service) Play(httpClient *http.Client, args *PlayArgs) (*PlayResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`Play`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{Play: args},
-		})
-	if err != nil {
+	return s.PlayContext(context.Background(), httpClient, args)
+}
+func (s *Service) PlayContext(ctx context.Context, httpClient *http.Client, args *PlayArgs) (*PlayResponse, error) {
+	var r PlayResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `Play`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.Play == nil {
-		return nil, errors.New(`unexpected respose from service calling virtuallinein.Play()`)
-	}
-
-	return r.Body.Play, nil
+	return &r, nil
 }
 
 type PauseArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *PauseArgs) Validate() error {
+	return nil
+}
+
 type PauseResponse struct {
 }
 
 func (s *Service) Pause(httpClient *http.Client, args *PauseArgs) (*PauseResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`Pause`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{Pause: args},
-		})
-	if err != nil {
+	return s.PauseContext(context.Background(), httpClient, args)
+}
+func (s *Service) PauseContext(ctx context.Context, httpClient *http.Client, args *PauseArgs) (*PauseResponse, error) {
+	var r PauseResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `Pause`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.Pause == nil {
-		return nil, errors.New(`unexpected respose from service calling virtuallinein.Pause()`)
-	}
-
-	return r.Body.Pause, nil
+	return &r, nil
 }
 
 type NextArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *NextArgs) Validate() error {
+	return nil
+}
+
 type NextResponse struct {
 }
 
 func (s *Service) Next(httpClient *http.Client, args *NextArgs) (*NextResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`Next`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{Next: args},
-		})
-	if err != nil {
+	return s.NextContext(context.Background(), httpClient, args)
+}
+func (s *Service) NextContext(ctx context.Context, httpClient *http.Client, args *NextArgs) (*NextResponse, error) {
+	var r NextResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `Next`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.Next == nil {
-		return nil, errors.New(`unexpected respose from service calling virtuallinein.Next()`)
-	}
-
-	return r.Body.Next, nil
+	return &r, nil
 }
 
 type PreviousArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *PreviousArgs) Validate() error {
+	return nil
+}
+
 type PreviousResponse struct {
 }
 
 func (s *Service) Previous(httpClient *http.Client, args *PreviousArgs) (*PreviousResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`Previous`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{Previous: args},
-		})
-	if err != nil {
+	return s.PreviousContext(context.Background(), httpClient, args)
+}
+func (s *Service) PreviousContext(ctx context.Context, httpClient *http.Client, args *PreviousArgs) (*PreviousResponse, error) {
+	var r PreviousResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `Previous`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.Previous == nil {
-		return nil, errors.New(`unexpected respose from service calling virtuallinein.Previous()`)
-	}
-
-	return r.Body.Previous, nil
+	return &r, nil
 }
 
 type StopArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	InstanceID uint32 `xml:"InstanceID"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *StopArgs) Validate() error {
+	return nil
+}
+
 type StopResponse struct {
 }
 
 func (s *Service) Stop(httpClient *http.Client, args *StopArgs) (*StopResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`Stop`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{Stop: args},
-		})
-	if err != nil {
+	return s.StopContext(context.Background(), httpClient, args)
+}
+func (s *Service) StopContext(ctx context.Context, httpClient *http.Client, args *StopArgs) (*StopResponse, error) {
+	var r StopResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `Stop`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.Stop == nil {
-		return nil, errors.New(`unexpected respose from service calling virtuallinein.Stop()`)
-	}
-
-	return r.Body.Stop, nil
+	return &r, nil
 }
 
 type SetVolumeArgs struct {
-	Xmlns         string `xml:"xmlns:u,attr"`
 	InstanceID    uint32 `xml:"InstanceID"`
 	DesiredVolume uint16 `xml:"DesiredVolume"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SetVolumeArgs) Validate() error {
+	return nil
+}
+
 type SetVolumeResponse struct {
 }
 
 func (s *Service) SetVolume(httpClient *http.Client, args *SetVolumeArgs) (*SetVolumeResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SetVolume`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SetVolume: args},
-		})
-	if err != nil {
+	return s.SetVolumeContext(context.Background(), httpClient, args)
+}
+func (s *Service) SetVolumeContext(ctx context.Context, httpClient *http.Client, args *SetVolumeArgs) (*SetVolumeResponse, error) {
+	var r SetVolumeResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SetVolume`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SetVolume == nil {
-		return nil, errors.New(`unexpected respose from service calling virtuallinein.SetVolume()`)
-	}
+	return &r, nil
+}
+
+// Client is the set of actions of the service. It is implemented by Service and Fake
+type Client interface {
+	StartTransmission(httpClient *http.Client, args *StartTransmissionArgs) (*StartTransmissionResponse, error)
+	StartTransmissionContext(ctx context.Context, httpClient *http.Client, args *StartTransmissionArgs) (*StartTransmissionResponse, error)
+	StopTransmission(httpClient *http.Client, args *StopTransmissionArgs) (*StopTransmissionResponse, error)
+	StopTransmissionContext(ctx context.Context, httpClient *http.Client, args *StopTransmissionArgs) (*StopTransmissionResponse, error)
+	Play(httpClient *http.Client, args *PlayArgs) (*PlayResponse, error)
+	PlayContext(ctx context.Context, httpClient *http.Client, args *PlayArgs) (*PlayResponse, error)
+	Pause(httpClient *http.Client, args *PauseArgs) (*PauseResponse, error)
+	PauseContext(ctx context.Context, httpClient *http.Client, args *PauseArgs) (*PauseResponse, error)
+	Next(httpClient *http.Client, args *NextArgs) (*NextResponse, error)
+	NextContext(ctx context.Context, httpClient *http.Client, args *NextArgs) (*NextResponse, error)
+	Previous(httpClient *http.Client, args *PreviousArgs) (*PreviousResponse, error)
+	PreviousContext(ctx context.Context, httpClient *http.Client, args *PreviousArgs) (*PreviousResponse, error)
+	Stop(httpClient *http.Client, args *StopArgs) (*StopResponse, error)
+	StopContext(ctx context.Context, httpClient *http.Client, args *StopArgs) (*StopResponse, error)
+	SetVolume(httpClient *http.Client, args *SetVolumeArgs) (*SetVolumeResponse, error)
+	SetVolumeContext(ctx context.Context, httpClient *http.Client, args *SetVolumeArgs) (*SetVolumeResponse, error)
+}
+
+var _ Client = (*Service)(nil)
+var _ Client = (*Fake)(nil)
+
+// Call is an action received by a Fake
+type Call struct {
+	Action string
+	// Args is the *<Action>Args the action was called with
+	Args interface{}
+}
+
+// Fake is an in-memory Client for tests. It records every call, and answers
+// an action with its On<Action> func, or with an empty response if that is nil
+type Fake struct {
+	mu                  sync.Mutex
+	calls               []Call
+	OnStartTransmission func(ctx context.Context, args *StartTransmissionArgs) (*StartTransmissionResponse, error)
+	OnStopTransmission  func(ctx context.Context, args *StopTransmissionArgs) (*StopTransmissionResponse, error)
+	OnPlay              func(ctx context.Context, args *PlayArgs) (*PlayResponse, error)
+	OnPause             func(ctx context.Context, args *PauseArgs) (*PauseResponse, error)
+	OnNext              func(ctx context.Context, args *NextArgs) (*NextResponse, error)
+	OnPrevious          func(ctx context.Context, args *PreviousArgs) (*PreviousResponse, error)
+	OnStop              func(ctx context.Context, args *StopArgs) (*StopResponse, error)
+	OnSetVolume         func(ctx context.Context, args *SetVolumeArgs) (*SetVolumeResponse, error)
+}
 
-	return r.Body.SetVolume, nil
+// Calls returns the calls received so far, oldest first
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// Reset forgets the calls received so far
+func (f *Fake) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+}
+func (f *Fake) record(action string, args interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{Action: action, Args: args})
+}
+func (f *Fake) StartTransmission(httpClient *http.Client, args *StartTransmissionArgs) (*StartTransmissionResponse, error) {
+	return f.StartTransmissionContext(context.Background(), httpClient, args)
+}
+func (f *Fake) StartTransmissionContext(ctx context.Context, httpClient *http.Client, args *StartTransmissionArgs) (*StartTransmissionResponse, error) {
+	f.record(`StartTransmission`, args)
+	if f.OnStartTransmission != nil {
+		return f.OnStartTransmission(ctx, args)
+	}
+	return &StartTransmissionResponse{}, nil
+}
+func (f *Fake) StopTransmission(httpClient *http.Client, args *StopTransmissionArgs) (*StopTransmissionResponse, error) {
+	return f.StopTransmissionContext(context.Background(), httpClient, args)
+}
+func (f *Fake) StopTransmissionContext(ctx context.Context, httpClient *http.Client, args *StopTransmissionArgs) (*StopTransmissionResponse, error) {
+	f.record(`StopTransmission`, args)
+	if f.OnStopTransmission != nil {
+		return f.OnStopTransmission(ctx, args)
+	}
+	return &StopTransmissionResponse{}, nil
+}
+func (f *Fake) Play(httpClient *http.Client, args *PlayArgs) (*PlayResponse, error) {
+	return f.PlayContext(context.Background(), httpClient, args)
+}
+func (f *Fake) PlayContext(ctx context.Context, httpClient *http.Client, args *PlayArgs) (*PlayResponse, error) {
+	f.record(`Play`, args)
+	if f.OnPlay != nil {
+		return f.OnPlay(ctx, args)
+	}
+	return &PlayResponse{}, nil
+}
+func (f *Fake) Pause(httpClient *http.Client, args *PauseArgs) (*PauseResponse, error) {
+	return f.PauseContext(context.Background(), httpClient, args)
+}
+func (f *Fake) PauseContext(ctx context.Context, httpClient *http.Client, args *PauseArgs) (*PauseResponse, error) {
+	f.record(`Pause`, args)
+	if f.OnPause != nil {
+		return f.OnPause(ctx, args)
+	}
+	return &PauseResponse{}, nil
+}
+func (f *Fake) Next(httpClient *http.Client, args *NextArgs) (*NextResponse, error) {
+	return f.NextContext(context.Background(), httpClient, args)
+}
+func (f *Fake) NextContext(ctx context.Context, httpClient *http.Client, args *NextArgs) (*NextResponse, error) {
+	f.record(`Next`, args)
+	if f.OnNext != nil {
+		return f.OnNext(ctx, args)
+	}
+	return &NextResponse{}, nil
+}
+func (f *Fake) Previous(httpClient *http.Client, args *PreviousArgs) (*PreviousResponse, error) {
+	return f.PreviousContext(context.Background(), httpClient, args)
+}
+func (f *Fake) PreviousContext(ctx context.Context, httpClient *http.Client, args *PreviousArgs) (*PreviousResponse, error) {
+	f.record(`Previous`, args)
+	if f.OnPrevious != nil {
+		return f.OnPrevious(ctx, args)
+	}
+	return &PreviousResponse{}, nil
+}
+func (f *Fake) Stop(httpClient *http.Client, args *StopArgs) (*StopResponse, error) {
+	return f.StopContext(context.Background(), httpClient, args)
+}
+func (f *Fake) StopContext(ctx context.Context, httpClient *http.Client, args *StopArgs) (*StopResponse, error) {
+	f.record(`Stop`, args)
+	if f.OnStop != nil {
+		return f.OnStop(ctx, args)
+	}
+	return &StopResponse{}, nil
+}
+func (f *Fake) SetVolume(httpClient *http.Client, args *SetVolumeArgs) (*SetVolumeResponse, error) {
+	return f.SetVolumeContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SetVolumeContext(ctx context.Context, httpClient *http.Client, args *SetVolumeArgs) (*SetVolumeResponse, error) {
+	f.record(`SetVolume`, args)
+	if f.OnSetVolume != nil {
+		return f.OnSetVolume(ctx, args)
+	}
+	return &SetVolumeResponse{}, nil
 }
@@ -1,23 +1,45 @@
 package zonegrouptopology
 
 import (
-	"bytes"
-	"encoding/xml"
-	"errors"
-	"io/ioutil"
+	"context"
 	"net/http"
 	"net/url"
+	"sync"
+
+	"github.com/szatmary/sonos/soap"
+)
+
+const _ServiceURN = "urn:schemas-upnp-org:service:ZoneGroupTopology:1"
+
+// ErrorCodes describes the error codes specific to this service
+var ErrorCodes = map[int]string{}
+
+func init() {
+	soap.RegisterErrorCodes(_ServiceURN, ErrorCodes)
+}
+
+// UpdateType holds the values allowed for the A_ARG_TYPE_UpdateType state variable
+type UpdateType string
+
+const (
+	UpdateTypeAll      UpdateType = "All"
+	UpdateTypeSoftware UpdateType = "Software"
 )
 
+// UnresponsiveDeviceActionType holds the values allowed for the A_ARG_TYPE_UnresponsiveDeviceActionType state variable
+type UnresponsiveDeviceActionType string
+
 const (
-	_ServiceURN     = "urn:schemas-upnp-org:service:ZoneGroupTopology:1"
-	_EncodingSchema = "http://schemas.xmlsoap.org/soap/encoding/"
-	_EnvelopeSchema = "http://schemas.xmlsoap.org/soap/envelope/"
+	UnresponsiveDeviceActionTypeRemove                     UnresponsiveDeviceActionType = "Remove"
+	UnresponsiveDeviceActionTypeTopologyMonitorProbe       UnresponsiveDeviceActionType = "TopologyMonitorProbe"
+	UnresponsiveDeviceActionTypeVerifyThenRemoveSystemwide UnresponsiveDeviceActionType = "VerifyThenRemoveSystemwide"
 )
 
 type Service struct {
 	ControlEndpoint *url.URL
 	EventEndpoint   *url.URL
+	// Client sends the actions, soap.DefaultClient is used if it is nil
+	Client *soap.Client
 }
 
 func NewService(deviceUrl *url.URL) *Service {
@@ -35,238 +57,176 @@ func NewService(deviceUrl *url.URL) *Service {
 	}
 }
 
-type Envelope struct {
-	XMLName       xml.Name `xml:"s:Envelope"`
-	Xmlns         string   `xml:"xmlns:s,attr"`
-	EncodingStyle string   `xml:"s:encodingStyle,attr"`
-	Body          Body     `xml:"s:Body"`
-}
-type Body struct {
-	XMLName                   xml.Name                       `xml:"s:Body"`
-	CheckForUpdate            *CheckForUpdateArgs            `xml:"u:CheckForUpdate,omitempty"`
-	BeginSoftwareUpdate       *BeginSoftwareUpdateArgs       `xml:"u:BeginSoftwareUpdate,omitempty"`
-	ReportUnresponsiveDevice  *ReportUnresponsiveDeviceArgs  `xml:"u:ReportUnresponsiveDevice,omitempty"`
-	ReportAlarmStartedRunning *ReportAlarmStartedRunningArgs `xml:"u:ReportAlarmStartedRunning,omitempty"`
-	SubmitDiagnostics         *SubmitDiagnosticsArgs         `xml:"u:SubmitDiagnostics,omitempty"`
-	RegisterMobileDevice      *RegisterMobileDeviceArgs      `xml:"u:RegisterMobileDevice,omitempty"`
-	GetZoneGroupAttributes    *GetZoneGroupAttributesArgs    `xml:"u:GetZoneGroupAttributes,omitempty"`
-	GetZoneGroupState         *GetZoneGroupStateArgs         `xml:"u:GetZoneGroupState,omitempty"`
-}
-type EnvelopeResponse struct {
-	XMLName       xml.Name     `xml:"Envelope"`
-	Xmlns         string       `xml:"xmlns:s,attr"`
-	EncodingStyle string       `xml:"encodingStyle,attr"`
-	Body          BodyResponse `xml:"Body"`
-}
-type BodyResponse struct {
-	XMLName                   xml.Name                           `xml:"Body"`
-	CheckForUpdate            *CheckForUpdateResponse            `xml:"CheckForUpdateResponse,omitempty"`
-	BeginSoftwareUpdate       *BeginSoftwareUpdateResponse       `xml:"BeginSoftwareUpdateResponse,omitempty"`
-	ReportUnresponsiveDevice  *ReportUnresponsiveDeviceResponse  `xml:"ReportUnresponsiveDeviceResponse,omitempty"`
-	ReportAlarmStartedRunning *ReportAlarmStartedRunningResponse `xml:"ReportAlarmStartedRunningResponse,omitempty"`
-	SubmitDiagnostics         *SubmitDiagnosticsResponse         `xml:"SubmitDiagnosticsResponse,omitempty"`
-	RegisterMobileDevice      *RegisterMobileDeviceResponse      `xml:"RegisterMobileDeviceResponse,omitempty"`
-	GetZoneGroupAttributes    *GetZoneGroupAttributesResponse    `xml:"GetZoneGroupAttributesResponse,omitempty"`
-	GetZoneGroupState         *GetZoneGroupStateResponse         `xml:"GetZoneGroupStateResponse,omitempty"`
-}
-
-func (s *Service) exec(actionName string, httpClient *http.Client, envelope *Envelope) (*EnvelopeResponse, error) {
-	marshaled, err := xml.Marshal(envelope)
-	if err != nil {
-		return nil, err
-	}
-	postBody := []byte(`<?xml version="1.0"?>`)
-	postBody = append(postBody, marshaled...)
-	req, err := http.NewRequest(`POST`, s.ControlEndpoint.String(), bytes.NewBuffer(postBody))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set(`Content-Type`, `text/xml; charset="utf-8"`)
-	req.Header.Set(`SOAPAction`, _ServiceURN+`#`+actionName)
-	res, err := httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-	responseBody, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-	var envelopeResponse EnvelopeResponse
-	err = xml.Unmarshal(responseBody, &envelopeResponse)
-	if err != nil {
-		return nil, err
-	}
-	return &envelopeResponse, nil
-}
-
 type CheckForUpdateArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 	// Allowed Value: All
 	// Allowed Value: Software
-	UpdateType string `xml:"UpdateType"`
-	CachedOnly bool   `xml:"CachedOnly"`
-	Version    string `xml:"Version"`
+	UpdateType UpdateType `xml:"UpdateType"`
+	CachedOnly bool       `xml:"CachedOnly"`
+	Version    string     `xml:"Version"`
+}
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *CheckForUpdateArgs) Validate() error {
+	switch a.UpdateType {
+	case "", UpdateTypeAll, UpdateTypeSoftware:
+	default:
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `CheckForUpdate`, Argument: `UpdateType`, Value: a.UpdateType, Allowed: "one of All, Software"}
+	}
+	return nil
 }
+
 type CheckForUpdateResponse struct {
 	UpdateItem string `xml:"UpdateItem"`
 }
 
 func (s *Service) CheckForUpdate(httpClient *http.Client, args *CheckForUpdateArgs) (*CheckForUpdateResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`CheckForUpdate`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{CheckForUpdate: args},
-		})
-	if err != nil {
+	return s.CheckForUpdateContext(context.Background(), httpClient, args)
+}
+func (s *Service) CheckForUpdateContext(ctx context.Context, httpClient *http.Client, args *CheckForUpdateArgs) (*CheckForUpdateResponse, error) {
+	var r CheckForUpdateResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `CheckForUpdate`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.CheckForUpdate == nil {
-		return nil, errors.New(`unexpected respose from service calling zonegrouptopology.CheckForUpdate()`)
-	}
-
-	return r.Body.CheckForUpdate, nil
+	return &r, nil
 }
 
 type BeginSoftwareUpdateArgs struct {
-	Xmlns        string `xml:"xmlns:u,attr"`
 	UpdateURL    string `xml:"UpdateURL"`
 	Flags        uint32 `xml:"Flags"`
 	ExtraOptions string `xml:"ExtraOptions"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *BeginSoftwareUpdateArgs) Validate() error {
+	return nil
+}
+
 type BeginSoftwareUpdateResponse struct {
 }
 
 func (s *Service) BeginSoftwareUpdate(httpClient *http.Client, args *BeginSoftwareUpdateArgs) (*BeginSoftwareUpdateResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`BeginSoftwareUpdate`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{BeginSoftwareUpdate: args},
-		})
-	if err != nil {
+	return s.BeginSoftwareUpdateContext(context.Background(), httpClient, args)
+}
+func (s *Service) BeginSoftwareUpdateContext(ctx context.Context, httpClient *http.Client, args *BeginSoftwareUpdateArgs) (*BeginSoftwareUpdateResponse, error) {
+	var r BeginSoftwareUpdateResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `BeginSoftwareUpdate`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.BeginSoftwareUpdate == nil {
-		return nil, errors.New(`unexpected respose from service calling zonegrouptopology.BeginSoftwareUpdate()`)
-	}
-
-	return r.Body.BeginSoftwareUpdate, nil
+	return &r, nil
 }
 
 type ReportUnresponsiveDeviceArgs struct {
-	Xmlns      string `xml:"xmlns:u,attr"`
 	DeviceUUID string `xml:"DeviceUUID"`
 	// Allowed Value: Remove
 	// Allowed Value: TopologyMonitorProbe
 	// Allowed Value: VerifyThenRemoveSystemwide
-	DesiredAction string `xml:"DesiredAction"`
+	DesiredAction UnresponsiveDeviceActionType `xml:"DesiredAction"`
+}
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *ReportUnresponsiveDeviceArgs) Validate() error {
+	switch a.DesiredAction {
+	case "", UnresponsiveDeviceActionTypeRemove, UnresponsiveDeviceActionTypeTopologyMonitorProbe, UnresponsiveDeviceActionTypeVerifyThenRemoveSystemwide:
+	default:
+		return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `ReportUnresponsiveDevice`, Argument: `DesiredAction`, Value: a.DesiredAction, Allowed: "one of Remove, TopologyMonitorProbe, VerifyThenRemoveSystemwide"}
+	}
+	return nil
 }
+
 type ReportUnresponsiveDeviceResponse struct {
 }
 
 func (s *Service) ReportUnresponsiveDevice(httpClient *http.Client, args *ReportUnresponsiveDeviceArgs) (*ReportUnresponsiveDeviceResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`ReportUnresponsiveDevice`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{ReportUnresponsiveDevice: args},
-		})
-	if err != nil {
+	return s.ReportUnresponsiveDeviceContext(context.Background(), httpClient, args)
+}
+func (s *Service) ReportUnresponsiveDeviceContext(ctx context.Context, httpClient *http.Client, args *ReportUnresponsiveDeviceArgs) (*ReportUnresponsiveDeviceResponse, error) {
+	var r ReportUnresponsiveDeviceResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `ReportUnresponsiveDevice`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.ReportUnresponsiveDevice == nil {
-		return nil, errors.New(`unexpected respose from service calling zonegrouptopology.ReportUnresponsiveDevice()`)
-	}
-
-	return r.Body.ReportUnresponsiveDevice, nil
+	return &r, nil
 }
 
 type ReportAlarmStartedRunningArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *ReportAlarmStartedRunningArgs) Validate() error {
+	return nil
+}
+
 type ReportAlarmStartedRunningResponse struct {
 }
 
 func (s *Service) ReportAlarmStartedRunning(httpClient *http.Client, args *ReportAlarmStartedRunningArgs) (*ReportAlarmStartedRunningResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`ReportAlarmStartedRunning`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{ReportAlarmStartedRunning: args},
-		})
-	if err != nil {
+	return s.ReportAlarmStartedRunningContext(context.Background(), httpClient, args)
+}
+func (s *Service) ReportAlarmStartedRunningContext(ctx context.Context, httpClient *http.Client, args *ReportAlarmStartedRunningArgs) (*ReportAlarmStartedRunningResponse, error) {
+	var r ReportAlarmStartedRunningResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `ReportAlarmStartedRunning`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.ReportAlarmStartedRunning == nil {
-		return nil, errors.New(`unexpected respose from service calling zonegrouptopology.ReportAlarmStartedRunning()`)
-	}
-
-	return r.Body.ReportAlarmStartedRunning, nil
+	return &r, nil
 }
 
 type SubmitDiagnosticsArgs struct {
-	Xmlns              string `xml:"xmlns:u,attr"`
 	IncludeControllers bool   `xml:"IncludeControllers"`
 	Type               string `xml:"Type"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *SubmitDiagnosticsArgs) Validate() error {
+	return nil
+}
+
 type SubmitDiagnosticsResponse struct {
 	DiagnosticID uint32 `xml:"DiagnosticID"`
 }
 
 func (s *Service) SubmitDiagnostics(httpClient *http.Client, args *SubmitDiagnosticsArgs) (*SubmitDiagnosticsResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`SubmitDiagnostics`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{SubmitDiagnostics: args},
-		})
-	if err != nil {
+	return s.SubmitDiagnosticsContext(context.Background(), httpClient, args)
+}
+func (s *Service) SubmitDiagnosticsContext(ctx context.Context, httpClient *http.Client, args *SubmitDiagnosticsArgs) (*SubmitDiagnosticsResponse, error) {
+	var r SubmitDiagnosticsResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `SubmitDiagnostics`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.SubmitDiagnostics == nil {
-		return nil, errors.New(`unexpected respose from service calling zonegrouptopology.SubmitDiagnostics()`)
-	}
-
-	return r.Body.SubmitDiagnostics, nil
+	return &r, nil
 }
 
 type RegisterMobileDeviceArgs struct {
-	Xmlns            string `xml:"xmlns:u,attr"`
 	MobileDeviceName string `xml:"MobileDeviceName"`
 	MobileDeviceUDN  string `xml:"MobileDeviceUDN"`
 	MobileIPAndPort  string `xml:"MobileIPAndPort"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *RegisterMobileDeviceArgs) Validate() error {
+	return nil
+}
+
 type RegisterMobileDeviceResponse struct {
 }
 
 func (s *Service) RegisterMobileDevice(httpClient *http.Client, args *RegisterMobileDeviceArgs) (*RegisterMobileDeviceResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`RegisterMobileDevice`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{RegisterMobileDevice: args},
-		})
-	if err != nil {
+	return s.RegisterMobileDeviceContext(context.Background(), httpClient, args)
+}
+func (s *Service) RegisterMobileDeviceContext(ctx context.Context, httpClient *http.Client, args *RegisterMobileDeviceArgs) (*RegisterMobileDeviceResponse, error) {
+	var r RegisterMobileDeviceResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `RegisterMobileDevice`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.RegisterMobileDevice == nil {
-		return nil, errors.New(`unexpected respose from service calling zonegrouptopology.RegisterMobileDevice()`)
-	}
-
-	return r.Body.RegisterMobileDevice, nil
+	return &r, nil
 }
 
 type GetZoneGroupAttributesArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetZoneGroupAttributesArgs) Validate() error {
+	return nil
+}
+
 type GetZoneGroupAttributesResponse struct {
 	CurrentZoneGroupName          string `xml:"CurrentZoneGroupName"`
 	CurrentZoneGroupID            string `xml:"CurrentZoneGroupID"`
@@ -275,44 +235,179 @@ type GetZoneGroupAttributesResponse struct {
 }
 
 func (s *Service) GetZoneGroupAttributes(httpClient *http.Client, args *GetZoneGroupAttributesArgs) (*GetZoneGroupAttributesResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetZoneGroupAttributes`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetZoneGroupAttributes: args},
-		})
-	if err != nil {
+	return s.GetZoneGroupAttributesContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetZoneGroupAttributesContext(ctx context.Context, httpClient *http.Client, args *GetZoneGroupAttributesArgs) (*GetZoneGroupAttributesResponse, error) {
+	var r GetZoneGroupAttributesResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetZoneGroupAttributes`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetZoneGroupAttributes == nil {
-		return nil, errors.New(`unexpected respose from service calling zonegrouptopology.GetZoneGroupAttributes()`)
-	}
-
-	return r.Body.GetZoneGroupAttributes, nil
+	return &r, nil
 }
 
 type GetZoneGroupStateArgs struct {
-	Xmlns string `xml:"xmlns:u,attr"`
 }
+
+// Validate checks the arguments against the values and ranges the service allows
+func (a *GetZoneGroupStateArgs) Validate() error {
+	return nil
+}
+
 type GetZoneGroupStateResponse struct {
 	ZoneGroupState string `xml:"ZoneGroupState"`
 }
 
 func (s *Service) GetZoneGroupState(httpClient *http.Client, args *GetZoneGroupStateArgs) (*GetZoneGroupStateResponse, error) {
-	args.Xmlns = _ServiceURN
-	r, err := s.exec(`GetZoneGroupState`, httpClient,
-		&Envelope{
-			EncodingStyle: _EncodingSchema,
-			Xmlns:         _EnvelopeSchema,
-			Body:          Body{GetZoneGroupState: args},
-		})
-	if err != nil {
+	return s.GetZoneGroupStateContext(context.Background(), httpClient, args)
+}
+func (s *Service) GetZoneGroupStateContext(ctx context.Context, httpClient *http.Client, args *GetZoneGroupStateArgs) (*GetZoneGroupStateResponse, error) {
+	var r GetZoneGroupStateResponse
+	if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `GetZoneGroupState`, args, &r); err != nil {
 		return nil, err
 	}
-	if r.Body.GetZoneGroupState == nil {
-		return nil, errors.New(`unexpected respose from service calling zonegrouptopology.GetZoneGroupState()`)
-	}
+	return &r, nil
+}
+
+// Client is the set of actions of the service. It is implemented by Service and Fake
+type Client interface {
+	CheckForUpdate(httpClient *http.Client, args *CheckForUpdateArgs) (*CheckForUpdateResponse, error)
+	CheckForUpdateContext(ctx context.Context, httpClient *http.Client, args *CheckForUpdateArgs) (*CheckForUpdateResponse, error)
+	BeginSoftwareUpdate(httpClient *http.Client, args *BeginSoftwareUpdateArgs) (*BeginSoftwareUpdateResponse, error)
+	BeginSoftwareUpdateContext(ctx context.Context, httpClient *http.Client, args *BeginSoftwareUpdateArgs) (*BeginSoftwareUpdateResponse, error)
+	ReportUnresponsiveDevice(httpClient *http.Client, args *ReportUnresponsiveDeviceArgs) (*ReportUnresponsiveDeviceResponse, error)
+	ReportUnresponsiveDeviceContext(ctx context.Context, httpClient *http.Client, args *ReportUnresponsiveDeviceArgs) (*ReportUnresponsiveDeviceResponse, error)
+	ReportAlarmStartedRunning(httpClient *http.Client, args *ReportAlarmStartedRunningArgs) (*ReportAlarmStartedRunningResponse, error)
+	ReportAlarmStartedRunningContext(ctx context.Context, httpClient *http.Client, args *ReportAlarmStartedRunningArgs) (*ReportAlarmStartedRunningResponse, error)
+	SubmitDiagnostics(httpClient *http.Client, args *SubmitDiagnosticsArgs) (*SubmitDiagnosticsResponse, error)
+	SubmitDiagnosticsContext(ctx context.Context, httpClient *http.Client, args *SubmitDiagnosticsArgs) (*SubmitDiagnosticsResponse, error)
+	RegisterMobileDevice(httpClient *http.Client, args *RegisterMobileDeviceArgs) (*RegisterMobileDeviceResponse, error)
+	RegisterMobileDeviceContext(ctx context.Context, httpClient *http.Client, args *RegisterMobileDeviceArgs) (*RegisterMobileDeviceResponse, error)
+	GetZoneGroupAttributes(httpClient *http.Client, args *GetZoneGroupAttributesArgs) (*GetZoneGroupAttributesResponse, error)
+	GetZoneGroupAttributesContext(ctx context.Context, httpClient *http.Client, args *GetZoneGroupAttributesArgs) (*GetZoneGroupAttributesResponse, error)
+	GetZoneGroupState(httpClient *http.Client, args *GetZoneGroupStateArgs) (*GetZoneGroupStateResponse, error)
+	GetZoneGroupStateContext(ctx context.Context, httpClient *http.Client, args *GetZoneGroupStateArgs) (*GetZoneGroupStateResponse, error)
+}
 
-	return r.Body.GetZoneGroupState, nil
+var _ Client = (*Service)(nil)
+var _ Client = (*Fake)(nil)
+
+// Call is an action received by a Fake
+type Call struct {
+	Action string
+	// Args is the *<Action>Args the action was called with
+	Args interface{}
+}
+
+// Fake is an in-memory Client for tests. It records every call, and answers
+// an action with its On<Action> func, or with an empty response if that is nil
+type Fake struct {
+	mu                          sync.Mutex
+	calls                       []Call
+	OnCheckForUpdate            func(ctx context.Context, args *CheckForUpdateArgs) (*CheckForUpdateResponse, error)
+	OnBeginSoftwareUpdate       func(ctx context.Context, args *BeginSoftwareUpdateArgs) (*BeginSoftwareUpdateResponse, error)
+	OnReportUnresponsiveDevice  func(ctx context.Context, args *ReportUnresponsiveDeviceArgs) (*ReportUnresponsiveDeviceResponse, error)
+	OnReportAlarmStartedRunning func(ctx context.Context, args *ReportAlarmStartedRunningArgs) (*ReportAlarmStartedRunningResponse, error)
+	OnSubmitDiagnostics         func(ctx context.Context, args *SubmitDiagnosticsArgs) (*SubmitDiagnosticsResponse, error)
+	OnRegisterMobileDevice      func(ctx context.Context, args *RegisterMobileDeviceArgs) (*RegisterMobileDeviceResponse, error)
+	OnGetZoneGroupAttributes    func(ctx context.Context, args *GetZoneGroupAttributesArgs) (*GetZoneGroupAttributesResponse, error)
+	OnGetZoneGroupState         func(ctx context.Context, args *GetZoneGroupStateArgs) (*GetZoneGroupStateResponse, error)
+}
+
+// Calls returns the calls received so far, oldest first
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// Reset forgets the calls received so far
+func (f *Fake) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+}
+func (f *Fake) record(action string, args interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{Action: action, Args: args})
+}
+func (f *Fake) CheckForUpdate(httpClient *http.Client, args *CheckForUpdateArgs) (*CheckForUpdateResponse, error) {
+	return f.CheckForUpdateContext(context.Background(), httpClient, args)
+}
+func (f *Fake) CheckForUpdateContext(ctx context.Context, httpClient *http.Client, args *CheckForUpdateArgs) (*CheckForUpdateResponse, error) {
+	f.record(`CheckForUpdate`, args)
+	if f.OnCheckForUpdate != nil {
+		return f.OnCheckForUpdate(ctx, args)
+	}
+	return &CheckForUpdateResponse{}, nil
+}
+func (f *Fake) BeginSoftwareUpdate(httpClient *http.Client, args *BeginSoftwareUpdateArgs) (*BeginSoftwareUpdateResponse, error) {
+	return f.BeginSoftwareUpdateContext(context.Background(), httpClient, args)
+}
+func (f *Fake) BeginSoftwareUpdateContext(ctx context.Context, httpClient *http.Client, args *BeginSoftwareUpdateArgs) (*BeginSoftwareUpdateResponse, error) {
+	f.record(`BeginSoftwareUpdate`, args)
+	if f.OnBeginSoftwareUpdate != nil {
+		return f.OnBeginSoftwareUpdate(ctx, args)
+	}
+	return &BeginSoftwareUpdateResponse{}, nil
+}
+func (f *Fake) ReportUnresponsiveDevice(httpClient *http.Client, args *ReportUnresponsiveDeviceArgs) (*ReportUnresponsiveDeviceResponse, error) {
+	return f.ReportUnresponsiveDeviceContext(context.Background(), httpClient, args)
+}
+func (f *Fake) ReportUnresponsiveDeviceContext(ctx context.Context, httpClient *http.Client, args *ReportUnresponsiveDeviceArgs) (*ReportUnresponsiveDeviceResponse, error) {
+	f.record(`ReportUnresponsiveDevice`, args)
+	if f.OnReportUnresponsiveDevice != nil {
+		return f.OnReportUnresponsiveDevice(ctx, args)
+	}
+	return &ReportUnresponsiveDeviceResponse{}, nil
+}
+func (f *Fake) ReportAlarmStartedRunning(httpClient *http.Client, args *ReportAlarmStartedRunningArgs) (*ReportAlarmStartedRunningResponse, error) {
+	return f.ReportAlarmStartedRunningContext(context.Background(), httpClient, args)
+}
+func (f *Fake) ReportAlarmStartedRunningContext(ctx context.Context, httpClient *http.Client, args *ReportAlarmStartedRunningArgs) (*ReportAlarmStartedRunningResponse, error) {
+	f.record(`ReportAlarmStartedRunning`, args)
+	if f.OnReportAlarmStartedRunning != nil {
+		return f.OnReportAlarmStartedRunning(ctx, args)
+	}
+	return &ReportAlarmStartedRunningResponse{}, nil
+}
+func (f *Fake) SubmitDiagnostics(httpClient *http.Client, args *SubmitDiagnosticsArgs) (*SubmitDiagnosticsResponse, error) {
+	return f.SubmitDiagnosticsContext(context.Background(), httpClient, args)
+}
+func (f *Fake) SubmitDiagnosticsContext(ctx context.Context, httpClient *http.Client, args *SubmitDiagnosticsArgs) (*SubmitDiagnosticsResponse, error) {
+	f.record(`SubmitDiagnostics`, args)
+	if f.OnSubmitDiagnostics != nil {
+		return f.OnSubmitDiagnostics(ctx, args)
+	}
+	return &SubmitDiagnosticsResponse{}, nil
+}
+func (f *Fake) RegisterMobileDevice(httpClient *http.Client, args *RegisterMobileDeviceArgs) (*RegisterMobileDeviceResponse, error) {
+	return f.RegisterMobileDeviceContext(context.Background(), httpClient, args)
+}
+func (f *Fake) RegisterMobileDeviceContext(ctx context.Context, httpClient *http.Client, args *RegisterMobileDeviceArgs) (*RegisterMobileDeviceResponse, error) {
+	f.record(`RegisterMobileDevice`, args)
+	if f.OnRegisterMobileDevice != nil {
+		return f.OnRegisterMobileDevice(ctx, args)
+	}
+	return &RegisterMobileDeviceResponse{}, nil
+}
+func (f *Fake) GetZoneGroupAttributes(httpClient *http.Client, args *GetZoneGroupAttributesArgs) (*GetZoneGroupAttributesResponse, error) {
+	return f.GetZoneGroupAttributesContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetZoneGroupAttributesContext(ctx context.Context, httpClient *http.Client, args *GetZoneGroupAttributesArgs) (*GetZoneGroupAttributesResponse, error) {
+	f.record(`GetZoneGroupAttributes`, args)
+	if f.OnGetZoneGroupAttributes != nil {
+		return f.OnGetZoneGroupAttributes(ctx, args)
+	}
+	return &GetZoneGroupAttributesResponse{}, nil
+}
+func (f *Fake) GetZoneGroupState(httpClient *http.Client, args *GetZoneGroupStateArgs) (*GetZoneGroupStateResponse, error) {
+	return f.GetZoneGroupStateContext(context.Background(), httpClient, args)
+}
+func (f *Fake) GetZoneGroupStateContext(ctx context.Context, httpClient *http.Client, args *GetZoneGroupStateArgs) (*GetZoneGroupStateResponse, error) {
+	f.record(`GetZoneGroupState`, args)
+	if f.OnGetZoneGroupState != nil {
+		return f.OnGetZoneGroupState(ctx, args)
+	}
+	return &GetZoneGroupStateResponse{}, nil
 }
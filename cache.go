@@ -0,0 +1,222 @@
+package sonos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// validateTimeout bounds the device description and topology fetches used to
+// check that a cached player is still where it was, and still a coordinator
+const validateTimeout = 500 * time.Millisecond
+
+type CacheEntry struct {
+	UDN       string    `json:"udn"`
+	Location  string    `json:"location"`
+	RoomName  string    `json:"roomName"`
+	ModelName string    `json:"modelName"`
+	Role      Role      `json:"role,omitempty"`
+	Seen      time.Time `json:"seen"`
+}
+
+// Cache remembers where players were found so that looking them up again
+// does not need a multicast search. It is stored as JSON at Path.
+type Cache struct {
+	Path string
+
+	mu      sync.Mutex
+	entries map[string]CacheEntry
+}
+
+// DefaultCachePath returns the cache file in the user's cache directory.
+func DefaultCachePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "sonos", "household.json"), nil
+}
+
+// LoadCache reads the cache stored at path. A missing file gives an empty
+// cache.
+func LoadCache(path string) (*Cache, error) {
+	c := Cache{
+		Path:    path,
+		entries: make(map[string]CacheEntry),
+	}
+
+	body, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []CacheEntry
+	if err := json.Unmarshal(body, &entries); err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		c.entries[entry.UDN] = entry
+	}
+	return &c, nil
+}
+
+// Save writes the cache to Path, creating its directory if needed.
+func (c *Cache) Save() error {
+	c.mu.Lock()
+	entries := make([]CacheEntry, 0, len(c.entries))
+	for _, entry := range c.entries {
+		entries = append(entries, entry)
+	}
+	c.mu.Unlock()
+
+	body, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0755); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so readers never see a partial cache
+	tmp, err := os.CreateTemp(filepath.Dir(c.Path), filepath.Base(c.Path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.Path)
+}
+
+// Entries returns the cached players.
+func (c *Cache) Entries() []CacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries := make([]CacheEntry, 0, len(c.entries))
+	for _, entry := range c.entries {
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// Add records where zp was found.
+func (c *Cache) Add(zp *ZonePlayer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[zp.Root.Device.UDN] = CacheEntry{
+		UDN:       zp.Root.Device.UDN,
+		Location:  zp.DeviceDescriptionURL.String(),
+		RoomName:  zp.RoomName(),
+		ModelName: zp.ModelName(),
+		Role:      zp.Role,
+		Seen:      time.Now(),
+	}
+}
+
+// Remove forgets the player with the given UDN.
+func (c *Cache) Remove(udn string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, udn)
+}
+
+func (c *Cache) FindRoom(room string, timeout time.Duration) (*ZonePlayer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	zp, err := c.FindRoomContext(ctx, room)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, errors.New("timeout")
+	}
+	return zp, err
+}
+
+// FindRoomContext is like the package level FindRoomContext but first tries
+// the cached coordinators of the room, checking each with a quick device
+// description and topology fetch. Only if none of them is still there and
+// still a coordinator does it fall back to a search, adding every coordinator
+// found along the way to the cache. Stale entries are removed or updated.
+// Call Save to keep the changes, whether or not the room was found.
+func (c *Cache) FindRoomContext(ctx context.Context, room string) (*ZonePlayer, error) {
+	for _, entry := range c.Entries() {
+		if entry.RoomName != room || entry.Role != RoleCoordinator {
+			continue
+		}
+		if zp := c.validate(ctx, entry); zp != nil {
+			return zp, nil
+		}
+	}
+
+	son, err := NewSonos()
+	if err != nil {
+		return nil, err
+	}
+	defer son.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	found, err := son.SearchContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for zp := range found {
+		c.Add(zp)
+		if zp.RoomName() == room {
+			return zp, nil
+		}
+	}
+	if err := son.Err(); err != nil {
+		return nil, err
+	}
+	return nil, ctx.Err()
+}
+
+// validate returns the player described by entry if it is still at the
+// cached location, in the cached room and the coordinator of its group.
+// Otherwise it forgets the entry, or records the player's new role.
+func (c *Cache) validate(ctx context.Context, entry CacheEntry) *ZonePlayer {
+	location, err := url.Parse(entry.Location)
+	if err != nil {
+		c.Remove(entry.UDN)
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, validateTimeout)
+	defer cancel()
+
+	zp, err := NewZonePlayerContext(ctx, location)
+	if err != nil || zp.Root.Device.UDN != entry.UDN || zp.RoomName() != entry.RoomName {
+		c.Remove(entry.UDN)
+		return nil
+	}
+
+	// The player may have joined another group since
+	state, err := zp.GetZoneGroupStateContext(ctx)
+	if err != nil {
+		c.Remove(entry.UDN)
+		return nil
+	}
+	zp.Role, _ = state.Role(zp.Root.Device.UDN)
+	c.Add(zp)
+	if zp.Role != RoleCoordinator {
+		return nil
+	}
+	return zp
+}
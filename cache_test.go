@@ -0,0 +1,81 @@
+package sonos
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeSpeaker serves the device description of the player udn in room, and
+// answers GetZoneGroupState with zoneGroupState
+func fakeSpeaker(t *testing.T, udn, room string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			fmt.Fprintf(w, `<root xmlns="urn:schemas-upnp-org:device-1-0"><device><UDN>%s</UDN><roomName>%s</roomName></device></root>`, udn, room)
+			return
+		}
+		var escaped strings.Builder
+		xml.EscapeText(&escaped, []byte(zoneGroupState))
+		fmt.Fprintf(w, `<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body>`+
+			`<u:GetZoneGroupStateResponse xmlns:u="urn:schemas-upnp-org:service:ZoneGroupTopology:1">`+
+			`<ZoneGroupState>%s</ZoneGroupState>`+
+			`</u:GetZoneGroupStateResponse></s:Body></s:Envelope>`, escaped.String())
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestCacheValidate(t *testing.T) {
+	livingRoom := fakeSpeaker(t, "uuid:RINCON_TV01400", "Living Room")
+	kitchen := fakeSpeaker(t, "uuid:RINCON_KI01400", "Kitchen")
+
+	c, err := LoadCache(filepath.Join(t.TempDir(), "household.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := []CacheEntry{
+		{UDN: "uuid:RINCON_TV01400", Location: livingRoom.URL + "/xml/device_description.xml", RoomName: "Living Room", Role: RoleCoordinator},
+		// The kitchen was a coordinator when cached, but has since joined
+		// the living room
+		{UDN: "uuid:RINCON_KI01400", Location: kitchen.URL + "/xml/device_description.xml", RoomName: "Kitchen", Role: RoleCoordinator},
+		// The bedroom moved away
+		{UDN: "uuid:RINCON_BL01400", Location: kitchen.URL + "/xml/device_description.xml", RoomName: "Bedroom", Role: RoleCoordinator},
+	}
+
+	ctx := context.Background()
+	if zp := c.validate(ctx, entries[0]); zp == nil || zp.Role != RoleCoordinator {
+		t.Errorf("validate(Living Room) = %+v, want the coordinator", zp)
+	}
+	if zp := c.validate(ctx, entries[1]); zp != nil {
+		t.Errorf("validate(Kitchen) = %+v, want nil as it is no longer a coordinator", zp)
+	}
+	if zp := c.validate(ctx, entries[2]); zp != nil {
+		t.Errorf("validate(Bedroom) = %+v, want nil as it moved", zp)
+	}
+
+	roles := map[string]Role{}
+	for _, entry := range c.Entries() {
+		roles[entry.RoomName] = entry.Role
+	}
+	want := map[string]Role{"Living Room": RoleCoordinator, "Kitchen": RoleMember}
+	if fmt.Sprint(roles) != fmt.Sprint(want) {
+		t.Errorf("cached roles = %v, want %v", roles, want)
+	}
+
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := LoadCache(c.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved.Entries()) != 2 {
+		t.Errorf("saved entries = %+v, want 2", saved.Entries())
+	}
+}
@@ -1,28 +1,40 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/szatmary/sonos"
 )
 
 func main() {
-	son, err := sonos.NewSonos()
+	son, err := sonos.NewSonos(sonos.WithAllPlayers(), sonos.WithSearchRepeat(3, time.Second))
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 		return
 	}
 	defer son.Close()
 
-	found, _ := son.Search()
-	to := time.After(10 * time.Second)
-	for {
-		select {
-		case <-to:
-			return
-		case zp := <-found:
-			fmt.Printf("%s\t%s\t%s", zp.RoomName(), zp.ModelName(), zp.SerialNum())
-		}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Probe the given addresses or ranges directly, or search by multicast
+	var found chan *sonos.ZonePlayer
+	if len(os.Args) > 1 {
+		found, err = son.ProbeContext(ctx, os.Args[1:])
+	} else {
+		found, err = son.SearchContext(ctx)
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	for zp := range found {
+		fmt.Printf("%s\t%s\t%s\t%s\n", zp.RoomName(), zp.ModelName(), zp.SerialNum(), zp.Role)
+	}
+	if err := son.Err(); err != nil {
+		fmt.Printf("Error: %v\n", err)
 	}
 }
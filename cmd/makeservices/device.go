@@ -0,0 +1,171 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type DeviceService struct {
+	ServiceType string `xml:"serviceType"`
+	ServiceId   string `xml:"serviceId"`
+	ControlURL  string `xml:"controlURL"`
+	EventSubURL string `xml:"eventSubURL"`
+	SCPDURL     string `xml:"SCPDURL"`
+}
+
+type Device struct {
+	DeviceType string          `xml:"deviceType"`
+	Services   []DeviceService `xml:"serviceList>service"`
+	Devices    []Device        `xml:"deviceList>device"`
+}
+
+type DeviceDescription struct {
+	XMLName xml.Name `xml:"root"`
+	Device  Device   `xml:"device"`
+}
+
+// AllServices walks the device and its embedded devices depth first. A
+// service offered by more than one device, such as ConnectionManager, is
+// only returned the first time it is seen.
+func (d *Device) AllServices() []DeviceService {
+	seen := map[string]bool{}
+	var services []DeviceService
+	var walk func(d *Device)
+	walk = func(d *Device) {
+		for _, service := range d.Services {
+			if seen[service.ServiceType] {
+				continue
+			}
+			seen[service.ServiceType] = true
+			services = append(services, service)
+		}
+		for i := range d.Devices {
+			walk(&d.Devices[i])
+		}
+	}
+	walk(d)
+	return services
+}
+
+// ServiceName returns the name part of a service type such as
+// urn:schemas-upnp-org:service:AVTransport:1
+func (s *DeviceService) ServiceName() (string, error) {
+	parts := strings.Split(s.ServiceType, ":")
+	if len(parts) != 5 || parts[2] != "service" || parts[3] == "" {
+		return "", fmt.Errorf("unexpected service type %q", s.ServiceType)
+	}
+	return parts[3], nil
+}
+
+// source reads the device description and the SCPDs it refers to, either
+// from a speaker or from the files of a saved copy
+type source struct {
+	location *url.URL
+	files    fs.FS
+}
+
+// httpClient fetches from speakers, and gives up on one that stops answering
+// instead of hanging generation or diff
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+func isRemote(location *url.URL) bool {
+	return location != nil && (location.Scheme == "http" || location.Scheme == "https")
+}
+
+func (s *source) read(ref string) ([]byte, error) {
+	if !isRemote(s.location) {
+		return fs.ReadFile(s.files, path.Base(ref))
+	}
+	u, err := url.Parse(ref)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Get(s.location.ResolveReference(u).String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", ref, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// openDevice reads the device description at device, a URL or a file. The
+// SCPDs of a saved description are looked up in scpdDir, or next to it if
+// scpdDir is empty.
+func openDevice(device, scpdDir string) (*source, *DeviceDescription, error) {
+	location, err := url.Parse(device)
+	if err != nil {
+		return nil, nil, err
+	}
+	src := &source{location: location}
+
+	var body []byte
+	if isRemote(location) {
+		body, err = src.read(location.String())
+	} else {
+		if scpdDir == "" {
+			scpdDir = filepath.Dir(device)
+		}
+		src.files = os.DirFS(scpdDir)
+		body, err = ioutil.ReadFile(device)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var description DeviceDescription
+	if err := xml.Unmarshal(body, &description); err != nil {
+		return nil, nil, err
+	}
+	return src, &description, nil
+}
+
+// generateDevice writes a package for every service of the device
+// description at device into outDir. Only the generated file of each package
+// is written, anything else in the package directories is left alone.
+func generateDevice(device, scpdDir, outDir string) error {
+	src, description, err := openDevice(device, scpdDir)
+	if err != nil {
+		return err
+	}
+
+	for _, service := range description.Device.AllServices() {
+		name, err := service.ServiceName()
+		if err != nil {
+			return err
+		}
+		scpd, err := src.read(service.SCPDURL)
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+		dotgo, err := MakeServiceApi(name, service.ServiceType, service.ControlURL, service.EventSubURL, scpd)
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+		dotgo, err = format.Source(dotgo)
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+		dir := filepath.Join(outDir, name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, name+".go"), dotgo, 0644); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "%s\n", filepath.Join(dir, name+".go"))
+	}
+	return nil
+}
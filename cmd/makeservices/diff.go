@@ -0,0 +1,258 @@
+package main
+
+import (
+	"embed"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+)
+
+// defaultDevice is the saved device description the checked-in packages are
+// generated from, relative to the source of makeservices
+const defaultDevice = "xml/device_description.xml"
+
+// saved holds defaultDevice and its SCPDs, so that diff can compare with them
+// wherever makeservices is run or installed
+//
+//go:embed xml/*.xml
+var saved embed.FS
+
+// openSaved reads the saved device description built into makeservices
+func openSaved() (*source, *DeviceDescription, error) {
+	files, err := fs.Sub(saved, "xml")
+	if err != nil {
+		return nil, nil, err
+	}
+	src := &source{files: files}
+	body, err := src.read(defaultDevice)
+	if err != nil {
+		return nil, nil, err
+	}
+	var description DeviceDescription
+	if err := xml.Unmarshal(body, &description); err != nil {
+		return nil, nil, err
+	}
+	return src, &description, nil
+}
+
+// loadScpds reads the SCPD of every service of a device description, keyed by
+// service name. An empty device is the saved one.
+func loadScpds(device string) (map[string]*Scpd, error) {
+	var src *source
+	var description *DeviceDescription
+	var err error
+	if device == "" {
+		src, description, err = openSaved()
+	} else {
+		src, description, err = openDevice(device, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+	scpds := map[string]*Scpd{}
+	for _, service := range description.Device.AllServices() {
+		name, err := service.ServiceName()
+		if err != nil {
+			return nil, err
+		}
+		body, err := src.read(service.SCPDURL)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		var s Scpd
+		if err := xml.Unmarshal(body, &s); err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		scpds[name] = &s
+	}
+	return scpds, nil
+}
+
+// differ collects the differences between two SCPD sets as lines of text
+type differ struct {
+	lines []string
+}
+
+func (d *differ) report(format string, a ...interface{}) {
+	d.lines = append(d.lines, fmt.Sprintf(format, a...))
+}
+
+// compareNames reports the names only in old as removed and the ones only in
+// new as added, and returns the names in both, sorted
+func (d *differ) compareNames(prefix, what string, old, new []string) []string {
+	inOld := map[string]bool{}
+	for _, name := range old {
+		inOld[name] = true
+	}
+	inNew := map[string]bool{}
+	for _, name := range new {
+		inNew[name] = true
+	}
+	var both []string
+	for _, name := range sortedNames(inOld) {
+		if inNew[name] {
+			both = append(both, name)
+		} else {
+			d.report("%s%s removed: %s", prefix, what, name)
+		}
+	}
+	for _, name := range sortedNames(inNew) {
+		if !inOld[name] {
+			d.report("%s%s added: %s", prefix, what, name)
+		}
+	}
+	return both
+}
+
+func sortedNames(set map[string]bool) []string {
+	var names []string
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (d *differ) services(old, new map[string]*Scpd) {
+	oldNames := make([]string, 0, len(old))
+	for name := range old {
+		oldNames = append(oldNames, name)
+	}
+	newNames := make([]string, 0, len(new))
+	for name := range new {
+		newNames = append(newNames, name)
+	}
+	for _, name := range d.compareNames("", "service", oldNames, newNames) {
+		d.service(name, old[name], new[name])
+	}
+}
+
+func (d *differ) service(name string, old, new *Scpd) {
+	prefix := name + ": "
+
+	oldActions := map[string]Action{}
+	var oldNames []string
+	for _, action := range old.Actions {
+		oldActions[action.Name] = action
+		oldNames = append(oldNames, action.Name)
+	}
+	newActions := map[string]Action{}
+	var newNames []string
+	for _, action := range new.Actions {
+		newActions[action.Name] = action
+		newNames = append(newNames, action.Name)
+	}
+	for _, actionName := range d.compareNames(prefix, "action", oldNames, newNames) {
+		d.action(prefix+actionName+": ", oldActions[actionName], newActions[actionName])
+	}
+
+	oldNames, newNames = nil, nil
+	for _, sv := range old.StateVariables {
+		oldNames = append(oldNames, sv.Name)
+	}
+	for _, sv := range new.StateVariables {
+		newNames = append(newNames, sv.Name)
+	}
+	for _, svName := range d.compareNames(prefix, "state variable", oldNames, newNames) {
+		d.stateVariable(prefix+svName+": ", old.GetStateVariable(svName), new.GetStateVariable(svName))
+	}
+}
+
+func (d *differ) action(prefix string, old, new Action) {
+	oldArguments := map[string]Argument{}
+	var oldNames []string
+	for _, argument := range old.Arguments {
+		oldArguments[argument.Name] = argument
+		oldNames = append(oldNames, argument.Name)
+	}
+	newArguments := map[string]Argument{}
+	var newNames []string
+	for _, argument := range new.Arguments {
+		newArguments[argument.Name] = argument
+		newNames = append(newNames, argument.Name)
+	}
+	for _, name := range d.compareNames(prefix, "argument", oldNames, newNames) {
+		o, n := oldArguments[name], newArguments[name]
+		if o.Direction != n.Direction {
+			d.report("%sargument %s: direction changed: %s -> %s", prefix, name, o.Direction, n.Direction)
+		}
+		if o.RelatedStateVariable != n.RelatedStateVariable {
+			d.report("%sargument %s: state variable changed: %s -> %s", prefix, name, o.RelatedStateVariable, n.RelatedStateVariable)
+		}
+	}
+}
+
+func (d *differ) stateVariable(prefix string, old, new *StateVariable) {
+	if strings.TrimSpace(old.DataType) != strings.TrimSpace(new.DataType) {
+		d.report("%sdata type changed: %s -> %s", prefix, old.DataType, new.DataType)
+	}
+	if rangeString(old.AllowedValueRange) != rangeString(new.AllowedValueRange) {
+		d.report("%sallowed range changed: %s -> %s", prefix, rangeString(old.AllowedValueRange), rangeString(new.AllowedValueRange))
+	}
+	d.compareNames(prefix, "allowed value", old.AllowedValues, new.AllowedValues)
+}
+
+func rangeString(r *AllowedValueRange) string {
+	if r == nil {
+		return "none"
+	}
+	return fmt.Sprintf("%s -> %s step: %s", r.Minimum, r.Maximum, r.Step)
+}
+
+// diffServices writes the differences between the services of two device
+// descriptions to w, and reports whether there were any. An empty oldDevice
+// is the saved one.
+func diffServices(w io.Writer, oldDevice, newDevice string) (bool, error) {
+	old, err := loadScpds(oldDevice)
+	if err != nil {
+		return false, err
+	}
+	new, err := loadScpds(newDevice)
+	if err != nil {
+		return false, err
+	}
+	var d differ
+	d.services(old, new)
+	for _, line := range d.lines {
+		fmt.Fprintln(w, line)
+	}
+	return len(d.lines) != 0, nil
+}
+
+// diffMain runs the diff subcommand. Like diff(1) it exits with 1 if the
+// services differ and 2 on errors.
+func diffMain(args []string) {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: makeservices diff [old] new\n\n")
+		fmt.Fprintf(os.Stderr, "Compares the services of two device descriptions, each a URL or a saved copy\n")
+		fmt.Fprintf(os.Stderr, "next to its SCPDs. old defaults to cmd/makeservices/%s, the one\n", defaultDevice)
+		fmt.Fprintf(os.Stderr, "the checked-in packages are generated from, as built into makeservices.\n")
+	}
+	flags.Parse(args)
+
+	var oldDevice, newDevice string
+	switch flags.NArg() {
+	case 1:
+		newDevice = flags.Arg(0)
+	case 2:
+		oldDevice, newDevice = flags.Arg(0), flags.Arg(1)
+	default:
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	changed, err := diffServices(os.Stdout, oldDevice, newDevice)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "err: %v\n", err)
+		os.Exit(2)
+	}
+	if changed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io/fs"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const oldSCPD = `<scpd xmlns="urn:schemas-upnp-org:service-1-0">
+<serviceStateTable>
+<stateVariable sendEvents="no"><name>A_ARG_TYPE_PlayMode</name><dataType>string</dataType>
+<allowedValueList><allowedValue>NORMAL</allowedValue><allowedValue>SHUFFLE</allowedValue></allowedValueList></stateVariable>
+<stateVariable sendEvents="no"><name>Volume</name><dataType>ui2</dataType>
+<allowedValueRange><minimum>0</minimum><maximum>100</maximum><step>1</step></allowedValueRange></stateVariable>
+<stateVariable sendEvents="no"><name>A_ARG_TYPE_InstanceID</name><dataType>ui4</dataType></stateVariable>
+</serviceStateTable>
+<actionList>
+<action><name>SetPlayMode</name><argumentList>
+<argument><name>InstanceID</name><direction>in</direction><relatedStateVariable>A_ARG_TYPE_InstanceID</relatedStateVariable></argument>
+<argument><name>NewPlayMode</name><direction>in</direction><relatedStateVariable>A_ARG_TYPE_PlayMode</relatedStateVariable></argument>
+</argumentList></action>
+<action><name>SetVolume</name><argumentList>
+<argument><name>InstanceID</name><direction>in</direction><relatedStateVariable>A_ARG_TYPE_InstanceID</relatedStateVariable></argument>
+<argument><name>DesiredVolume</name><direction>in</direction><relatedStateVariable>Volume</relatedStateVariable></argument>
+</argumentList></action>
+<action><name>Pause</name><argumentList>
+<argument><name>InstanceID</name><direction>in</direction><relatedStateVariable>A_ARG_TYPE_InstanceID</relatedStateVariable></argument>
+</argumentList></action>
+</actionList>
+</scpd>`
+
+const newSCPD = `<scpd xmlns="urn:schemas-upnp-org:service-1-0">
+<serviceStateTable>
+<stateVariable sendEvents="no"><name>A_ARG_TYPE_PlayMode</name><dataType>string</dataType>
+<allowedValueList><allowedValue>NORMAL</allowedValue><allowedValue>SHUFFLE_NOREPEAT</allowedValue></allowedValueList></stateVariable>
+<stateVariable sendEvents="no"><name>Volume</name><dataType>ui4</dataType>
+<allowedValueRange><minimum>0</minimum><maximum>100</maximum></allowedValueRange></stateVariable>
+<stateVariable sendEvents="no"><name>A_ARG_TYPE_InstanceID</name><dataType>ui4</dataType></stateVariable>
+<stateVariable sendEvents="no"><name>A_ARG_TYPE_Channel</name><dataType>string</dataType></stateVariable>
+</serviceStateTable>
+<actionList>
+<action><name>SetPlayMode</name><argumentList>
+<argument><name>NewPlayMode</name><direction>in</direction><relatedStateVariable>A_ARG_TYPE_PlayMode</relatedStateVariable></argument>
+</argumentList></action>
+<action><name>SetVolume</name><argumentList>
+<argument><name>InstanceID</name><direction>in</direction><relatedStateVariable>A_ARG_TYPE_InstanceID</relatedStateVariable></argument>
+<argument><name>Channel</name><direction>in</direction><relatedStateVariable>A_ARG_TYPE_Channel</relatedStateVariable></argument>
+<argument><name>DesiredVolume</name><direction>out</direction><relatedStateVariable>A_ARG_TYPE_InstanceID</relatedStateVariable></argument>
+</argumentList></action>
+<action><name>Play</name></action>
+</actionList>
+</scpd>`
+
+func parseSCPD(t *testing.T, body string) *Scpd {
+	t.Helper()
+	var s Scpd
+	if err := xml.Unmarshal([]byte(body), &s); err != nil {
+		t.Fatal(err)
+	}
+	return &s
+}
+
+func TestDiffer(t *testing.T) {
+	old := map[string]*Scpd{"AVTransport": parseSCPD(t, oldSCPD), "AlarmClock": parseSCPD(t, oldSCPD)}
+	new := map[string]*Scpd{"AVTransport": parseSCPD(t, newSCPD), "Queue": parseSCPD(t, oldSCPD)}
+
+	var d differ
+	d.services(old, new)
+	want := []string{
+		"service removed: AlarmClock",
+		"service added: Queue",
+		"AVTransport: action removed: Pause",
+		"AVTransport: action added: Play",
+		"AVTransport: SetPlayMode: argument removed: InstanceID",
+		"AVTransport: SetVolume: argument added: Channel",
+		"AVTransport: SetVolume: argument DesiredVolume: direction changed: in -> out",
+		"AVTransport: SetVolume: argument DesiredVolume: state variable changed: Volume -> A_ARG_TYPE_InstanceID",
+		"AVTransport: state variable added: A_ARG_TYPE_Channel",
+		"AVTransport: A_ARG_TYPE_PlayMode: allowed value removed: SHUFFLE",
+		"AVTransport: A_ARG_TYPE_PlayMode: allowed value added: SHUFFLE_NOREPEAT",
+		"AVTransport: Volume: data type changed: ui2 -> ui4",
+		"AVTransport: Volume: allowed range changed: 0 -> 100 step: 1 -> 0 -> 100 step: ",
+	}
+	if got := strings.Join(d.lines, "\n"); got != strings.Join(want, "\n") {
+		t.Errorf("differences =\n%s\nwant\n%s", got, strings.Join(want, "\n"))
+	}
+
+	d = differ{}
+	d.services(old, old)
+	if len(d.lines) != 0 {
+		t.Errorf("differences of the same services = %q, want none", d.lines)
+	}
+}
+
+// TestDiffServicesSaved compares the saved device description built into
+// makeservices with a copy on disk
+func TestDiffServicesSaved(t *testing.T) {
+	dir := t.TempDir()
+	files, err := fs.Sub(saved, "xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	names, err := fs.Glob(files, "*.xml")
+	if err != nil || len(names) < 2 {
+		t.Fatalf("saved files = %v, %v", names, err)
+	}
+	for _, name := range names {
+		body, err := fs.ReadFile(files, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name == "AlarmClock1.xml" {
+			body = bytes.Replace(body, []byte("<name>DestroyAlarm</name>"), []byte("<name>RemoveAlarm</name>"), 1)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, name), body, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out bytes.Buffer
+	changed, err := diffServices(&out, "", filepath.Join(dir, "device_description.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "AlarmClock: action removed: DestroyAlarm\nAlarmClock: action added: RemoveAlarm\n"
+	if !changed || out.String() != want {
+		t.Errorf("diffServices = %t\n%s\nwant true\n%s", changed, out.String(), want)
+	}
+
+	out.Reset()
+	if changed, err := diffServices(&out, "", ""); err != nil || changed {
+		t.Errorf("diffServices(saved, saved) = %t, %v\n%s", changed, err, out.String())
+	}
+	if _, err := diffServices(&out, "", filepath.Join(dir, "missing.xml")); err == nil {
+		t.Error("diffServices(missing) succeeded")
+	}
+}
+
+func TestSourceReadTimeout(t *testing.T) {
+	hang := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-hang:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(hang)
+
+	timeout := httpClient.Timeout
+	httpClient.Timeout = 50 * time.Millisecond
+	defer func() { httpClient.Timeout = timeout }()
+
+	done := make(chan error)
+	go func() {
+		_, err := loadScpds(srv.URL + "/xml/device_description.xml")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("loadScpds from a hanging speaker succeeded")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("loadScpds hangs on a speaker that does not answer")
+	}
+}
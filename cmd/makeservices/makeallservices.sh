@@ -1,33 +1,7 @@
 #!/bin/bash
-declare -a arr=("VirtualLineIn" "GroupRenderingControl" "Queue" "AVTransport" "ConnectionManager" "RenderingControl")
-
-for i in "${arr[@]}"
-do
-    rm -rf ${GOPATH}/src/github.com/szatmary/sonos/${i}/
-    mkdir -p ${GOPATH}/src/github.com/szatmary/sonos/${i}/
-    go run makeservice.go ${i} /MediaRenderer/${i}/Control /MediaRenderer/${i}/Event ${GOPATH}/src/github.com/szatmary/sonos/cmd/makeservices/xml/${i}1.xml >${i}.go
-    go fmt ${i}.go
-    mv ${i}.go ${GOPATH}/src/github.com/szatmary/sonos/${i}/
-done
-
-declare -a arr=("ContentDirectory" "ConnectionManager")
-
-for i in "${arr[@]}"
-do
-    rm -rf ${GOPATH}/src/github.com/szatmary/sonos/${i}/
-    mkdir -p ${GOPATH}/src/github.com/szatmary/sonos/${i}/
-    go run makeservice.go ${i} /MediaServer/${i}/Control /MediaServer/${i}/Event ${GOPATH}/src/github.com/szatmary/sonos/cmd/makeservices/xml/${i}1.xml >${i}.go
-    go fmt ${i}.go
-    mv ${i}.go ${GOPATH}/src/github.com/szatmary/sonos/${i}/
-done
-
-declare -a arr=("AlarmClock" "MusicServices" "DeviceProperties" "SystemProperties" "ZoneGroupTopology" "GroupManagement" "QPlay")
-
-for i in "${arr[@]}"
-do
-    rm -rf ${GOPATH}/src/github.com/szatmary/sonos/${i}/
-    mkdir -p ${GOPATH}/src/github.com/szatmary/sonos/${i}/
-    go run makeservice.go ${i} /${i}/Control /${i}/Event ${GOPATH}/src/github.com/szatmary/sonos/cmd/makeservices/xml/${i}1.xml >${i}.go
-    go fmt ${i}.go
-    mv ${i}.go ${GOPATH}/src/github.com/szatmary/sonos/${i}/
-done
\ No newline at end of file
+# Regenerate every service package from the saved device description. Pass a
+# speaker's description URL, e.g. http://192.168.1.10:1400/xml/device_description.xml,
+# to pick up the services of its firmware instead.
+set -e
+cd "$(dirname "$0")"
+go run . -device "${1:-xml/device_description.xml}" -out ../..
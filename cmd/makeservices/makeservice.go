@@ -4,12 +4,84 @@ import (
 	"bytes"
 	"encoding/xml"
 	"errors"
+	"flag"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
+// errorCodes lists the service specific UPnP error codes known for each
+// service, on top of the ones common to all services in soap.ErrorCodes
+var errorCodes = map[string]map[int]string{
+	"AVTransport": {
+		701: "Transition not available",
+		702: "No contents",
+		703: "Read error",
+		704: "Format not supported for playback",
+		705: "Transport is locked",
+		706: "Write error",
+		707: "Media is protected or not writeable",
+		708: "Format not supported for recording",
+		709: "Media is full",
+		710: "Seek mode not supported",
+		711: "Illegal seek target",
+		712: "Play mode not supported",
+		713: "Record quality not supported",
+		714: "Illegal MIME-type",
+		715: "Content 'BUSY'",
+		716: "Resource not found",
+		717: "Play speed not supported",
+		718: "Invalid InstanceID",
+		737: "No DNS server",
+		738: "Bad domain name",
+		739: "Server error",
+		800: "Command not supported by this player, it may not be the group coordinator",
+	},
+	"ConnectionManager": {
+		701: "Incompatible protocol info",
+		702: "Incompatible directions",
+		703: "Insufficient network resources",
+		704: "Local restrictions",
+		705: "Access denied",
+		706: "Invalid connection reference",
+		707: "Not in network",
+	},
+	"ContentDirectory": {
+		701: "No such object",
+		702: "Invalid CurrentTagValue",
+		703: "Invalid NewTagValue",
+		704: "Required tag",
+		705: "Read only tag",
+		706: "Parameter mismatch",
+		708: "Unsupported or invalid search criteria",
+		709: "Unsupported or invalid sort criteria",
+		710: "No such container",
+		711: "Restricted object",
+		712: "Bad metadata",
+		713: "Restricted parent object",
+		714: "No such source resource",
+		715: "Resource access denied",
+		716: "Transfer busy",
+		717: "No such file transfer",
+		718: "No such destination resource",
+		719: "Destination resource access denied",
+		720: "Cannot process the request",
+	},
+	"Queue": {
+		800: "Command not supported by this player, it may not be the group coordinator",
+	},
+	"RenderingControl": {
+		701: "Invalid name",
+		702: "Invalid InstanceID",
+	},
+}
+
 type AllowedValueRange struct {
 	XMLName xml.Name `xml:"allowedValueRange"`
 	Minimum string   `xml:"minimum"`
@@ -28,48 +100,239 @@ type StateVariable struct {
 	AllowedValues     []string           `xml:"allowedValueList>allowedValue"`
 }
 
-func (s *StateVariable) GoDataType() string {
-	switch s.DataType {
-	case "ui1":
-		return "uint8"
-	case "ui2":
-		return "uint16"
-	case "ui4":
-		return "uint32"
-	case "i1":
-		return "int8"
-	case "i2":
-		return "int16"
-	case "i4":
-		return "int32"
-	case "int":
-		return "int64"
-	case "r4":
-		return "flaot32"
-	case "number", "r8":
-		return "float"
-	case "float", "float64":
-		return "float"
-	// case "fixed.14.4": // TODO fixed
-	case "char":
-		return "rune"
-	case "string":
-		return "string"
-		// TODO data/time
-	case "date", "dateTime", " dateTime.tz", "time", "time.tz":
-		return "string"
-	case "boolean":
-		return "bool"
-		// TODO
-	// case "bin.base64", "bin.hex":
-	// 	return "string"
-	case "uri":
-		return "*url.URL"
-	case "uuid":
-		return "string"
+// dataTypes maps the UPnP data types to Go types. The soap package provides
+// the types that encoding/xml would otherwise put on the wire in the wrong
+// form.
+var dataTypes = map[string]string{
+	"ui1":         "uint8",
+	"ui2":         "uint16",
+	"ui4":         "uint32",
+	"ui8":         "uint64",
+	"i1":          "int8",
+	"i2":          "int16",
+	"i4":          "int32",
+	"i8":          "int64",
+	"int":         "int64",
+	"r4":          "float32",
+	"r8":          "float64",
+	"number":      "float64",
+	"float":       "float64",
+	"fixed.14.4":  "float64",
+	"char":        "soap.Char",
+	"string":      "string",
+	"date":        "soap.Date",
+	"dateTime":    "soap.DateTime",
+	"dateTime.tz": "soap.DateTimeTZ",
+	"time":        "soap.Time",
+	"time.tz":     "soap.TimeTZ",
+	"boolean":     "bool",
+	"bin.base64":  "soap.Base64",
+	"bin.hex":     "soap.Hex",
+	"uri":         "string",
+	"uuid":        "string",
+}
+
+// GoDataType returns the Go type of the state variable's data type, or an
+// error if the data type is unknown.
+func (s *StateVariable) GoDataType() (string, error) {
+	goType, ok := dataTypes[strings.TrimSpace(s.DataType)]
+	if !ok {
+		return "", fmt.Errorf("state variable %s has unknown data type %q", s.Name, s.DataType)
+	}
+	return goType, nil
+}
+
+// openEnded lists the state variables whose allowed values are examples
+// rather than a complete list, per service. They are not validated.
+var openEnded = map[string][]string{
+	// Recurrence also takes days of the week, e.g. ON_12345
+	"AlarmClock": {"A_ARG_TYPE_Recurrence"},
+}
+
+func isOpenEnded(serviceName, stateVariable string) bool {
+	for _, name := range openEnded[serviceName] {
+		if name == stateVariable {
+			return true
+		}
+	}
+	return false
+}
+
+// integerBounds returns the range of the Go integer types, ok is false for
+// any other type. The range of uint64 is cut to that of int64, which the SCPD
+// bounds are read in.
+func integerBounds(goType string) (min, max int64, ok bool) {
+	switch goType {
+	case "uint8":
+		return 0, math.MaxUint8, true
+	case "uint16":
+		return 0, math.MaxUint16, true
+	case "uint32":
+		return 0, math.MaxUint32, true
+	case "uint64":
+		return 0, math.MaxInt64, true
+	case "int8":
+		return math.MinInt8, math.MaxInt8, true
+	case "int16":
+		return math.MinInt16, math.MaxInt16, true
+	case "int32":
+		return math.MinInt32, math.MaxInt32, true
+	case "int64":
+		return math.MinInt64, math.MaxInt64, true
+	}
+	return 0, 0, false
+}
+
+// writeValidation writes the checks Validate makes for one argument. An empty
+// enumerated argument is left for the service to check, so the zero value of
+// the Args keeps working for the actions that accept it.
+func writeValidation(buf *bytes.Buffer, serviceName, actionName string, argument Argument, sv *StateVariable) error {
+	if len(sv.AllowedValues) > 0 && !sv.IsEnum() {
+		return fmt.Errorf("allowed values on %s state variable %s", sv.DataType, sv.Name)
+	}
+	if sv.IsEnum() && !isOpenEnded(serviceName, sv.Name) {
+		constants := []string{`""`}
+		for _, allowedValue := range sv.AllowedValues {
+			constants = append(constants, sv.EnumConstant(allowedValue))
+		}
+		fmt.Fprintf(buf, "switch a.%s {\ncase %s:\ndefault:\n", argument.Name, strings.Join(constants, ", "))
+		fmt.Fprintf(buf, "return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `%s`, Argument: `%s`, Value: a.%s, Allowed: %q}\n}\n",
+			actionName, argument.Name, argument.Name, "one of "+strings.Join(sv.AllowedValues, ", "))
+	}
+
+	if sv.AllowedValueRange == nil {
+		return nil
+	}
+	goType, err := sv.GoDataType()
+	if err != nil {
+		return err
+	}
+	typeMin, typeMax, ok := integerBounds(goType)
+	if !ok {
+		return fmt.Errorf("allowed range on %s state variable %s", sv.DataType, sv.Name)
+	}
+	min, err := strconv.ParseInt(sv.AllowedValueRange.Minimum, 10, 64)
+	if err != nil {
+		return err
+	}
+	max, err := strconv.ParseInt(sv.AllowedValueRange.Maximum, 10, 64)
+	if err != nil {
+		return err
+	}
+	step := int64(1)
+	if sv.AllowedValueRange.Step != "" {
+		if step, err = strconv.ParseInt(sv.AllowedValueRange.Step, 10, 64); err != nil {
+			return err
+		}
+	}
+
+	// Leave out the comparisons the type itself already guarantees
+	var conditions []string
+	if min > typeMin {
+		conditions = append(conditions, fmt.Sprintf("a.%s < %d", argument.Name, min))
+	}
+	if max < typeMax {
+		conditions = append(conditions, fmt.Sprintf("a.%s > %d", argument.Name, max))
+	}
+	switch {
+	case step <= 1:
+	case min == 0:
+		conditions = append(conditions, fmt.Sprintf("int64(a.%s)%%%d != 0", argument.Name, step))
+	case min < 0:
+		conditions = append(conditions, fmt.Sprintf("(int64(a.%s)+%d)%%%d != 0", argument.Name, -min, step))
 	default:
-		return ""
+		conditions = append(conditions, fmt.Sprintf("(int64(a.%s)-%d)%%%d != 0", argument.Name, min, step))
+	}
+	if len(conditions) == 0 {
+		return nil
+	}
+	allowed := fmt.Sprintf("%d to %d", min, max)
+	if step > 1 {
+		allowed += fmt.Sprintf(" in steps of %d", step)
+	}
+	fmt.Fprintf(buf, "if %s {\n", strings.Join(conditions, " || "))
+	fmt.Fprintf(buf, "return &soap.ArgumentError{ServiceURN: _ServiceURN, Action: `%s`, Argument: `%s`, Value: a.%s, Allowed: %q}\n}\n",
+		actionName, argument.Name, argument.Name, allowed)
+	return nil
+}
+
+// enumNames renames enumerated state variables whose SCPD name does not
+// describe the arguments that use them
+var enumNames = map[string]string{
+	"A_ARG_TYPE_SeekMode": "SeekUnit",
+}
+
+// enumWords spells out the words of allowed values that are run together
+var enumWords = map[string]string{
+	"NOREPEAT": "NoRepeat",
+}
+
+// IsEnum reports whether the state variable gets its own named type.
+func (s *StateVariable) IsEnum() bool {
+	return len(s.AllowedValues) > 0 && strings.TrimSpace(s.DataType) == "string"
+}
+
+// EnumName returns the name of the type generated for an enumerated state
+// variable, e.g. PlayMode for CurrentPlayMode.
+func (s *StateVariable) EnumName() string {
+	if name, ok := enumNames[s.Name]; ok {
+		return name
+	}
+	name := strings.TrimPrefix(s.Name, "A_ARG_TYPE_")
+	return strings.TrimPrefix(name, "Current")
+}
+
+// EnumConstant returns the name of the constant for one allowed value, e.g.
+// PlayModeShuffleNoRepeat for SHUFFLE_NOREPEAT.
+func (s *StateVariable) EnumConstant(value string) string {
+	name := s.EnumName()
+	for _, word := range strings.FieldsFunc(value, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		switch {
+		case enumWords[word] != "":
+			word = enumWords[word]
+		case strings.ToUpper(word) != word:
+			// Already mixed case, e.g. BrowseMetadata
+			word = strings.ToUpper(word[:1]) + word[1:]
+		case len(word) > 2:
+			// Keep short abbreviations such as LF and OK
+			word = word[:1] + strings.ToLower(word[1:])
+		}
+		// Keep numbers apart, so that 1.5 is not named like 15
+		last, _ := utf8.DecodeLastRuneInString(name)
+		first, _ := utf8.DecodeRuneInString(word)
+		if unicode.IsDigit(last) && unicode.IsDigit(first) {
+			name += "_"
+		}
+		name += word
 	}
+	return name
+}
+
+// checkEnumConstants returns an error if two allowed values, or an allowed
+// value and the type, would be given the same name
+func (s *StateVariable) checkEnumConstants() error {
+	named := map[string]string{}
+	for _, value := range s.AllowedValues {
+		constant := s.EnumConstant(value)
+		if constant == s.EnumName() {
+			return fmt.Errorf("allowed value %q of state variable %s has no name of its own", value, s.Name)
+		}
+		if other, ok := named[constant]; ok {
+			return fmt.Errorf("allowed values %q and %q of state variable %s are both named %s", other, value, s.Name, constant)
+		}
+		named[constant] = value
+	}
+	return nil
+}
+
+// GoType returns the type of arguments related to the state variable.
+func (s *StateVariable) GoType() (string, error) {
+	if s.IsEnum() {
+		return s.EnumName(), nil
+	}
+	return s.GoDataType()
 }
 
 type Argument struct {
@@ -108,80 +371,68 @@ func (s *Scpd) GetStateVariable(name string) *StateVariable {
 	return nil
 }
 
-func MakeServiceApi(ServiceName, serviceControlEndpoint, serviceEventEndpoint string, scdp []byte) ([]byte, error) {
+func MakeServiceApi(ServiceName, serviceURN, serviceControlEndpoint, serviceEventEndpoint string, scdp []byte) ([]byte, error) {
 	var s Scpd
 	err := xml.Unmarshal(scdp, &s)
 	if err != nil {
 		return nil, err
 	}
 
+	// Refuse to generate code that would not compile
+	for _, sv := range s.StateVariables {
+		if _, err := sv.GoDataType(); err != nil {
+			return nil, err
+		}
+		if sv.IsEnum() {
+			if err := sv.checkEnumConstants(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	buf := bytes.NewBufferString("")
 	fmt.Fprintf(buf, "package %s\n\n", strings.ToLower(ServiceName))
-	fmt.Fprint(buf, "import (\n\"net/url\"\n\"errors\"\n\"io/ioutil\"\n\"encoding/xml\"\n\"bytes\"\n\"net/http\"\n)\n")
+	fmt.Fprint(buf, "import (\n\"context\"\n\"net/url\"\n\"net/http\"\n\"sync\"\n\n\"github.com/szatmary/sonos/soap\"\n)\n")
+
+	fmt.Fprintf(buf, "const _ServiceURN = %q\n", serviceURN)
+
+	// Error codes
+	codes := errorCodes[ServiceName]
+	var numbers []int
+	for code := range codes {
+		numbers = append(numbers, code)
+	}
+	sort.Ints(numbers)
+	fmt.Fprint(buf, "// ErrorCodes describes the error codes specific to this service\n")
+	fmt.Fprint(buf, "var ErrorCodes = map[int]string{\n")
+	for _, code := range numbers {
+		fmt.Fprintf(buf, "%d: %q,\n", code, codes[code])
+	}
+	fmt.Fprint(buf, "}\n")
+	fmt.Fprint(buf, "func init() {\nsoap.RegisterErrorCodes(_ServiceURN, ErrorCodes)\n}\n")
 
-	fmt.Fprint(buf, "const (\n")
-	fmt.Fprintf(buf, "_ServiceURN = \"urn:schemas-upnp-org:service:%s:1\"\n", ServiceName)
-	fmt.Fprintf(buf, "_EncodingSchema = \"http://schemas.xmlsoap.org/soap/encoding/\"\n")
-	fmt.Fprintf(buf, "_EnvelopeSchema = \"http://schemas.xmlsoap.org/soap/envelope/\"\n")
-	fmt.Fprint(buf, ")\n")
+	// Enumerations
+	for _, sv := range s.StateVariables {
+		if !sv.IsEnum() {
+			continue
+		}
+		fmt.Fprintf(buf, "// %s holds the values allowed for the %s state variable\n", sv.EnumName(), sv.Name)
+		fmt.Fprintf(buf, "type %s string\n", sv.EnumName())
+		fmt.Fprint(buf, "const (\n")
+		for _, allowedValue := range sv.AllowedValues {
+			fmt.Fprintf(buf, "%s %s = %q\n", sv.EnumConstant(allowedValue), sv.EnumName(), allowedValue)
+		}
+		fmt.Fprint(buf, ")\n")
+	}
 
 	// Service object
-	fmt.Fprintf(buf, "type Service struct {\nControlEndpoint *url.URL\nEventEndpoint *url.URL\n}\n")
+	fmt.Fprint(buf, "type Service struct {\nControlEndpoint *url.URL\nEventEndpoint *url.URL\n")
+	fmt.Fprint(buf, "// Client sends the actions, soap.DefaultClient is used if it is nil\nClient *soap.Client\n}\n")
 	fmt.Fprintf(buf, "func NewService(deviceUrl *url.URL) *Service {\n")
 	fmt.Fprintf(buf, "c, err := url.Parse(`%s`)\nif nil != err { panic(err) }\n", serviceControlEndpoint)
 	fmt.Fprintf(buf, "e, err := url.Parse(`%s`)\nif nil != err { panic(err) }\n", serviceEventEndpoint)
 	fmt.Fprintf(buf, "return &Service{\nControlEndpoint: deviceUrl.ResolveReference(c),\nEventEndpoint: deviceUrl.ResolveReference(e),\n}\n}\n")
 
-	// Martial structs
-	fmt.Fprintf(buf, "type Envelope struct {\n")
-	fmt.Fprintf(buf, "XMLName xml.Name `xml:\"s:Envelope\"`\n")
-	fmt.Fprintf(buf, "Xmlns string `xml:\"xmlns:s,attr\"`\n")
-	fmt.Fprintf(buf, "EncodingStyle string `xml:\"s:encodingStyle,attr\"`\n")
-	fmt.Fprintf(buf, "Body Body `xml:\"s:Body\"`\n")
-	fmt.Fprintf(buf, "}\n")
-
-	fmt.Fprintf(buf, "type Body struct {\n")
-	fmt.Fprint(buf, "XMLName xml.Name `xml:\"s:Body\"`\n")
-	for _, action := range s.Actions {
-		fmt.Fprintf(buf, "%s *%sArgs `xml:\"u:%s,omitempty\"`\n", action.Name, action.Name, action.Name)
-	}
-	fmt.Fprint(buf, "}\n")
-
-	// Unmartial structs
-	fmt.Fprintf(buf, "type EnvelopeResponse struct {\n")
-	fmt.Fprintf(buf, "XMLName xml.Name `xml:\"Envelope\"`\n")
-	fmt.Fprintf(buf, "Xmlns string `xml:\"xmlns:s,attr\"`\n")
-	fmt.Fprintf(buf, "EncodingStyle string `xml:\"encodingStyle,attr\"`\n")
-	fmt.Fprintf(buf, "Body BodyResponse `xml:\"Body\"`\n")
-	fmt.Fprintf(buf, "}\n")
-
-	fmt.Fprintf(buf, "type BodyResponse struct {\n")
-	fmt.Fprint(buf, "XMLName xml.Name `xml:\"Body\"`\n")
-	for _, action := range s.Actions {
-		fmt.Fprintf(buf, "%s *%sResponse `xml:\"%sResponse,omitempty\"`\n", action.Name, action.Name, action.Name)
-	}
-	fmt.Fprintf(buf, "}\n")
-
-	// exec function
-	fmt.Fprintf(buf, "func (s *Service) exec(actionName string, httpClient *http.Client, envelope *Envelope) (*EnvelopeResponse, error) {\n")
-	fmt.Fprintf(buf, "marshaled, err := xml.Marshal(envelope)\n")
-	fmt.Fprintf(buf, "if err != nil { return nil, err\n}\n")
-	fmt.Fprintf(buf, "postBody := []byte(`<?xml version=\"1.0\"?>`)\n")
-	fmt.Fprintf(buf, "postBody = append(postBody, marshaled...)\n")
-	fmt.Fprintf(buf, "req, err := http.NewRequest(`POST`, s.ControlEndpoint.String(), bytes.NewBuffer(postBody))\n")
-	fmt.Fprintf(buf, "if err != nil { return nil, err\n}\n")
-	fmt.Fprintf(buf, "req.Header.Set(`Content-Type`, `text/xml; charset=\"utf-8\"`)\n")
-	fmt.Fprintf(buf, "req.Header.Set(`SOAPAction`, _ServiceURN+`#`+actionName)\n")
-	fmt.Fprintf(buf, "res, err := httpClient.Do(req)\n")
-	fmt.Fprintf(buf, "if err != nil { return nil, err\n}\n")
-	fmt.Fprintf(buf, "defer res.Body.Close()\n")
-	fmt.Fprintf(buf, "responseBody, err := ioutil.ReadAll(res.Body)\n")
-	fmt.Fprintf(buf, "if err != nil { return nil, err\n}\n")
-	fmt.Fprintf(buf, "var envelopeResponse EnvelopeResponse\n")
-	fmt.Fprintf(buf, "err = xml.Unmarshal(responseBody,&envelopeResponse)\n")
-	fmt.Fprintf(buf, "if err != nil { return nil, err\n}\n")
-	fmt.Fprintf(buf, "return &envelopeResponse, nil\n}\n")
-
 	for _, action := range s.Actions {
 		var inArguments, outArguments []Argument
 		for _, argument := range action.Arguments {
@@ -196,7 +447,6 @@ func MakeServiceApi(ServiceName, serviceControlEndpoint, serviceEventEndpoint st
 		}
 
 		fmt.Fprintf(buf, "type %sArgs struct {\n", action.Name)
-		fmt.Fprintf(buf, "Xmlns string `xml:\"xmlns:u,attr\"`\n")
 		for _, argument := range inArguments {
 			sv := s.GetStateVariable(argument.RelatedStateVariable)
 			if sv == nil {
@@ -208,49 +458,141 @@ func MakeServiceApi(ServiceName, serviceControlEndpoint, serviceEventEndpoint st
 			for _, allowedValue := range sv.AllowedValues {
 				fmt.Fprintf(buf, "// Allowed Value: %s\n", allowedValue)
 			}
-			fmt.Fprintf(buf, "%s %s `xml:\"%s\"`\n", argument.Name, sv.GoDataType(), argument.Name)
+			goType, err := sv.GoType()
+			if err != nil {
+				return []byte{}, err
+			}
+			fmt.Fprintf(buf, "%s %s `xml:\"%s\"`\n", argument.Name, goType, argument.Name)
 		}
 		fmt.Fprintf(buf, "}\n")
 
+		fmt.Fprint(buf, "// Validate checks the arguments against the values and ranges the service allows\n")
+		fmt.Fprintf(buf, "func (a *%sArgs) Validate() error {\n", action.Name)
+		for _, argument := range inArguments {
+			if err := writeValidation(buf, ServiceName, action.Name, argument, s.GetStateVariable(argument.RelatedStateVariable)); err != nil {
+				return []byte{}, err
+			}
+		}
+		fmt.Fprint(buf, "return nil\n}\n")
+
 		fmt.Fprintf(buf, "type %sResponse struct {\n", action.Name)
 		for _, argument := range outArguments {
 			sv := s.GetStateVariable(argument.RelatedStateVariable)
 			if sv == nil {
 				return []byte{}, fmt.Errorf("unexpected state variable %s", argument.RelatedStateVariable)
 			}
-			fmt.Fprintf(buf, "%s %s\t`xml:\"%s\"`\n", argument.Name, sv.GoDataType(), argument.Name)
+			goType, err := sv.GoType()
+			if err != nil {
+				return []byte{}, err
+			}
+			fmt.Fprintf(buf, "%s %s\t`xml:\"%s\"`\n", argument.Name, goType, argument.Name)
 		}
 		fmt.Fprintf(buf, "}\n")
 
-		// TODO Validate, inputs
 		fmt.Fprintf(buf, "func (s *Service) %s(httpClient *http.Client, args *%sArgs) (*%sResponse, error) {\n", action.Name, action.Name, action.Name)
-		fmt.Fprintf(buf, "args.Xmlns = _ServiceURN\n")
-		fmt.Fprintf(buf, "r, err := s.exec(`%s`, httpClient, \n&Envelope{\n", action.Name)
-		fmt.Fprintf(buf, "EncodingStyle: _EncodingSchema,\n")
-		fmt.Fprintf(buf, "Xmlns: _EnvelopeSchema,\n")
-		fmt.Fprintf(buf, "Body: Body{%s: args},\n", action.Name)
-		fmt.Fprintf(buf, "})\n")
-		fmt.Fprintf(buf, "if err != nil { return nil, err }\n")
-		fmt.Fprintf(buf, "if r.Body.%s == nil { return nil, errors.New(`unexpected respose from service calling %s.%s()`) }\n",
-			action.Name, strings.ToLower(ServiceName), action.Name)
-		fmt.Fprintf(buf, "\nreturn r.Body.%s, nil }\n", action.Name)
+		fmt.Fprintf(buf, "return s.%sContext(context.Background(), httpClient, args)\n}\n", action.Name)
+		fmt.Fprintf(buf, "func (s *Service) %sContext(ctx context.Context, httpClient *http.Client, args *%sArgs) (*%sResponse, error) {\n", action.Name, action.Name, action.Name)
+		fmt.Fprintf(buf, "var r %sResponse\n", action.Name)
+		fmt.Fprintf(buf, "if err := s.Client.Call(ctx, httpClient, s.ControlEndpoint, _ServiceURN, `%s`, args, &r); err != nil { return nil, err }\n", action.Name)
+		fmt.Fprint(buf, "return &r, nil\n}\n")
 	}
+	writeClient(buf, s.Actions)
 	return buf.Bytes(), nil
 }
 
+// writeClient emits the Client interface listing every action, and Fake, an
+// in-memory Client that code using the service can be tested against
+func writeClient(buf *bytes.Buffer, actions []Action) {
+	fmt.Fprint(buf, "// Client is the set of actions of the service. It is implemented by Service and Fake\n")
+	fmt.Fprint(buf, "type Client interface {\n")
+	for _, action := range actions {
+		fmt.Fprintf(buf, "%s(httpClient *http.Client, args *%sArgs) (*%sResponse, error)\n", action.Name, action.Name, action.Name)
+		fmt.Fprintf(buf, "%sContext(ctx context.Context, httpClient *http.Client, args *%sArgs) (*%sResponse, error)\n", action.Name, action.Name, action.Name)
+	}
+	fmt.Fprint(buf, "}\n")
+	fmt.Fprint(buf, "var _ Client = (*Service)(nil)\n")
+	fmt.Fprint(buf, "var _ Client = (*Fake)(nil)\n")
+
+	fmt.Fprint(buf, "// Call is an action received by a Fake\n")
+	fmt.Fprint(buf, "type Call struct {\nAction string\n// Args is the *<Action>Args the action was called with\nArgs interface{}\n}\n")
+	fmt.Fprint(buf, "// Fake is an in-memory Client for tests. It records every call, and answers\n")
+	fmt.Fprint(buf, "// an action with its On<Action> func, or with an empty response if that is nil\n")
+	fmt.Fprint(buf, "type Fake struct {\nmu sync.Mutex\ncalls []Call\n")
+	for _, action := range actions {
+		fmt.Fprintf(buf, "On%s func(ctx context.Context, args *%sArgs) (*%sResponse, error)\n", action.Name, action.Name, action.Name)
+	}
+	fmt.Fprint(buf, "}\n")
+	fmt.Fprint(buf, "// Calls returns the calls received so far, oldest first\n")
+	fmt.Fprint(buf, "func (f *Fake) Calls() []Call {\nf.mu.Lock()\ndefer f.mu.Unlock()\nreturn append([]Call(nil), f.calls...)\n}\n")
+	fmt.Fprint(buf, "// Reset forgets the calls received so far\n")
+	fmt.Fprint(buf, "func (f *Fake) Reset() {\nf.mu.Lock()\ndefer f.mu.Unlock()\nf.calls = nil\n}\n")
+	fmt.Fprint(buf, "func (f *Fake) record(action string, args interface{}) {\nf.mu.Lock()\ndefer f.mu.Unlock()\nf.calls = append(f.calls, Call{Action: action, Args: args})\n}\n")
+	for _, action := range actions {
+		fmt.Fprintf(buf, "func (f *Fake) %s(httpClient *http.Client, args *%sArgs) (*%sResponse, error) {\n", action.Name, action.Name, action.Name)
+		fmt.Fprintf(buf, "return f.%sContext(context.Background(), httpClient, args)\n}\n", action.Name)
+		fmt.Fprintf(buf, "func (f *Fake) %sContext(ctx context.Context, httpClient *http.Client, args *%sArgs) (*%sResponse, error) {\n", action.Name, action.Name, action.Name)
+		fmt.Fprintf(buf, "f.record(`%s`, args)\n", action.Name)
+		fmt.Fprintf(buf, "if f.On%s != nil {\nreturn f.On%s(ctx, args)\n}\n", action.Name, action.Name)
+		fmt.Fprintf(buf, "return &%sResponse{}, nil\n}\n", action.Name)
+	}
+}
+
+// serviceURNs lists the service types not published under schemas-upnp-org,
+// for services given by hand without -urn
+var serviceURNs = map[string]string{
+	"Queue": "urn:schemas-sonos-com:service:Queue:1",
+	"QPlay": "urn:schemas-tencent-com:service:QPlay:1",
+}
+
+// defaultServiceURN returns the service type of the named service as its
+// device description lists it
+func defaultServiceURN(serviceName string) string {
+	if urn, ok := serviceURNs[serviceName]; ok {
+		return urn
+	}
+	return fmt.Sprintf("urn:schemas-upnp-org:service:%s:1", serviceName)
+}
+
 func main() {
-	// "http://192.168.131.242:1400/xml/RenderingControl1.xml"
-	// "RenderingControl"
-	serviceName := os.Args[1]
-	serviceEndpoint := os.Args[2]
-	controlEndpoint := os.Args[3]
-	serviceXml := os.Args[4]
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		diffMain(os.Args[2:])
+		return
+	}
+
+	device := flag.String("device", "", "URL or saved copy of a device description, every service it lists is generated")
+	scpdDir := flag.String("scpd", "", "directory holding the SCPD files of a saved device description, defaults to its directory")
+	outDir := flag.String("out", ".", "directory the service packages are written to")
+	urn := flag.String("urn", "", "service type of a service given by hand, e.g. urn:schemas-sonos-com:service:Queue:1, defaults to the one its player lists")
+	flag.Parse()
+
+	if *device != "" {
+		if err := generateDevice(*device, *scpdDir, *outDir); err != nil {
+			fmt.Fprintf(os.Stderr, "err: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// A single service, given by hand
+	// "RenderingControl" "/MediaRenderer/RenderingControl/Control" "/MediaRenderer/RenderingControl/Event" "xml/RenderingControl1.xml"
+	if flag.NArg() != 4 {
+		fmt.Fprintf(os.Stderr, "usage: makeservices -device url|file [-scpd dir] [-out dir]\n       makeservices [-urn urn] name control event scpd\n       makeservices diff [old] new\n")
+		os.Exit(2)
+	}
+	serviceName := flag.Arg(0)
+	serviceEndpoint := flag.Arg(1)
+	controlEndpoint := flag.Arg(2)
+	serviceXml := flag.Arg(3)
 	body, err := ioutil.ReadFile(serviceXml)
 	if err != nil {
 		fmt.Printf("err: %v\n", err)
 		return
 	}
-	dotgo, err := MakeServiceApi(serviceName, serviceEndpoint, controlEndpoint, body)
+	serviceURN := *urn
+	if serviceURN == "" {
+		serviceURN = defaultServiceURN(serviceName)
+	}
+	dotgo, err := MakeServiceApi(serviceName, serviceURN, serviceEndpoint, controlEndpoint, body)
 	if err != nil {
 		fmt.Printf("err: %v\n", err)
 		return
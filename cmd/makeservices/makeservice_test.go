@@ -0,0 +1,309 @@
+package main
+
+import (
+	"go/format"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestGoDataType(t *testing.T) {
+	tests := []struct {
+		dataType string
+		goType   string
+	}{
+		{"ui1", "uint8"},
+		{"ui2", "uint16"},
+		{"ui4", "uint32"},
+		{"ui8", "uint64"},
+		{"i1", "int8"},
+		{"i2", "int16"},
+		{"i4", "int32"},
+		{"i8", "int64"},
+		{"int", "int64"},
+		{"r4", "float32"},
+		{"r8", "float64"},
+		{"number", "float64"},
+		{"float", "float64"},
+		{"fixed.14.4", "float64"},
+		{"char", "soap.Char"},
+		{"string", "string"},
+		{"date", "soap.Date"},
+		{"dateTime", "soap.DateTime"},
+		{"dateTime.tz", "soap.DateTimeTZ"},
+		{"time", "soap.Time"},
+		{"time.tz", "soap.TimeTZ"},
+		{"boolean", "bool"},
+		{"bin.base64", "soap.Base64"},
+		{"bin.hex", "soap.Hex"},
+		{"uri", "string"},
+		{"uuid", "string"},
+		// Some Sonos SCPDs pad the data type
+		{" dateTime.tz", "soap.DateTimeTZ"},
+		{"ui4 ", "uint32"},
+	}
+	covered := make(map[string]bool)
+	for _, test := range tests {
+		sv := StateVariable{Name: "A_ARG_TYPE_Test", DataType: test.dataType}
+		goType, err := sv.GoDataType()
+		if err != nil {
+			t.Errorf("GoDataType(%q): %v", test.dataType, err)
+			continue
+		}
+		if goType != test.goType {
+			t.Errorf("GoDataType(%q) = %s, want %s", test.dataType, goType, test.goType)
+		}
+		covered[strings.TrimSpace(test.dataType)] = true
+	}
+	for dataType := range dataTypes {
+		if !covered[dataType] {
+			t.Errorf("data type %q is not tested", dataType)
+		}
+	}
+}
+
+func TestGoDataTypeUnknown(t *testing.T) {
+	for _, dataType := range []string{"", "ui16", "bin", "DateTime", "string.tz"} {
+		sv := StateVariable{Name: "A_ARG_TYPE_Test", DataType: dataType}
+		if goType, err := sv.GoDataType(); err == nil {
+			t.Errorf("GoDataType(%q) = %s, want an error", dataType, goType)
+		}
+	}
+}
+
+func TestMakeServiceApiUnknownDataType(t *testing.T) {
+	scpd := `<scpd xmlns="urn:schemas-upnp-org:service-1-0">
+<serviceStateTable>
+<stateVariable sendEvents="no"><name>A_ARG_TYPE_Odd</name><dataType>ui16</dataType></stateVariable>
+</serviceStateTable>
+<actionList/>
+</scpd>`
+	_, err := MakeServiceApi("Test", "urn:schemas-upnp-org:service:Test:1", "/Test/Control", "/Test/Event", []byte(scpd))
+	if err == nil || !strings.Contains(err.Error(), "A_ARG_TYPE_Odd") {
+		t.Errorf("MakeServiceApi = %v, want an error naming A_ARG_TYPE_Odd", err)
+	}
+}
+
+func TestDefaultServiceURN(t *testing.T) {
+	tests := map[string]string{
+		"AVTransport": "urn:schemas-upnp-org:service:AVTransport:1",
+		"Queue":       "urn:schemas-sonos-com:service:Queue:1",
+		"QPlay":       "urn:schemas-tencent-com:service:QPlay:1",
+	}
+	for name, want := range tests {
+		if urn := defaultServiceURN(name); urn != want {
+			t.Errorf("defaultServiceURN(%s) = %s, want %s", name, urn, want)
+		}
+	}
+}
+
+const validateSCPD = `<scpd xmlns="urn:schemas-upnp-org:service-1-0">
+<serviceStateTable>
+<stateVariable sendEvents="no"><name>A_ARG_TYPE_Channel</name><dataType>string</dataType>
+<allowedValueList><allowedValue>Master</allowedValue><allowedValue>LF</allowedValue></allowedValueList></stateVariable>
+<stateVariable sendEvents="no"><name>A_ARG_TYPE_Recurrence</name><dataType>string</dataType>
+<allowedValueList><allowedValue>ONCE</allowedValue><allowedValue>DAILY</allowedValue></allowedValueList></stateVariable>
+<stateVariable sendEvents="no"><name>Volume</name><dataType>ui2</dataType>
+<allowedValueRange><minimum>0</minimum><maximum>100</maximum><step>1</step></allowedValueRange></stateVariable>
+<stateVariable sendEvents="no"><name>Bass</name><dataType>i2</dataType>
+<allowedValueRange><minimum>-10</minimum><maximum>10</maximum><step>2</step></allowedValueRange></stateVariable>
+<stateVariable sendEvents="no"><name>Level</name><dataType>ui2</dataType>
+<allowedValueRange><minimum>0</minimum><maximum>100</maximum><step>5</step></allowedValueRange></stateVariable>
+<stateVariable sendEvents="no"><name>Delay</name><dataType>ui4</dataType>
+<allowedValueRange><minimum>10</minimum><maximum>20</maximum><step>5</step></allowedValueRange></stateVariable>
+<stateVariable sendEvents="no"><name>Position</name><dataType>ui8</dataType>
+<allowedValueRange><minimum>1</minimum><maximum>1000000</maximum></allowedValueRange></stateVariable>
+<stateVariable sendEvents="no"><name>Full</name><dataType>ui1</dataType>
+<allowedValueRange><minimum>0</minimum><maximum>255</maximum></allowedValueRange></stateVariable>
+</serviceStateTable>
+<actionList>
+<action><name>SetVolume</name><argumentList>
+<argument><name>Channel</name><direction>in</direction><relatedStateVariable>A_ARG_TYPE_Channel</relatedStateVariable></argument>
+<argument><name>DesiredVolume</name><direction>in</direction><relatedStateVariable>Volume</relatedStateVariable></argument>
+</argumentList></action>
+<action><name>SetAlarm</name><argumentList>
+<argument><name>Recurrence</name><direction>in</direction><relatedStateVariable>A_ARG_TYPE_Recurrence</relatedStateVariable></argument>
+</argumentList></action>
+<action><name>SetLevels</name><argumentList>
+<argument><name>DesiredBass</name><direction>in</direction><relatedStateVariable>Bass</relatedStateVariable></argument>
+<argument><name>DesiredLevel</name><direction>in</direction><relatedStateVariable>Level</relatedStateVariable></argument>
+<argument><name>DesiredDelay</name><direction>in</direction><relatedStateVariable>Delay</relatedStateVariable></argument>
+<argument><name>DesiredPosition</name><direction>in</direction><relatedStateVariable>Position</relatedStateVariable></argument>
+<argument><name>DesiredFull</name><direction>in</direction><relatedStateVariable>Full</relatedStateVariable></argument>
+</argumentList></action>
+</actionList>
+</scpd>`
+
+// validation returns the body of the Validate method of action in code
+func validation(t *testing.T, code, action string) string {
+	t.Helper()
+	start := strings.Index(code, "func (a *"+action+"Args) Validate() error {")
+	if start < 0 {
+		t.Fatalf("no Validate for %s", action)
+	}
+	end := strings.Index(code[start:], "\n}\n")
+	return code[start : start+end]
+}
+
+func TestMakeServiceApiValidate(t *testing.T) {
+	// AlarmClock, as its Recurrence takes more values than its SCPD lists
+	generated, err := MakeServiceApi("AlarmClock", "urn:schemas-upnp-org:service:AlarmClock:1", "/AlarmClock/Control", "/AlarmClock/Event", []byte(validateSCPD))
+	if err != nil {
+		t.Fatal(err)
+	}
+	formatted, err := format.Source(generated)
+	if err != nil {
+		t.Fatalf("generated code does not parse: %v", err)
+	}
+	code := string(formatted)
+
+	tests := []struct {
+		action string
+		want   []string
+		absent []string
+	}{
+		{"SetVolume", []string{
+			"switch a.Channel {\n\tcase \"\", ChannelMaster, ChannelLF:\n\tdefault:",
+			`Allowed: "one of Master, LF"`,
+			"if a.DesiredVolume > 100 {",
+			`Allowed: "0 to 100"`,
+		}, []string{"a.DesiredVolume < 0", "%"}},
+		{"SetAlarm", nil, []string{"switch", "if "}},
+		{"SetLevels", []string{
+			"if a.DesiredBass < -10 || a.DesiredBass > 10 || (int64(a.DesiredBass)+10)%2 != 0 {",
+			`Allowed: "-10 to 10 in steps of 2"`,
+			"if a.DesiredLevel > 100 || int64(a.DesiredLevel)%5 != 0 {",
+			"if a.DesiredDelay < 10 || a.DesiredDelay > 20 || (int64(a.DesiredDelay)-10)%5 != 0 {",
+			"if a.DesiredPosition < 1 || a.DesiredPosition > 1000000 {",
+		}, []string{"a.DesiredFull"}},
+	}
+	for _, test := range tests {
+		body := validation(t, code, test.action)
+		for _, want := range test.want {
+			if !strings.Contains(body, want) {
+				t.Errorf("%s Validate does not contain %q:\n%s", test.action, want, body)
+			}
+		}
+		for _, absent := range test.absent {
+			if strings.Contains(body, absent) {
+				t.Errorf("%s Validate contains %q:\n%s", test.action, absent, body)
+			}
+		}
+	}
+	if !strings.Contains(code, "DesiredPosition uint64") {
+		t.Error("ui8 argument is not a uint64")
+	}
+}
+
+func TestMakeServiceApiValidateErrors(t *testing.T) {
+	tests := map[string]string{
+		"allowed values": `<stateVariable sendEvents="no"><name>A_ARG_TYPE_Test</name><dataType>ui2</dataType>
+<allowedValueList><allowedValue>1</allowedValue></allowedValueList></stateVariable>`,
+		"range": `<stateVariable sendEvents="no"><name>A_ARG_TYPE_Test</name><dataType>string</dataType>
+<allowedValueRange><minimum>0</minimum><maximum>1</maximum></allowedValueRange></stateVariable>`,
+	}
+	for name, stateVariable := range tests {
+		scpd := `<scpd xmlns="urn:schemas-upnp-org:service-1-0"><serviceStateTable>` + stateVariable + `</serviceStateTable>
+<actionList><action><name>Test</name><argumentList>
+<argument><name>Value</name><direction>in</direction><relatedStateVariable>A_ARG_TYPE_Test</relatedStateVariable></argument>
+</argumentList></action></actionList></scpd>`
+		if _, err := MakeServiceApi("Test", "urn:schemas-upnp-org:service:Test:1", "/Test/Control", "/Test/Event", []byte(scpd)); err == nil || !strings.Contains(err.Error(), "A_ARG_TYPE_Test") {
+			t.Errorf("%s on a state variable of the wrong type: MakeServiceApi = %v, want an error naming it", name, err)
+		}
+	}
+}
+
+func TestIntegerBounds(t *testing.T) {
+	tests := []struct {
+		goType   string
+		min, max int64
+		ok       bool
+	}{
+		{"uint8", 0, math.MaxUint8, true},
+		{"uint16", 0, math.MaxUint16, true},
+		{"uint32", 0, math.MaxUint32, true},
+		{"uint64", 0, math.MaxInt64, true},
+		{"int8", math.MinInt8, math.MaxInt8, true},
+		{"int16", math.MinInt16, math.MaxInt16, true},
+		{"int32", math.MinInt32, math.MaxInt32, true},
+		{"int64", math.MinInt64, math.MaxInt64, true},
+		{"float64", 0, 0, false},
+		{"string", 0, 0, false},
+	}
+	for _, test := range tests {
+		if min, max, ok := integerBounds(test.goType); min != test.min || max != test.max || ok != test.ok {
+			t.Errorf("integerBounds(%s) = %d, %d, %t, want %d, %d, %t", test.goType, min, max, ok, test.min, test.max, test.ok)
+		}
+	}
+}
+
+func TestIsOpenEnded(t *testing.T) {
+	if !isOpenEnded("AlarmClock", "A_ARG_TYPE_Recurrence") {
+		t.Error("AlarmClock Recurrence is not open ended")
+	}
+	if isOpenEnded("AVTransport", "A_ARG_TYPE_Recurrence") || isOpenEnded("AlarmClock", "A_ARG_TYPE_AlarmID") {
+		t.Error("open ended state variables leak to other services or variables")
+	}
+}
+
+func TestEnumConstant(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"CurrentPlayMode", "SHUFFLE_NOREPEAT", "PlayModeShuffleNoRepeat"},
+		{"CurrentPlayMode", "NORMAL", "PlayModeNormal"},
+		{"A_ARG_TYPE_BrowseFlag", "BrowseMetadata", "BrowseFlagBrowseMetadata"},
+		{"A_ARG_TYPE_SeekMode", "TRACK_NR", "SeekUnitTrackNR"},
+		// Words of up to two letters keep their case
+		{"A_ARG_TYPE_Channel", "LF", "ChannelLF"},
+		// Punctuation separates words
+		{"A_ARG_TYPE_Protocol", "x-rincon-queue", "ProtocolXRinconQueue"},
+		{"A_ARG_TYPE_Protocol", "http-get:*:audio/mpeg:*", "ProtocolHttpGetAudioMpeg"},
+		{"A_ARG_TYPE_Recurrence", "ON_DAYS ", "RecurrenceONDays"},
+		// Leading digits follow the type name, numbers are kept apart
+		{"A_ARG_TYPE_Speed", "1", "Speed1"},
+		{"A_ARG_TYPE_Speed", "1/2", "Speed1_2"},
+		{"A_ARG_TYPE_Speed", "12", "Speed12"},
+		{"A_ARG_TYPE_Speed", "2x", "Speed2x"},
+		{"A_ARG_TYPE_Version", "1.5a", "Version1_5a"},
+		// Nothing but punctuation leaves the type name
+		{"A_ARG_TYPE_Speed", "-", "Speed"},
+		{"A_ARG_TYPE_Speed", "", "Speed"},
+	}
+	for _, test := range tests {
+		sv := StateVariable{Name: test.name, DataType: "string", AllowedValues: []string{test.value}}
+		if got := sv.EnumConstant(test.value); got != test.want {
+			t.Errorf("EnumConstant(%s, %q) = %s, want %s", test.name, test.value, got, test.want)
+		}
+	}
+}
+
+func TestCheckEnumConstants(t *testing.T) {
+	tests := []struct {
+		values []string
+		err    bool
+	}{
+		{[]string{"NORMAL", "REPEAT_ALL", "SHUFFLE_NOREPEAT", "1", "1/2"}, false},
+		{[]string{"Master", "MASTER"}, true},
+		{[]string{"REPEAT_ALL", "REPEAT-ALL"}, true},
+		{[]string{"1.2", "1/2"}, true},
+		{[]string{"NORMAL", "*"}, true},
+		{[]string{""}, true},
+	}
+	for _, test := range tests {
+		sv := StateVariable{Name: "A_ARG_TYPE_Test", DataType: "string", AllowedValues: test.values}
+		if err := sv.checkEnumConstants(); (err != nil) != test.err {
+			t.Errorf("checkEnumConstants(%q) = %v, want error %t", test.values, err, test.err)
+		}
+	}
+
+	scpd := `<scpd xmlns="urn:schemas-upnp-org:service-1-0"><serviceStateTable>
+<stateVariable sendEvents="no"><name>A_ARG_TYPE_Channel</name><dataType>string</dataType>
+<allowedValueList><allowedValue>Master</allowedValue><allowedValue>MASTER</allowedValue></allowedValueList></stateVariable>
+</serviceStateTable><actionList/></scpd>`
+	if _, err := MakeServiceApi("Test", "urn:schemas-upnp-org:service:Test:1", "/Test/Control", "/Test/Event", []byte(scpd)); err == nil || !strings.Contains(err.Error(), "A_ARG_TYPE_Channel") {
+		t.Errorf("MakeServiceApi = %v, want an error naming A_ARG_TYPE_Channel", err)
+	}
+}
@@ -14,7 +14,7 @@ func main() {
 		return
 	}
 
-	zp, err := sonos.FindRoom(os.Args[1], 5*time.Second)
+	zp, err := findRoom(os.Args[1])
 	if err != nil {
 		fmt.Printf("FindRoom Error: %v\n", err)
 		return
@@ -30,3 +30,24 @@ func main() {
 		return
 	}
 }
+
+// findRoom looks the room up in the discovery cache, searching only if it
+// is not there any more.
+func findRoom(room string) (*sonos.ZonePlayer, error) {
+	path, err := sonos.DefaultCachePath()
+	if err != nil {
+		return sonos.FindRoom(room, 5*time.Second)
+	}
+	cache, err := sonos.LoadCache(path)
+	if err != nil {
+		fmt.Printf("Cache Error: %v\n", err)
+		return sonos.FindRoom(room, 5*time.Second)
+	}
+
+	// Save even if the room is not found, to keep the stale entries dropped
+	zp, err := cache.FindRoom(room, 5*time.Second)
+	if saveErr := cache.Save(); saveErr != nil {
+		fmt.Printf("Cache Error: %v\n", saveErr)
+	}
+	return zp, err
+}
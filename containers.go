@@ -0,0 +1,176 @@
+package sonos
+
+import (
+	"context"
+	"sort"
+
+	dir "github.com/szatmary/sonos/ContentDirectory"
+	que "github.com/szatmary/sonos/Queue"
+	"github.com/szatmary/sonos/gena"
+)
+
+// ContainerEvent reports a container whose content changed, so views of it
+// can be refreshed.
+type ContainerEvent struct {
+	// Container is the ContentDirectory container that changed, e.g. "Q:0",
+	// "SQ:" or "FV:2". It is empty for changes, such as SystemUpdateID, that
+	// are not tied to a container.
+	Container string
+	UpdateID  uint32
+	// Property is the state variable the change was reported in
+	Property string
+}
+
+// ContainerWatcher follows the Queue and ContentDirectory events of a player
+// and reports the containers whose UpdateID changed.
+type ContainerWatcher struct {
+	Manager *gena.Manager
+	Player  *ZonePlayer
+
+	updateIDs map[string]uint32
+	err       error
+}
+
+// NewContainerWatcher watches the containers of zp, using m to subscribe to
+// its events.
+func NewContainerWatcher(m *gena.Manager, zp *ZonePlayer) *ContainerWatcher {
+	return &ContainerWatcher{
+		Manager: m,
+		Player:  zp,
+	}
+}
+
+// Err returns the error that stopped Watch, if any, once its channel is closed.
+func (w *ContainerWatcher) Err() error {
+	return w.err
+}
+
+// Watch subscribes to the Queue and ContentDirectory events and delivers the
+// changed containers until ctx is done, either subscription is closed or an
+// event cannot be decoded, then unsubscribes and closes the returned channel.
+// The initial events report the current UpdateID of every container, after
+// that a container is only reported when its UpdateID changes.
+func (w *ContainerWatcher) Watch(ctx context.Context) (chan ContainerEvent, error) {
+	// Derived from the device description URL, as the player's services may
+	// be other Clients, such as Fakes
+	queue, err := w.Manager.Subscribe(ctx, que.NewService(w.Player.DeviceDescriptionURL).EventEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	directory, err := w.Manager.Subscribe(ctx, dir.NewService(w.Player.DeviceDescriptionURL).EventEndpoint)
+	if err != nil {
+		queue.Unsubscribe(ctx)
+		return nil, err
+	}
+
+	events := make(chan ContainerEvent)
+	w.err = nil
+	w.updateIDs = make(map[string]uint32)
+	go w.watch(ctx, queue, directory, events)
+	return events, nil
+}
+
+func (w *ContainerWatcher) watch(ctx context.Context, queue, directory *gena.Subscription, events chan ContainerEvent) {
+	defer close(events)
+	defer queue.Unsubscribe(context.Background())
+	defer directory.Unsubscribe(context.Background())
+
+	send := func(event ContainerEvent) bool {
+		key := event.Container
+		if key == "" {
+			key = event.Property
+		}
+		if updateID, ok := w.updateIDs[key]; ok && updateID == event.UpdateID {
+			return true
+		}
+		w.updateIDs[key] = event.UpdateID
+		select {
+		case events <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	for {
+		var changes []ContainerEvent
+		var err error
+		select {
+		case <-ctx.Done():
+			return
+		case notification, ok := <-queue.Events():
+			if !ok {
+				w.err = ErrSubscriptionClosed
+				return
+			}
+			changes, err = queueChanges(notification)
+		case notification, ok := <-directory.Events():
+			if !ok {
+				w.err = ErrSubscriptionClosed
+				return
+			}
+			changes, err = directoryChanges(notification)
+		}
+		if err != nil {
+			w.err = err
+			return
+		}
+		for _, change := range changes {
+			if !send(change) {
+				return
+			}
+		}
+	}
+}
+
+func queueChanges(notification gena.Event) ([]ContainerEvent, error) {
+	lastChange, ok := notification.Properties["LastChange"]
+	if !ok {
+		return nil, nil
+	}
+	l, err := que.DecodeLastChange(lastChange)
+	if err != nil {
+		return nil, err
+	}
+	var changes []ContainerEvent
+	for _, q := range l.Queues {
+		if q.UpdateID != nil {
+			changes = append(changes, ContainerEvent{Container: q.Container(), UpdateID: *q.UpdateID, Property: "LastChange"})
+		}
+	}
+	return changes, nil
+}
+
+func directoryChanges(notification gena.Event) ([]ContainerEvent, error) {
+	var names []string
+	for name := range notification.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var changes []ContainerEvent
+	for _, name := range names {
+		value := notification.Properties[name]
+		switch container, ok := dir.UpdateIDContainers[name]; {
+		case name == "ContainerUpdateIDs":
+			updates, err := dir.ParseContainerUpdateIDs(value)
+			if err != nil {
+				return nil, err
+			}
+			for _, update := range updates {
+				changes = append(changes, ContainerEvent{Container: update.Container, UpdateID: update.UpdateID, Property: name})
+			}
+		case ok, name == "SystemUpdateID", name == "UserRadioUpdateID", name == "RecentlyPlayedUpdateID",
+			name == "RadioLocationUpdateID", name == "FavoritePresetsUpdateID":
+			if value == "" {
+				continue
+			}
+			_, updateID, err := dir.ParseUpdateID(value)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, ContainerEvent{Container: container, UpdateID: updateID, Property: name})
+		}
+	}
+	return changes, nil
+}
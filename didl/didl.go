@@ -0,0 +1,193 @@
+// Package didl reads and writes DIDL-Lite, the metadata format of UPnP AV.
+// Sonos players send it in events and in the results of Browse and
+// GetPositionInfo, and expect it alongside the URIs they are asked to play.
+package didl
+
+import (
+	"bytes"
+	"encoding/xml"
+)
+
+const (
+	Namespace       = "urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/"
+	NamespaceDC     = "http://purl.org/dc/elements/1.1/"
+	NamespaceUPnP   = "urn:schemas-upnp-org:metadata-1-0/upnp/"
+	NamespaceRincon = "urn:schemas-rinconnetworks-com:metadata-1-0/"
+)
+
+// Common values of Object.Class
+const (
+	ClassMusicTrack     = "object.item.audioItem.musicTrack"
+	ClassAudioBroadcast = "object.item.audioItem.audioBroadcast"
+	ClassMusicAlbum     = "object.container.album.musicAlbum"
+	ClassMusicArtist    = "object.container.person.musicArtist"
+	ClassPlaylist       = "object.container.playlistContainer"
+	ClassContainer      = "object.container"
+)
+
+// Lite is a DIDL-Lite document.
+type Lite struct {
+	XMLName    xml.Name    `xml:"DIDL-Lite"`
+	Items      []Item      `xml:"item"`
+	Containers []Container `xml:"container"`
+}
+
+// Object holds the properties items and containers share. Elements are
+// matched by name alone when decoding, whatever namespace prefix they carry.
+type Object struct {
+	ID         string `xml:"id,attr"`
+	ParentID   string `xml:"parentID,attr"`
+	Restricted bool   `xml:"restricted,attr"`
+
+	// dc: properties
+	Title   string `xml:"title"`
+	Creator string `xml:"creator"`
+
+	// upnp: properties
+	Class               string `xml:"class"`
+	Album               string `xml:"album"`
+	Artist              string `xml:"artist"`
+	AlbumArtURI         string `xml:"albumArtURI"`
+	OriginalTrackNumber int    `xml:"originalTrackNumber"`
+
+	// r: properties, Sonos extensions
+	AlbumArtist   string `xml:"albumArtist"`
+	StreamContent string `xml:"streamContent"`
+	RadioShowMd   string `xml:"radioShowMd"`
+	Description   string `xml:"description"`
+
+	Res  []Res  `xml:"res"`
+	Desc []Desc `xml:"desc"`
+}
+
+// Item is a playable object such as a track or a radio stream.
+type Item struct {
+	Object
+}
+
+// Container is an object holding others, such as an album or a queue.
+type Container struct {
+	Object
+	ChildCount string `xml:"childCount,attr"`
+}
+
+// Res is a resource, the URI an object is played from.
+type Res struct {
+	ProtocolInfo string `xml:"protocolInfo,attr"`
+	// Duration is formatted H:MM:SS with optional fractions of a second
+	Duration string `xml:"duration,attr,omitempty"`
+	URI      string `xml:",chardata"`
+}
+
+// Desc carries extra metadata. Sonos uses it for the account token of music
+// services, e.g. ID "cdudn" and Value "SA_RINCON2311_X_#Svc2311-0-Token".
+type Desc struct {
+	ID        string `xml:"id,attr"`
+	NameSpace string `xml:"nameSpace,attr"`
+	Value     string `xml:",chardata"`
+}
+
+// ServiceDesc returns the desc Sonos expects on items of the music service
+// account identified by token, e.g. "SA_RINCON2311_X_#Svc2311-0-Token".
+func ServiceDesc(token string) Desc {
+	return Desc{ID: "cdudn", NameSpace: NamespaceRincon, Value: token}
+}
+
+// Decode parses metadata. It accepts what Sonos players send: empty metadata
+// and NOT_IMPLEMENTED give an empty document, HTML entities are understood
+// and stray ampersands in URIs are kept as they are.
+func Decode(metadata string) (*Lite, error) {
+	var l Lite
+	if metadata == "" || metadata == "NOT_IMPLEMENTED" {
+		return &l, nil
+	}
+	d := xml.NewDecoder(bytes.NewReader([]byte(metadata)))
+	d.Strict = false
+	d.Entity = xml.HTMLEntity
+	if err := d.Decode(&l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// Encode formats l the way Sonos players send it, with the dc:, upnp: and
+// r: prefixes they expect. An empty document gives empty metadata.
+func Encode(l *Lite) (string, error) {
+	if l == nil || len(l.Items) == 0 && len(l.Containers) == 0 {
+		return "", nil
+	}
+	out := encodedLite{
+		XmlnsDC:     NamespaceDC,
+		XmlnsUPnP:   NamespaceUPnP,
+		XmlnsRincon: NamespaceRincon,
+		Xmlns:       Namespace,
+	}
+	for _, item := range l.Items {
+		out.Items = append(out.Items, encodeObject(&item.Object, ""))
+	}
+	for _, container := range l.Containers {
+		out.Containers = append(out.Containers, encodeObject(&container.Object, container.ChildCount))
+	}
+	b, err := xml.Marshal(out)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// EncodeItem is a shortcut for encoding a document holding a single item.
+func EncodeItem(item Item) (string, error) {
+	return Encode(&Lite{Items: []Item{item}})
+}
+
+type encodedLite struct {
+	XMLName     xml.Name        `xml:"DIDL-Lite"`
+	XmlnsDC     string          `xml:"xmlns:dc,attr"`
+	XmlnsUPnP   string          `xml:"xmlns:upnp,attr"`
+	XmlnsRincon string          `xml:"xmlns:r,attr"`
+	Xmlns       string          `xml:"xmlns,attr"`
+	Items       []encodedObject `xml:"item"`
+	Containers  []encodedObject `xml:"container"`
+}
+
+type encodedObject struct {
+	ID                  string `xml:"id,attr"`
+	ParentID            string `xml:"parentID,attr"`
+	Restricted          bool   `xml:"restricted,attr"`
+	ChildCount          string `xml:"childCount,attr,omitempty"`
+	Res                 []Res  `xml:"res"`
+	AlbumArtURI         string `xml:"upnp:albumArtURI,omitempty"`
+	Title               string `xml:"dc:title,omitempty"`
+	Class               string `xml:"upnp:class,omitempty"`
+	Creator             string `xml:"dc:creator,omitempty"`
+	Album               string `xml:"upnp:album,omitempty"`
+	Artist              string `xml:"upnp:artist,omitempty"`
+	OriginalTrackNumber int    `xml:"upnp:originalTrackNumber,omitempty"`
+	AlbumArtist         string `xml:"r:albumArtist,omitempty"`
+	StreamContent       string `xml:"r:streamContent,omitempty"`
+	RadioShowMd         string `xml:"r:radioShowMd,omitempty"`
+	Description         string `xml:"r:description,omitempty"`
+	Desc                []Desc `xml:"desc"`
+}
+
+func encodeObject(o *Object, childCount string) encodedObject {
+	return encodedObject{
+		ID:                  o.ID,
+		ParentID:            o.ParentID,
+		Restricted:          o.Restricted,
+		ChildCount:          childCount,
+		Res:                 o.Res,
+		AlbumArtURI:         o.AlbumArtURI,
+		Title:               o.Title,
+		Class:               o.Class,
+		Creator:             o.Creator,
+		Album:               o.Album,
+		Artist:              o.Artist,
+		OriginalTrackNumber: o.OriginalTrackNumber,
+		AlbumArtist:         o.AlbumArtist,
+		StreamContent:       o.StreamContent,
+		RadioShowMd:         o.RadioShowMd,
+		Description:         o.Description,
+		Desc:                o.Desc,
+	}
+}
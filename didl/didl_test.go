@@ -0,0 +1,192 @@
+package didl
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// track is the TrackMetaData of a music service track as sent by a Sonos
+// player: the res holds unescaped ampersands and the album art escaped ones,
+// and the r: and desc elements are Sonos extensions
+const track = `<DIDL-Lite xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:upnp="urn:schemas-upnp-org:metadata-1-0/upnp/" xmlns:r="urn:schemas-rinconnetworks-com:metadata-1-0/" xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/">` +
+	`<item id="-1" parentID="-1" restricted="true">` +
+	`<res protocolInfo="sonos.com-http:*:audio/mp4:*" duration="0:03:20">x-sonos-http:librarytrack%3aa.1234.mp4?sid=204&flags=8224&sn=1</res>` +
+	`<r:streamContent></r:streamContent>` +
+	`<upnp:albumArtURI>/getaa?s=1&amp;u=x-sonos-http%3alibrarytrack%253aa.1234.mp4%3fsid%3d204%26flags%3d8224%26sn%3d1</upnp:albumArtURI>` +
+	`<dc:title>Tom &amp; Jerry&apos;s Song</dc:title>` +
+	`<upnp:class>object.item.audioItem.musicTrack</upnp:class>` +
+	`<dc:creator>The Artist</dc:creator>` +
+	`<upnp:album>The Album</upnp:album>` +
+	`<upnp:originalTrackNumber>7</upnp:originalTrackNumber>` +
+	`<r:albumArtist>Various Artists</r:albumArtist>` +
+	`<desc id="cdudn" nameSpace="urn:schemas-rinconnetworks-com:metadata-1-0/">SA_RINCON52231_X_#Svc52231-0-Token</desc>` +
+	`</item></DIDL-Lite>`
+
+// station is the CurrentURIMetaData of a radio station, with an HTML entity
+// Sonos passes through from the station name
+const station = `<DIDL-Lite xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:upnp="urn:schemas-upnp-org:metadata-1-0/upnp/" xmlns:r="urn:schemas-rinconnetworks-com:metadata-1-0/" xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/">` +
+	`<item id="-1" parentID="-1" restricted="true">` +
+	`<dc:title>Caf&eacute; Radio</dc:title>` +
+	`<upnp:class>object.item</upnp:class>` +
+	`<desc id="cdudn" nameSpace="urn:schemas-rinconnetworks-com:metadata-1-0/">SA_RINCON65031_</desc>` +
+	`</item></DIDL-Lite>`
+
+// queue is a container as returned by Browse
+const queue = `<DIDL-Lite xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:upnp="urn:schemas-upnp-org:metadata-1-0/upnp/" xmlns:r="urn:schemas-rinconnetworks-com:metadata-1-0/" xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/">` +
+	`<container id="Q:0" parentID="Q:" restricted="true" childCount="12">` +
+	`<dc:title>Queue Instance 0</dc:title>` +
+	`<upnp:class>object.container.playlistContainer</upnp:class>` +
+	`<res protocolInfo="x-rincon-queue:*:*:*">x-rincon-queue:RINCON_000E58000000001400#0</res>` +
+	`</container></DIDL-Lite>`
+
+func TestDecode(t *testing.T) {
+	l, err := Decode(track)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Lite{Items: []Item{{Object{
+		ID:                  "-1",
+		ParentID:            "-1",
+		Restricted:          true,
+		Title:               "Tom & Jerry's Song",
+		Creator:             "The Artist",
+		Class:               ClassMusicTrack,
+		Album:               "The Album",
+		AlbumArtURI:         "/getaa?s=1&u=x-sonos-http%3alibrarytrack%253aa.1234.mp4%3fsid%3d204%26flags%3d8224%26sn%3d1",
+		OriginalTrackNumber: 7,
+		AlbumArtist:         "Various Artists",
+		Res: []Res{{
+			ProtocolInfo: "sonos.com-http:*:audio/mp4:*",
+			Duration:     "0:03:20",
+			URI:          "x-sonos-http:librarytrack%3aa.1234.mp4?sid=204&flags=8224&sn=1",
+		}},
+		Desc: []Desc{ServiceDesc("SA_RINCON52231_X_#Svc52231-0-Token")},
+	}}}}
+	l.XMLName = want.XMLName
+	if !reflect.DeepEqual(l, want) {
+		t.Errorf("Decode =\n%+v\nwant\n%+v", l, want)
+	}
+}
+
+func TestDecodeHTMLEntity(t *testing.T) {
+	l, err := Decode(station)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l.Items) != 1 || l.Items[0].Title != "Café Radio" {
+		t.Errorf("Decode = %+v, want one item titled Café Radio", l)
+	}
+}
+
+func TestDecodeContainer(t *testing.T) {
+	l, err := Decode(queue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l.Items) != 0 || len(l.Containers) != 1 {
+		t.Fatalf("Decode = %+v, want one container", l)
+	}
+	c := l.Containers[0]
+	if c.ID != "Q:0" || c.ChildCount != "12" || c.Class != ClassPlaylist || len(c.Res) != 1 {
+		t.Errorf("Decode = %+v", c)
+	}
+}
+
+func TestDecodeEmpty(t *testing.T) {
+	for _, metadata := range []string{"", "NOT_IMPLEMENTED"} {
+		l, err := Decode(metadata)
+		if err != nil {
+			t.Errorf("Decode(%q): %v", metadata, err)
+			continue
+		}
+		if len(l.Items) != 0 || len(l.Containers) != 0 {
+			t.Errorf("Decode(%q) = %+v, want an empty document", metadata, l)
+		}
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	if _, err := Decode("<DIDL-Lite><item>"); err == nil {
+		t.Error("Decode of truncated metadata succeeded")
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	for _, metadata := range []string{track, station, queue} {
+		l, err := Decode(metadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		encoded, err := Encode(l)
+		if err != nil {
+			t.Fatal(err)
+		}
+		again, err := Decode(encoded)
+		if err != nil {
+			t.Fatalf("Decode(Encode(l)): %v\n%s", err, encoded)
+		}
+		if !reflect.DeepEqual(again, l) {
+			t.Errorf("Decode(Encode(l)) =\n%+v\nwant\n%+v", again, l)
+		}
+	}
+}
+
+// Players only accept metadata with the prefixes they use themselves, and
+// with the ampersands of URIs escaped
+func TestEncodeForm(t *testing.T) {
+	l, err := Decode(track)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := Encode(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<DIDL-Lite xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:upnp="urn:schemas-upnp-org:metadata-1-0/upnp/" xmlns:r="urn:schemas-rinconnetworks-com:metadata-1-0/" xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/">`,
+		`<item id="-1" parentID="-1" restricted="true">`,
+		`<res protocolInfo="sonos.com-http:*:audio/mp4:*" duration="0:03:20">x-sonos-http:librarytrack%3aa.1234.mp4?sid=204&amp;flags=8224&amp;sn=1</res>`,
+		`<dc:title>Tom &amp; Jerry&#39;s Song</dc:title>`,
+		`<upnp:class>object.item.audioItem.musicTrack</upnp:class>`,
+		`<upnp:originalTrackNumber>7</upnp:originalTrackNumber>`,
+		`<r:albumArtist>Various Artists</r:albumArtist>`,
+		`<desc id="cdudn" nameSpace="urn:schemas-rinconnetworks-com:metadata-1-0/">SA_RINCON52231_X_#Svc52231-0-Token</desc>`,
+	} {
+		if !strings.Contains(encoded, want) {
+			t.Errorf("Encode output lacks %s\n%s", want, encoded)
+		}
+	}
+	if strings.Contains(encoded, "<r:streamContent>") {
+		t.Errorf("Encode output has an empty streamContent\n%s", encoded)
+	}
+}
+
+func TestEncodeEmpty(t *testing.T) {
+	for _, l := range []*Lite{nil, {}} {
+		encoded, err := Encode(l)
+		if err != nil || encoded != "" {
+			t.Errorf("Encode(%+v) = %q, %v, want empty metadata", l, encoded, err)
+		}
+	}
+}
+
+func TestEncodeItem(t *testing.T) {
+	encoded, err := EncodeItem(Item{Object{
+		ID:       "10032020spotify%3atrack%3a1",
+		ParentID: "",
+		Title:    "Song",
+		Class:    ClassMusicTrack,
+		Desc:     []Desc{ServiceDesc("SA_RINCON2311_X_#Svc2311-0-Token")},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := Decode(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l.Items) != 1 || l.Items[0].Title != "Song" || len(l.Items[0].Desc) != 1 {
+		t.Errorf("Decode(EncodeItem(item)) = %+v", l)
+	}
+}
@@ -0,0 +1,501 @@
+// Package gena subscribes to the events UPnP services send to their
+// subscribers (GENA), and delivers them on a channel per subscription.
+//
+// A Manager runs the HTTP server the services call back, and keeps every
+// subscription alive: it renews them before they expire and subscribes again
+// when a renewal is refused, as happens after the speaker restarts.
+package gena
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultTimeout is the subscription length asked for when
+	// Manager.Timeout is not set
+	DefaultTimeout = 1800 * time.Second
+	// retryInterval is how long to wait before subscribing again after a
+	// failed attempt
+	retryInterval = 10 * time.Second
+	// requestTimeout bounds the renewals and subscriptions sent in the
+	// background
+	requestTimeout = 10 * time.Second
+	// eventBuffer is how many events a subscription queues for a slow reader
+	// before holding up the publisher
+	eventBuffer = 16
+)
+
+// Manager runs the callback server and the subscriptions sent to it.
+type Manager struct {
+	HttpClient *http.Client
+	// Timeout is the subscription length asked for, DefaultTimeout if zero.
+	// Services may grant a different one.
+	Timeout time.Duration
+
+	listener net.Listener
+	server   *http.Server
+
+	mu            sync.Mutex
+	subscriptions map[string]*Subscription // by callback path
+	next          int
+}
+
+// NewManager starts the callback server on addr, e.g. ":0" for any free port
+// on all interfaces, which is also used if addr is empty.
+func NewManager(addr string) (*Manager, error) {
+	if addr == "" {
+		addr = ":0"
+	}
+	listener, err := net.Listen("tcp4", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manager{
+		HttpClient:    &http.Client{},
+		listener:      listener,
+		subscriptions: make(map[string]*Subscription),
+	}
+	m.server = &http.Server{Handler: m}
+	go m.server.Serve(listener)
+	return m, nil
+}
+
+// Close unsubscribes every subscription and stops the callback server.
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	var subscriptions []*Subscription
+	for _, s := range m.subscriptions {
+		subscriptions = append(subscriptions, s)
+	}
+	m.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	for _, s := range subscriptions {
+		s.Unsubscribe(ctx)
+	}
+	return m.server.Close()
+}
+
+// Addr returns the address the callback server listens on.
+func (m *Manager) Addr() net.Addr {
+	return m.listener.Addr()
+}
+
+// ServeHTTP receives the NOTIFY requests of all subscriptions.
+func (m *Manager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	s := m.subscriptions[r.URL.Path]
+	m.mu.Unlock()
+	if s == nil {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+
+	event, status := readEvent(r)
+	if event == nil {
+		w.WriteHeader(status)
+		return
+	}
+	w.WriteHeader(s.receive(r.Context(), event))
+}
+
+func (m *Manager) httpClient() *http.Client {
+	if m.HttpClient == nil {
+		return http.DefaultClient
+	}
+	return m.HttpClient
+}
+
+func (m *Manager) timeout() time.Duration {
+	if m.Timeout <= 0 {
+		return DefaultTimeout
+	}
+	return m.Timeout
+}
+
+// callbackURL returns the URL the service at eventEndpoint can reach the
+// callback server on for path. Unless the server listens on a single address,
+// the address of the interface that routes to the service is used.
+func (m *Manager) callbackURL(eventEndpoint *url.URL, path string) (string, error) {
+	addr := m.listener.Addr().(*net.TCPAddr)
+	ip := addr.IP
+	if ip == nil || ip.IsUnspecified() {
+		port := eventEndpoint.Port()
+		if port == "" {
+			port = "80"
+		}
+		conn, err := net.Dial("udp4", net.JoinHostPort(eventEndpoint.Hostname(), port))
+		if err != nil {
+			return "", err
+		}
+		ip = conn.LocalAddr().(*net.UDPAddr).IP
+		conn.Close()
+	}
+	return "http://" + net.JoinHostPort(ip.String(), strconv.Itoa(addr.Port)) + path, nil
+}
+
+func (m *Manager) remove(path string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.subscriptions, path)
+}
+
+// Subscription is a subscription to the events of one service.
+type Subscription struct {
+	// EventEndpoint is the event URL of the service, e.g. the EventEndpoint of
+	// a generated Service
+	EventEndpoint *url.URL
+
+	m           *Manager
+	path        string
+	callback    string
+	events      chan Event
+	ctx         context.Context
+	cancel      context.CancelFunc
+	stopped     chan struct{}
+	resubscribe chan struct{}
+	closeOnce   sync.Once
+
+	mu          sync.Mutex
+	sid         string
+	seq         uint32 // SEQ expected next for sid
+	timeout     time.Duration
+	subscribing bool
+	pending     []*Event // received while subscribing, before the SID is known
+	err         error
+
+	deliverMu sync.Mutex
+}
+
+// Subscribe subscribes to the events of the service at eventEndpoint. The
+// initial event, holding the current value of every evented state variable,
+// is normally the first one received.
+func (m *Manager) Subscribe(ctx context.Context, eventEndpoint *url.URL) (*Subscription, error) {
+	m.mu.Lock()
+	m.next++
+	path := "/" + strconv.Itoa(m.next)
+	m.mu.Unlock()
+
+	callback, err := m.callbackURL(eventEndpoint, path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Subscription{
+		EventEndpoint: eventEndpoint,
+		m:             m,
+		path:          path,
+		callback:      callback,
+		events:        make(chan Event, eventBuffer),
+		stopped:       make(chan struct{}),
+		resubscribe:   make(chan struct{}, 1),
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	m.mu.Lock()
+	m.subscriptions[path] = s
+	m.mu.Unlock()
+
+	if err := s.subscribe(ctx); err != nil {
+		m.remove(path)
+		s.cancel()
+		return nil, err
+	}
+	go s.run()
+	return s, nil
+}
+
+// Events returns the channel events are delivered on. It is closed by
+// Unsubscribe.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// SID returns the current subscription identifier. It changes when the
+// subscription has to be made again.
+func (s *Subscription) SID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sid
+}
+
+// Err returns the error of the last failed renewal, or nil once the
+// subscription is back in place.
+func (s *Subscription) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Resubscribe makes a new subscription in the background without waiting for
+// the current one to fail renewal, e.g. when a Watcher reports the speaker
+// rebooted. It is also done when a gap in the SEQ of the events shows some
+// were missed, the initial event of the new subscription bringing back the
+// current state.
+func (s *Subscription) Resubscribe() {
+	select {
+	case s.resubscribe <- struct{}{}:
+	default:
+	}
+}
+
+// Unsubscribe stops renewing the subscription, closes the events channel and
+// cancels the subscription with the service.
+func (s *Subscription) Unsubscribe(ctx context.Context) error {
+	var err error
+	s.closeOnce.Do(func() {
+		s.m.remove(s.path)
+		s.cancel()
+		<-s.stopped
+
+		s.deliverMu.Lock()
+		close(s.events)
+		s.deliverMu.Unlock()
+
+		s.mu.Lock()
+		sid := s.sid
+		s.sid = ""
+		s.mu.Unlock()
+		if sid == "" {
+			return
+		}
+
+		req, e := s.request(ctx, "UNSUBSCRIBE")
+		if e != nil {
+			err = e
+			return
+		}
+		req.Header["SID"] = []string{sid}
+		err = s.send(req, nil)
+	})
+	return err
+}
+
+// run renews the subscription half way through its timeout, and subscribes
+// again if that fails
+func (s *Subscription) run() {
+	defer close(s.stopped)
+	for {
+		s.mu.Lock()
+		wait := s.timeout / 2
+		if s.sid == "" {
+			wait = retryInterval
+		}
+		s.mu.Unlock()
+
+		force := false
+		timer := time.NewTimer(wait)
+		select {
+		case <-s.ctx.Done():
+			timer.Stop()
+			return
+		case <-s.resubscribe:
+			timer.Stop()
+			force = true
+		case <-timer.C:
+		}
+
+		ctx, cancel := context.WithTimeout(s.ctx, requestTimeout)
+		err := s.refresh(ctx, force)
+		cancel()
+		if s.ctx.Err() != nil {
+			return
+		}
+		s.mu.Lock()
+		s.err = err
+		s.mu.Unlock()
+	}
+}
+
+func (s *Subscription) refresh(ctx context.Context, force bool) error {
+	if !force && s.SID() != "" {
+		if err := s.renew(ctx); err == nil {
+			return nil
+		}
+	}
+	err := s.subscribe(ctx)
+	if err != nil {
+		s.mu.Lock()
+		s.sid = ""
+		s.mu.Unlock()
+	}
+	return err
+}
+
+func (s *Subscription) subscribe(ctx context.Context) error {
+	req, err := s.request(ctx, "SUBSCRIBE")
+	if err != nil {
+		return err
+	}
+	req.Header["CALLBACK"] = []string{"<" + s.callback + ">"}
+	req.Header["NT"] = []string{"upnp:event"}
+	req.Header["TIMEOUT"] = []string{timeoutHeader(s.m.timeout())}
+
+	// The initial event can arrive before the response with its SID, so
+	// events are held until then
+	s.mu.Lock()
+	s.subscribing = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.subscribing = false
+		s.pending = nil
+		s.mu.Unlock()
+	}()
+
+	return s.send(req, func(resp *http.Response) error {
+		sid := resp.Header.Get("SID")
+		if sid == "" {
+			return errors.New("gena: subscription response without SID")
+		}
+
+		// Hold up the events of the new SID until the held ones are queued
+		s.deliverMu.Lock()
+		defer s.deliverMu.Unlock()
+
+		s.mu.Lock()
+		s.sid = sid
+		s.seq = 0
+		s.timeout = parseTimeout(resp.Header.Get("TIMEOUT"), s.m.timeout())
+		s.subscribing = false
+		var held []*Event
+		for _, event := range s.pending {
+			if event.SID == sid {
+				held = append(held, event)
+			}
+		}
+		s.pending = nil
+		sort.Slice(held, func(i, j int) bool { return held[i].Seq < held[j].Seq })
+		for _, event := range held {
+			if s.sequence(event.Seq) {
+				s.Resubscribe()
+			}
+		}
+		s.mu.Unlock()
+
+		for _, event := range held {
+			s.queue(ctx, event)
+		}
+		return nil
+	})
+}
+
+func (s *Subscription) renew(ctx context.Context) error {
+	req, err := s.request(ctx, "SUBSCRIBE")
+	if err != nil {
+		return err
+	}
+	req.Header["SID"] = []string{s.SID()}
+	req.Header["TIMEOUT"] = []string{timeoutHeader(s.m.timeout())}
+	return s.send(req, func(resp *http.Response) error {
+		s.mu.Lock()
+		s.timeout = parseTimeout(resp.Header.Get("TIMEOUT"), s.m.timeout())
+		s.mu.Unlock()
+		return nil
+	})
+}
+
+func (s *Subscription) request(ctx context.Context, method string) (*http.Request, error) {
+	return http.NewRequestWithContext(ctx, method, s.EventEndpoint.String(), nil)
+}
+
+// send sends req and hands a successful response to ok
+func (s *Subscription) send(req *http.Request, ok func(resp *http.Response) error) error {
+	resp, err := s.m.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("gena: %s %s: %s", req.Method, req.URL, resp.Status)
+	}
+	if ok == nil {
+		return nil
+	}
+	return ok(resp)
+}
+
+// receive delivers event if it was sent for the current SID, or holds it
+// while a subscription is being made, and returns the status to answer the
+// publisher with
+func (s *Subscription) receive(ctx context.Context, event *Event) int {
+	s.mu.Lock()
+	switch {
+	case s.sid != "" && event.SID == s.sid:
+		if s.sequence(event.Seq) {
+			s.Resubscribe()
+		}
+		s.mu.Unlock()
+		s.deliver(ctx, event)
+		return http.StatusOK
+	case s.subscribing:
+		if len(s.pending) < eventBuffer {
+			s.pending = append(s.pending, event)
+		}
+		s.mu.Unlock()
+		return http.StatusOK
+	}
+	s.mu.Unlock()
+	return http.StatusPreconditionFailed
+}
+
+// sequence records the SEQ of an event for the current SID, and reports
+// whether events were missed before it. s.mu must be held.
+func (s *Subscription) sequence(seq uint32) (missed bool) {
+	missed = seq != s.seq
+	s.seq = seq + 1
+	if s.seq == 0 {
+		s.seq = 1 // SEQ wraps to 1, 0 is only used by the initial event
+	}
+	return missed
+}
+
+// deliver queues event, waiting for room while the publisher is connected.
+// Events are delivered in the order they are received.
+func (s *Subscription) deliver(ctx context.Context, event *Event) {
+	s.deliverMu.Lock()
+	defer s.deliverMu.Unlock()
+	s.queue(ctx, event)
+}
+
+// queue is deliver with s.deliverMu held
+func (s *Subscription) queue(ctx context.Context, event *Event) {
+	if s.ctx.Err() != nil {
+		return // unsubscribed, events is closed
+	}
+	select {
+	case s.events <- *event:
+	case <-ctx.Done():
+	case <-s.ctx.Done():
+	}
+}
+
+func timeoutHeader(timeout time.Duration) string {
+	return "Second-" + strconv.Itoa(int(timeout/time.Second))
+}
+
+// parseTimeout parses a TIMEOUT header such as "Second-1800", falling back to
+// def for "infinite" and anything unexpected
+func parseTimeout(header string, def time.Duration) time.Duration {
+	value, ok := strings.CutPrefix(strings.TrimSpace(header), "Second-")
+	if !ok {
+		return def
+	}
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds <= 0 {
+		return def
+	}
+	return time.Duration(seconds) * time.Second
+}
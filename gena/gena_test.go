@@ -0,0 +1,473 @@
+package gena
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// request is what the fake service keeps of a request it received
+type request struct {
+	Method string
+	Header http.Header
+	At     time.Time
+}
+
+// service is a fake evented service. It grants subscriptions numbered from
+// uuid:sub-1, and answers renewals with renewStatus.
+type service struct {
+	*httptest.Server
+	grant string
+
+	mu          sync.Mutex
+	requests    []request
+	callbacks   map[string]string // by SID
+	renewStatus int
+	// onSubscribe is called before a new subscription is answered
+	onSubscribe func(callback, sid string)
+}
+
+func newService(t *testing.T, grant string) *service {
+	t.Helper()
+	svc := &service{grant: grant, callbacks: make(map[string]string), renewStatus: http.StatusOK}
+	svc.Server = httptest.NewServer(svc)
+	t.Cleanup(svc.Close)
+	return svc
+}
+
+func (svc *service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	svc.mu.Lock()
+	svc.requests = append(svc.requests, request{Method: r.Method, Header: r.Header.Clone(), At: time.Now()})
+	renewStatus := svc.renewStatus
+	onSubscribe := svc.onSubscribe
+	var sid, callback string
+	if r.Method == "SUBSCRIBE" && r.Header.Get("SID") == "" {
+		sid = fmt.Sprintf("uuid:sub-%d", len(svc.callbacks)+1)
+		callback = strings.Trim(r.Header.Get("CALLBACK"), "<>")
+		svc.callbacks[sid] = callback
+	}
+	svc.mu.Unlock()
+
+	switch {
+	case r.Method == "SUBSCRIBE" && sid == "":
+		if renewStatus != http.StatusOK {
+			w.WriteHeader(renewStatus)
+			return
+		}
+		w.Header().Set("TIMEOUT", svc.grant)
+	case r.Method == "SUBSCRIBE":
+		if onSubscribe != nil {
+			onSubscribe(callback, sid)
+		}
+		w.Header().Set("SID", sid)
+		w.Header().Set("TIMEOUT", svc.grant)
+	case r.Method == "UNSUBSCRIBE":
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (svc *service) Requests() []request {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	return append([]request(nil), svc.requests...)
+}
+
+func (svc *service) Callback(sid string) string {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	return svc.callbacks[sid]
+}
+
+func (svc *service) URL(t *testing.T) *url.URL {
+	u, err := url.Parse(svc.Server.URL + "/Event")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+func newManager(t *testing.T) *Manager {
+	t.Helper()
+	m, err := NewManager("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { m.Close() })
+	return m
+}
+
+const propertySetXML = `<e:propertyset xmlns:e="urn:schemas-upnp-org:event-1-0">` +
+	`<e:property><LastChange>&lt;Event xmlns=&quot;urn:schemas-upnp-org:metadata-1-0/AVT/&quot;/&gt;</LastChange></e:property>` +
+	`<e:property><Volume>23</Volume></e:property>` +
+	`</e:propertyset>`
+
+// notify sends a NOTIFY to callback and returns the status answered
+func notify(t *testing.T, callback, sid string, seq int) int {
+	t.Helper()
+	req, err := http.NewRequest("NOTIFY", callback, strings.NewReader(propertySetXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header["NT"] = []string{"upnp:event"}
+	req.Header["NTS"] = []string{"upnp:propchange"}
+	req.Header["SID"] = []string{sid}
+	req.Header["SEQ"] = []string{fmt.Sprint(seq)}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func receive(t *testing.T, s *Subscription) Event {
+	t.Helper()
+	select {
+	case event, ok := <-s.Events():
+		if !ok {
+			t.Fatal("Events closed")
+		}
+		return event
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event received")
+	}
+	return Event{}
+}
+
+// waitFor polls cond until it holds or a few seconds passed
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(3 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if cond() {
+			return
+		}
+	}
+	t.Fatalf("timed out waiting for %s", what)
+}
+
+func TestSubscribeHeaders(t *testing.T) {
+	svc := newService(t, "Second-300")
+	m := newManager(t)
+	m.Timeout = 300 * time.Second
+
+	s, err := m.Subscribe(context.Background(), svc.URL(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sid := s.SID(); sid != "uuid:sub-1" {
+		t.Errorf("SID = %s, want uuid:sub-1", sid)
+	}
+	if err := s.Unsubscribe(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := <-s.Events(); ok {
+		t.Error("Events not closed by Unsubscribe")
+	}
+
+	requests := svc.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("requests = %+v, want SUBSCRIBE and UNSUBSCRIBE", requests)
+	}
+	subscribe, unsubscribe := requests[0], requests[1]
+	callback := "http://" + m.Addr().String() + "/1"
+	if subscribe.Method != "SUBSCRIBE" ||
+		subscribe.Header.Get("CALLBACK") != "<"+callback+">" ||
+		subscribe.Header.Get("NT") != "upnp:event" ||
+		subscribe.Header.Get("TIMEOUT") != "Second-300" ||
+		subscribe.Header.Get("SID") != "" {
+		t.Errorf("SUBSCRIBE headers = %v, want CALLBACK <%s>, NT and TIMEOUT Second-300", subscribe.Header, callback)
+	}
+	if unsubscribe.Method != "UNSUBSCRIBE" ||
+		unsubscribe.Header.Get("SID") != "uuid:sub-1" ||
+		unsubscribe.Header.Get("CALLBACK") != "" ||
+		unsubscribe.Header.Get("NT") != "" {
+		t.Errorf("UNSUBSCRIBE headers = %v, want SID uuid:sub-1 alone", unsubscribe.Header)
+	}
+
+	// Unsubscribing again does nothing
+	if err := s.Unsubscribe(context.Background()); err != nil || len(svc.Requests()) != 2 {
+		t.Errorf("second Unsubscribe = %v, sent %d requests", err, len(svc.Requests())-2)
+	}
+}
+
+// The subscription is renewed half way through the timeout granted
+func TestRenew(t *testing.T) {
+	svc := newService(t, "Second-1")
+	m := newManager(t)
+
+	s, err := m.Subscribe(context.Background(), svc.URL(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "a renewal", func() bool { return len(svc.Requests()) >= 2 })
+
+	requests := svc.Requests()
+	renew := requests[1]
+	if renew.Method != "SUBSCRIBE" ||
+		renew.Header.Get("SID") != "uuid:sub-1" ||
+		renew.Header.Get("TIMEOUT") != "Second-1800" ||
+		renew.Header.Get("CALLBACK") != "" ||
+		renew.Header.Get("NT") != "" {
+		t.Errorf("renewal headers = %v, want SID uuid:sub-1 and TIMEOUT Second-1800 only", renew.Header)
+	}
+	if after := renew.At.Sub(requests[0].At); after < 400*time.Millisecond || after > 900*time.Millisecond {
+		t.Errorf("renewed after %v, want about 500ms", after)
+	}
+	if sid := s.SID(); sid != "uuid:sub-1" {
+		t.Errorf("SID after renewal = %s, want uuid:sub-1", sid)
+	}
+}
+
+// A refused renewal, as after the speaker restarted, makes a new subscription
+func TestResubscribeAfterFailedRenew(t *testing.T) {
+	svc := newService(t, "Second-1")
+	svc.renewStatus = http.StatusPreconditionFailed
+	m := newManager(t)
+
+	s, err := m.Subscribe(context.Background(), svc.URL(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "a new subscription", func() bool { return s.SID() == "uuid:sub-2" })
+
+	requests := svc.Requests()
+	if len(requests) < 3 {
+		t.Fatalf("requests = %+v", requests)
+	}
+	if requests[1].Header.Get("SID") != "uuid:sub-1" {
+		t.Errorf("second request = %v, want the renewal of uuid:sub-1", requests[1].Header)
+	}
+	if requests[2].Header.Get("SID") != "" || requests[2].Header.Get("CALLBACK") == "" {
+		t.Errorf("third request = %v, want a new subscription", requests[2].Header)
+	}
+	if err := s.Err(); err != nil {
+		t.Errorf("Err = %v, want nil once subscribed again", err)
+	}
+
+	// Events of the old subscription are refused
+	if status := notify(t, svc.Callback("uuid:sub-2"), "uuid:sub-1", 1); status != http.StatusPreconditionFailed {
+		t.Errorf("NOTIFY for the old SID = %d, want 412", status)
+	}
+}
+
+func TestNotify(t *testing.T) {
+	svc := newService(t, "Second-300")
+	m := newManager(t)
+	s, err := m.Subscribe(context.Background(), svc.URL(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	callback := svc.Callback("uuid:sub-1")
+
+	if status := notify(t, callback, "uuid:sub-1", 0); status != http.StatusOK {
+		t.Fatalf("NOTIFY = %d, want 200", status)
+	}
+	event := receive(t, s)
+	if event.SID != "uuid:sub-1" || event.Seq != 0 {
+		t.Errorf("event = %s #%d, want uuid:sub-1 #0", event.SID, event.Seq)
+	}
+	if len(event.Properties) != 2 ||
+		event.Properties["LastChange"] != `<Event xmlns="urn:schemas-upnp-org:metadata-1-0/AVT/"/>` ||
+		event.Properties["Volume"] != "23" {
+		t.Errorf("event properties = %q", event.Properties)
+	}
+
+	if status := notify(t, callback, "uuid:other", 1); status != http.StatusPreconditionFailed {
+		t.Errorf("NOTIFY for an unknown SID = %d, want 412", status)
+	}
+	if status := notify(t, "http://"+m.Addr().String()+"/99", "uuid:sub-1", 1); status != http.StatusPreconditionFailed {
+		t.Errorf("NOTIFY to an unknown path = %d, want 412", status)
+	}
+	select {
+	case event := <-s.Events():
+		t.Errorf("refused event delivered: %+v", event)
+	default:
+	}
+}
+
+func TestReadEvent(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		header map[string]string
+		body   string
+		status int
+	}{
+		{"valid", "NOTIFY", nil, propertySetXML, http.StatusOK},
+		{"method", "POST", nil, propertySetXML, http.StatusMethodNotAllowed},
+		{"NT", "NOTIFY", map[string]string{"NT": "upnp:other"}, propertySetXML, http.StatusBadRequest},
+		{"NTS", "NOTIFY", map[string]string{"NTS": ""}, propertySetXML, http.StatusBadRequest},
+		{"SID", "NOTIFY", map[string]string{"SID": ""}, propertySetXML, http.StatusPreconditionFailed},
+		{"SEQ", "NOTIFY", map[string]string{"SEQ": "-1"}, propertySetXML, http.StatusBadRequest},
+		{"body", "NOTIFY", nil, "<e:propertyset", http.StatusBadRequest},
+		{"root", "NOTIFY", nil, "<Event/>", http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest(test.method, "/1", strings.NewReader(test.body))
+		r.Header.Set("NT", "upnp:event")
+		r.Header.Set("NTS", "upnp:propchange")
+		r.Header.Set("SID", "uuid:sub-1")
+		r.Header.Set("SEQ", "7")
+		for k, v := range test.header {
+			r.Header.Set(k, v)
+		}
+		event, status := readEvent(r)
+		if status != test.status || (event != nil) != (status == http.StatusOK) {
+			t.Errorf("%s: readEvent = %+v, %d, want %d", test.name, event, status, test.status)
+			continue
+		}
+		if event != nil && (event.Seq != 7 || event.Properties["Volume"] != "23") {
+			t.Errorf("%s: readEvent = %+v", test.name, event)
+		}
+	}
+}
+
+// The initial event can arrive before the response to SUBSCRIBE. It is held
+// until the SID is known, and events of other SIDs are dropped meanwhile.
+func TestInitialEventBeforeResponse(t *testing.T) {
+	svc := newService(t, "Second-300")
+	svc.onSubscribe = func(callback, sid string) {
+		if status := notify(t, callback, "uuid:stale", 4); status != http.StatusOK {
+			t.Errorf("NOTIFY while subscribing = %d, want 200", status)
+		}
+		if status := notify(t, callback, sid, 0); status != http.StatusOK {
+			t.Errorf("NOTIFY while subscribing = %d, want 200", status)
+		}
+	}
+	m := newManager(t)
+	s, err := m.Subscribe(context.Background(), svc.URL(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if event := receive(t, s); event.SID != "uuid:sub-1" || event.Seq != 0 {
+		t.Errorf("event = %s #%d, want the initial event of uuid:sub-1", event.SID, event.Seq)
+	}
+	select {
+	case event := <-s.Events():
+		t.Errorf("event of another SID delivered: %+v", event)
+	default:
+	}
+	if status := notify(t, svc.Callback("uuid:sub-1"), "uuid:stale", 5); status != http.StatusPreconditionFailed {
+		t.Errorf("NOTIFY for another SID once subscribed = %d, want 412", status)
+	}
+}
+
+// A gap in SEQ means events were missed, the subscription is made again to
+// get the current state in a new initial event
+func TestMissedEvents(t *testing.T) {
+	svc := newService(t, "Second-300")
+	m := newManager(t)
+	s, err := m.Subscribe(context.Background(), svc.URL(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	callback := svc.Callback("uuid:sub-1")
+
+	for _, seq := range []int{0, 1, 2} {
+		notify(t, callback, "uuid:sub-1", seq)
+		receive(t, s)
+	}
+	if s.SID() != "uuid:sub-1" {
+		t.Fatalf("SID = %s after events in sequence", s.SID())
+	}
+
+	notify(t, callback, "uuid:sub-1", 5)
+	if event := receive(t, s); event.Seq != 5 {
+		t.Errorf("event #%d, want #5 delivered", event.Seq)
+	}
+	waitFor(t, "a new subscription", func() bool { return s.SID() == "uuid:sub-2" })
+}
+
+func TestSequence(t *testing.T) {
+	s := &Subscription{}
+	for _, test := range []struct {
+		seq    uint32
+		missed bool
+	}{
+		{0, false},
+		{1, false},
+		{3, true},
+		{4, false},
+		{4294967295, true},
+		{1, false}, // wrapped
+	} {
+		if missed := s.sequence(test.seq); missed != test.missed {
+			t.Errorf("sequence(%d) = %t, want %t", test.seq, missed, test.missed)
+		}
+	}
+}
+
+// Unsubscribe closes Events even while a NOTIFY waits for a reader
+func TestUnsubscribeWhileBlocked(t *testing.T) {
+	svc := newService(t, "Second-300")
+	m := newManager(t)
+	s, err := m.Subscribe(context.Background(), svc.URL(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	callback := svc.Callback("uuid:sub-1")
+	for seq := 0; seq < eventBuffer; seq++ {
+		notify(t, callback, "uuid:sub-1", seq)
+	}
+	blocked := make(chan int)
+	go func() {
+		blocked <- notify(t, callback, "uuid:sub-1", eventBuffer)
+	}()
+	select {
+	case status := <-blocked:
+		t.Fatalf("NOTIFY with a full queue answered %d, want it held", status)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	done := make(chan error)
+	go func() { done <- s.Unsubscribe(context.Background()) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Unsubscribe deadlocked")
+	}
+	select {
+	case <-blocked:
+	case <-time.After(2 * time.Second):
+		t.Fatal("held NOTIFY not released")
+	}
+
+	n := 0
+	for range s.Events() {
+		n++
+	}
+	if n != eventBuffer {
+		t.Errorf("%d events queued before Unsubscribe, want %d", n, eventBuffer)
+	}
+}
+
+func TestParseTimeout(t *testing.T) {
+	tests := map[string]time.Duration{
+		"Second-300":   300 * time.Second,
+		" Second-60 ":  60 * time.Second,
+		"Second-0":     DefaultTimeout,
+		"Second-x":     DefaultTimeout,
+		"infinite":     DefaultTimeout,
+		"":             DefaultTimeout,
+		"Second--1800": DefaultTimeout,
+	}
+	for header, want := range tests {
+		if timeout := parseTimeout(header, DefaultTimeout); timeout != want {
+			t.Errorf("parseTimeout(%q) = %v, want %v", header, timeout, want)
+		}
+	}
+	if header := timeoutHeader(DefaultTimeout); header != "Second-1800" {
+		t.Errorf("timeoutHeader(%v) = %s, want Second-1800", DefaultTimeout, header)
+	}
+}
@@ -0,0 +1,75 @@
+package gena
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// Event is a property set received in a NOTIFY request.
+type Event struct {
+	// SID is the subscription the event was sent for
+	SID string
+	// Seq counts the events sent for SID, starting at 0 with the initial
+	// event that carries the current value of every evented state variable
+	Seq uint32
+	// Properties maps each state variable in the event to its value. Sonos
+	// sends most changes as a LastChange document, which is left for the
+	// service to decode.
+	Properties map[string]string
+}
+
+type propertySet struct {
+	XMLName    xml.Name   `xml:"propertyset"`
+	Properties []property `xml:"property"`
+}
+
+type property struct {
+	Variables []variable `xml:",any"`
+}
+
+type variable struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+// readEvent parses a NOTIFY request. It answers with the HTTP status the
+// publisher should get if the request is not a valid event.
+func readEvent(r *http.Request) (*Event, int) {
+	if r.Method != "NOTIFY" {
+		return nil, http.StatusMethodNotAllowed
+	}
+	if r.Header.Get("NT") != "upnp:event" || r.Header.Get("NTS") != "upnp:propchange" {
+		return nil, http.StatusBadRequest
+	}
+	sid := r.Header.Get("SID")
+	if sid == "" {
+		return nil, http.StatusPreconditionFailed
+	}
+	seq, err := strconv.ParseUint(r.Header.Get("SEQ"), 10, 32)
+	if err != nil {
+		return nil, http.StatusBadRequest
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, http.StatusBadRequest
+	}
+	var set propertySet
+	if err := xml.Unmarshal(body, &set); err != nil {
+		return nil, http.StatusBadRequest
+	}
+
+	event := &Event{
+		SID:        sid,
+		Seq:        uint32(seq),
+		Properties: make(map[string]string),
+	}
+	for _, p := range set.Properties {
+		for _, v := range p.Variables {
+			event.Properties[v.XMLName.Local] = v.Value
+		}
+	}
+	return event, http.StatusOK
+}
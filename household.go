@@ -0,0 +1,269 @@
+package sonos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	zgt "github.com/szatmary/sonos/ZoneGroupTopology"
+)
+
+const (
+	devicePort            = "1400"
+	deviceDescriptionPath = "/xml/device_description.xml"
+)
+
+// Household is the set of players that share a topology. It is built from
+// the ZoneGroupState of a single player, which lists every member of the
+// household, so it does not depend on multicast discovery. ZonePlayers are
+// only created, and their device descriptions fetched, when first asked for.
+type Household struct {
+	HttpClient *http.Client
+	// Location is the device description URL of the player the topology
+	// is read from
+	Location *url.URL
+	State    *ZoneGroupState
+
+	mu      sync.Mutex
+	players map[string]*ZonePlayer
+}
+
+// NewHousehold reads the topology from the player whose device description
+// is at location.
+func NewHousehold(location *url.URL) (*Household, error) {
+	return NewHouseholdContext(context.Background(), location)
+}
+
+// NewHouseholdContext is like NewHousehold but reads the topology with the
+// given context.
+func NewHouseholdContext(ctx context.Context, location *url.URL) (*Household, error) {
+	h := Household{
+		HttpClient: &http.Client{},
+		Location:   location,
+		players:    make(map[string]*ZonePlayer),
+	}
+	if err := h.RefreshContext(ctx); err != nil {
+		return nil, err
+	}
+
+	return &h, nil
+}
+
+// NewHouseholdFromIP reads the topology from the player at the given IP
+// address or host name.
+func NewHouseholdFromIP(ip string) (*Household, error) {
+	return NewHousehold(deviceDescriptionURL(ip))
+}
+
+// FindHousehold searches for any player and reads the topology from the first
+// one that responds.
+func FindHousehold(timeout time.Duration) (*Household, error) {
+	son, err := NewSonos(WithAllPlayers())
+	if err != nil {
+		return nil, err
+	}
+	defer son.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	found, err := son.SearchContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	zp, ok := <-found
+	if !ok {
+		if err := son.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("timeout")
+	}
+
+	h, err := NewHouseholdContext(ctx, zp.DeviceDescriptionURL)
+	if err != nil {
+		return nil, err
+	}
+	id := uuid(zp.Root.Device.UDN)
+	zp.Role, _ = h.State.Role(id)
+	zp.HttpClient = h.HttpClient
+	h.players[id] = zp
+	return h, nil
+}
+
+func deviceDescriptionURL(host string) *url.URL {
+	return &url.URL{
+		Scheme: "http",
+		Host:   net.JoinHostPort(host, devicePort),
+		Path:   deviceDescriptionPath,
+	}
+}
+
+// Refresh reads the topology again. Players that were already created are
+// kept as they are, their Role is the one read when they were created; Role
+// gives the current one.
+func (h *Household) Refresh() error {
+	return h.RefreshContext(context.Background())
+}
+
+// RefreshContext is like Refresh but reads the topology with the given
+// context.
+func (h *Household) RefreshContext(ctx context.Context) error {
+	state, err := getZoneGroupState(ctx, h.HttpClient, zgt.NewService(h.Location))
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.State = state
+	return nil
+}
+
+// Role returns the role of the player with the given UUID in the topology
+// last read. The second return value is false if the player is not part of
+// it.
+func (h *Household) Role(id string) (Role, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.State.Role(id)
+}
+
+// UUIDs returns the UUID of every player in the household, including
+// satellites and the hidden halves of bonded players.
+func (h *Household) UUIDs() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var uuids []string
+	for _, group := range h.State.ZoneGroups {
+		for _, member := range group.ZoneGroupMember {
+			uuids = append(uuids, member.UUID)
+			for _, satellite := range member.Satellite {
+				uuids = append(uuids, satellite.UUID)
+			}
+		}
+	}
+	return uuids
+}
+
+// Player returns the player with the given UUID, creating it on first use.
+// Players created share the HttpClient of the household.
+func (h *Household) Player(id string) (*ZonePlayer, error) {
+	return h.PlayerContext(context.Background(), id)
+}
+
+// PlayerContext is like Player but fetches the device description of a
+// player created with the given context.
+func (h *Household) PlayerContext(ctx context.Context, id string) (*ZonePlayer, error) {
+	id = uuid(id)
+
+	h.mu.Lock()
+	zp, ok := h.players[id]
+	state := h.State
+	h.mu.Unlock()
+	if ok {
+		return zp, nil
+	}
+
+	location, err := state.location(id)
+	if err != nil {
+		return nil, err
+	}
+	zp, err = newZonePlayer(ctx, h.HttpClient, location)
+	if err != nil {
+		return nil, err
+	}
+	zp.Role, _ = state.Role(id)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	// Another caller may have won the race, hand out a single instance
+	if existing, ok := h.players[id]; ok {
+		return existing, nil
+	}
+	h.players[id] = zp
+	return zp, nil
+}
+
+// Players returns every player in the household, creating those not used
+// before.
+func (h *Household) Players() ([]*ZonePlayer, error) {
+	var players []*ZonePlayer
+	for _, id := range h.UUIDs() {
+		zp, err := h.Player(id)
+		if err != nil {
+			return nil, err
+		}
+		players = append(players, zp)
+	}
+	return players, nil
+}
+
+// Coordinators returns the coordinator of every group in the household.
+func (h *Household) Coordinators() ([]*ZonePlayer, error) {
+	h.mu.Lock()
+	var ids []string
+	for _, group := range h.State.ZoneGroups {
+		ids = append(ids, group.Coordinator)
+	}
+	h.mu.Unlock()
+
+	var players []*ZonePlayer
+	for _, id := range ids {
+		zp, err := h.Player(id)
+		if err != nil {
+			return nil, err
+		}
+		players = append(players, zp)
+	}
+	return players, nil
+}
+
+// Room returns the visible player of the named room.
+func (h *Household) Room(name string) (*ZonePlayer, error) {
+	h.mu.Lock()
+	var id string
+	for _, group := range h.State.ZoneGroups {
+		for _, member := range group.ZoneGroupMember {
+			if member.ZoneName == name && member.Invisible != "1" {
+				id = member.UUID
+			}
+		}
+	}
+	h.mu.Unlock()
+
+	if id == "" {
+		return nil, fmt.Errorf("no room named %q", name)
+	}
+	return h.Player(id)
+}
+
+// location returns the device description URL of the player with the given
+// UUID.
+func (z *ZoneGroupState) location(id string) (*url.URL, error) {
+	for _, group := range z.ZoneGroups {
+		for _, member := range group.ZoneGroupMember {
+			if member.UUID == id {
+				return url.Parse(member.Location)
+			}
+			for _, satellite := range member.Satellite {
+				if satellite.UUID == id {
+					return url.Parse(satellite.Location)
+				}
+			}
+		}
+	}
+	return nil, fmt.Errorf("no player with UUID %s", id)
+}
+
+// uuid strips the "uuid:" prefix device descriptions put in front of the
+// UUIDs used by the topology.
+func uuid(udn string) string {
+	return strings.TrimPrefix(udn, "uuid:")
+}
@@ -0,0 +1,74 @@
+package sonos
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+// redirect sends every request to the fake speaker at target, whatever the
+// host asked for
+type redirect struct {
+	target *url.URL
+	hosts  []string
+}
+
+func (r *redirect) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.hosts = append(r.hosts, req.URL.Host)
+	req = req.Clone(req.Context())
+	req.URL.Scheme = r.target.Scheme
+	req.URL.Host = r.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestHouseholdPlayer(t *testing.T) {
+	srv := fakeSpeaker(t, "uuid:RINCON_KI01400", "Kitchen")
+	location, err := url.Parse(srv.URL + deviceDescriptionPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, err := NewHouseholdContext(context.Background(), location)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The players of the household are reached through its HttpClient
+	target, _ := url.Parse(srv.URL)
+	transport := &redirect{target: target}
+	h.HttpClient = &http.Client{Transport: transport}
+
+	zp, err := h.PlayerContext(context.Background(), "uuid:RINCON_KI01400")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if zp.HttpClient != h.HttpClient {
+		t.Error("Player did not get the HttpClient of the household")
+	}
+	if len(transport.hosts) != 1 || transport.hosts[0] != "192.168.1.20:1400" {
+		t.Errorf("requests = %v, want the device description of 192.168.1.20", transport.hosts)
+	}
+	if zp.Root.Device.RoomName != "Kitchen" || zp.Role != RoleMember {
+		t.Errorf("Player = %s as %s, want Kitchen as member", zp.Root.Device.RoomName, zp.Role)
+	}
+	if again, err := h.Player("RINCON_KI01400"); err != nil || again != zp {
+		t.Errorf("Player again = %p, %v, want the same player", again, err)
+	}
+
+	// Refreshing leaves the players handed out alone, so they can be read
+	// meanwhile
+	done := make(chan error)
+	go func() { done <- h.RefreshContext(context.Background()) }()
+	if zp.Role != RoleMember {
+		t.Errorf("Role = %s while refreshing, want member", zp.Role)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if role, ok := h.Role("uuid:RINCON_KI01400"); !ok || role != RoleMember {
+		t.Errorf("Household.Role = %s, %t, want member", role, ok)
+	}
+	if len(transport.hosts) != 2 {
+		t.Errorf("requests = %v, want the topology read through the HttpClient", transport.hosts)
+	}
+}
@@ -0,0 +1,54 @@
+// Package lastchange parses the values of the state variables sent in
+// LastChange events, for the decoders of the service packages. Each parser
+// returns a pointer, as the decoders leave the variables that did not change
+// nil.
+package lastchange
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Bool parses a UPnP boolean, which is 0, false or no, or 1, true or yes.
+func Bool(val string) (*bool, error) {
+	var b bool
+	switch strings.ToLower(val) {
+	case "0", "false", "no":
+	case "1", "true", "yes":
+		b = true
+	default:
+		return nil, fmt.Errorf("invalid boolean %q", val)
+	}
+	return &b, nil
+}
+
+// Uint16 parses a UPnP ui2.
+func Uint16(val string) (*uint16, error) {
+	i, err := strconv.ParseUint(val, 10, 16)
+	if err != nil {
+		return nil, err
+	}
+	v := uint16(i)
+	return &v, nil
+}
+
+// Uint32 parses a UPnP ui4.
+func Uint32(val string) (*uint32, error) {
+	i, err := strconv.ParseUint(val, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	v := uint32(i)
+	return &v, nil
+}
+
+// Int16 parses a UPnP i2.
+func Int16(val string) (*int16, error) {
+	i, err := strconv.ParseInt(val, 10, 16)
+	if err != nil {
+		return nil, err
+	}
+	v := int16(i)
+	return &v, nil
+}
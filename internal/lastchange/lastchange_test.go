@@ -0,0 +1,54 @@
+package lastchange
+
+import "testing"
+
+func TestBool(t *testing.T) {
+	tests := []struct {
+		val  string
+		want bool
+		err  bool
+	}{
+		{val: "0"},
+		{val: "false"},
+		{val: "no"},
+		{val: "1", want: true},
+		{val: "true", want: true},
+		{val: "TRUE", want: true},
+		{val: "yes", want: true},
+		{val: "", err: true},
+		{val: "maybe", err: true},
+		{val: "2", err: true},
+	}
+	for _, test := range tests {
+		b, err := Bool(test.val)
+		if test.err {
+			if err == nil {
+				t.Errorf("Bool(%q) = %v, want an error", test.val, *b)
+			}
+			continue
+		}
+		if err != nil || *b != test.want {
+			t.Errorf("Bool(%q) = %v, %v, want %v", test.val, b, err, test.want)
+		}
+	}
+}
+
+func TestIntegers(t *testing.T) {
+	if v, err := Uint16("65535"); err != nil || *v != 65535 {
+		t.Errorf("Uint16(65535) = %v, %v", v, err)
+	}
+	if v, err := Uint32("4294967295"); err != nil || *v != 4294967295 {
+		t.Errorf("Uint32(4294967295) = %v, %v", v, err)
+	}
+	if v, err := Int16("-32768"); err != nil || *v != -32768 {
+		t.Errorf("Int16(-32768) = %v, %v", v, err)
+	}
+	for _, val := range []string{"", "-1", "65536", "1.5"} {
+		if v, err := Uint16(val); err == nil {
+			t.Errorf("Uint16(%q) = %d, want an error", val, *v)
+		}
+	}
+	if v, err := Int16("32768"); err == nil {
+		t.Errorf("Int16(32768) = %d, want an error", *v)
+	}
+}
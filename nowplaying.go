@@ -0,0 +1,175 @@
+package sonos
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	avt "github.com/szatmary/sonos/AVTransport"
+	"github.com/szatmary/sonos/didl"
+)
+
+// SourceKind is where the audio a player is playing comes from.
+type SourceKind string
+
+const (
+	SourceNone  SourceKind = "none"
+	SourceQueue SourceKind = "queue"
+	SourceRadio SourceKind = "radio"
+	// SourceLineIn is the analog input of a player
+	SourceLineIn SourceKind = "line-in"
+	// SourceTV is the HDMI or optical input of a home theater player
+	SourceTV SourceKind = "tv"
+	// SourceVirtualLineIn is audio pushed to the player, e.g. by AirPlay or
+	// Spotify Connect
+	SourceVirtualLineIn SourceKind = "virtual-line-in"
+	// SourceGroup means the player follows its group coordinator
+	SourceGroup SourceKind = "group"
+	// SourceStream is a single track or stream played directly
+	SourceStream SourceKind = "stream"
+)
+
+// sourcePrefixes maps the URI schemes Sonos uses to the kind of source
+var sourcePrefixes = []struct {
+	prefix string
+	kind   SourceKind
+}{
+	{"x-rincon-queue:", SourceQueue},
+	{"x-rincon-stream:", SourceLineIn},
+	{"x-sonos-htastream:", SourceTV},
+	{"x-sonos-vli:", SourceVirtualLineIn},
+	{"x-rincon:", SourceGroup},
+	{"x-sonosapi-stream:", SourceRadio},
+	{"x-sonosapi-radio:", SourceRadio},
+	{"x-sonosapi-hls:", SourceRadio},
+	{"x-rincon-mp3radio:", SourceRadio},
+	{"aac:", SourceRadio},
+	{"hls-radio:", SourceRadio},
+}
+
+// SourceOf returns the kind of source a transport URI, such as the CurrentURI
+// of GetMediaInfo, refers to.
+func SourceOf(uri string) SourceKind {
+	if uri == "" {
+		return SourceNone
+	}
+	for _, source := range sourcePrefixes {
+		if strings.HasPrefix(uri, source.prefix) {
+			return source.kind
+		}
+	}
+	return SourceStream
+}
+
+// NowPlaying describes what a player is doing.
+type NowPlaying struct {
+	State    avt.TransportState
+	PlayMode avt.PlayMode
+	Source   SourceKind
+	// URI is the source being played, e.g. the queue, and TrackURI the
+	// current track of it
+	URI      string
+	TrackURI string
+	// Track is the position of the current track in the queue, starting at 1,
+	// and NumberOfTracks the length of the queue
+	Track          uint32
+	NumberOfTracks uint32
+
+	Title  string
+	Artist string
+	Album  string
+	// Station is the name of the radio station, and StreamContent what it is
+	// currently playing as reported by the station
+	Station       string
+	StreamContent string
+	// AlbumArtURL is absolute, or nil if there is no art
+	AlbumArtURL *url.URL
+
+	Position time.Duration
+	// Duration is 0 for streams without a length
+	Duration time.Duration
+
+	// TrackMetaData and MediaMetaData are the full metadata of the track and
+	// of the source
+	TrackMetaData *didl.Lite
+	MediaMetaData *didl.Lite
+}
+
+func (z *ZonePlayer) NowPlaying() (*NowPlaying, error) {
+	return z.NowPlayingContext(context.Background())
+}
+
+// NowPlayingContext collects the transport state, position, media and play
+// mode of the player's group from its coordinator, as only the coordinator
+// knows the play mode.
+func (z *ZonePlayer) NowPlayingContext(ctx context.Context) (*NowPlaying, error) {
+	var np *NowPlaying
+	err := z.routed(ctx, func(c *ZonePlayer) error {
+		var err error
+		np, err = c.nowPlaying(ctx)
+		return err
+	})
+	return np, err
+}
+
+func (z *ZonePlayer) nowPlaying(ctx context.Context) (*NowPlaying, error) {
+	transport, err := z.AVTransport.GetTransportInfoContext(ctx, z.HttpClient, &avt.GetTransportInfoArgs{})
+	if err != nil {
+		return nil, err
+	}
+	position, err := z.AVTransport.GetPositionInfoContext(ctx, z.HttpClient, &avt.GetPositionInfoArgs{})
+	if err != nil {
+		return nil, err
+	}
+	media, err := z.AVTransport.GetMediaInfoContext(ctx, z.HttpClient, &avt.GetMediaInfoArgs{})
+	if err != nil {
+		return nil, err
+	}
+	settings, err := z.AVTransport.GetTransportSettingsContext(ctx, z.HttpClient, &avt.GetTransportSettingsArgs{})
+	if err != nil {
+		return nil, err
+	}
+
+	np := &NowPlaying{
+		State:          transport.CurrentTransportState,
+		PlayMode:       settings.PlayMode,
+		Source:         SourceOf(media.CurrentURI),
+		URI:            media.CurrentURI,
+		TrackURI:       position.TrackURI,
+		Track:          position.Track,
+		NumberOfTracks: media.NrTracks,
+	}
+	if np.Position, err = avt.ParseDuration(position.RelTime); err != nil {
+		return nil, err
+	}
+	if np.Duration, err = avt.ParseDuration(position.TrackDuration); err != nil {
+		return nil, err
+	}
+	if np.TrackMetaData, err = didl.Decode(position.TrackMetaData); err != nil {
+		return nil, err
+	}
+	if np.MediaMetaData, err = didl.Decode(media.CurrentURIMetaData); err != nil {
+		return nil, err
+	}
+
+	if len(np.TrackMetaData.Items) > 0 {
+		track := &np.TrackMetaData.Items[0]
+		np.Title = track.Title
+		np.Artist = track.Creator
+		if np.Artist == "" {
+			np.Artist = track.Artist
+		}
+		np.Album = track.Album
+		np.StreamContent = track.StreamContent
+		if track.AlbumArtURI != "" {
+			if art, err := url.Parse(track.AlbumArtURI); err == nil {
+				np.AlbumArtURL = z.DeviceDescriptionURL.ResolveReference(art)
+			}
+		}
+	}
+	if np.Source == SourceRadio && len(np.MediaMetaData.Items) > 0 {
+		np.Station = np.MediaMetaData.Items[0].Title
+	}
+	return np, nil
+}
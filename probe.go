@@ -0,0 +1,110 @@
+package sonos
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+	"sync"
+)
+
+// maxProbeHostBits limits a single CIDR range to 65536 addresses
+const maxProbeHostBits = 16
+
+// ProbeContext looks for players by fetching the device description from
+// each target directly instead of relying on multicast. A target is an IP
+// address, a host name or a CIDR range such as "192.168.10.0/24". Players are
+// delivered on the returned channel exactly as SearchContext does, and the
+// channel is closed once every target has been tried or ctx is done.
+func (s *Sonos) ProbeContext(ctx context.Context, targets []string) (chan *ZonePlayer, error) {
+	var hosts []string
+	for _, target := range targets {
+		expanded, err := expandTarget(target)
+		if err != nil {
+			return nil, err
+		}
+		hosts = append(hosts, expanded...)
+	}
+
+	jobs := make(chan string)
+	go func() {
+		defer close(jobs)
+		for _, host := range hosts {
+			select {
+			case jobs <- host:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	found := make(chan *ZonePlayer)
+	var roles roleCache
+	var wg sync.WaitGroup
+	for i := 0; i < s.probeParallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for host := range jobs {
+				zp := s.probe(ctx, host)
+				if zp == nil {
+					continue
+				}
+				zp.Role = roles.role(ctx, zp)
+				if s.coordinatorsOnly && zp.Role != RoleCoordinator {
+					continue
+				}
+				select {
+				case found <- zp:
+				case <-ctx.Done():
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(found)
+	}()
+
+	return found, nil
+}
+
+// probe returns the player at host, or nil if there is no player there.
+func (s *Sonos) probe(ctx context.Context, host string) *ZonePlayer {
+	ctx, cancel := context.WithTimeout(ctx, s.probeTimeout)
+	defer cancel()
+
+	zp, err := NewZonePlayerContext(ctx, deviceDescriptionURL(host))
+	if err != nil || zp.Root.Device.DeviceType != st {
+		return nil
+	}
+	return zp
+}
+
+// expandTarget turns a CIDR range into the host addresses it contains. Any
+// other target is returned as is.
+func expandTarget(target string) ([]string, error) {
+	if !strings.Contains(target, "/") {
+		return []string{target}, nil
+	}
+
+	prefix, err := netip.ParsePrefix(target)
+	if err != nil {
+		return nil, err
+	}
+	prefix = prefix.Masked()
+	hostBits := prefix.Addr().BitLen() - prefix.Bits()
+	if hostBits > maxProbeHostBits {
+		return nil, fmt.Errorf("range %s is too large to probe", target)
+	}
+
+	var hosts []string
+	for addr := prefix.Addr(); prefix.Contains(addr); addr = addr.Next() {
+		hosts = append(hosts, addr.String())
+	}
+	// Skip the network and broadcast addresses of IPv4 subnets
+	if prefix.Addr().Is4() && hostBits > 1 {
+		hosts = hosts[1 : len(hosts)-1]
+	}
+	return hosts, nil
+}
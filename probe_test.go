@@ -0,0 +1,107 @@
+package sonos
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestExpandTarget(t *testing.T) {
+	tests := []struct {
+		target string
+		count  int
+		first  string
+		last   string
+		err    bool
+	}{
+		// Anything but a range is probed as is
+		{target: "192.168.1.20", count: 1, first: "192.168.1.20", last: "192.168.1.20"},
+		{target: "living-room.local", count: 1, first: "living-room.local", last: "living-room.local"},
+		// Without the network and broadcast addresses
+		{target: "192.168.1.0/24", count: 254, first: "192.168.1.1", last: "192.168.1.254"},
+		{target: "192.168.1.0/30", count: 2, first: "192.168.1.1", last: "192.168.1.2"},
+		// Masked to the network first
+		{target: "192.168.1.77/30", count: 2, first: "192.168.1.77", last: "192.168.1.78"},
+		// Point to point and single host ranges have neither
+		{target: "192.168.1.4/31", count: 2, first: "192.168.1.4", last: "192.168.1.5"},
+		{target: "192.168.1.7/32", count: 1, first: "192.168.1.7", last: "192.168.1.7"},
+		// Up to a /16
+		{target: "10.1.0.0/16", count: 65534, first: "10.1.0.1", last: "10.1.255.254"},
+		{target: "10.0.0.0/15", err: true},
+		{target: "0.0.0.0/0", err: true},
+		// IPv6 has no broadcast address
+		{target: "fd00::/126", count: 4, first: "fd00::", last: "fd00::3"},
+		{target: "fd00::/112", count: 65536, first: "fd00::", last: "fd00::ffff"},
+		{target: "fd00::/64", err: true},
+		// Bad input
+		{target: "192.168.1.0/33", err: true},
+		{target: "192.168.1.0/", err: true},
+		{target: "speakers/24", err: true},
+		{target: "192.168.1/24", err: true},
+	}
+	for _, test := range tests {
+		hosts, err := expandTarget(test.target)
+		if test.err {
+			if err == nil {
+				t.Errorf("expandTarget(%q) = %d hosts, want an error", test.target, len(hosts))
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("expandTarget(%q) = %v", test.target, err)
+			continue
+		}
+		if len(hosts) != test.count || hosts[0] != test.first || hosts[len(hosts)-1] != test.last {
+			t.Errorf("expandTarget(%q) = %d hosts from %s to %s, want %d from %s to %s",
+				test.target, len(hosts), hosts[0], hosts[len(hosts)-1], test.count, test.first, test.last)
+		}
+	}
+}
+
+func TestProbeParallelism(t *testing.T) {
+	// Probes always go to the player port
+	l, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", devicePort))
+	if err != nil {
+		t.Skipf("player port not available: %v", err)
+	}
+	var mu sync.Mutex
+	var probes, probing, most int
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		probes++
+		probing++
+		if probing > most {
+			most = probing
+		}
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		probing--
+		mu.Unlock()
+		http.NotFound(w, r)
+	})}
+	go srv.Serve(l)
+	defer srv.Close()
+
+	targets := make([]string, 12)
+	for i := range targets {
+		targets[i] = "127.0.0.1"
+	}
+	s := &Sonos{probeParallelism: 3, probeTimeout: 2 * time.Second}
+	found, err := s.ProbeContext(context.Background(), targets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for zp := range found {
+		t.Errorf("found %+v, want nothing", zp)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if probes != len(targets) || most > 3 {
+		t.Errorf("%d probes, at most %d at once, want %d, at most 3", probes, most, len(targets))
+	}
+}
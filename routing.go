@@ -0,0 +1,181 @@
+package sonos
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	avt "github.com/szatmary/sonos/AVTransport"
+	ren "github.com/szatmary/sonos/RenderingControl"
+	zgt "github.com/szatmary/sonos/ZoneGroupTopology"
+	"github.com/szatmary/sonos/didl"
+	"github.com/szatmary/sonos/soap"
+)
+
+// coordinatorTTL is how long the coordinator found for a player is trusted
+// before the topology is read again. A stale coordinator is noticed anyway,
+// as it refuses the commands with error 800.
+const coordinatorTTL = 5 * time.Second
+
+// errNotCoordinator is the UPnP error players return for transport and queue
+// commands sent to a group member instead of its coordinator
+const errNotCoordinator = 800
+
+type coordinatorCache struct {
+	mu      sync.Mutex
+	player  *ZonePlayer
+	expires time.Time
+}
+
+func (z *ZonePlayer) Coordinator() (*ZonePlayer, error) {
+	return z.CoordinatorContext(context.Background())
+}
+
+// CoordinatorContext returns the coordinator of the player's group, read from
+// its current ZoneGroupState. For a satellite it is the coordinator of the
+// group of its home theater. It is z itself if z coordinates its group, is
+// not part of the topology, or has NoCoordinatorRouting set.
+func (z *ZonePlayer) CoordinatorContext(ctx context.Context) (*ZonePlayer, error) {
+	return z.coordinator(ctx, true)
+}
+
+func (z *ZonePlayer) coordinator(ctx context.Context, refresh bool) (*ZonePlayer, error) {
+	if z.NoCoordinatorRouting {
+		return z, nil
+	}
+
+	cache := &z.coordinatorCache
+	cache.mu.Lock()
+	cached, expires := cache.player, cache.expires
+	cache.mu.Unlock()
+	if !refresh && cached != nil && time.Now().Before(expires) {
+		return cached, nil
+	}
+
+	// The cache is not held while the coordinator is looked up, so a slow
+	// player does not hold up the commands that can use the cached one
+	state, err := z.GetZoneGroupStateContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	self := uuid(z.Root.Device.UDN)
+	coordinator := self
+	for _, group := range state.ZoneGroups {
+		for _, member := range group.ZoneGroupMember {
+			if member.UUID == self {
+				coordinator = group.Coordinator
+			}
+			// Satellites take no transport commands of their own, they
+			// play what the group of their home theater plays
+			for _, satellite := range member.Satellite {
+				if satellite.UUID == self {
+					coordinator = group.Coordinator
+				}
+			}
+		}
+	}
+
+	player := z
+	switch {
+	case coordinator == self:
+	case cached != nil && uuid(cached.Root.Device.UDN) == coordinator:
+		player = cached
+	default:
+		location, err := state.location(coordinator)
+		if err != nil {
+			return nil, err
+		}
+		player, err = newZonePlayer(ctx, z.HttpClient, location)
+		if err != nil {
+			return nil, err
+		}
+		z.shareClients(player)
+		player.Role = RoleCoordinator
+		player.NoCoordinatorRouting = true
+	}
+
+	cache.mu.Lock()
+	cache.player = player
+	cache.expires = time.Now().Add(coordinatorTTL)
+	cache.mu.Unlock()
+	return player, nil
+}
+
+// shareClients makes player, the coordinator found for z, send its actions
+// the way z does. The services z has replaced, e.g. with Fakes, replace those
+// of player, and its generated services use the soap.Client of z's
+// AVTransport.
+func (z *ZonePlayer) shareClients(player *ZonePlayer) {
+	if service, ok := z.AVTransport.(*avt.Service); ok {
+		if service != nil {
+			player.SetSOAPClient(service.Client)
+		}
+	} else if z.AVTransport != nil {
+		player.AVTransport = z.AVTransport
+	}
+	if _, ok := z.RenderingControl.(*ren.Service); !ok && z.RenderingControl != nil {
+		player.RenderingControl = z.RenderingControl
+	}
+	if _, ok := z.ZoneGroupTopology.(*zgt.Service); !ok && z.ZoneGroupTopology != nil {
+		player.ZoneGroupTopology = z.ZoneGroupTopology
+	}
+}
+
+// routed runs call on the coordinator of the player's group. If the
+// coordinator turns out to have changed, the topology is read again and call
+// is retried once.
+func (z *ZonePlayer) routed(ctx context.Context, call func(c *ZonePlayer) error) error {
+	c, err := z.coordinator(ctx, false)
+	if err != nil {
+		return err
+	}
+	err = call(c)
+	var upnpErr *soap.UPnPError
+	if z.NoCoordinatorRouting || !errors.As(err, &upnpErr) || upnpErr.Code != errNotCoordinator {
+		return err
+	}
+	if c, err = z.coordinator(ctx, true); err != nil {
+		return err
+	}
+	return call(c)
+}
+
+func (z *ZonePlayer) AddURIToQueue(uri string, metadata *didl.Lite) (int, error) {
+	return z.AddURIToQueueContext(context.Background(), uri, metadata)
+}
+
+// AddURIToQueueContext appends uri, described by metadata which may be nil,
+// to the queue of the player's group, and returns the position of the first
+// track added.
+func (z *ZonePlayer) AddURIToQueueContext(ctx context.Context, uri string, metadata *didl.Lite) (int, error) {
+	encoded, err := didl.Encode(metadata)
+	if err != nil {
+		return 0, err
+	}
+	var first int
+	err = z.routed(ctx, func(c *ZonePlayer) error {
+		res, err := c.AVTransport.AddURIToQueueContext(ctx, c.HttpClient, &avt.AddURIToQueueArgs{
+			EnqueuedURI:         uri,
+			EnqueuedURIMetaData: encoded,
+		})
+		if err != nil {
+			return err
+		}
+		first = int(res.FirstTrackNumberEnqueued)
+		return nil
+	})
+	return first, err
+}
+
+func (z *ZonePlayer) ClearQueue() error {
+	return z.ClearQueueContext(context.Background())
+}
+
+// ClearQueueContext removes every track from the queue of the player's group.
+func (z *ZonePlayer) ClearQueueContext(ctx context.Context) error {
+	return z.routed(ctx, func(c *ZonePlayer) error {
+		_, err := c.AVTransport.RemoveAllTracksFromQueueContext(ctx, c.HttpClient, &avt.RemoveAllTracksFromQueueArgs{})
+		return err
+	})
+}
@@ -0,0 +1,191 @@
+package soap
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	EncodingStyle     = "http://schemas.xmlsoap.org/soap/encoding/"
+	EnvelopeNamespace = "http://schemas.xmlsoap.org/soap/envelope/"
+)
+
+// Exchange describes a finished call to OnResponse hooks.
+type Exchange struct {
+	ServiceURN   string
+	Action       string
+	Request      *http.Request
+	RequestBody  []byte
+	Response     *http.Response // nil if no response was received
+	ResponseBody []byte
+	Duration     time.Duration
+	Err          error
+}
+
+// Client sends actions to UPnP control endpoints. The generated service
+// packages send every action through one, so anything set here applies to
+// all services alike.
+type Client struct {
+	// Transport, if set, replaces the transport of the http.Client passed to
+	// Call, e.g. to add retries
+	Transport http.RoundTripper
+	// OnRequest, if set, is called with every request before it is sent
+	OnRequest func(req *http.Request)
+	// OnResponse, if set, is called once every call has finished
+	OnResponse func(exchange *Exchange)
+	// DisableValidation skips validating arguments before sending them
+	DisableValidation bool
+}
+
+// DefaultClient is used by services that have no Client of their own.
+var DefaultClient = &Client{}
+
+type envelope struct {
+	XMLName       xml.Name `xml:"s:Envelope"`
+	Xmlns         string   `xml:"xmlns:s,attr"`
+	EncodingStyle string   `xml:"s:encodingStyle,attr"`
+	Body          body     `xml:"s:Body"`
+}
+
+type body struct {
+	Action action
+}
+
+// action marshals the arguments of an action as <u:Name xmlns:u="urn">.
+type action struct {
+	serviceURN string
+	name       string
+	args       interface{}
+}
+
+func (a action) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	return e.EncodeElement(a.args, xml.StartElement{
+		Name: xml.Name{Local: "u:" + a.name},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns:u"}, Value: a.serviceURN}},
+	})
+}
+
+type envelopeResponse struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Body    struct {
+		Fault   *Fault `xml:"Fault"`
+		Content []byte `xml:",innerxml"`
+	} `xml:"Body"`
+}
+
+// Call sends the action of the service with the given URN to endpoint using
+// httpClient, or http.DefaultClient if it is nil, and decodes the response
+// into reply. Unless validation is disabled, args is validated first if it
+// is a Validator. A nil Client behaves like DefaultClient.
+func (c *Client) Call(ctx context.Context, httpClient *http.Client, endpoint *url.URL, serviceURN, actionName string, args, reply interface{}) error {
+	if c == nil {
+		c = DefaultClient
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if c.Transport != nil {
+		withTransport := *httpClient
+		withTransport.Transport = c.Transport
+		httpClient = &withTransport
+	}
+
+	exchange := Exchange{
+		ServiceURN: serviceURN,
+		Action:     actionName,
+	}
+	start := time.Now()
+	exchange.Err = c.call(ctx, httpClient, endpoint, args, reply, &exchange)
+	exchange.Duration = time.Since(start)
+	if c.OnResponse != nil {
+		c.OnResponse(&exchange)
+	}
+	return exchange.Err
+}
+
+func (c *Client) call(ctx context.Context, httpClient *http.Client, endpoint *url.URL, args, reply interface{}, exchange *Exchange) error {
+	if validator, ok := args.(Validator); ok && !c.DisableValidation {
+		if err := validator.Validate(); err != nil {
+			return err
+		}
+	}
+
+	marshaled, err := xml.Marshal(&envelope{
+		Xmlns:         EnvelopeNamespace,
+		EncodingStyle: EncodingStyle,
+		Body: body{Action: action{
+			serviceURN: exchange.ServiceURN,
+			name:       exchange.Action,
+			args:       args,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	postBody := []byte(`<?xml version="1.0"?>`)
+	postBody = append(postBody, marshaled...)
+	exchange.RequestBody = postBody
+
+	req, err := http.NewRequestWithContext(ctx, `POST`, endpoint.String(), bytes.NewReader(postBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(`Content-Type`, `text/xml; charset="utf-8"`)
+	req.Header.Set(`SOAPAction`, exchange.ServiceURN+`#`+exchange.Action)
+	exchange.Request = req
+	if c.OnRequest != nil {
+		c.OnRequest(req)
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	exchange.Response = res
+	responseBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	exchange.ResponseBody = responseBody
+
+	var envelopeResponse envelopeResponse
+	err = xml.Unmarshal(responseBody, &envelopeResponse)
+	if err == nil && envelopeResponse.Body.Fault != nil {
+		return envelopeResponse.Body.Fault.Err(exchange.ServiceURN, exchange.Action)
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf(`unexpected HTTP status %s calling %s.%s()`, res.Status, serviceName(exchange.ServiceURN), exchange.Action)
+	}
+	if err != nil {
+		return err
+	}
+	return decodeResponse(envelopeResponse.Body.Content, exchange.ServiceURN, exchange.Action, reply)
+}
+
+// decodeResponse finds the <ActionResponse> element in the body of a
+// response envelope and decodes it into reply.
+func decodeResponse(content []byte, serviceURN, actionName string, reply interface{}) error {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return fmt.Errorf(`unexpected response from service calling %s.%s()`, serviceName(serviceURN), actionName)
+		}
+		if err != nil {
+			return err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			if start.Name.Local != actionName+"Response" {
+				return fmt.Errorf(`unexpected response <%s> from service calling %s.%s()`, start.Name.Local, serviceName(serviceURN), actionName)
+			}
+			return decoder.DecodeElement(reply, &start)
+		}
+	}
+}
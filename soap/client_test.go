@@ -0,0 +1,253 @@
+package soap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const volumeServiceURN = "urn:schemas-upnp-org:service:RenderingControl:1"
+
+type setVolumeArgs struct {
+	InstanceID    uint32 `xml:"InstanceID"`
+	Channel       string `xml:"Channel"`
+	DesiredVolume uint16 `xml:"DesiredVolume"`
+
+	invalid error
+}
+
+func (a *setVolumeArgs) Validate() error {
+	return a.invalid
+}
+
+type getVolumeResponse struct {
+	CurrentVolume uint16 `xml:"CurrentVolume"`
+}
+
+// received is what the fake service keeps of a request
+type received struct {
+	method     string
+	soapAction string
+	mediaType  string
+	body       string
+}
+
+// fakeService answers every action with status and body, after waiting for
+// release if it is set. It returns the endpoint, and a function returning the
+// requests received.
+func fakeService(t *testing.T, status int, body string, release chan struct{}) (*url.URL, func() []received) {
+	t.Helper()
+	var mu sync.Mutex
+	var requests []received
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestBody, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, received{
+			method:     r.Method,
+			soapAction: r.Header.Get("SOAPACTION"),
+			mediaType:  r.Header.Get("Content-Type"),
+			body:       string(requestBody),
+		})
+		mu.Unlock()
+		if release != nil {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+				return
+			}
+		}
+		w.WriteHeader(status)
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	endpoint, err := url.Parse(srv.URL + "/MediaRenderer/RenderingControl/Control")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return endpoint, func() []received {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]received(nil), requests...)
+	}
+}
+
+func responseEnvelope(content string) string {
+	return `<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/"><s:Body>` +
+		content + `</s:Body></s:Envelope>`
+}
+
+func TestCall(t *testing.T) {
+	endpoint, requests := fakeService(t, http.StatusOK,
+		responseEnvelope(`<u:SetVolumeResponse xmlns:u="`+volumeServiceURN+`"><CurrentVolume>23</CurrentVolume></u:SetVolumeResponse>`), nil)
+
+	var reply getVolumeResponse
+	args := &setVolumeArgs{Channel: "Master", DesiredVolume: 23}
+	if err := (&Client{}).Call(context.Background(), nil, endpoint, volumeServiceURN, "SetVolume", args, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply.CurrentVolume != 23 {
+		t.Errorf("reply = %+v, want CurrentVolume 23", reply)
+	}
+
+	if len(requests()) != 1 {
+		t.Fatalf("requests = %+v, want one", requests())
+	}
+	req := requests()[0]
+	if req.method != http.MethodPost || req.soapAction != volumeServiceURN+"#SetVolume" || req.mediaType != `text/xml; charset="utf-8"` {
+		t.Errorf("request = %s with SOAPACTION %q and Content-Type %q", req.method, req.soapAction, req.mediaType)
+	}
+	want := `<?xml version="1.0"?>` +
+		`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/"><s:Body>` +
+		`<u:SetVolume xmlns:u="` + volumeServiceURN + `"><InstanceID>0</InstanceID><Channel>Master</Channel><DesiredVolume>23</DesiredVolume></u:SetVolume>` +
+		`</s:Body></s:Envelope>`
+	if req.body != want {
+		t.Errorf("request body =\n%s\nwant\n%s", req.body, want)
+	}
+}
+
+type recordingTransport struct {
+	order *[]string
+}
+
+func (rt recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	*rt.order = append(*rt.order, "transport "+req.Header.Get("X-Request-Hook"))
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestCallHooks(t *testing.T) {
+	endpoint, _ := fakeService(t, http.StatusOK,
+		responseEnvelope(`<u:SetVolumeResponse xmlns:u="`+volumeServiceURN+`"/>`), nil)
+
+	var order []string
+	var exchange *Exchange
+	client := &Client{
+		Transport: recordingTransport{&order},
+		OnRequest: func(req *http.Request) {
+			order = append(order, "request")
+			req.Header.Set("X-Request-Hook", "seen")
+		},
+		OnResponse: func(e *Exchange) {
+			order = append(order, "response")
+			exchange = e
+		},
+	}
+	// The transport of the client passed to Call is replaced, not modified
+	httpClient := &http.Client{}
+	if err := client.Call(context.Background(), httpClient, endpoint, volumeServiceURN, "SetVolume", &setVolumeArgs{}, &getVolumeResponse{}); err != nil {
+		t.Fatal(err)
+	}
+	if httpClient.Transport != nil {
+		t.Error("Call changed the transport of the http.Client passed to it")
+	}
+
+	if want := []string{"request", "transport seen", "response"}; fmt.Sprint(order) != fmt.Sprint(want) {
+		t.Errorf("hooks called as %q, want %q", order, want)
+	}
+	if exchange == nil {
+		t.Fatal("OnResponse not called")
+	}
+	if exchange.ServiceURN != volumeServiceURN || exchange.Action != "SetVolume" || exchange.Err != nil ||
+		exchange.Request == nil || exchange.Response == nil || exchange.Response.StatusCode != http.StatusOK ||
+		!strings.Contains(string(exchange.RequestBody), "<u:SetVolume ") ||
+		!strings.Contains(string(exchange.ResponseBody), "<u:SetVolumeResponse ") ||
+		exchange.Duration <= 0 {
+		t.Errorf("exchange = %+v", exchange)
+	}
+}
+
+func TestCallFault(t *testing.T) {
+	endpoint, _ := fakeService(t, http.StatusInternalServerError, fmt.Sprintf(sonosFault, "402", ""), nil)
+	var exchange *Exchange
+	client := &Client{OnResponse: func(e *Exchange) { exchange = e }}
+
+	err := client.Call(context.Background(), nil, endpoint, volumeServiceURN, "SetVolume", &setVolumeArgs{}, &getVolumeResponse{})
+	var upnpErr *UPnPError
+	if !errors.As(err, &upnpErr) || upnpErr.Code != 402 || upnpErr.Action != "SetVolume" || upnpErr.Description != "Invalid Args" {
+		t.Errorf("Call = %v, want UPnP error 402", err)
+	}
+	if exchange == nil || exchange.Err != err || exchange.Response.StatusCode != http.StatusInternalServerError {
+		t.Errorf("exchange = %+v, want the fault", exchange)
+	}
+}
+
+func TestCallErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"status", http.StatusNotFound, "not found"},
+		{"not xml", http.StatusOK, "not xml"},
+		{"other response", http.StatusOK, responseEnvelope(`<u:GetVolumeResponse xmlns:u="` + volumeServiceURN + `"/>`)},
+		{"empty body", http.StatusOK, responseEnvelope("")},
+	}
+	for _, test := range tests {
+		endpoint, _ := fakeService(t, test.status, test.body, nil)
+		if err := (&Client{}).Call(context.Background(), nil, endpoint, volumeServiceURN, "SetVolume", &setVolumeArgs{}, &getVolumeResponse{}); err == nil {
+			t.Errorf("%s: Call succeeded, want an error", test.name)
+		}
+	}
+}
+
+func TestCallValidation(t *testing.T) {
+	endpoint, requests := fakeService(t, http.StatusOK,
+		responseEnvelope(`<u:SetVolumeResponse xmlns:u="`+volumeServiceURN+`"/>`), nil)
+	invalid := &ArgumentError{ServiceURN: volumeServiceURN, Action: "SetVolume", Argument: "DesiredVolume", Value: 300, Allowed: "0 to 100"}
+	args := &setVolumeArgs{DesiredVolume: 300, invalid: invalid}
+
+	var exchange *Exchange
+	client := &Client{OnResponse: func(e *Exchange) { exchange = e }}
+	if err := client.Call(context.Background(), nil, endpoint, volumeServiceURN, "SetVolume", args, &getVolumeResponse{}); err != invalid {
+		t.Errorf("Call = %v, want the validation error", err)
+	}
+	if len(requests()) != 0 {
+		t.Errorf("invalid arguments sent: %+v", requests())
+	}
+	if exchange == nil || exchange.Err != invalid || exchange.Request != nil {
+		t.Errorf("exchange = %+v, want the validation error without a request", exchange)
+	}
+
+	client.DisableValidation = true
+	if err := client.Call(context.Background(), nil, endpoint, volumeServiceURN, "SetVolume", args, &getVolumeResponse{}); err != nil {
+		t.Errorf("Call without validation = %v", err)
+	}
+	if len(requests()) != 1 {
+		t.Errorf("requests = %+v, want the arguments sent", requests())
+	}
+}
+
+func TestCallCancel(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	endpoint, requests := fakeService(t, http.StatusOK,
+		responseEnvelope(`<u:SetVolumeResponse xmlns:u="`+volumeServiceURN+`"/>`), release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		// A nil Client behaves like DefaultClient
+		var client *Client
+		done <- client.Call(ctx, nil, endpoint, volumeServiceURN, "SetVolume", &setVolumeArgs{}, &getVolumeResponse{})
+	}()
+	for deadline := time.Now().Add(2 * time.Second); len(requests()) == 0 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Call = %v, want context.Canceled", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Call not cancelled")
+	}
+}
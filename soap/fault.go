@@ -0,0 +1,111 @@
+// Package soap holds what the generated UPnP service packages share.
+package soap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrorCodes describes the error codes the UPnP Device Architecture defines
+// for every service.
+var ErrorCodes = map[int]string{
+	401: "Invalid Action",
+	402: "Invalid Args",
+	403: "Out of Sync",
+	501: "Action Failed",
+	600: "Argument Value Invalid",
+	601: "Argument Value Out of Range",
+	602: "Optional Action Not Implemented",
+	603: "Out of Memory",
+	604: "Human Intervention Required",
+	605: "String Argument Too Long",
+	606: "Action not authorized",
+	607: "Signature failure",
+	608: "Signature missing",
+	609: "Not encrypted",
+	610: "Invalid sequence",
+	611: "Invalid control URL",
+	612: "No such session",
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]map[int]string)
+)
+
+// RegisterErrorCodes makes UPnPError describe the service specific codes of
+// the service with the given URN. Generated packages call it from init.
+func RegisterErrorCodes(serviceURN string, codes map[int]string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[serviceURN] = codes
+}
+
+// Describe returns the description of code for the service with the given
+// URN, or "" if the code is unknown.
+func Describe(serviceURN string, code int) string {
+	registryMu.RLock()
+	description, ok := registry[serviceURN][code]
+	registryMu.RUnlock()
+	if ok {
+		return description
+	}
+	return ErrorCodes[code]
+}
+
+// UPnPError is the error returned when a device rejects an action with a
+// UPnP error code. Use errors.As to inspect it.
+type UPnPError struct {
+	ServiceURN  string
+	Action      string
+	Code        int
+	Description string
+}
+
+func (e *UPnPError) Error() string {
+	return fmt.Sprintf("%s.%s(): UPnP error %d: %s", serviceName(e.ServiceURN), e.Action, e.Code, e.Description)
+}
+
+// serviceName returns the lower case service name of a service URN such as
+// "urn:schemas-upnp-org:service:AVTransport:1".
+func serviceName(serviceURN string) string {
+	parts := strings.Split(serviceURN, ":")
+	if len(parts) < 2 {
+		return serviceURN
+	}
+	return strings.ToLower(parts[len(parts)-2])
+}
+
+// Fault is the body of a SOAP fault response.
+type Fault struct {
+	XMLName     xml.Name `xml:"Fault"`
+	FaultCode   string   `xml:"faultcode"`
+	FaultString string   `xml:"faultstring"`
+	Detail      struct {
+		UPnPError *struct {
+			ErrorCode        int    `xml:"errorCode"`
+			ErrorDescription string `xml:"errorDescription"`
+		} `xml:"UPnPError"`
+	} `xml:"detail"`
+}
+
+// Err returns the error the fault reports for the given action. It is a
+// *UPnPError if the fault carries a UPnP error code.
+func (f *Fault) Err(serviceURN, action string) error {
+	if f.Detail.UPnPError == nil {
+		return fmt.Errorf("%s.%s(): SOAP fault %s: %s", serviceName(serviceURN), action, f.FaultCode, f.FaultString)
+	}
+
+	description := f.Detail.UPnPError.ErrorDescription
+	if description == "" {
+		description = Describe(serviceURN, f.Detail.UPnPError.ErrorCode)
+	}
+	return &UPnPError{
+		ServiceURN:  serviceURN,
+		Action:      action,
+		Code:        f.Detail.UPnPError.ErrorCode,
+		Description: description,
+	}
+}
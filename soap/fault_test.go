@@ -0,0 +1,114 @@
+package soap
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// A fault as Sonos players send it, without an errorDescription
+const sonosFault = `<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">` +
+	`<s:Body><s:Fault><faultcode>s:Client</faultcode><faultstring>UPnPError</faultstring>` +
+	`<detail><UPnPError xmlns="urn:schemas-upnp-org:control-1-0"><errorCode>%s</errorCode>%s</UPnPError></detail>` +
+	`</s:Fault></s:Body></s:Envelope>`
+
+const testServiceURN = "urn:schemas-upnp-org:service:FaultTest:1"
+
+func decodeFault(t *testing.T, envelope string) *Fault {
+	t.Helper()
+	var response envelopeResponse
+	if err := xml.Unmarshal([]byte(envelope), &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Body.Fault == nil {
+		t.Fatalf("no fault decoded from %s", envelope)
+	}
+	return response.Body.Fault
+}
+
+func TestFaultErr(t *testing.T) {
+	RegisterErrorCodes(testServiceURN, map[int]string{
+		701: "Transition not available",
+		// Overrides the code of the UPnP Device Architecture
+		402: "Invalid Args for FaultTest",
+	})
+
+	tests := []struct {
+		code        string
+		description string
+		want        UPnPError
+	}{
+		// Described by the device
+		{"701", "<errorDescription>Not now</errorDescription>", UPnPError{Code: 701, Description: "Not now"}},
+		// Described by the codes of the service
+		{"701", "", UPnPError{Code: 701, Description: "Transition not available"}},
+		{"402", "", UPnPError{Code: 402, Description: "Invalid Args for FaultTest"}},
+		// Described by the UPnP Device Architecture
+		{"501", "", UPnPError{Code: 501, Description: "Action Failed"}},
+		// Unknown
+		{"999", "", UPnPError{Code: 999}},
+	}
+	for _, test := range tests {
+		fault := decodeFault(t, fmt.Sprintf(sonosFault, test.code, test.description))
+		if fault.FaultCode != "s:Client" || fault.FaultString != "UPnPError" {
+			t.Errorf("fault = %s: %s, want s:Client: UPnPError", fault.FaultCode, fault.FaultString)
+		}
+
+		err := fault.Err(testServiceURN, "Play")
+		var upnpErr *UPnPError
+		if !errors.As(err, &upnpErr) {
+			t.Errorf("Err(%s) = %v, want a *UPnPError", test.code, err)
+			continue
+		}
+		test.want.ServiceURN = testServiceURN
+		test.want.Action = "Play"
+		if *upnpErr != test.want {
+			t.Errorf("Err(%s) = %+v, want %+v", test.code, *upnpErr, test.want)
+		}
+	}
+}
+
+func TestFaultErrWithoutUPnPError(t *testing.T) {
+	fault := decodeFault(t, `<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body>`+
+		`<s:Fault><faultcode>s:Server</faultcode><faultstring>Internal Error</faultstring></s:Fault>`+
+		`</s:Body></s:Envelope>`)
+	err := fault.Err(testServiceURN, "Play")
+	var upnpErr *UPnPError
+	if err == nil || errors.As(err, &upnpErr) {
+		t.Errorf("Err = %#v, want a plain error", err)
+	}
+	if want := "faulttest.Play(): SOAP fault s:Server: Internal Error"; err.Error() != want {
+		t.Errorf("Err = %q, want %q", err, want)
+	}
+}
+
+func TestUPnPErrorString(t *testing.T) {
+	err := &UPnPError{ServiceURN: "urn:schemas-upnp-org:service:AVTransport:1", Action: "Seek", Code: 711, Description: "Illegal seek target"}
+	if want := "avtransport.Seek(): UPnP error 711: Illegal seek target"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err, want)
+	}
+	if got := serviceName("not a urn"); got != "not a urn" {
+		t.Errorf("serviceName(not a urn) = %q", got)
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	const serviceURN = "urn:schemas-upnp-org:service:DescribeTest:1"
+	RegisterErrorCodes(serviceURN, map[int]string{714: "Illegal MIME-type"})
+	tests := []struct {
+		serviceURN string
+		code       int
+		want       string
+	}{
+		{serviceURN, 714, "Illegal MIME-type"},
+		{serviceURN, 401, "Invalid Action"},
+		{"urn:schemas-upnp-org:service:Unregistered:1", 714, ""},
+		{"urn:schemas-upnp-org:service:Unregistered:1", 606, "Action not authorized"},
+	}
+	for _, test := range tests {
+		if got := Describe(test.serviceURN, test.code); got != test.want {
+			t.Errorf("Describe(%s, %d) = %q, want %q", test.serviceURN, test.code, got, test.want)
+		}
+	}
+}
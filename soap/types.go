@@ -0,0 +1,181 @@
+package soap
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The types below carry UPnP data types that encoding/xml cannot put on the
+// wire correctly on its own. Convert them to and from their underlying Go
+// types freely.
+
+// Char is a UPnP char, a single Unicode character.
+type Char rune
+
+func (c Char) MarshalText() ([]byte, error) {
+	return []byte(string(rune(c))), nil
+}
+
+func (c *Char) UnmarshalText(text []byte) error {
+	runes := []rune(string(text))
+	if len(runes) != 1 {
+		return fmt.Errorf("char %q is not a single character", text)
+	}
+	*c = Char(runes[0])
+	return nil
+}
+
+// Base64 is UPnP bin.base64, binary data sent in base64.
+type Base64 []byte
+
+func (b Base64) MarshalText() ([]byte, error) {
+	return []byte(base64.StdEncoding.EncodeToString(b)), nil
+}
+
+func (b *Base64) UnmarshalText(text []byte) error {
+	decoded, err := base64.StdEncoding.DecodeString(string(text))
+	if err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+// Hex is UPnP bin.hex, binary data sent in hexadecimal.
+type Hex []byte
+
+func (h Hex) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(h)), nil
+}
+
+func (h *Hex) UnmarshalText(text []byte) error {
+	decoded, err := hex.DecodeString(string(text))
+	if err != nil {
+		return err
+	}
+	*h = decoded
+	return nil
+}
+
+const (
+	dateLayout       = "2006-01-02"
+	dateTimeLayout   = "2006-01-02T15:04:05"
+	dateTimeTZLayout = "2006-01-02T15:04:05Z07:00"
+	timeTZLayout     = "15:04:05Z07:00"
+)
+
+// Date is a UPnP date such as 2006-01-02.
+type Date time.Time
+
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(time.Time(d).Format(dateLayout)), nil
+}
+
+func (d *Date) UnmarshalText(text []byte) error {
+	return parseTime((*time.Time)(d), dateLayout, text)
+}
+
+// DateTime is a UPnP dateTime, a date and time without a zone.
+type DateTime time.Time
+
+func (d DateTime) MarshalText() ([]byte, error) {
+	return []byte(time.Time(d).Format(dateTimeLayout)), nil
+}
+
+func (d *DateTime) UnmarshalText(text []byte) error {
+	return parseTime((*time.Time)(d), dateTimeLayout, text)
+}
+
+// DateTimeTZ is a UPnP dateTime.tz, a date and time with an optional zone.
+type DateTimeTZ time.Time
+
+func (d DateTimeTZ) MarshalText() ([]byte, error) {
+	return []byte(time.Time(d).Format(dateTimeTZLayout)), nil
+}
+
+func (d *DateTimeTZ) UnmarshalText(text []byte) error {
+	if err := parseTime((*time.Time)(d), dateTimeTZLayout, text); err == nil {
+		return nil
+	}
+	return parseTime((*time.Time)(d), dateTimeLayout, text)
+}
+
+// Time is a UPnP time, a time of day given as the time since midnight.
+type Time time.Duration
+
+func (t Time) MarshalText() ([]byte, error) {
+	d := time.Duration(t)
+	return []byte(fmt.Sprintf("%02d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)), nil
+}
+
+func (t *Time) UnmarshalText(text []byte) error {
+	d, err := ParseHMS(string(text))
+	if err != nil {
+		return fmt.Errorf("time %v", err)
+	}
+	*t = Time(d)
+	return nil
+}
+
+// ParseHMS parses a time given as H:MM:SS, the form of the UPnP time type and
+// of the times and durations of the AVTransport service. The hours may take
+// more than two digits, a fraction of a second may follow the seconds, and a
+// single sign may come first.
+func ParseHMS(s string) (time.Duration, error) {
+	negative := false
+	unsigned := s
+	switch {
+	case strings.HasPrefix(s, "-"):
+		negative, unsigned = true, s[1:]
+	case strings.HasPrefix(s, "+"):
+		unsigned = s[1:]
+	}
+	fields := strings.Split(unsigned, ":")
+	if len(fields) != 3 {
+		return 0, fmt.Errorf("%q is not H:MM:SS", s)
+	}
+	hours, err := strconv.ParseUint(fields[0], 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%q has bad hours", s)
+	}
+	minutes, err := strconv.ParseUint(fields[1], 10, 8)
+	if err != nil || minutes > 59 {
+		return 0, fmt.Errorf("%q has bad minutes", s)
+	}
+	// Only digits, ParseFloat would also take signs, exponents and Inf
+	whole, fraction, _ := strings.Cut(fields[2], ".")
+	seconds, err := strconv.ParseFloat(fields[2], 64)
+	if err != nil || whole == "" || strings.Trim(whole+fraction, "0123456789") != "" || seconds >= 60 {
+		return 0, fmt.Errorf("%q has bad seconds", s)
+	}
+	d := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds*float64(time.Second))
+	if negative {
+		d = -d
+	}
+	return d, nil
+}
+
+// TimeTZ is a UPnP time.tz, a time of day with a zone. Only the clock and
+// zone of the time.Time are used.
+type TimeTZ time.Time
+
+func (t TimeTZ) MarshalText() ([]byte, error) {
+	return []byte(time.Time(t).Format(timeTZLayout)), nil
+}
+
+func (t *TimeTZ) UnmarshalText(text []byte) error {
+	return parseTime((*time.Time)(t), timeTZLayout, text)
+}
+
+func parseTime(t *time.Time, layout string, text []byte) error {
+	parsed, err := time.Parse(layout, string(text))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
@@ -0,0 +1,170 @@
+package soap
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/xml"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTypesRoundTrip(t *testing.T) {
+	plus2 := time.FixedZone("", 2*60*60)
+	tests := []struct {
+		name  string
+		value encoding.TextMarshaler
+		text  string
+		// decoded is a pointer to a zero value of the type of value
+		decoded encoding.TextUnmarshaler
+	}{
+		{"Char", Char('é'), "é", new(Char)},
+		{"Base64", Base64("hello\x00"), "aGVsbG8A", new(Base64)},
+		{"Hex", Hex{0xde, 0xad, 0xbe, 0xef}, "deadbeef", new(Hex)},
+		{"Date", Date(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)), "2024-02-29", new(Date)},
+		{"DateTime", DateTime(time.Date(2024, 2, 29, 13, 4, 5, 0, time.UTC)), "2024-02-29T13:04:05", new(DateTime)},
+		{"DateTimeTZ", DateTimeTZ(time.Date(2024, 2, 29, 13, 4, 5, 0, plus2)), "2024-02-29T13:04:05+02:00", new(DateTimeTZ)},
+		{"DateTimeTZ UTC", DateTimeTZ(time.Date(2024, 2, 29, 13, 4, 5, 0, time.UTC)), "2024-02-29T13:04:05Z", new(DateTimeTZ)},
+		{"Time", Time(13*time.Hour + 4*time.Minute + 5*time.Second), "13:04:05", new(Time)},
+		{"TimeTZ", TimeTZ(time.Date(0, 1, 1, 13, 4, 5, 0, plus2)), "13:04:05+02:00", new(TimeTZ)},
+	}
+	for _, test := range tests {
+		text, err := test.value.MarshalText()
+		if err != nil {
+			t.Errorf("%s: MarshalText: %v", test.name, err)
+			continue
+		}
+		if string(text) != test.text {
+			t.Errorf("%s: MarshalText = %q, want %q", test.name, text, test.text)
+		}
+		if err := test.decoded.UnmarshalText(text); err != nil {
+			t.Errorf("%s: UnmarshalText(%q): %v", test.name, text, err)
+			continue
+		}
+		if !equal(reflect.ValueOf(test.decoded).Elem().Interface(), test.value) {
+			t.Errorf("%s: UnmarshalText(%q) = %v, want %v", test.name, text, reflect.ValueOf(test.decoded).Elem().Interface(), test.value)
+		}
+	}
+}
+
+// equal compares decoded values, comparing times by instant and zone offset
+// rather than by location
+func equal(a, b interface{}) bool {
+	toTime := func(v interface{}) (time.Time, bool) {
+		switch v := v.(type) {
+		case Date:
+			return time.Time(v), true
+		case DateTime:
+			return time.Time(v), true
+		case DateTimeTZ:
+			return time.Time(v), true
+		case TimeTZ:
+			return time.Time(v), true
+		}
+		return time.Time{}, false
+	}
+	if ta, ok := toTime(a); ok {
+		tb, _ := toTime(b)
+		_, offsetA := ta.Zone()
+		_, offsetB := tb.Zone()
+		return ta.Equal(tb) && offsetA == offsetB
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func TestTypesUnmarshalErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		decoded encoding.TextUnmarshaler
+	}{
+		{"Char empty", "", new(Char)},
+		{"Char two", "ab", new(Char)},
+		{"Base64", "not base64!", new(Base64)},
+		{"Hex", "xyz", new(Hex)},
+		{"Date", "29/02/2024", new(Date)},
+		{"DateTime", "2024-02-29 13:04:05", new(DateTime)},
+		{"DateTimeTZ", "2024-02-29", new(DateTimeTZ)},
+		{"Time", "noon", new(Time)},
+		{"Time fields", "13:04", new(Time)},
+		{"Time minutes", "13:60:00", new(Time)},
+		{"Time trailing", "13:04:05x", new(Time)},
+		{"TimeTZ", "13:04", new(TimeTZ)},
+	}
+	for _, test := range tests {
+		if err := test.decoded.UnmarshalText([]byte(test.text)); err == nil {
+			t.Errorf("%s: UnmarshalText(%q) succeeded, want an error", test.name, test.text)
+		}
+	}
+}
+
+// DateTimeTZ also accepts a date and time without a zone, which the UPnP
+// specification allows
+func TestDateTimeTZWithoutZone(t *testing.T) {
+	var d DateTimeTZ
+	if err := d.UnmarshalText([]byte("2024-02-29T13:04:05")); err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2024, 2, 29, 13, 4, 5, 0, time.UTC); !time.Time(d).Equal(want) {
+		t.Errorf("got %v, want %v", time.Time(d), want)
+	}
+}
+
+// The types are used as fields of the generated argument structs, so they
+// must survive encoding/xml as well
+func TestTypesXML(t *testing.T) {
+	type args struct {
+		Char  Char
+		Bin   Base64
+		Hex   Hex
+		Date  Date
+		Clock Time
+	}
+	in := args{
+		Char:  'x',
+		Bin:   Base64{1, 2, 3},
+		Hex:   Hex{0xff},
+		Date:  Date(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)),
+		Clock: Time(7 * time.Hour),
+	}
+	b, err := xml.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []byte("<args><Char>x</Char><Bin>AQID</Bin><Hex>ff</Hex><Date>2024-02-29</Date><Clock>07:00:00</Clock></args>")
+	if !bytes.Equal(b, want) {
+		t.Errorf("xml.Marshal = %s, want %s", b, want)
+	}
+	var out args
+	if err := xml.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("xml.Unmarshal = %+v, want %+v", out, in)
+	}
+}
+
+func TestParseHMS(t *testing.T) {
+	tests := []struct {
+		s  string
+		d  time.Duration
+		ok bool
+	}{
+		{"0:00:00", 0, true},
+		{"13:04:05", 13*time.Hour + 4*time.Minute + 5*time.Second, true},
+		{"100:00:00.25", 100*time.Hour + 250*time.Millisecond, true},
+		{"-0:00:10", -10 * time.Second, true},
+		{"+0:00:10", 10 * time.Second, true},
+		{"--0:00:10", 0, false},
+		{"+-0:00:10", 0, false},
+		{"0:00:1e1", 0, false},
+		{"0:00:60", 0, false},
+		{"", 0, false},
+	}
+	for _, test := range tests {
+		d, err := ParseHMS(test.s)
+		if (err == nil) != test.ok || d != test.d {
+			t.Errorf("ParseHMS(%q) = %v, %v, want %v", test.s, d, err, test.d)
+		}
+	}
+}
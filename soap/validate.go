@@ -0,0 +1,26 @@
+package soap
+
+import "fmt"
+
+// Validator is implemented by the generated argument structs. Client.Call
+// validates arguments that implement it before sending them. Empty enumerated
+// arguments are left for the service to check, so the zero value of the
+// argument structs is not refused.
+type Validator interface {
+	Validate() error
+}
+
+// ArgumentError is returned when an argument is not one the service allows,
+// according to the allowed values and ranges of its service description.
+type ArgumentError struct {
+	ServiceURN string
+	Action     string
+	Argument   string
+	Value      interface{}
+	// Allowed describes the allowed values, e.g. "0 to 100"
+	Allowed string
+}
+
+func (e *ArgumentError) Error() string {
+	return fmt.Sprintf("%s.%s(): %s %#v is not allowed, expected %s", serviceName(e.ServiceURN), e.Action, e.Argument, e.Value, e.Allowed)
+}
@@ -0,0 +1,17 @@
+//go:build !(aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris || windows)
+
+package sonos
+
+import (
+	"errors"
+	"net"
+)
+
+// setMulticastOptions fails if any multicast option is requested, as they
+// cannot be set on this platform.
+func setMulticastOptions(conn *net.UDPConn, ifaddr net.IP, ttl int, loopback *bool) error {
+	if ifaddr != nil || ttl > 0 || loopback != nil {
+		return errors.New("multicast options are not supported on this platform")
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+
+package sonos
+
+import (
+	"net"
+	"syscall"
+)
+
+// setMulticastOptions selects the interface owning ifaddr for outgoing
+// multicast and sets the multicast TTL and loopback. Zero values leave the
+// system defaults in place.
+func setMulticastOptions(conn *net.UDPConn, ifaddr net.IP, ttl int, loopback *bool) error {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	var sockErr error
+	err = raw.Control(func(fd uintptr) {
+		if ifaddr != nil {
+			var addr [4]byte
+			copy(addr[:], ifaddr.To4())
+			if sockErr = syscall.SetsockoptInet4Addr(int(fd), syscall.IPPROTO_IP, syscall.IP_MULTICAST_IF, addr); sockErr != nil {
+				return
+			}
+		}
+		// BSDs expect a single byte for these, which Linux accepts as well
+		if ttl > 0 {
+			if sockErr = syscall.SetsockoptByte(int(fd), syscall.IPPROTO_IP, syscall.IP_MULTICAST_TTL, byte(ttl)); sockErr != nil {
+				return
+			}
+		}
+		if loopback != nil {
+			var loop byte
+			if *loopback {
+				loop = 1
+			}
+			sockErr = syscall.SetsockoptByte(int(fd), syscall.IPPROTO_IP, syscall.IP_MULTICAST_LOOP, loop)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return sockErr
+}
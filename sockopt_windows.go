@@ -0,0 +1,43 @@
+package sonos
+
+import (
+	"net"
+	"syscall"
+)
+
+// setMulticastOptions selects the interface owning ifaddr for outgoing
+// multicast and sets the multicast TTL and loopback. Zero values leave the
+// system defaults in place.
+func setMulticastOptions(conn *net.UDPConn, ifaddr net.IP, ttl int, loopback *bool) error {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	var sockErr error
+	err = raw.Control(func(fd uintptr) {
+		if ifaddr != nil {
+			var addr [4]byte
+			copy(addr[:], ifaddr.To4())
+			if sockErr = syscall.SetsockoptInet4Addr(syscall.Handle(fd), syscall.IPPROTO_IP, syscall.IP_MULTICAST_IF, addr); sockErr != nil {
+				return
+			}
+		}
+		if ttl > 0 {
+			if sockErr = syscall.SetsockoptInt(syscall.Handle(fd), syscall.IPPROTO_IP, syscall.IP_MULTICAST_TTL, ttl); sockErr != nil {
+				return
+			}
+		}
+		if loopback != nil {
+			loop := 0
+			if *loopback {
+				loop = 1
+			}
+			sockErr = syscall.SetsockoptInt(syscall.Handle(fd), syscall.IPPROTO_IP, syscall.IP_MULTICAST_LOOP, loop)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return sockErr
+}
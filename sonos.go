@@ -2,11 +2,14 @@ package sonos
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 )
 
@@ -17,84 +20,471 @@ const (
 )
 
 type Sonos struct {
-	// Context Context
-	listenSocket *net.UDPConn
-	udpReader    *bufio.Reader
-	found        chan *ZonePlayer
+	listenSockets    []*net.UDPConn
+	coordinatorsOnly bool
+	probeParallelism int
+	probeTimeout     time.Duration
+
+	// multicast settings
+	interfaces     []net.Interface
+	sourceIP       net.IP
+	ttl            int
+	loopback       *bool
+	repeat         int
+	repeatInterval time.Duration
+
+	mu  sync.Mutex
+	err error
+}
+
+// Option configures a Sonos created by NewSonos.
+type Option func(*Sonos) error
+
+// WithAllPlayers makes searches deliver every player that responds, not only
+// group coordinators. Each player's Role tells them apart.
+func WithAllPlayers() Option {
+	return func(s *Sonos) error {
+		s.coordinatorsOnly = false
+		return nil
+	}
+}
+
+// WithProbeParallelism sets how many hosts ProbeContext contacts at once.
+func WithProbeParallelism(n int) Option {
+	return func(s *Sonos) error {
+		if n < 1 {
+			return errors.New("probe parallelism must be at least 1")
+		}
+		s.probeParallelism = n
+		return nil
+	}
+}
+
+// WithProbeTimeout sets how long ProbeContext waits for each host.
+func WithProbeTimeout(timeout time.Duration) Option {
+	return func(s *Sonos) error {
+		s.probeTimeout = timeout
+		return nil
+	}
+}
+
+// WithInterfaces sends searches out of each of the given interfaces instead
+// of the one the kernel picks. See MulticastInterfaces.
+func WithInterfaces(ifis ...net.Interface) Option {
+	return func(s *Sonos) error {
+		s.interfaces = append(s.interfaces, ifis...)
+		return nil
+	}
+}
+
+// WithAllInterfaces sends searches out of every interface returned by
+// MulticastInterfaces.
+func WithAllInterfaces() Option {
+	return func(s *Sonos) error {
+		ifis, err := MulticastInterfaces()
+		if err != nil {
+			return err
+		}
+		if len(ifis) == 0 {
+			return errors.New("no multicast capable interfaces")
+		}
+		s.interfaces = append(s.interfaces, ifis...)
+		return nil
+	}
+}
+
+// WithInterfaceName sends searches out of the named interface, e.g. "eth1".
+func WithInterfaceName(name string) Option {
+	return func(s *Sonos) error {
+		ifi, err := net.InterfaceByName(name)
+		if err != nil {
+			return err
+		}
+		s.interfaces = append(s.interfaces, *ifi)
+		return nil
+	}
+}
+
+// WithSourceIP sends searches from the given local address, and so out of
+// the interface that owns it. It cannot be combined with the options that
+// choose interfaces, which send from the address of each interface.
+func WithSourceIP(ip net.IP) Option {
+	return func(s *Sonos) error {
+		if ip.To4() == nil {
+			return fmt.Errorf("%s is not an IPv4 address", ip)
+		}
+		s.sourceIP = ip
+		return nil
+	}
+}
+
+// WithMulticastTTL sets how many routers the M-SEARCH may cross. The system
+// default is 1, which keeps it on the local network.
+func WithMulticastTTL(ttl int) Option {
+	return func(s *Sonos) error {
+		if ttl < 1 || ttl > 255 {
+			return errors.New("multicast TTL must be between 1 and 255")
+		}
+		s.ttl = ttl
+		return nil
+	}
+}
+
+// WithMulticastLoopback sets whether the M-SEARCH is also delivered to the
+// local host, which is needed to find players emulated on the same machine.
+func WithMulticastLoopback(loopback bool) Option {
+	return func(s *Sonos) error {
+		s.loopback = &loopback
+		return nil
+	}
+}
+
+// WithSearchRepeat sends the M-SEARCH count times, interval apart, so a
+// search survives lost UDP packets. Players that answer more than once are
+// only delivered once.
+func WithSearchRepeat(count int, interval time.Duration) Option {
+	return func(s *Sonos) error {
+		if count < 1 {
+			return errors.New("search count must be at least 1")
+		}
+		if count > 1 && interval <= 0 {
+			return errors.New("search interval must be positive")
+		}
+		s.repeat = count
+		s.repeatInterval = interval
+		return nil
+	}
 }
 
-func NewSonos() (*Sonos, error) {
-	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: []byte{0, 0, 0, 0}, Port: 0, Zone: ""})
+// MulticastInterfaces returns the interfaces that are up, support multicast,
+// are not loopback and have an IPv4 address.
+func MulticastInterfaces() ([]net.Interface, error) {
+	ifis, err := net.Interfaces()
 	if err != nil {
 		return nil, err
 	}
 
+	var multicast []net.Interface
+	for _, ifi := range ifis {
+		if ifi.Flags&net.FlagUp == 0 || ifi.Flags&net.FlagMulticast == 0 || ifi.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		if ip, err := interfaceIPv4(&ifi); err == nil && ip != nil {
+			multicast = append(multicast, ifi)
+		}
+	}
+	return multicast, nil
+}
+
+// interfaceIPv4 returns the first IPv4 address of ifi, or nil if it has none.
+func interfaceIPv4(ifi *net.Interface) (net.IP, error) {
+	addrs, err := ifi.Addrs()
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		if ipnet, ok := addr.(*net.IPNet); ok && ipnet.IP.To4() != nil {
+			return ipnet.IP.To4(), nil
+		}
+	}
+	return nil, nil
+}
+
+func NewSonos(opts ...Option) (*Sonos, error) {
 	s := Sonos{
-		listenSocket: conn,
-		udpReader:    bufio.NewReader(conn),
-		found:        make(chan *ZonePlayer),
+		coordinatorsOnly: true,
+		probeParallelism: 32,
+		probeTimeout:     2 * time.Second,
+		repeat:           1,
+	}
+	for _, opt := range opts {
+		if err := opt(&s); err != nil {
+			return nil, err
+		}
+	}
+
+	// One socket per interface, or a single one the kernel routes
+	type source struct {
+		ip     net.IP
+		ifaddr net.IP
+	}
+	sources := []source{{ip: s.sourceIP, ifaddr: s.sourceIP}}
+	if len(s.interfaces) > 0 {
+		if s.sourceIP != nil {
+			return nil, errors.New("a source IP cannot be combined with interfaces")
+		}
+		sources = nil
+		for i := range s.interfaces {
+			ip, err := interfaceIPv4(&s.interfaces[i])
+			if err != nil {
+				return nil, err
+			}
+			if ip == nil {
+				return nil, fmt.Errorf("interface %s has no IPv4 address", s.interfaces[i].Name)
+			}
+			sources = append(sources, source{ip: ip, ifaddr: ip})
+		}
+	}
+
+	for _, src := range sources {
+		ip := src.ip
+		if ip == nil {
+			ip = net.IPv4zero
+		}
+		conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: ip, Port: 0})
+		if err != nil {
+			s.Close()
+			return nil, err
+		}
+		s.listenSockets = append(s.listenSockets, conn)
+		if err := setMulticastOptions(conn, src.ifaddr, s.ttl, s.loopback); err != nil {
+			s.Close()
+			return nil, err
+		}
 	}
 
 	return &s, nil
 }
 
 func (s *Sonos) Close() {
-	s.listenSocket.Close()
+	for _, conn := range s.listenSockets {
+		conn.Close()
+	}
 }
 
+// Err returns the error that stopped the most recent search, if any. It
+// returns nil if the search ended because its context was done or the Sonos
+// was closed.
+func (s *Sonos) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *Sonos) setErr(err error) {
+	s.mu.Lock()
+	if s.err == nil {
+		s.err = err
+	}
+	s.mu.Unlock()
+}
+
+// Search is like SearchContext with a background context. The search runs
+// until the Sonos is closed.
 func (s *Sonos) Search() (chan *ZonePlayer, error) {
-	go func() {
-		for {
-			response, err := http.ReadResponse(s.udpReader, nil)
-			if err != nil {
-				continue
-			}
+	return s.SearchContext(context.Background())
+}
 
-			location, err := url.Parse(response.Header.Get("Location"))
-			if err != nil {
-				continue
-			}
-			zp, err := NewZonePlayer(location)
-			if err != nil {
-				continue
-			}
-			if zp.IsCoordinator() {
-				s.found <- zp
-			}
+// SearchContext sends an SSDP M-SEARCH and delivers the responding group
+// coordinators, or all players when created WithAllPlayers, on the returned
+// channel. The channel is closed when ctx is done, when the Sonos is closed,
+// or when reading from a socket fails; in the latter case Err reports the
+// failure. Only one search may run at a time.
+func (s *Sonos) SearchContext(ctx context.Context) (chan *ZonePlayer, error) {
+	// Clear the deadline a previous, cancelled search may have left behind
+	for _, conn := range s.listenSockets {
+		if err := conn.SetReadDeadline(time.Time{}); err != nil {
+			return nil, err
 		}
+	}
+
+	if err := s.send(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.err = nil
+	s.mu.Unlock()
+
+	responses := make(chan *http.Response)
+	var wg sync.WaitGroup
+	for _, conn := range s.listenSockets {
+		wg.Add(1)
+		go func(conn *net.UDPConn) {
+			defer wg.Done()
+			s.read(ctx, conn, responses)
+		}(conn)
+	}
+	go func() {
+		wg.Wait()
+		close(responses)
 	}()
 
+	if s.repeat > 1 {
+		go s.resend(ctx)
+	}
+
+	found := make(chan *ZonePlayer)
+	go s.collect(ctx, responses, found)
+
+	return found, nil
+}
+
+// send writes the M-SEARCH to every socket.
+func (s *Sonos) send() error {
 	// MX should be set to use timeout value in integer seconds
 	pkt := []byte(fmt.Sprintf("M-SEARCH * HTTP/1.1\r\nHOST: %s\r\nMAN: \"ssdp:discover\"\r\nMX: %d\r\nST: %s\r\n\r\n", bcastaddr, mx, st))
-	bcast, err := net.ResolveUDPAddr("udp", bcastaddr)
+	bcast, err := net.ResolveUDPAddr("udp4", bcastaddr)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	_, err = s.listenSocket.WriteTo(pkt, bcast)
-	if err != nil {
-		return nil, err
+	for _, conn := range s.listenSockets {
+		if _, err := conn.WriteTo(pkt, bcast); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resend repeats the M-SEARCH until the configured count is reached or ctx
+// is done.
+func (s *Sonos) resend(ctx context.Context) {
+	ticker := time.NewTicker(s.repeatInterval)
+	defer ticker.Stop()
+	for i := 1; i < s.repeat; i++ {
+		select {
+		case <-ticker.C:
+			if err := s.send(); err != nil {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// read passes the SSDP responses received on conn to responses until ctx is
+// done or reading fails.
+func (s *Sonos) read(ctx context.Context, conn *net.UDPConn, responses chan *http.Response) {
+	// Unblock the pending read once the caller is no longer interested
+	stop := context.AfterFunc(ctx, func() {
+		conn.SetReadDeadline(time.Now())
+	})
+	defer stop()
+
+	buf := make([]byte, 2048)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() == nil && !errors.Is(err, net.ErrClosed) {
+				s.setErr(err)
+			}
+			return
+		}
+
+		response, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(buf[:n])), nil)
+		if err != nil {
+			// Not an SSDP response, ignore it
+			continue
+		}
+
+		select {
+		case responses <- response:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// collect turns responses into players, delivering each player only once.
+func (s *Sonos) collect(ctx context.Context, responses chan *http.Response, found chan *ZonePlayer) {
+	defer close(found)
+
+	var roles roleCache
+	seen := make(map[string]bool)
+	for response := range responses {
+		key := response.Header.Get("USN")
+		if key == "" {
+			key = response.Header.Get("Location")
+		}
+		if seen[key] {
+			continue
+		}
+
+		location, err := url.Parse(response.Header.Get("Location"))
+		if err != nil {
+			continue
+		}
+		zp, err := NewZonePlayerContext(ctx, location)
+		if err != nil {
+			continue
+		}
+		seen[key] = true
+		zp.Role = roles.role(ctx, zp)
+		if s.coordinatorsOnly && zp.Role != RoleCoordinator {
+			continue
+		}
+
+		select {
+		case found <- zp:
+		case <-ctx.Done():
+		}
+	}
+}
+
+// roleCache resolves player roles from as few ZoneGroupState fetches as
+// possible. Any player can report the topology of its whole household, so a
+// new state is only fetched for players not found in the ones already known.
+type roleCache struct {
+	mu     sync.Mutex
+	states []*ZoneGroupState
+}
+
+func (c *roleCache) role(ctx context.Context, zp *ZonePlayer) Role {
+	c.mu.Lock()
+	for _, state := range c.states {
+		if role, ok := state.Role(zp.Root.Device.UDN); ok {
+			c.mu.Unlock()
+			return role
+		}
 	}
+	c.mu.Unlock()
 
-	return s.found, nil
+	state, err := zp.GetZoneGroupStateContext(ctx)
+	if err != nil {
+		return ""
+	}
+	c.mu.Lock()
+	c.states = append(c.states, state)
+	c.mu.Unlock()
+	role, _ := state.Role(zp.Root.Device.UDN)
+	return role
 }
 
 func FindRoom(room string, timeout time.Duration) (*ZonePlayer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	zp, err := FindRoomContext(ctx, room)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, errors.New("timeout")
+	}
+	return zp, err
+}
+
+// FindRoomContext searches for the coordinator of the named room until it is
+// found or ctx is done.
+func FindRoomContext(ctx context.Context, room string) (*ZonePlayer, error) {
 	son, err := NewSonos()
 	if err != nil {
 		return nil, err
 	}
 	defer son.Close()
 
-	found, _ := son.Search()
-	to := time.After(timeout)
-	for {
-		select {
-		case <-to:
-			return nil, errors.New("timeout")
-		case zp := <-found:
-			if zp.RoomName() == room {
-				return zp, nil
-			}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	found, err := son.SearchContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for zp := range found {
+		if zp.RoomName() == room {
+			return zp, nil
 		}
 	}
+	if err := son.Err(); err != nil {
+		return nil, err
+	}
+	return nil, ctx.Err()
 }
@@ -0,0 +1,314 @@
+package sonos
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+
+	zgt "github.com/szatmary/sonos/ZoneGroupTopology"
+	"github.com/szatmary/sonos/gena"
+)
+
+// ErrSubscriptionClosed is the error of a watcher whose event subscription
+// was closed under it, e.g. by closing its gena.Manager.
+var ErrSubscriptionClosed = errors.New("event subscription closed")
+
+type TopologyEventType int
+
+const (
+	// GroupCreated is sent for a group that was not in the previous state
+	GroupCreated TopologyEventType = iota
+	// GroupDissolved is sent for a group that is no longer in the state
+	GroupDissolved
+	// CoordinatorChanged is sent when another player takes over a group
+	CoordinatorChanged
+	// PlayerJoinedGroup is sent when a player becomes a member of a group,
+	// including the groups of players new to the household
+	PlayerJoinedGroup
+	// PlayerLeftGroup is sent when a player stops being a member of a group,
+	// including players that left the household
+	PlayerLeftGroup
+	// PlayerRenamed is sent when the room name of a player changes
+	PlayerRenamed
+	// SatelliteAppeared is sent when a satellite or sub is bonded to a player
+	SatelliteAppeared
+	// SatelliteVanished is sent when a satellite or sub stops being bonded to
+	// a player, or goes offline
+	SatelliteVanished
+	// TopologyPropertyChanged is sent for the other state variables of the
+	// ZoneGroupTopology service, such as AvailableSoftwareUpdate,
+	// ThirdPartyMediaServersX and AlarmRunSequence
+	TopologyPropertyChanged
+)
+
+func (t TopologyEventType) String() string {
+	switch t {
+	case GroupCreated:
+		return "GroupCreated"
+	case GroupDissolved:
+		return "GroupDissolved"
+	case CoordinatorChanged:
+		return "CoordinatorChanged"
+	case PlayerJoinedGroup:
+		return "PlayerJoinedGroup"
+	case PlayerLeftGroup:
+		return "PlayerLeftGroup"
+	case PlayerRenamed:
+		return "PlayerRenamed"
+	case SatelliteAppeared:
+		return "SatelliteAppeared"
+	case SatelliteVanished:
+		return "SatelliteVanished"
+	case TopologyPropertyChanged:
+		return "TopologyPropertyChanged"
+	default:
+		return "TopologyEventType(" + strconv.Itoa(int(t)) + ")"
+	}
+}
+
+type TopologyEvent struct {
+	Type TopologyEventType
+	// GroupID is the group created, dissolved, joined or left, or whose
+	// coordinator changed
+	GroupID string
+	// Coordinator is the UUID of the coordinator of GroupID, and
+	// PreviousCoordinator the one it replaced on CoordinatorChanged events
+	Coordinator         string
+	PreviousCoordinator string
+	// UUID is the player that joined, left or was renamed, or the satellite
+	// that appeared or vanished
+	UUID string
+	// ZoneName is the room name of UUID, and PreviousZoneName the one it
+	// had before on PlayerRenamed events
+	ZoneName         string
+	PreviousZoneName string
+	// Host is the UUID of the player a satellite is bonded to
+	Host string
+	// Property and Value are the state variable and its new value on
+	// TopologyPropertyChanged events
+	Property string
+	Value    string
+}
+
+// topologyPlayer is where a player or satellite sits in a ZoneGroupState
+type topologyPlayer struct {
+	groupID  string
+	host     string
+	zoneName string
+}
+
+func indexZoneGroupState(z *ZoneGroupState) (groups map[string]*ZoneGroup, members, satellites map[string]topologyPlayer) {
+	groups = make(map[string]*ZoneGroup)
+	members = make(map[string]topologyPlayer)
+	satellites = make(map[string]topologyPlayer)
+	if z == nil {
+		return
+	}
+	for i := range z.ZoneGroups {
+		group := &z.ZoneGroups[i]
+		groups[group.ID] = group
+		for _, member := range group.ZoneGroupMember {
+			members[member.UUID] = topologyPlayer{groupID: group.ID, zoneName: member.ZoneName}
+			for _, satellite := range member.Satellite {
+				satellites[satellite.UUID] = topologyPlayer{groupID: group.ID, host: member.UUID, zoneName: satellite.ZoneName}
+			}
+		}
+	}
+	return
+}
+
+func sortedKeys(m map[string]topologyPlayer) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// DiffZoneGroupState describes how the topology changed from old to new. A
+// nil old is treated as an empty household, so every group and player in new
+// is reported as created or joined.
+func DiffZoneGroupState(old, new *ZoneGroupState) []TopologyEvent {
+	oldGroups, oldMembers, oldSatellites := indexZoneGroupState(old)
+	newGroups, newMembers, newSatellites := indexZoneGroupState(new)
+	var events []TopologyEvent
+
+	// Players leave their old groups before the groups are dissolved, and
+	// join new groups after they are created
+	for _, id := range sortedKeys(oldMembers) {
+		was := oldMembers[id]
+		if is, ok := newMembers[id]; !ok || is.groupID != was.groupID {
+			events = append(events, TopologyEvent{Type: PlayerLeftGroup, GroupID: was.groupID, UUID: id, ZoneName: was.zoneName})
+		}
+	}
+	for _, id := range sortedKeys(oldSatellites) {
+		was := oldSatellites[id]
+		if is, ok := newSatellites[id]; !ok || is.host != was.host {
+			events = append(events, TopologyEvent{Type: SatelliteVanished, GroupID: was.groupID, UUID: id, ZoneName: was.zoneName, Host: was.host})
+		}
+	}
+
+	var groupIDs []string
+	for id := range oldGroups {
+		groupIDs = append(groupIDs, id)
+	}
+	for id := range newGroups {
+		if _, ok := oldGroups[id]; !ok {
+			groupIDs = append(groupIDs, id)
+		}
+	}
+	sort.Strings(groupIDs)
+	for _, id := range groupIDs {
+		was, wasOK := oldGroups[id]
+		is, isOK := newGroups[id]
+		switch {
+		case !isOK:
+			events = append(events, TopologyEvent{Type: GroupDissolved, GroupID: id, Coordinator: was.Coordinator})
+		case !wasOK:
+			events = append(events, TopologyEvent{Type: GroupCreated, GroupID: id, Coordinator: is.Coordinator})
+		case was.Coordinator != is.Coordinator:
+			events = append(events, TopologyEvent{Type: CoordinatorChanged, GroupID: id, Coordinator: is.Coordinator, PreviousCoordinator: was.Coordinator})
+		}
+	}
+
+	for _, id := range sortedKeys(newMembers) {
+		is := newMembers[id]
+		was, ok := oldMembers[id]
+		if !ok || is.groupID != was.groupID {
+			events = append(events, TopologyEvent{Type: PlayerJoinedGroup, GroupID: is.groupID, Coordinator: newGroups[is.groupID].Coordinator, UUID: id, ZoneName: is.zoneName})
+		}
+		if ok && is.zoneName != was.zoneName {
+			events = append(events, TopologyEvent{Type: PlayerRenamed, GroupID: is.groupID, UUID: id, ZoneName: is.zoneName, PreviousZoneName: was.zoneName})
+		}
+	}
+	for _, id := range sortedKeys(newSatellites) {
+		is := newSatellites[id]
+		if was, ok := oldSatellites[id]; !ok || is.host != was.host {
+			events = append(events, TopologyEvent{Type: SatelliteAppeared, GroupID: is.groupID, UUID: id, ZoneName: is.zoneName, Host: is.host})
+		}
+	}
+	return events
+}
+
+// TopologyWatcher follows the ZoneGroupTopology events of a player and turns
+// them into TopologyEvents.
+type TopologyWatcher struct {
+	Manager *gena.Manager
+	Player  *ZonePlayer
+
+	mu    sync.Mutex
+	state *ZoneGroupState
+	err   error
+}
+
+// NewTopologyWatcher watches the topology as seen by zp, using m to subscribe
+// to its events. Any player of the household will do.
+func NewTopologyWatcher(m *gena.Manager, zp *ZonePlayer) *TopologyWatcher {
+	return &TopologyWatcher{
+		Manager: m,
+		Player:  zp,
+	}
+}
+
+// State returns the last ZoneGroupState received. It may be called while
+// Watch is running, the state returned is never modified.
+func (w *TopologyWatcher) State() *ZoneGroupState {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.state
+}
+
+// Err returns the error that stopped Watch, if any, once its channel is closed.
+func (w *TopologyWatcher) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+func (w *TopologyWatcher) setErr(err error) {
+	w.mu.Lock()
+	w.err = err
+	w.mu.Unlock()
+}
+
+// Watch subscribes to the topology events and delivers the changes until ctx
+// is done, the subscription is closed or an event cannot be decoded, then
+// unsubscribes and closes the returned channel. The initial event is compared
+// to an empty household, so the first changes received describe the whole
+// topology.
+func (w *TopologyWatcher) Watch(ctx context.Context) (chan TopologyEvent, error) {
+	// Derived from the device description URL, as the player's service may
+	// be another zgt.Client, such as a Fake
+	sub, err := w.Manager.Subscribe(ctx, zgt.NewService(w.Player.DeviceDescriptionURL).EventEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	events := make(chan TopologyEvent)
+	w.mu.Lock()
+	w.err = nil
+	w.state = nil
+	w.mu.Unlock()
+	go w.watch(ctx, sub, events)
+	return events, nil
+}
+
+func (w *TopologyWatcher) watch(ctx context.Context, sub *gena.Subscription, events chan TopologyEvent) {
+	defer close(events)
+	defer sub.Unsubscribe(context.Background())
+
+	send := func(event TopologyEvent) bool {
+		select {
+		case events <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	for {
+		var notification gena.Event
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return
+		case notification, ok = <-sub.Events():
+			if !ok {
+				w.setErr(ErrSubscriptionClosed)
+				return
+			}
+		}
+
+		var names []string
+		for name := range notification.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			value := notification.Properties[name]
+			if name != "ZoneGroupState" {
+				if !send(TopologyEvent{Type: TopologyPropertyChanged, Property: name, Value: value}) {
+					return
+				}
+				continue
+			}
+
+			state, err := parseZoneGroupState(value)
+			if err != nil {
+				w.setErr(err)
+				return
+			}
+			w.mu.Lock()
+			changes := DiffZoneGroupState(w.state, state)
+			w.state = state
+			w.mu.Unlock()
+			for _, change := range changes {
+				if !send(change) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package sonos
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/szatmary/sonos/gena"
+)
+
+func mustParseZoneGroupState(t *testing.T, state string) *ZoneGroupState {
+	t.Helper()
+	z, err := parseZoneGroupState(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return z
+}
+
+// Older firmware sends the ZoneGroups element as the root
+func TestParseZoneGroupStateBare(t *testing.T) {
+	bare := strings.TrimSuffix(strings.TrimPrefix(zoneGroupState, "<ZoneGroupState>"), "<VanishedDevices/></ZoneGroupState>")
+	want := mustParseZoneGroupState(t, zoneGroupState)
+	got := mustParseZoneGroupState(t, bare)
+	if !reflect.DeepEqual(got.ZoneGroups, want.ZoneGroups) {
+		t.Errorf("parseZoneGroupState(bare) = %+v, want %+v", got.ZoneGroups, want.ZoneGroups)
+	}
+	if _, err := parseZoneGroupState("<Nothing/>"); err == nil {
+		t.Error("parseZoneGroupState of another document succeeded")
+	}
+}
+
+func TestDiffZoneGroupStateInitial(t *testing.T) {
+	events := DiffZoneGroupState(nil, mustParseZoneGroupState(t, zoneGroupState))
+	want := []TopologyEvent{
+		{Type: GroupCreated, GroupID: "RINCON_BL01400:7", Coordinator: "RINCON_BL01400"},
+		{Type: GroupCreated, GroupID: "RINCON_TV01400:1", Coordinator: "RINCON_TV01400"},
+		{Type: PlayerJoinedGroup, GroupID: "RINCON_BL01400:7", Coordinator: "RINCON_BL01400", UUID: "RINCON_BL01400", ZoneName: "Bedroom"},
+		{Type: PlayerJoinedGroup, GroupID: "RINCON_BL01400:7", Coordinator: "RINCON_BL01400", UUID: "RINCON_BR01400", ZoneName: "Bedroom"},
+		{Type: PlayerJoinedGroup, GroupID: "RINCON_BL01400:7", Coordinator: "RINCON_BL01400", UUID: "RINCON_BS01400", ZoneName: "Bedroom"},
+		{Type: PlayerJoinedGroup, GroupID: "RINCON_TV01400:1", Coordinator: "RINCON_TV01400", UUID: "RINCON_KI01400", ZoneName: "Kitchen"},
+		{Type: PlayerJoinedGroup, GroupID: "RINCON_TV01400:1", Coordinator: "RINCON_TV01400", UUID: "RINCON_TV01400", ZoneName: "Living Room"},
+		{Type: SatelliteAppeared, GroupID: "RINCON_TV01400:1", UUID: "RINCON_SL01400", ZoneName: "Living Room", Host: "RINCON_TV01400"},
+		{Type: SatelliteAppeared, GroupID: "RINCON_TV01400:1", UUID: "RINCON_SR01400", ZoneName: "Living Room", Host: "RINCON_TV01400"},
+		{Type: SatelliteAppeared, GroupID: "RINCON_TV01400:1", UUID: "RINCON_SW01400", ZoneName: "Living Room", Host: "RINCON_TV01400"},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("DiffZoneGroupState(nil, state) =\n%v\nwant\n%v", events, want)
+	}
+}
+
+func TestDiffZoneGroupStateUnchanged(t *testing.T) {
+	if events := DiffZoneGroupState(mustParseZoneGroupState(t, zoneGroupState), mustParseZoneGroupState(t, zoneGroupState)); len(events) != 0 {
+		t.Errorf("DiffZoneGroupState(state, state) = %v, want none", events)
+	}
+}
+
+func TestDiffZoneGroupState(t *testing.T) {
+	old := mustParseZoneGroupState(t, zoneGroupState)
+
+	// The kitchen leaves for a group of its own, the bedroom is renamed and
+	// the right surround goes offline
+	new := strings.Replace(zoneGroupState,
+		`<ZoneGroupMember UUID="RINCON_KI01400" Location="http://192.168.1.20:1400/xml/device_description.xml" ZoneName="Kitchen"/>`+
+			`</ZoneGroup>`,
+		`</ZoneGroup>`+
+			`<ZoneGroup Coordinator="RINCON_KI01400" ID="RINCON_KI01400:3">`+
+			`<ZoneGroupMember UUID="RINCON_KI01400" Location="http://192.168.1.20:1400/xml/device_description.xml" ZoneName="Kitchen"/>`+
+			`</ZoneGroup>`, 1)
+	new = strings.ReplaceAll(new, `ZoneName="Bedroom"`, `ZoneName="Guest Room"`)
+	new = strings.Replace(new, `<Satellite UUID="RINCON_SR01400" Location="http://192.168.1.12:1400/xml/device_description.xml" ZoneName="Living Room" HTSatChanMapSet="RINCON_TV01400:LF,RF;RINCON_SL01400:LR;RINCON_SR01400:RR;RINCON_SW01400:SW" Invisible="1"/>`, "", 1)
+
+	events := DiffZoneGroupState(old, mustParseZoneGroupState(t, new))
+	want := []TopologyEvent{
+		{Type: PlayerLeftGroup, GroupID: "RINCON_TV01400:1", UUID: "RINCON_KI01400", ZoneName: "Kitchen"},
+		{Type: SatelliteVanished, GroupID: "RINCON_TV01400:1", UUID: "RINCON_SR01400", ZoneName: "Living Room", Host: "RINCON_TV01400"},
+		{Type: GroupCreated, GroupID: "RINCON_KI01400:3", Coordinator: "RINCON_KI01400"},
+		{Type: PlayerRenamed, GroupID: "RINCON_BL01400:7", UUID: "RINCON_BL01400", ZoneName: "Guest Room", PreviousZoneName: "Bedroom"},
+		{Type: PlayerRenamed, GroupID: "RINCON_BL01400:7", UUID: "RINCON_BR01400", ZoneName: "Guest Room", PreviousZoneName: "Bedroom"},
+		{Type: PlayerRenamed, GroupID: "RINCON_BL01400:7", UUID: "RINCON_BS01400", ZoneName: "Guest Room", PreviousZoneName: "Bedroom"},
+		{Type: PlayerJoinedGroup, GroupID: "RINCON_KI01400:3", Coordinator: "RINCON_KI01400", UUID: "RINCON_KI01400", ZoneName: "Kitchen"},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("DiffZoneGroupState =\n%v\nwant\n%v", events, want)
+	}
+}
+
+func TestDiffZoneGroupStateCoordinatorChanged(t *testing.T) {
+	old := mustParseZoneGroupState(t, zoneGroupState)
+	new := mustParseZoneGroupState(t, strings.Replace(zoneGroupState, `Coordinator="RINCON_TV01400"`, `Coordinator="RINCON_KI01400"`, 1))
+
+	events := DiffZoneGroupState(old, new)
+	want := []TopologyEvent{
+		{Type: CoordinatorChanged, GroupID: "RINCON_TV01400:1", Coordinator: "RINCON_KI01400", PreviousCoordinator: "RINCON_TV01400"},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("DiffZoneGroupState =\n%v\nwant\n%v", events, want)
+	}
+	if events := DiffZoneGroupState(old, nil); len(events) == 0 || events[len(events)-1].Type != GroupDissolved {
+		t.Errorf("DiffZoneGroupState(state, nil) = %v, want the groups dissolved last", events)
+	}
+}
+
+// topologyEvents is a ZoneGroupTopology event source that sends each property
+// set of events to the first subscriber, once it has the SID
+func topologyEvents(t *testing.T, events ...string) *httptest.Server {
+	t.Helper()
+	var once sync.Once
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "SUBSCRIBE" || r.Header.Get("SID") != "" {
+			return
+		}
+		callback := strings.Trim(r.Header.Get("CALLBACK"), "<>")
+		w.Header().Set("SID", "uuid:topology-1")
+		w.Header().Set("TIMEOUT", "Second-300")
+		once.Do(func() {
+			go func() {
+				for seq, event := range events {
+					req, _ := http.NewRequest("NOTIFY", callback, strings.NewReader(event))
+					req.Header.Set("NT", "upnp:event")
+					req.Header.Set("NTS", "upnp:propchange")
+					req.Header.Set("SID", "uuid:topology-1")
+					req.Header.Set("SEQ", fmt.Sprint(seq))
+					if resp, err := http.DefaultClient.Do(req); err == nil {
+						resp.Body.Close()
+					}
+				}
+			}()
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func propertySet(name, value string) string {
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(value))
+	return `<e:propertyset xmlns:e="urn:schemas-upnp-org:event-1-0"><e:property>` +
+		`<` + name + `>` + escaped.String() + `</` + name + `>` +
+		`</e:property></e:propertyset>`
+}
+
+func TestTopologyWatcher(t *testing.T) {
+	srv := topologyEvents(t,
+		propertySet("ZoneGroupState", zoneGroupState),
+		propertySet("ZoneGroupState", "<Nothing/>"))
+	m, err := gena.NewManager("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	location, _ := url.Parse(srv.URL + deviceDescriptionPath)
+
+	w := NewTopologyWatcher(m, &ZonePlayer{DeviceDescriptionURL: location})
+	events, err := w.Watch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// State can be read while the events are delivered
+	stop := make(chan struct{})
+	reading := make(chan struct{})
+	go func() {
+		defer close(reading)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				w.State()
+				w.Err()
+			}
+		}
+	}()
+	var received []TopologyEvent
+	for event := range events {
+		received = append(received, event)
+	}
+	close(stop)
+	<-reading
+
+	want := DiffZoneGroupState(nil, mustParseZoneGroupState(t, zoneGroupState))
+	if !reflect.DeepEqual(received, want) {
+		t.Errorf("events =\n%v\nwant\n%v", received, want)
+	}
+	if state := w.State(); state == nil || len(state.ZoneGroups) != 2 {
+		t.Errorf("State = %+v, want the last state decoded", state)
+	}
+	if w.Err() == nil {
+		t.Error("Err = nil, want the decoding error that stopped Watch")
+	}
+}
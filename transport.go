@@ -0,0 +1,213 @@
+package sonos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	avt "github.com/szatmary/sonos/AVTransport"
+	"github.com/szatmary/sonos/soap"
+)
+
+// ErrNotSupported is returned by the transport helpers when the current
+// source cannot do what was asked, such as seeking in a radio stream.
+var ErrNotSupported = errors.New("not supported by the current source")
+
+// notSupported turns the errors players return when the current source cannot
+// do an action into ErrNotSupported, keeping the original error
+func notSupported(err error) error {
+	var upnpErr *soap.UPnPError
+	if errors.As(err, &upnpErr) {
+		switch upnpErr.Code {
+		case 701, 710, 711, 712: // transition, seek mode, seek target, play mode
+			return fmt.Errorf("%w: %w", ErrNotSupported, err)
+		}
+	}
+	return err
+}
+
+// Repeat is the repeat part of a play mode.
+type Repeat int
+
+const (
+	RepeatOff Repeat = iota
+	RepeatAll
+	RepeatOne
+)
+
+var playModes = map[bool]map[Repeat]avt.PlayMode{
+	false: {RepeatOff: avt.PlayModeNormal, RepeatAll: avt.PlayModeRepeatAll, RepeatOne: avt.PlayModeRepeatOne},
+	true:  {RepeatOff: avt.PlayModeShuffleNoRepeat, RepeatAll: avt.PlayModeShuffle, RepeatOne: avt.PlayModeShuffleRepeatOne},
+}
+
+// PlayModeOf splits a play mode into its shuffle and repeat parts.
+func PlayModeOf(mode avt.PlayMode) (shuffle bool, repeat Repeat) {
+	for shuffle, repeats := range playModes {
+		for repeat, m := range repeats {
+			if m == mode {
+				return shuffle, repeat
+			}
+		}
+	}
+	return false, RepeatOff
+}
+
+func (z *ZonePlayer) Pause() error {
+	return z.PauseContext(context.Background())
+}
+
+func (z *ZonePlayer) PauseContext(ctx context.Context) error {
+	return z.routed(ctx, func(c *ZonePlayer) error {
+		_, err := c.AVTransport.PauseContext(ctx, c.HttpClient, &avt.PauseArgs{})
+		return notSupported(err)
+	})
+}
+
+func (z *ZonePlayer) Stop() error {
+	return z.StopContext(context.Background())
+}
+
+func (z *ZonePlayer) StopContext(ctx context.Context) error {
+	return z.routed(ctx, func(c *ZonePlayer) error {
+		_, err := c.AVTransport.StopContext(ctx, c.HttpClient, &avt.StopArgs{})
+		return err
+	})
+}
+
+func (z *ZonePlayer) Next() error {
+	return z.NextContext(context.Background())
+}
+
+// NextContext skips to the next track. Radio streams have none and give
+// ErrNotSupported.
+func (z *ZonePlayer) NextContext(ctx context.Context) error {
+	return z.routed(ctx, func(c *ZonePlayer) error {
+		_, err := c.AVTransport.NextContext(ctx, c.HttpClient, &avt.NextArgs{})
+		return notSupported(err)
+	})
+}
+
+func (z *ZonePlayer) Previous() error {
+	return z.PreviousContext(context.Background())
+}
+
+// PreviousContext goes back to the previous track. Radio streams have none
+// and give ErrNotSupported.
+func (z *ZonePlayer) PreviousContext(ctx context.Context) error {
+	return z.routed(ctx, func(c *ZonePlayer) error {
+		_, err := c.AVTransport.PreviousContext(ctx, c.HttpClient, &avt.PreviousArgs{})
+		return notSupported(err)
+	})
+}
+
+func (z *ZonePlayer) SeekTo(position time.Duration) error {
+	return z.SeekToContext(context.Background(), position)
+}
+
+// SeekToContext moves to position in the current track. Streams without a
+// length give ErrNotSupported.
+func (z *ZonePlayer) SeekToContext(ctx context.Context, position time.Duration) error {
+	if position < 0 {
+		return fmt.Errorf("seek to negative position %v", position)
+	}
+	return z.routed(ctx, func(c *ZonePlayer) error {
+		return c.seekTo(ctx, position)
+	})
+}
+
+func (z *ZonePlayer) seekTo(ctx context.Context, position time.Duration) error {
+	_, err := z.AVTransport.SeekContext(ctx, z.HttpClient, &avt.SeekArgs{
+		Unit:   avt.SeekUnitRelTime,
+		Target: avt.FormatDuration(position),
+	})
+	return notSupported(err)
+}
+
+func (z *ZonePlayer) SeekRelative(offset time.Duration) error {
+	return z.SeekRelativeContext(context.Background(), offset)
+}
+
+// SeekRelativeContext moves offset forward, or backward if it is negative, in
+// the current track, stopping at its start and end. Streams without a length
+// give ErrNotSupported.
+func (z *ZonePlayer) SeekRelativeContext(ctx context.Context, offset time.Duration) error {
+	return z.routed(ctx, func(c *ZonePlayer) error {
+		return c.seekRelative(ctx, offset)
+	})
+}
+
+func (z *ZonePlayer) seekRelative(ctx context.Context, offset time.Duration) error {
+	position, err := z.AVTransport.GetPositionInfoContext(ctx, z.HttpClient, &avt.GetPositionInfoArgs{})
+	if err != nil {
+		return err
+	}
+	duration, err := avt.ParseDuration(position.TrackDuration)
+	if err != nil {
+		return err
+	}
+	if duration == 0 {
+		return ErrNotSupported
+	}
+	current, err := avt.ParseDuration(position.RelTime)
+	if err != nil {
+		return err
+	}
+
+	target := current + offset
+	if target < 0 {
+		target = 0
+	}
+	if target > duration {
+		target = duration
+	}
+	return z.seekTo(ctx, target)
+}
+
+func (z *ZonePlayer) SeekTrack(track int) error {
+	return z.SeekTrackContext(context.Background(), track)
+}
+
+// SeekTrackContext moves to a track of the queue, counting from 1. Sources
+// other than the queue give ErrNotSupported.
+func (z *ZonePlayer) SeekTrackContext(ctx context.Context, track int) error {
+	if track < 1 {
+		return fmt.Errorf("seek to track %d, tracks count from 1", track)
+	}
+	return z.routed(ctx, func(c *ZonePlayer) error {
+		_, err := c.AVTransport.SeekContext(ctx, c.HttpClient, &avt.SeekArgs{
+			Unit:   avt.SeekUnitTrackNR,
+			Target: strconv.Itoa(track),
+		})
+		return notSupported(err)
+	})
+}
+
+func (z *ZonePlayer) SetPlayMode(shuffle bool, repeat Repeat) error {
+	return z.SetPlayModeContext(context.Background(), shuffle, repeat)
+}
+
+// SetPlayModeContext sets the shuffle and repeat modes of the queue. Other
+// sources give ErrNotSupported.
+func (z *ZonePlayer) SetPlayModeContext(ctx context.Context, shuffle bool, repeat Repeat) error {
+	mode, ok := playModes[shuffle][repeat]
+	if !ok {
+		return fmt.Errorf("unknown repeat mode %d", repeat)
+	}
+	return z.routed(ctx, func(c *ZonePlayer) error {
+		_, err := c.AVTransport.SetPlayModeContext(ctx, c.HttpClient, &avt.SetPlayModeArgs{NewPlayMode: mode})
+		return notSupported(err)
+	})
+}
+
+func (z *ZonePlayer) SetCrossfade(crossfade bool) error {
+	return z.SetCrossfadeContext(context.Background(), crossfade)
+}
+
+func (z *ZonePlayer) SetCrossfadeContext(ctx context.Context, crossfade bool) error {
+	return z.routed(ctx, func(c *ZonePlayer) error {
+		_, err := c.AVTransport.SetCrossfadeModeContext(ctx, c.HttpClient, &avt.SetCrossfadeModeArgs{CrossfadeMode: crossfade})
+		return notSupported(err)
+	})
+}
@@ -0,0 +1,248 @@
+package sonos
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sweepInterval is how often the watcher looks for players whose
+// announcements have expired
+const sweepInterval = time.Second
+
+type PlayerEventType int
+
+const (
+	// PlayerAppeared is sent the first time a player announces itself
+	PlayerAppeared PlayerEventType = iota
+	// PlayerUpdated is sent when a known player changes location, reboots or
+	// announces a new BootID with ssdp:update
+	PlayerUpdated
+	// PlayerVanished is sent when a player says goodbye or its last
+	// announcement expires
+	PlayerVanished
+)
+
+func (t PlayerEventType) String() string {
+	switch t {
+	case PlayerAppeared:
+		return "Appeared"
+	case PlayerUpdated:
+		return "Updated"
+	case PlayerVanished:
+		return "Vanished"
+	default:
+		return "PlayerEventType(" + strconv.Itoa(int(t)) + ")"
+	}
+}
+
+type PlayerEvent struct {
+	Type PlayerEventType
+	// UDN identifies the player, e.g. "uuid:RINCON_B8E937833C7401400"
+	UDN      string
+	Location *url.URL
+	// BootID and BootSeq are taken from the BOOTID.UPNP.ORG and
+	// X-RINCON-BOOTSEQ headers and grow every time the player restarts
+	BootID  int
+	BootSeq int
+	// Rebooted is set on PlayerUpdated events caused by a restart, that is an
+	// ssdp:alive with a higher BootID or BootSeq. A BootID changed by
+	// ssdp:update does not count, the player did not restart.
+	Rebooted bool
+}
+
+type watchedPlayer struct {
+	event   PlayerEvent
+	expires time.Time
+}
+
+// Watcher listens for the ssdp:alive and ssdp:byebye NOTIFY messages players
+// multicast, and so keeps track of players without polling.
+type Watcher struct {
+	conn    *net.UDPConn
+	players map[string]*watchedPlayer
+	err     error
+}
+
+// NewWatcher joins the SSDP multicast group on ifi, or on the system default
+// interface if ifi is nil.
+func NewWatcher(ifi *net.Interface) (*Watcher, error) {
+	group, err := net.ResolveUDPAddr("udp4", bcastaddr)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.ListenMulticastUDP("udp4", ifi, group)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Watcher{
+		conn:    conn,
+		players: make(map[string]*watchedPlayer),
+	}, nil
+}
+
+func (w *Watcher) Close() {
+	w.conn.Close()
+}
+
+// Err returns the error that stopped Watch, if any, once its channel is closed.
+func (w *Watcher) Err() error {
+	return w.err
+}
+
+// Watch delivers player events until ctx is done or reading from the socket
+// fails, then closes the returned channel. Only one Watch may run at a time.
+func (w *Watcher) Watch(ctx context.Context) (chan PlayerEvent, error) {
+	events := make(chan PlayerEvent)
+	w.err = nil
+	go w.watch(ctx, events)
+	return events, nil
+}
+
+func (w *Watcher) watch(ctx context.Context, events chan PlayerEvent) {
+	defer close(events)
+
+	send := func(event PlayerEvent) bool {
+		select {
+		case events <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	buf := make([]byte, 2048)
+	for ctx.Err() == nil {
+		w.conn.SetReadDeadline(time.Now().Add(sweepInterval))
+		n, _, err := w.conn.ReadFrom(buf)
+		if err != nil {
+			var netErr net.Error
+			if !errors.As(err, &netErr) || !netErr.Timeout() {
+				if !errors.Is(err, net.ErrClosed) {
+					w.err = err
+				}
+				return
+			}
+		}
+
+		if n > 0 {
+			if event, ok := w.notify(buf[:n]); ok && !send(event) {
+				return
+			}
+		}
+
+		now := time.Now()
+		for udn, player := range w.players {
+			if now.After(player.expires) {
+				delete(w.players, udn)
+				event := player.event
+				event.Type = PlayerVanished
+				event.Rebooted = false
+				if !send(event) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// notify updates the known players from a NOTIFY message and returns the
+// resulting event, if any.
+func (w *Watcher) notify(pkt []byte) (PlayerEvent, bool) {
+	request, err := http.ReadRequest(bufio.NewReader(bytes.NewReader(pkt)))
+	if err != nil || request.Method != "NOTIFY" {
+		return PlayerEvent{}, false
+	}
+	// Players announce themselves once per device and service type, listen
+	// to only one of them
+	if request.Header.Get("NT") != st {
+		return PlayerEvent{}, false
+	}
+	udn, _, _ := strings.Cut(request.Header.Get("USN"), "::")
+	if udn == "" {
+		return PlayerEvent{}, false
+	}
+
+	known, ok := w.players[udn]
+	switch request.Header.Get("NTS") {
+	case "ssdp:byebye":
+		if !ok {
+			return PlayerEvent{}, false
+		}
+		delete(w.players, udn)
+		event := known.event
+		event.Type = PlayerVanished
+		event.Rebooted = false
+		return event, true
+
+	case "ssdp:alive", "ssdp:update":
+		location, err := url.Parse(request.Header.Get("LOCATION"))
+		if err != nil {
+			return PlayerEvent{}, false
+		}
+		event := PlayerEvent{
+			Type:     PlayerAppeared,
+			UDN:      udn,
+			Location: location,
+			BootID:   headerInt(request.Header, "BOOTID.UPNP.ORG"),
+			BootSeq:  headerInt(request.Header, "X-RINCON-BOOTSEQ"),
+		}
+		update := request.Header.Get("NTS") == "ssdp:update"
+		if update {
+			// The player moves to NEXTBOOTID without restarting
+			if next := headerInt(request.Header, "NEXTBOOTID.UPNP.ORG"); next != 0 {
+				event.BootID = next
+			}
+			if ok && request.Header.Get("X-RINCON-BOOTSEQ") == "" {
+				event.BootSeq = known.event.BootSeq
+			}
+		}
+		player := &watchedPlayer{
+			event:   event,
+			expires: time.Now().Add(maxAge(request.Header)),
+		}
+		w.players[udn] = player
+		if !ok {
+			return event, true
+		}
+
+		player.event.Type = PlayerUpdated
+		if update {
+			return player.event, true
+		}
+		player.event.Rebooted = event.BootID > known.event.BootID || event.BootSeq > known.event.BootSeq
+		if player.event.Rebooted || location.String() != known.event.Location.String() {
+			return player.event, true
+		}
+		return PlayerEvent{}, false
+	}
+
+	return PlayerEvent{}, false
+}
+
+func headerInt(header http.Header, key string) int {
+	i, _ := strconv.Atoi(header.Get(key))
+	return i
+}
+
+// maxAge returns how long an announcement is valid for according to its
+// CACHE-CONTROL header, defaulting to the 1800 seconds UPnP recommends.
+func maxAge(header http.Header) time.Duration {
+	for _, directive := range strings.Split(header.Get("CACHE-CONTROL"), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		if strings.EqualFold(strings.TrimSpace(name), "max-age") {
+			if seconds, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+				return time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	return 1800 * time.Second
+}
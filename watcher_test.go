@@ -0,0 +1,66 @@
+package sonos
+
+import (
+	"fmt"
+	"testing"
+)
+
+func notifyPacket(nts, location string, headers string) []byte {
+	return []byte(fmt.Sprintf("NOTIFY * HTTP/1.1\r\n"+
+		"HOST: 239.255.255.250:1900\r\n"+
+		"CACHE-CONTROL: max-age = 1800\r\n"+
+		"LOCATION: %s\r\n"+
+		"NT: urn:schemas-upnp-org:device:ZonePlayer:1\r\n"+
+		"NTS: %s\r\n"+
+		"USN: uuid:RINCON_TV01400::urn:schemas-upnp-org:device:ZonePlayer:1\r\n"+
+		"%s\r\n", location, nts, headers))
+}
+
+func TestWatcherNotify(t *testing.T) {
+	const location = "http://192.168.1.10:1400/xml/device_description.xml"
+	tests := []struct {
+		name     string
+		pkt      []byte
+		sent     bool
+		typ      PlayerEventType
+		rebooted bool
+		bootID   int
+	}{
+		{"first alive", notifyPacket("ssdp:alive", location, "BOOTID.UPNP.ORG: 10\r\nX-RINCON-BOOTSEQ: 10\r\n"), true, PlayerAppeared, false, 10},
+		{"same alive", notifyPacket("ssdp:alive", location, "BOOTID.UPNP.ORG: 10\r\nX-RINCON-BOOTSEQ: 10\r\n"), false, 0, false, 0},
+		{"update", notifyPacket("ssdp:update", location, "BOOTID.UPNP.ORG: 10\r\nNEXTBOOTID.UPNP.ORG: 11\r\n"), true, PlayerUpdated, false, 11},
+		{"alive after update", notifyPacket("ssdp:alive", location, "BOOTID.UPNP.ORG: 11\r\nX-RINCON-BOOTSEQ: 10\r\n"), false, 0, false, 0},
+		{"moved", notifyPacket("ssdp:alive", "http://192.168.1.99:1400/xml/device_description.xml", "BOOTID.UPNP.ORG: 11\r\nX-RINCON-BOOTSEQ: 10\r\n"), true, PlayerUpdated, false, 11},
+		{"restart", notifyPacket("ssdp:alive", "http://192.168.1.99:1400/xml/device_description.xml", "BOOTID.UPNP.ORG: 12\r\nX-RINCON-BOOTSEQ: 11\r\n"), true, PlayerUpdated, true, 12},
+		{"byebye", notifyPacket("ssdp:byebye", location, ""), true, PlayerVanished, false, 12},
+		{"byebye unknown", notifyPacket("ssdp:byebye", location, ""), false, 0, false, 0},
+	}
+
+	w := &Watcher{players: make(map[string]*watchedPlayer)}
+	for _, test := range tests {
+		event, sent := w.notify(test.pkt)
+		if sent != test.sent {
+			t.Errorf("%s: sent = %t, want %t", test.name, sent, test.sent)
+			continue
+		}
+		if !sent {
+			continue
+		}
+		if event.Type != test.typ || event.Rebooted != test.rebooted || event.BootID != test.bootID || event.UDN != "uuid:RINCON_TV01400" {
+			t.Errorf("%s: event = %+v, want %s with Rebooted %t and BootID %d", test.name, event, test.typ, test.rebooted, test.bootID)
+		}
+	}
+}
+
+func TestWatcherNotifyIgnored(t *testing.T) {
+	w := &Watcher{players: make(map[string]*watchedPlayer)}
+	for _, pkt := range [][]byte{
+		[]byte("M-SEARCH * HTTP/1.1\r\nHOST: 239.255.255.250:1900\r\nMAN: \"ssdp:discover\"\r\n\r\n"),
+		[]byte("NOTIFY * HTTP/1.1\r\nNT: upnp:rootdevice\r\nNTS: ssdp:alive\r\nUSN: uuid:RINCON_TV01400::upnp:rootdevice\r\nLOCATION: http://192.168.1.10:1400/xml/device_description.xml\r\n\r\n"),
+		[]byte("not http"),
+	} {
+		if event, sent := w.notify(pkt); sent {
+			t.Errorf("notify(%q) = %+v, want it ignored", pkt, event)
+		}
+	}
+}
@@ -1,96 +1,104 @@
 package sonos
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"strings"
+)
 
 type VanishedDevice struct {
 	XMLName                 xml.Name `xml:"VanishedDevice"`
-	UUID                    string   `xml:"UUID"`
-	Location                string   `xml:"Location"`
-	ZoneName                string   `xml:"ZoneName"`
-	Icon                    string   `xml:"Icon"`
-	Configuration           string   `xml:"Configuration"`
-	SoftwareVersion         string   `xml:"SoftwareVersion"`
-	SWGen                   string   `xml:"SWGen"`
-	MinCompatibleVersion    string   `xml:"MinCompatibleVersion"`
-	LegacyCompatibleVersion string   `xml:"LegacyCompatibleVersion"`
-	BootSeq                 string   `xml:"BootSeq"`
-	TVConfigurationError    string   `xml:"TVConfigurationError"`
-	HdmiCecAvailable        string   `xml:"HdmiCecAvailable"`
-	WirelessMode            string   `xml:"WirelessMode"`
-	WirelessLeafOnly        string   `xml:"WirelessLeafOnly"`
-	HasConfiguredSSID       string   `xml:"HasConfiguredSSID"`
-	ChannelFreq             string   `xml:"ChannelFreq"`
-	BehindWifiExtender      string   `xml:"BehindWifiExtender"`
-	WifiEnabled             string   `xml:"WifiEnabled"`
-	Orientation             string   `xml:"Orientation"`
-	RoomCalibrationState    string   `xml:"RoomCalibrationState"`
-	SecureRegState          string   `xml:"SecureRegState"`
-	VoiceConfigState        string   `xml:"VoiceConfigState"`
-	MicEnabled              string   `xml:"MicEnabled"`
-	AirPlayEnabled          string   `xml:"AirPlayEnabled"`
-	IdleState               string   `xml:"IdleState"`
-	MoreInfo                string   `xml:"MoreInfo"`
+	UUID                    string   `xml:"UUID,attr"`
+	Location                string   `xml:"Location,attr"`
+	ZoneName                string   `xml:"ZoneName,attr"`
+	Icon                    string   `xml:"Icon,attr"`
+	Configuration           string   `xml:"Configuration,attr"`
+	SoftwareVersion         string   `xml:"SoftwareVersion,attr"`
+	SWGen                   string   `xml:"SWGen,attr"`
+	MinCompatibleVersion    string   `xml:"MinCompatibleVersion,attr"`
+	LegacyCompatibleVersion string   `xml:"LegacyCompatibleVersion,attr"`
+	BootSeq                 string   `xml:"BootSeq,attr"`
+	TVConfigurationError    string   `xml:"TVConfigurationError,attr"`
+	HdmiCecAvailable        string   `xml:"HdmiCecAvailable,attr"`
+	WirelessMode            string   `xml:"WirelessMode,attr"`
+	WirelessLeafOnly        string   `xml:"WirelessLeafOnly,attr"`
+	HasConfiguredSSID       string   `xml:"HasConfiguredSSID,attr"`
+	ChannelFreq             string   `xml:"ChannelFreq,attr"`
+	BehindWifiExtender      string   `xml:"BehindWifiExtender,attr"`
+	WifiEnabled             string   `xml:"WifiEnabled,attr"`
+	Orientation             string   `xml:"Orientation,attr"`
+	RoomCalibrationState    string   `xml:"RoomCalibrationState,attr"`
+	SecureRegState          string   `xml:"SecureRegState,attr"`
+	VoiceConfigState        string   `xml:"VoiceConfigState,attr"`
+	MicEnabled              string   `xml:"MicEnabled,attr"`
+	AirPlayEnabled          string   `xml:"AirPlayEnabled,attr"`
+	IdleState               string   `xml:"IdleState,attr"`
+	MoreInfo                string   `xml:"MoreInfo,attr"`
 }
 
 type Satellite struct {
 	XMLName                 xml.Name `xml:"Satellite"`
-	UUID                    string   `xml:"UUID"`
-	Location                string   `xml:"Location"`
-	ZoneName                string   `xml:"ZoneName"`
-	Icon                    string   `xml:"Icon"`
-	Configuration           string   `xml:"Configuration"`
-	SoftwareVersion         string   `xml:"SoftwareVersion"`
-	SWGen                   string   `xml:"SWGen"`
-	MinCompatibleVersion    string   `xml:"MinCompatibleVersion"`
-	LegacyCompatibleVersion string   `xml:"LegacyCompatibleVersion"`
-	BootSeq                 string   `xml:"BootSeq"`
-	TVConfigurationError    string   `xml:"TVConfigurationError"`
-	HdmiCecAvailable        string   `xml:"HdmiCecAvailable"`
-	WirelessMode            string   `xml:"WirelessMode"`
-	WirelessLeafOnly        string   `xml:"WirelessLeafOnly"`
-	HasConfiguredSSID       string   `xml:"HasConfiguredSSID"`
-	ChannelFreq             string   `xml:"ChannelFreq"`
-	BehindWifiExtender      string   `xml:"BehindWifiExtender"`
-	WifiEnabled             string   `xml:"WifiEnabled"`
-	Orientation             string   `xml:"Orientation"`
-	RoomCalibrationState    string   `xml:"RoomCalibrationState"`
-	SecureRegState          string   `xml:"SecureRegState"`
-	VoiceConfigState        string   `xml:"VoiceConfigState"`
-	MicEnabled              string   `xml:"MicEnabled"`
-	AirPlayEnabled          string   `xml:"AirPlayEnabled"`
-	IdleState               string   `xml:"IdleState"`
-	MoreInfo                string   `xml:"MoreInfo"`
+	UUID                    string   `xml:"UUID,attr"`
+	Location                string   `xml:"Location,attr"`
+	ZoneName                string   `xml:"ZoneName,attr"`
+	Icon                    string   `xml:"Icon,attr"`
+	Configuration           string   `xml:"Configuration,attr"`
+	SoftwareVersion         string   `xml:"SoftwareVersion,attr"`
+	SWGen                   string   `xml:"SWGen,attr"`
+	MinCompatibleVersion    string   `xml:"MinCompatibleVersion,attr"`
+	LegacyCompatibleVersion string   `xml:"LegacyCompatibleVersion,attr"`
+	BootSeq                 string   `xml:"BootSeq,attr"`
+	TVConfigurationError    string   `xml:"TVConfigurationError,attr"`
+	HdmiCecAvailable        string   `xml:"HdmiCecAvailable,attr"`
+	WirelessMode            string   `xml:"WirelessMode,attr"`
+	WirelessLeafOnly        string   `xml:"WirelessLeafOnly,attr"`
+	HasConfiguredSSID       string   `xml:"HasConfiguredSSID,attr"`
+	ChannelFreq             string   `xml:"ChannelFreq,attr"`
+	BehindWifiExtender      string   `xml:"BehindWifiExtender,attr"`
+	WifiEnabled             string   `xml:"WifiEnabled,attr"`
+	Orientation             string   `xml:"Orientation,attr"`
+	RoomCalibrationState    string   `xml:"RoomCalibrationState,attr"`
+	SecureRegState          string   `xml:"SecureRegState,attr"`
+	VoiceConfigState        string   `xml:"VoiceConfigState,attr"`
+	MicEnabled              string   `xml:"MicEnabled,attr"`
+	AirPlayEnabled          string   `xml:"AirPlayEnabled,attr"`
+	IdleState               string   `xml:"IdleState,attr"`
+	MoreInfo                string   `xml:"MoreInfo,attr"`
+	Invisible               string   `xml:"Invisible,attr"`
+	ChannelMapSet           string   `xml:"ChannelMapSet,attr"`
 }
 
 type ZoneGroupMember struct {
 	XMLName                 xml.Name         `xml:"ZoneGroupMember"`
-	UUID                    string           `xml:"UUID"`
-	Location                string           `xml:"Location"`
-	ZoneName                string           `xml:"ZoneName"`
-	Icon                    string           `xml:"Icon"`
-	Configuration           string           `xml:"Configuration"`
-	SoftwareVersion         string           `xml:"SoftwareVersion"`
-	SWGen                   string           `xml:"SWGen"`
-	MinCompatibleVersion    string           `xml:"MinCompatibleVersion"`
-	LegacyCompatibleVersion string           `xml:"LegacyCompatibleVersion"`
-	BootSeq                 string           `xml:"BootSeq"`
-	TVConfigurationError    string           `xml:"TVConfigurationError"`
-	HdmiCecAvailable        string           `xml:"HdmiCecAvailable"`
-	WirelessMode            string           `xml:"WirelessMode"`
-	WirelessLeafOnly        string           `xml:"WirelessLeafOnly"`
-	HasConfiguredSSID       string           `xml:"HasConfiguredSSID"`
-	ChannelFreq             string           `xml:"ChannelFreq"`
-	BehindWifiExtender      string           `xml:"BehindWifiExtender"`
-	WifiEnabled             string           `xml:"WifiEnabled"`
-	Orientation             string           `xml:"Orientation"`
-	RoomCalibrationState    string           `xml:"RoomCalibrationState"`
-	SecureRegState          string           `xml:"SecureRegState"`
-	VoiceConfigState        string           `xml:"VoiceConfigState"`
-	MicEnabled              string           `xml:"MicEnabled"`
-	AirPlayEnabled          string           `xml:"AirPlayEnabled"`
-	IdleState               string           `xml:"IdleState"`
-	MoreInfo                string           `xml:"MoreInfo"`
-	Satellite               []Satellite      `xml:"Satellite>Satellite"`
+	UUID                    string           `xml:"UUID,attr"`
+	Location                string           `xml:"Location,attr"`
+	ZoneName                string           `xml:"ZoneName,attr"`
+	Icon                    string           `xml:"Icon,attr"`
+	Configuration           string           `xml:"Configuration,attr"`
+	SoftwareVersion         string           `xml:"SoftwareVersion,attr"`
+	SWGen                   string           `xml:"SWGen,attr"`
+	MinCompatibleVersion    string           `xml:"MinCompatibleVersion,attr"`
+	LegacyCompatibleVersion string           `xml:"LegacyCompatibleVersion,attr"`
+	BootSeq                 string           `xml:"BootSeq,attr"`
+	TVConfigurationError    string           `xml:"TVConfigurationError,attr"`
+	HdmiCecAvailable        string           `xml:"HdmiCecAvailable,attr"`
+	WirelessMode            string           `xml:"WirelessMode,attr"`
+	WirelessLeafOnly        string           `xml:"WirelessLeafOnly,attr"`
+	HasConfiguredSSID       string           `xml:"HasConfiguredSSID,attr"`
+	ChannelFreq             string           `xml:"ChannelFreq,attr"`
+	BehindWifiExtender      string           `xml:"BehindWifiExtender,attr"`
+	WifiEnabled             string           `xml:"WifiEnabled,attr"`
+	Orientation             string           `xml:"Orientation,attr"`
+	RoomCalibrationState    string           `xml:"RoomCalibrationState,attr"`
+	SecureRegState          string           `xml:"SecureRegState,attr"`
+	VoiceConfigState        string           `xml:"VoiceConfigState,attr"`
+	MicEnabled              string           `xml:"MicEnabled,attr"`
+	AirPlayEnabled          string           `xml:"AirPlayEnabled,attr"`
+	IdleState               string           `xml:"IdleState,attr"`
+	MoreInfo                string           `xml:"MoreInfo,attr"`
+	Invisible               string           `xml:"Invisible,attr"`
+	ChannelMapSet           string           `xml:"ChannelMapSet,attr"`
+	HTSatChanMapSet         string           `xml:"HTSatChanMapSet,attr"`
+	Satellite               []Satellite      `xml:"Satellite"`
 	VanishedDevice          []VanishedDevice `xml:"VanishedDevices>VanishedDevice"`
 }
 
@@ -105,3 +113,87 @@ type ZoneGroupState struct {
 	XMLName    xml.Name    `xml:"ZoneGroupState"`
 	ZoneGroups []ZoneGroup `xml:"ZoneGroups>ZoneGroup"`
 }
+
+// parseZoneGroupState parses the ZoneGroupState state variable. Older
+// firmware leaves out the ZoneGroupState element and starts at ZoneGroups.
+func parseZoneGroupState(state string) (*ZoneGroupState, error) {
+	var zoneGroupState ZoneGroupState
+	err := xml.Unmarshal([]byte(state), &zoneGroupState)
+	if err == nil {
+		return &zoneGroupState, nil
+	}
+	var zoneGroups struct {
+		XMLName    xml.Name    `xml:"ZoneGroups"`
+		ZoneGroups []ZoneGroup `xml:"ZoneGroup"`
+	}
+	if xml.Unmarshal([]byte(state), &zoneGroups) != nil {
+		return nil, err
+	}
+	zoneGroupState.ZoneGroups = zoneGroups.ZoneGroups
+	return &zoneGroupState, nil
+}
+
+// Role describes how a player takes part in its household's topology.
+type Role string
+
+const (
+	// RoleCoordinator is the player that controls playback for its group.
+	RoleCoordinator Role = "coordinator"
+	// RoleMember is a visible player grouped under another coordinator.
+	RoleMember Role = "member"
+	// RoleSatellite is a surround speaker bonded to a home theater player.
+	RoleSatellite Role = "satellite"
+	// RoleSub is a subwoofer bonded to another player.
+	RoleSub Role = "sub"
+	// RoleStereoPairSlave is the hidden half of a stereo pair.
+	RoleStereoPairSlave Role = "stereo-pair-slave"
+)
+
+// Role returns the role of the player with the given UUID, with or without
+// the "uuid:" prefix used by device descriptions. The second return value
+// is false if the player is not part of the state.
+func (z *ZoneGroupState) Role(id string) (Role, bool) {
+	id = uuid(id)
+	for _, group := range z.ZoneGroups {
+		for _, member := range group.ZoneGroupMember {
+			if member.UUID == id {
+				switch {
+				case group.Coordinator == id:
+					return RoleCoordinator, true
+				case member.Invisible != "1" || member.ChannelMapSet == "":
+					return RoleMember, true
+				case hasChannel(member.ChannelMapSet, id, "SW"):
+					return RoleSub, true
+				default:
+					return RoleStereoPairSlave, true
+				}
+			}
+			for _, satellite := range member.Satellite {
+				if satellite.UUID == id {
+					if hasChannel(member.HTSatChanMapSet, id, "SW") {
+						return RoleSub, true
+					}
+					return RoleSatellite, true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// hasChannel reports whether uuid is mapped to channel in a channel map such
+// as "RINCON_A:LF,RF;RINCON_B:SW".
+func hasChannel(channelMap, uuid, channel string) bool {
+	for _, entry := range strings.Split(channelMap, ";") {
+		player, channels, ok := strings.Cut(entry, ":")
+		if !ok || player != uuid {
+			continue
+		}
+		for _, c := range strings.Split(channels, ",") {
+			if c == channel {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package sonos
+
+import "testing"
+
+// zoneGroupState is a household with a home theater player grouped with a
+// kitchen player, and a stereo pair with a sub
+const zoneGroupState = `<ZoneGroupState><ZoneGroups>` +
+	`<ZoneGroup Coordinator="RINCON_TV01400" ID="RINCON_TV01400:1">` +
+	`<ZoneGroupMember UUID="RINCON_TV01400" Location="http://192.168.1.10:1400/xml/device_description.xml" ZoneName="Living Room" HTSatChanMapSet="RINCON_TV01400:LF,RF;RINCON_SL01400:LR;RINCON_SR01400:RR;RINCON_SW01400:SW">` +
+	`<Satellite UUID="RINCON_SL01400" Location="http://192.168.1.11:1400/xml/device_description.xml" ZoneName="Living Room" HTSatChanMapSet="RINCON_TV01400:LF,RF;RINCON_SL01400:LR;RINCON_SR01400:RR;RINCON_SW01400:SW" Invisible="1"/>` +
+	`<Satellite UUID="RINCON_SR01400" Location="http://192.168.1.12:1400/xml/device_description.xml" ZoneName="Living Room" HTSatChanMapSet="RINCON_TV01400:LF,RF;RINCON_SL01400:LR;RINCON_SR01400:RR;RINCON_SW01400:SW" Invisible="1"/>` +
+	`<Satellite UUID="RINCON_SW01400" Location="http://192.168.1.13:1400/xml/device_description.xml" ZoneName="Living Room" HTSatChanMapSet="RINCON_TV01400:LF,RF;RINCON_SL01400:LR;RINCON_SR01400:RR;RINCON_SW01400:SW" Invisible="1"/>` +
+	`</ZoneGroupMember>` +
+	`<ZoneGroupMember UUID="RINCON_KI01400" Location="http://192.168.1.20:1400/xml/device_description.xml" ZoneName="Kitchen"/>` +
+	`</ZoneGroup>` +
+	`<ZoneGroup Coordinator="RINCON_BL01400" ID="RINCON_BL01400:7">` +
+	`<ZoneGroupMember UUID="RINCON_BL01400" Location="http://192.168.1.30:1400/xml/device_description.xml" ZoneName="Bedroom" ChannelMapSet="RINCON_BL01400:LF,LF;RINCON_BR01400:RF,RF;RINCON_BS01400:SW,SW"/>` +
+	`<ZoneGroupMember UUID="RINCON_BR01400" Location="http://192.168.1.31:1400/xml/device_description.xml" ZoneName="Bedroom" ChannelMapSet="RINCON_BL01400:LF,LF;RINCON_BR01400:RF,RF;RINCON_BS01400:SW,SW" Invisible="1"/>` +
+	`<ZoneGroupMember UUID="RINCON_BS01400" Location="http://192.168.1.32:1400/xml/device_description.xml" ZoneName="Bedroom" ChannelMapSet="RINCON_BL01400:LF,LF;RINCON_BR01400:RF,RF;RINCON_BS01400:SW,SW" Invisible="1"/>` +
+	`</ZoneGroup>` +
+	`</ZoneGroups><VanishedDevices/></ZoneGroupState>`
+
+func TestZoneGroupStateRole(t *testing.T) {
+	state, err := parseZoneGroupState(zoneGroupState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		id   string
+		role Role
+	}{
+		{"RINCON_TV01400", RoleCoordinator},
+		{"uuid:RINCON_TV01400", RoleCoordinator},
+		{"RINCON_KI01400", RoleMember},
+		{"RINCON_SL01400", RoleSatellite},
+		{"RINCON_SR01400", RoleSatellite},
+		{"RINCON_SW01400", RoleSub},
+		{"RINCON_BL01400", RoleCoordinator},
+		{"RINCON_BR01400", RoleStereoPairSlave},
+		{"RINCON_BS01400", RoleSub},
+	}
+	for _, test := range tests {
+		role, ok := state.Role(test.id)
+		if !ok || role != test.role {
+			t.Errorf("Role(%s) = %q, %t, want %q", test.id, role, ok, test.role)
+		}
+	}
+	if role, ok := state.Role("RINCON_XX01400"); ok {
+		t.Errorf("Role of an unknown player = %q, want not found", role)
+	}
+}
+
+func TestHasChannel(t *testing.T) {
+	tests := []struct {
+		channelMap, uuid, channel string
+		want                      bool
+	}{
+		{"RINCON_A:LF,RF;RINCON_B:SW", "RINCON_B", "SW", true},
+		{"RINCON_A:LF,RF;RINCON_B:SW", "RINCON_A", "RF", true},
+		{"RINCON_A:LF,RF;RINCON_B:SW", "RINCON_A", "SW", false},
+		{"RINCON_A:LF,RF;RINCON_B:SW", "RINCON_C", "SW", false},
+		{"", "RINCON_A", "SW", false},
+	}
+	for _, test := range tests {
+		if got := hasChannel(test.channelMap, test.uuid, test.channel); got != test.want {
+			t.Errorf("hasChannel(%q, %s, %s) = %t, want %t", test.channelMap, test.uuid, test.channel, got, test.want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package sonos
 
 import (
+	"context"
 	"encoding/xml"
 	"io/ioutil"
 	"net/http"
@@ -20,6 +21,7 @@ import (
 	sys "github.com/szatmary/sonos/SystemProperties"
 	vli "github.com/szatmary/sonos/VirtualLineIn"
 	zgt "github.com/szatmary/sonos/ZoneGroupTopology"
+	"github.com/szatmary/sonos/soap"
 )
 
 type SpecVersion struct {
@@ -101,27 +103,49 @@ type ZonePlayer struct {
 	Root                 *Root
 	HttpClient           *http.Client
 	DeviceDescriptionURL *url.URL
-	// services
-	AlarmClock            *clk.Service
-	AVTransport           *avt.Service
-	ConnectionManager     *con.Service
-	ContentDirectory      *dir.Service
-	DeviceProperties      *dev.Service
-	GroupManagement       *gmn.Service
-	GroupRenderingControl *rcg.Service
-	MusicServices         *mus.Service
-	QPlay                 *ply.Service
-	Queue                 *que.Service
-	RenderingControl      *ren.Service
-	SystemProperties      *sys.Service
-	VirtualLineIn         *vli.Service
-	ZoneGroupTopology     *zgt.Service
+	// Role is the player's role when it was discovered, empty if unknown.
+	// It is a snapshot that is not updated as the topology changes.
+	Role Role
+	// NoCoordinatorRouting sends transport and queue commands to this player
+	// even when it is a member of a group, rather than to the coordinator
+	// of the group
+	NoCoordinatorRouting bool
+	// services, the generated Service of each package unless replaced, e.g.
+	// by the Fake of the package to test code using the player
+	AlarmClock            clk.Client
+	AVTransport           avt.Client
+	ConnectionManager     con.Client
+	ContentDirectory      dir.Client
+	DeviceProperties      dev.Client
+	GroupManagement       gmn.Client
+	GroupRenderingControl rcg.Client
+	MusicServices         mus.Client
+	QPlay                 ply.Client
+	Queue                 que.Client
+	RenderingControl      ren.Client
+	SystemProperties      sys.Client
+	VirtualLineIn         vli.Client
+	ZoneGroupTopology     zgt.Client
+
+	coordinatorCache coordinatorCache
 }
 
 func NewZonePlayer(deviceDescriptionURL *url.URL) (*ZonePlayer, error) {
+	return NewZonePlayerContext(context.Background(), deviceDescriptionURL)
+}
+
+// NewZonePlayerContext is like NewZonePlayer but fetches the device
+// description with the given context.
+func NewZonePlayerContext(ctx context.Context, deviceDescriptionURL *url.URL) (*ZonePlayer, error) {
+	return newZonePlayer(ctx, &http.Client{}, deviceDescriptionURL)
+}
+
+// newZonePlayer creates a player using httpClient, which also fetches the
+// device description.
+func newZonePlayer(ctx context.Context, httpClient *http.Client, deviceDescriptionURL *url.URL) (*ZonePlayer, error) {
 	zp := ZonePlayer{
 		Root:                  &Root{},
-		HttpClient:            &http.Client{},
+		HttpClient:            httpClient,
 		DeviceDescriptionURL:  deviceDescriptionURL,
 		AlarmClock:            clk.NewService(deviceDescriptionURL),
 		AVTransport:           avt.NewService(deviceDescriptionURL),
@@ -139,7 +163,11 @@ func NewZonePlayer(deviceDescriptionURL *url.URL) (*ZonePlayer, error) {
 		ZoneGroupTopology:     zgt.NewService(deviceDescriptionURL),
 	}
 
-	resp, err := zp.HttpClient.Get(zp.DeviceDescriptionURL.String())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, zp.DeviceDescriptionURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := zp.HttpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -158,6 +186,53 @@ func NewZonePlayer(deviceDescriptionURL *url.URL) (*ZonePlayer, error) {
 	return &zp, nil
 }
 
+// SetSOAPClient makes every generated service of the player send its actions
+// through client. Services replaced by other Clients are left alone.
+func (z *ZonePlayer) SetSOAPClient(client *soap.Client) {
+	if s, ok := z.AlarmClock.(*clk.Service); ok && s != nil {
+		s.Client = client
+	}
+	if s, ok := z.AVTransport.(*avt.Service); ok && s != nil {
+		s.Client = client
+	}
+	if s, ok := z.ConnectionManager.(*con.Service); ok && s != nil {
+		s.Client = client
+	}
+	if s, ok := z.ContentDirectory.(*dir.Service); ok && s != nil {
+		s.Client = client
+	}
+	if s, ok := z.DeviceProperties.(*dev.Service); ok && s != nil {
+		s.Client = client
+	}
+	if s, ok := z.GroupManagement.(*gmn.Service); ok && s != nil {
+		s.Client = client
+	}
+	if s, ok := z.GroupRenderingControl.(*rcg.Service); ok && s != nil {
+		s.Client = client
+	}
+	if s, ok := z.MusicServices.(*mus.Service); ok && s != nil {
+		s.Client = client
+	}
+	if s, ok := z.QPlay.(*ply.Service); ok && s != nil {
+		s.Client = client
+	}
+	if s, ok := z.Queue.(*que.Service); ok && s != nil {
+		s.Client = client
+	}
+	if s, ok := z.RenderingControl.(*ren.Service); ok && s != nil {
+		s.Client = client
+	}
+	if s, ok := z.SystemProperties.(*sys.Service); ok && s != nil {
+		s.Client = client
+	}
+	if s, ok := z.VirtualLineIn.(*vli.Service); ok && s != nil {
+		s.Client = client
+	}
+	if s, ok := z.ZoneGroupTopology.(*zgt.Service); ok && s != nil {
+		s.Client = client
+	}
+}
+
 func (z *ZonePlayer) RoomName() string {
 	return z.Root.Device.RoomName
 }
@@ -179,33 +254,30 @@ func (z *ZonePlayer) IsCoordinator() bool {
 	if err != nil {
 		return false
 	}
-	for _, group := range zoneGroupState.ZoneGroups {
-		if "uuid:"+group.Coordinator == z.Root.Device.UDN {
-			return true
-		}
-	}
-
-	return false
+	role, _ := zoneGroupState.Role(z.Root.Device.UDN)
+	return role == RoleCoordinator
 }
 
 // Convience functions
 
 func (z *ZonePlayer) GetZoneGroupState() (*ZoneGroupState, error) {
-	zoneGroupStateResponse, err := z.ZoneGroupTopology.GetZoneGroupState(z.HttpClient, &zgt.GetZoneGroupStateArgs{})
-	if err != nil {
-		return nil, err
-	}
-	var zoneGroupState ZoneGroupState
-	err = xml.Unmarshal([]byte(zoneGroupStateResponse.ZoneGroupState), &zoneGroupState)
+	return z.GetZoneGroupStateContext(context.Background())
+}
+
+func (z *ZonePlayer) GetZoneGroupStateContext(ctx context.Context) (*ZoneGroupState, error) {
+	return getZoneGroupState(ctx, z.HttpClient, z.ZoneGroupTopology)
+}
+
+func getZoneGroupState(ctx context.Context, httpClient *http.Client, service zgt.Client) (*ZoneGroupState, error) {
+	zoneGroupStateResponse, err := service.GetZoneGroupStateContext(ctx, httpClient, &zgt.GetZoneGroupStateArgs{})
 	if err != nil {
 		return nil, err
 	}
-
-	return &zoneGroupState, nil
+	return parseZoneGroupState(zoneGroupStateResponse.ZoneGroupState)
 }
 
 func (z *ZonePlayer) GetVolume() (int, error) {
-	res, err := z.RenderingControl.GetVolume(z.HttpClient, &ren.GetVolumeArgs{Channel: "Master"})
+	res, err := z.RenderingControl.GetVolume(z.HttpClient, &ren.GetVolumeArgs{Channel: ren.ChannelMaster})
 	if err != nil {
 		return 0, err
 	}
@@ -215,22 +287,34 @@ func (z *ZonePlayer) GetVolume() (int, error) {
 
 func (z *ZonePlayer) SetVolume(desiredVolume int) error {
 	_, err := z.RenderingControl.SetVolume(z.HttpClient, &ren.SetVolumeArgs{
-		Channel:       "Master",
+		Channel:       ren.ChannelMaster,
 		DesiredVolume: uint16(desiredVolume),
 	})
 	return err
 }
 
 func (z *ZonePlayer) Play() error {
-	_, err := z.AVTransport.Play(z.HttpClient, &avt.PlayArgs{
-		Speed: "1.0",
+	return z.PlayContext(context.Background())
+}
+
+func (z *ZonePlayer) PlayContext(ctx context.Context) error {
+	return z.routed(ctx, func(c *ZonePlayer) error {
+		_, err := c.AVTransport.PlayContext(ctx, c.HttpClient, &avt.PlayArgs{
+			Speed: avt.TransportPlaySpeed1,
+		})
+		return err
 	})
-	return err
 }
 
 func (z *ZonePlayer) SetAVTransportURI(url string) error {
-	_, err := z.AVTransport.SetAVTransportURI(z.HttpClient, &avt.SetAVTransportURIArgs{
-		CurrentURI: url,
+	return z.SetAVTransportURIContext(context.Background(), url)
+}
+
+func (z *ZonePlayer) SetAVTransportURIContext(ctx context.Context, url string) error {
+	return z.routed(ctx, func(c *ZonePlayer) error {
+		_, err := c.AVTransport.SetAVTransportURIContext(ctx, c.HttpClient, &avt.SetAVTransportURIArgs{
+			CurrentURI: url,
+		})
+		return err
 	})
-	return err
 }
@@ -0,0 +1,369 @@
+package sonos
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	avt "github.com/szatmary/sonos/AVTransport"
+	ren "github.com/szatmary/sonos/RenderingControl"
+	zgt "github.com/szatmary/sonos/ZoneGroupTopology"
+	"github.com/szatmary/sonos/soap"
+)
+
+// fakePlayer returns the Living Room player of zoneGroupState, the
+// coordinator of its group, talking to fakes
+func fakePlayer(t *testing.T) (*ZonePlayer, *avt.Fake, *zgt.Fake) {
+	t.Helper()
+	location, err := url.Parse("http://192.168.1.10:1400/xml/device_description.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport := &avt.Fake{}
+	topology := &zgt.Fake{
+		OnGetZoneGroupState: func(ctx context.Context, args *zgt.GetZoneGroupStateArgs) (*zgt.GetZoneGroupStateResponse, error) {
+			return &zgt.GetZoneGroupStateResponse{ZoneGroupState: zoneGroupState}, nil
+		},
+	}
+	zp := &ZonePlayer{
+		Root:                 &Root{Device: Device{UDN: "uuid:RINCON_TV01400", RoomName: "Living Room"}},
+		DeviceDescriptionURL: location,
+		AVTransport:          transport,
+		ZoneGroupTopology:    topology,
+	}
+	return zp, transport, topology
+}
+
+func actions(calls interface{}) []string {
+	var names []string
+	switch calls := calls.(type) {
+	case []avt.Call:
+		for _, call := range calls {
+			names = append(names, call.Action)
+		}
+	case []zgt.Call:
+		for _, call := range calls {
+			names = append(names, call.Action)
+		}
+	}
+	return names
+}
+
+func TestPlay(t *testing.T) {
+	zp, transport, topology := fakePlayer(t)
+	if err := zp.Play(); err != nil {
+		t.Fatal(err)
+	}
+	calls := transport.Calls()
+	if len(calls) != 1 || calls[0].Action != "Play" || calls[0].Args.(*avt.PlayArgs).Speed != avt.TransportPlaySpeed1 {
+		t.Errorf("AVTransport calls = %+v, want Play at speed 1", calls)
+	}
+	if got := actions(topology.Calls()); len(got) != 1 {
+		t.Errorf("ZoneGroupTopology calls = %v, want one GetZoneGroupState", got)
+	}
+
+	// The coordinator found is reused by the next command
+	if err := zp.Pause(); err != nil {
+		t.Fatal(err)
+	}
+	if got := actions(topology.Calls()); len(got) != 1 {
+		t.Errorf("ZoneGroupTopology calls = %v, want the coordinator cached", got)
+	}
+}
+
+func TestNoCoordinatorRouting(t *testing.T) {
+	zp, transport, topology := fakePlayer(t)
+	zp.NoCoordinatorRouting = true
+	if err := zp.SetAVTransportURI("x-rincon-queue:RINCON_TV01400#0"); err != nil {
+		t.Fatal(err)
+	}
+	if got := actions(topology.Calls()); len(got) != 0 {
+		t.Errorf("ZoneGroupTopology calls = %v, want none", got)
+	}
+	calls := transport.Calls()
+	if len(calls) != 1 || calls[0].Args.(*avt.SetAVTransportURIArgs).CurrentURI != "x-rincon-queue:RINCON_TV01400#0" {
+		t.Errorf("AVTransport calls = %+v", calls)
+	}
+}
+
+// A player refusing a command as not being the coordinator makes the
+// topology be read again and the command be retried once
+func TestRoutedRetry(t *testing.T) {
+	zp, transport, topology := fakePlayer(t)
+	transport.OnStop = func(ctx context.Context, args *avt.StopArgs) (*avt.StopResponse, error) {
+		return nil, &soap.UPnPError{Action: "Stop", Code: errNotCoordinator}
+	}
+	err := zp.Stop()
+	var upnpErr *soap.UPnPError
+	if !errors.As(err, &upnpErr) || upnpErr.Code != errNotCoordinator {
+		t.Errorf("Stop = %v, want UPnP error 800", err)
+	}
+	if got := actions(transport.Calls()); len(got) != 2 {
+		t.Errorf("AVTransport calls = %v, want Stop twice", got)
+	}
+	if got := actions(topology.Calls()); len(got) != 2 {
+		t.Errorf("ZoneGroupTopology calls = %v, want the topology read twice", got)
+	}
+}
+
+func TestNotSupported(t *testing.T) {
+	zp, transport, _ := fakePlayer(t)
+	transport.OnNext = func(ctx context.Context, args *avt.NextArgs) (*avt.NextResponse, error) {
+		return nil, &soap.UPnPError{Action: "Next", Code: 711}
+	}
+	err := zp.Next()
+	if !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Next = %v, want ErrNotSupported", err)
+	}
+	var upnpErr *soap.UPnPError
+	if !errors.As(err, &upnpErr) {
+		t.Errorf("Next = %v, want the UPnP error kept", err)
+	}
+}
+
+func TestSeekRelative(t *testing.T) {
+	tests := []struct {
+		duration string
+		offset   time.Duration
+		target   string
+		err      error
+	}{
+		{"0:03:00", 30 * time.Second, "0:01:30", nil},
+		{"0:03:00", -2 * time.Minute, "0:00:00", nil},
+		{"0:03:00", 10 * time.Minute, "0:03:00", nil},
+		{"NOT_IMPLEMENTED", 30 * time.Second, "", ErrNotSupported},
+	}
+	for _, test := range tests {
+		zp, transport, _ := fakePlayer(t)
+		transport.OnGetPositionInfo = func(ctx context.Context, args *avt.GetPositionInfoArgs) (*avt.GetPositionInfoResponse, error) {
+			return &avt.GetPositionInfoResponse{TrackDuration: test.duration, RelTime: "0:01:00"}, nil
+		}
+		err := zp.SeekRelative(test.offset)
+		if !errors.Is(err, test.err) {
+			t.Errorf("SeekRelative(%v) in %s = %v, want %v", test.offset, test.duration, err, test.err)
+			continue
+		}
+		calls := transport.Calls()
+		if test.target == "" {
+			if len(calls) != 1 {
+				t.Errorf("SeekRelative(%v) in %s called %v, want no seek", test.offset, test.duration, actions(calls))
+			}
+			continue
+		}
+		if len(calls) != 2 {
+			t.Errorf("SeekRelative(%v) in %s called %v", test.offset, test.duration, actions(calls))
+			continue
+		}
+		if args := calls[1].Args.(*avt.SeekArgs); args.Unit != avt.SeekUnitRelTime || args.Target != test.target {
+			t.Errorf("SeekRelative(%v) in %s = Seek(%s, %s), want Seek(REL_TIME, %s)", test.offset, test.duration, args.Unit, args.Target, test.target)
+		}
+	}
+}
+
+func TestSetPlayMode(t *testing.T) {
+	zp, transport, _ := fakePlayer(t)
+	if err := zp.SetPlayMode(true, RepeatOne); err != nil {
+		t.Fatal(err)
+	}
+	calls := transport.Calls()
+	if len(calls) != 1 || calls[0].Args.(*avt.SetPlayModeArgs).NewPlayMode != avt.PlayModeShuffleRepeatOne {
+		t.Errorf("AVTransport calls = %+v, want SetPlayMode(SHUFFLE_REPEAT_ONE)", calls)
+	}
+	if shuffle, repeat := PlayModeOf(avt.PlayModeShuffleRepeatOne); !shuffle || repeat != RepeatOne {
+		t.Errorf("PlayModeOf(SHUFFLE_REPEAT_ONE) = %t, %d", shuffle, repeat)
+	}
+	if err := zp.SetPlayMode(false, Repeat(7)); err == nil {
+		t.Error("SetPlayMode with an unknown repeat mode succeeded")
+	}
+}
+
+func TestNowPlaying(t *testing.T) {
+	zp, transport, _ := fakePlayer(t)
+	transport.OnGetTransportInfo = func(ctx context.Context, args *avt.GetTransportInfoArgs) (*avt.GetTransportInfoResponse, error) {
+		return &avt.GetTransportInfoResponse{CurrentTransportState: avt.TransportStatePlaying}, nil
+	}
+	transport.OnGetPositionInfo = func(ctx context.Context, args *avt.GetPositionInfoArgs) (*avt.GetPositionInfoResponse, error) {
+		return &avt.GetPositionInfoResponse{
+			Track:         3,
+			TrackDuration: "0:03:43",
+			RelTime:       "0:01:05",
+			TrackURI:      "x-file-cifs://nas/music/song.mp3",
+			TrackMetaData: `<DIDL-Lite xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:upnp="urn:schemas-upnp-org:metadata-1-0/upnp/" xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/"><item id="-1" parentID="-1"><dc:title>Song</dc:title><upnp:artist>Artist</upnp:artist><upnp:album>Album</upnp:album><upnp:albumArtURI>/getaa?s=1&amp;u=x</upnp:albumArtURI></item></DIDL-Lite>`,
+		}, nil
+	}
+	transport.OnGetMediaInfo = func(ctx context.Context, args *avt.GetMediaInfoArgs) (*avt.GetMediaInfoResponse, error) {
+		return &avt.GetMediaInfoResponse{NrTracks: 12, CurrentURI: "x-rincon-queue:RINCON_TV01400#0", CurrentURIMetaData: "NOT_IMPLEMENTED"}, nil
+	}
+	transport.OnGetTransportSettings = func(ctx context.Context, args *avt.GetTransportSettingsArgs) (*avt.GetTransportSettingsResponse, error) {
+		return &avt.GetTransportSettingsResponse{PlayMode: avt.PlayModeShuffle}, nil
+	}
+
+	np, err := zp.NowPlaying()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if np.State != avt.TransportStatePlaying || np.PlayMode != avt.PlayModeShuffle || np.Source != SourceQueue {
+		t.Errorf("NowPlaying state = %s, %s, %s", np.State, np.PlayMode, np.Source)
+	}
+	if np.Track != 3 || np.NumberOfTracks != 12 || np.Position != 65*time.Second || np.Duration != 223*time.Second {
+		t.Errorf("NowPlaying position = track %d of %d, %v of %v", np.Track, np.NumberOfTracks, np.Position, np.Duration)
+	}
+	if np.Title != "Song" || np.Artist != "Artist" || np.Album != "Album" {
+		t.Errorf("NowPlaying track = %q by %q on %q", np.Title, np.Artist, np.Album)
+	}
+	if np.AlbumArtURL == nil || np.AlbumArtURL.String() != "http://192.168.1.10:1400/getaa?s=1&u=x" {
+		t.Errorf("NowPlaying AlbumArtURL = %v", np.AlbumArtURL)
+	}
+}
+
+func TestGetVolume(t *testing.T) {
+	zp, _, _ := fakePlayer(t)
+	zp.RenderingControl = &ren.Fake{
+		OnGetVolume: func(ctx context.Context, args *ren.GetVolumeArgs) (*ren.GetVolumeResponse, error) {
+			if args.Channel != ren.ChannelMaster {
+				t.Errorf("GetVolume channel = %s, want Master", args.Channel)
+			}
+			return &ren.GetVolumeResponse{CurrentVolume: 23}, nil
+		},
+	}
+	volume, err := zp.GetVolume()
+	if err != nil || volume != 23 {
+		t.Errorf("GetVolume = %d, %v, want 23", volume, err)
+	}
+}
+
+func TestSourceOf(t *testing.T) {
+	tests := []struct {
+		uri  string
+		kind SourceKind
+	}{
+		{"", SourceNone},
+		{"x-rincon-queue:RINCON_TV01400#0", SourceQueue},
+		{"x-sonos-htastream:RINCON_TV01400:spdif", SourceTV},
+		{"x-rincon-stream:RINCON_KI01400", SourceLineIn},
+		{"x-rincon:RINCON_TV01400", SourceGroup},
+		{"x-sonosapi-stream:s24861?sid=254&flags=8224&sn=0", SourceRadio},
+		{"x-sonos-vli:RINCON_TV01400:1,airplay:abc", SourceVirtualLineIn},
+		{"http://example.com/song.mp3", SourceStream},
+	}
+	for _, test := range tests {
+		if kind := SourceOf(test.uri); kind != test.kind {
+			t.Errorf("SourceOf(%q) = %s, want %s", test.uri, kind, test.kind)
+		}
+	}
+}
+
+// Commands sent to a satellite go to the coordinator of its home theater,
+// created with the HttpClient and the Clients of the satellite
+func TestSatelliteRouting(t *testing.T) {
+	srv := fakeSpeaker(t, "uuid:RINCON_TV01400", "Living Room")
+	target, _ := url.Parse(srv.URL)
+	redirected := &redirect{target: target}
+
+	satellite, transport, topology := fakePlayer(t)
+	satellite.Root.Device.UDN = "uuid:RINCON_SL01400"
+	satellite.HttpClient = &http.Client{Transport: redirected}
+
+	if err := satellite.Play(); err != nil {
+		t.Fatal(err)
+	}
+	if len(redirected.hosts) != 1 || redirected.hosts[0] != "192.168.1.10:1400" {
+		t.Errorf("requests = %v, want the device description of the coordinator", redirected.hosts)
+	}
+	if got := actions(transport.Calls()); len(got) != 1 || got[0] != "Play" {
+		t.Errorf("AVTransport calls = %v, want Play", got)
+	}
+
+	coordinator, err := satellite.Coordinator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if coordinator.Root.Device.UDN != "uuid:RINCON_TV01400" || !coordinator.NoCoordinatorRouting {
+		t.Errorf("Coordinator = %s, want RINCON_TV01400 without routing", coordinator.Root.Device.UDN)
+	}
+	if coordinator.HttpClient != satellite.HttpClient || coordinator.AVTransport != satellite.AVTransport || coordinator.ZoneGroupTopology != satellite.ZoneGroupTopology {
+		t.Error("Coordinator does not share the HttpClient and Clients of the satellite")
+	}
+	if got := actions(topology.Calls()); len(got) != 2 {
+		t.Errorf("ZoneGroupTopology calls = %v, want one per lookup", got)
+	}
+}
+
+// A player without a generated AVTransport still gets its coordinator
+func TestRoutingWithoutService(t *testing.T) {
+	srv := fakeSpeaker(t, "uuid:RINCON_TV01400", "Living Room")
+	target, _ := url.Parse(srv.URL)
+
+	zp, _, _ := fakePlayer(t)
+	zp.Root.Device.UDN = "uuid:RINCON_KI01400"
+	zp.HttpClient = &http.Client{Transport: &redirect{target: target}}
+	for _, transport := range []avt.Client{nil, (*avt.Service)(nil)} {
+		zp.AVTransport = transport
+		coordinator, err := zp.Coordinator()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if coordinator.Root.Device.UDN != "uuid:RINCON_TV01400" {
+			t.Errorf("Coordinator = %s, want RINCON_TV01400", coordinator.Root.Device.UDN)
+		}
+		if _, ok := coordinator.AVTransport.(*avt.Service); !ok {
+			t.Errorf("Coordinator AVTransport = %T, want its own service", coordinator.AVTransport)
+		}
+	}
+}
+
+// Looking up the coordinator does not hold up commands that can use the one
+// cached
+func TestCoordinatorLookupNotBlocking(t *testing.T) {
+	zp, transport, topology := fakePlayer(t)
+	release := make(chan struct{})
+	topology.OnGetZoneGroupState = func(ctx context.Context, args *zgt.GetZoneGroupStateArgs) (*zgt.GetZoneGroupStateResponse, error) {
+		<-release
+		return &zgt.GetZoneGroupStateResponse{ZoneGroupState: zoneGroupState}, nil
+	}
+	zp.coordinatorCache.player = zp
+	zp.coordinatorCache.expires = time.Now().Add(time.Minute)
+
+	lookup := make(chan error)
+	go func() {
+		_, err := zp.Coordinator()
+		lookup <- err
+	}()
+	for len(topology.Calls()) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	played := make(chan error)
+	go func() { played <- zp.Pause() }()
+	select {
+	case err := <-played:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("Pause held up by the coordinator lookup")
+	}
+	close(release)
+	if err := <-lookup; err != nil {
+		t.Error(err)
+	}
+	if got := actions(transport.Calls()); len(got) != 1 || got[0] != "Pause" {
+		t.Errorf("AVTransport calls = %v, want Pause", got)
+	}
+}
+
+func TestSetSOAPClient(t *testing.T) {
+	zp, _, _ := fakePlayer(t)
+	rendering := ren.NewService(zp.DeviceDescriptionURL)
+	zp.RenderingControl = rendering
+
+	// Fakes and services left unset are skipped
+	client := &soap.Client{}
+	zp.SetSOAPClient(client)
+	if rendering.Client != client {
+		t.Error("SetSOAPClient did not set the client of the generated service")
+	}
+}